@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+func newEventsCommand() *cobra.Command {
+	var follow bool
+	cmd := &cobra.Command{
+		Use:   "events <postgres|redis|blobstorage> <name>",
+		Short: "Show the events recorded for a resource, optionally following new ones",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ns, err := requireNamespace()
+			if err != nil {
+				return err
+			}
+			_, kind, err := resourceFor(args[0])
+			if err != nil {
+				return err
+			}
+			cs, err := newClientSet()
+			if err != nil {
+				return err
+			}
+			selector := fields.Set{
+				"involvedObject.kind": kind,
+				"involvedObject.name": args[1],
+			}.AsSelector().String()
+			ctx := context.Background()
+			list, err := cs.CoreV1().Events(ns).List(ctx, metav1.ListOptions{FieldSelector: selector})
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(w, "LAST SEEN\tTYPE\tREASON\tMESSAGE")
+			for i := range list.Items {
+				printEvent(w, &list.Items[i])
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			if !follow {
+				return nil
+			}
+			watcher, err := cs.CoreV1().Events(ns).Watch(ctx, metav1.ListOptions{
+				FieldSelector:   selector,
+				ResourceVersion: list.ResourceVersion,
+			})
+			if err != nil {
+				return err
+			}
+			defer watcher.Stop()
+			for watchEvent := range watcher.ResultChan() {
+				event, ok := watchEvent.Object.(*corev1.Event)
+				if !ok {
+					continue
+				}
+				printEvent(w, event)
+				if err := w.Flush(); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep watching for new events after printing the existing ones")
+	return cmd
+}
+
+// printEvent writes a single event line, the last seen column follows kubectl and shows
+// the age of the latest occurrence
+func printEvent(w *tabwriter.Writer, event *corev1.Event) {
+	lastSeen := event.LastTimestamp.Time
+	if lastSeen.IsZero() {
+		lastSeen = event.CreationTimestamp.Time
+	}
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", duration.HumanDuration(time.Since(lastSeen)), event.Type, event.Reason, event.Message)
+}
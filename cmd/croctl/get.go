@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resourceRow a single line of get output, the columns shared by every resource kind
+type resourceRow struct {
+	kind       string
+	namespace  string
+	name       string
+	phase      croType.StatusPhase
+	strategy   string
+	resourceID string
+	message    croType.StatusMessage
+}
+
+func newGetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [postgres|redis|blobstorage]",
+		Short: "List cloud resources with their phase, strategy, cloud identifier and last reconcile message",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kinds := []string{"postgres", "redis", "blobstorage"}
+			if len(args) == 1 {
+				if _, _, err := resourceFor(args[0]); err != nil {
+					return err
+				}
+				kinds = args[:1]
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			var rows []resourceRow
+			for _, kind := range kinds {
+				kindRows, err := listResources(context.Background(), c, kind)
+				if err != nil {
+					return err
+				}
+				rows = append(rows, kindRows...)
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tPHASE\tSTRATEGY\tRESOURCE ID\tMESSAGE")
+			for _, r := range rows {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.kind, r.namespace, r.name, r.phase, r.strategy, r.resourceID, r.message)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// listResources lists the crs of a single kind and maps them to output rows
+func listResources(ctx context.Context, c client.Client, kind string) ([]resourceRow, error) {
+	var listOpts []client.ListOption
+	if namespace != "" {
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	}
+	var rows []resourceRow
+	switch kind {
+	case "postgres":
+		list := &v1alpha1.PostgresList{}
+		if err := c.List(ctx, list, listOpts...); err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			rows = append(rows, resourceRow{
+				kind:       "Postgres",
+				namespace:  item.Namespace,
+				name:       item.Name,
+				phase:      item.Status.Phase,
+				strategy:   item.Status.Strategy,
+				resourceID: item.Status.ResourceID,
+				message:    item.Status.Message,
+			})
+		}
+	case "redis":
+		list := &v1alpha1.RedisList{}
+		if err := c.List(ctx, list, listOpts...); err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			rows = append(rows, resourceRow{
+				kind:       "Redis",
+				namespace:  item.Namespace,
+				name:       item.Name,
+				phase:      item.Status.Phase,
+				strategy:   item.Status.Strategy,
+				resourceID: item.Status.ResourceID,
+				message:    item.Status.Message,
+			})
+		}
+	case "blobstorage":
+		list := &v1alpha1.BlobStorageList{}
+		if err := c.List(ctx, list, listOpts...); err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			rows = append(rows, resourceRow{
+				kind:       "BlobStorage",
+				namespace:  item.Namespace,
+				name:       item.Name,
+				phase:      item.Status.Phase,
+				strategy:   item.Status.Strategy,
+				resourceID: item.Status.ResourceID,
+				message:    item.Status.Message,
+			})
+		}
+	}
+	return rows, nil
+}
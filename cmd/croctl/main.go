@@ -0,0 +1,93 @@
+// croctl is a small cli for inspecting and operating on the resources managed by the
+// cloud resource operator, it lists crs with their phase and cloud identifiers, triggers
+// snapshots and reconciles and tails the events recorded for a resource
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis"
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+var (
+	kubeconfig string
+	namespace  string
+)
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:           "croctl",
+		Short:         "Inspect and operate on cloud resource operator resources",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig file, defaults to the standard kubeconfig locations")
+	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "Namespace to operate in, lists cover all namespaces when unset")
+	rootCmd.AddCommand(newGetCommand(), newSnapshotCommand(), newReconcileCommand(), newEventsCommand())
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// restConfig builds the rest config from the kubeconfig flag or the standard locations
+func restConfig() (*rest.Config, error) {
+	if kubeconfig != "" {
+		return clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	return config.GetConfig()
+}
+
+// newClient builds a controller runtime client with the operator api types registered
+func newClient() (client.Client, error) {
+	cfg, err := restConfig()
+	if err != nil {
+		return nil, err
+	}
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+}
+
+// newClientSet builds a kubernetes client set for the core apis
+func newClientSet() (*kubernetes.Clientset, error) {
+	cfg, err := restConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(cfg)
+}
+
+// resourceFor maps the kind argument accepted on the command line to a new instance of
+// the matching cr and its kind name
+func resourceFor(kind string) (runtime.Object, string, error) {
+	switch strings.ToLower(kind) {
+	case "postgres":
+		return &v1alpha1.Postgres{}, "Postgres", nil
+	case "redis":
+		return &v1alpha1.Redis{}, "Redis", nil
+	case "blobstorage":
+		return &v1alpha1.BlobStorage{}, "BlobStorage", nil
+	}
+	return nil, "", fmt.Errorf("unsupported resource kind %s, expected postgres, redis or blobstorage", kind)
+}
+
+// requireNamespace returns the namespace flag, erroring when it is unset
+func requireNamespace() (string, error) {
+	if namespace == "" {
+		return "", fmt.Errorf("a namespace is required, set one with -n")
+	}
+	return namespace, nil
+}
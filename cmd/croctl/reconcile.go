@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/annotations"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// forceReconcileAnnotation bumping this annotation generates a watch event for the cr so
+// its controller reconciles it immediately instead of waiting for the next resync
+const forceReconcileAnnotation = "integreatly.org/force-reconcile"
+
+func newReconcileCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reconcile <postgres|redis|blobstorage> <name>",
+		Short: "Force an immediate reconcile of a resource",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ns, err := requireNamespace()
+			if err != nil {
+				return err
+			}
+			obj, kind, err := resourceFor(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			if err := c.Get(ctx, types.NamespacedName{Name: args[1], Namespace: ns}, obj); err != nil {
+				return err
+			}
+			objMeta, err := meta.Accessor(obj)
+			if err != nil {
+				return err
+			}
+			annotations.Add(objMeta, forceReconcileAnnotation, time.Now().UTC().Format(time.RFC3339))
+			if err := c.Update(ctx, obj); err != nil {
+				return err
+			}
+			fmt.Printf("triggered reconcile of %s %s/%s\n", kind, ns, args[1])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/spf13/cobra"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+)
+
+func newSnapshotCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "snapshot <postgres|redis> <name>",
+		Short: "Trigger a snapshot of a postgres or redis resource",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ns, err := requireNamespace()
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			resourceName := args[1]
+			meta := controllerruntime.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s", resourceName, time.Now().UTC().Format("20060102-150405")),
+				Namespace: ns,
+			}
+			ctx := context.Background()
+			switch strings.ToLower(args[0]) {
+			case "postgres":
+				snapshot := &v1alpha1.PostgresSnapshot{
+					ObjectMeta: meta,
+					Spec:       v1alpha1.PostgresSnapshotSpec{ResourceName: resourceName},
+				}
+				if err := c.Create(ctx, snapshot); err != nil {
+					return err
+				}
+				fmt.Printf("created postgres snapshot %s/%s\n", ns, snapshot.Name)
+			case "redis":
+				snapshot := &v1alpha1.RedisSnapshot{
+					ObjectMeta: meta,
+					Spec:       v1alpha1.RedisSnapshotSpec{ResourceName: resourceName},
+				}
+				if err := c.Create(ctx, snapshot); err != nil {
+					return err
+				}
+				fmt.Printf("created redis snapshot %s/%s\n", ns, snapshot.Name)
+			default:
+				return fmt.Errorf("snapshots are only supported for postgres and redis resources, got %s", args[0])
+			}
+			return nil
+		},
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strconv"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 
@@ -15,7 +17,11 @@ import (
 
 	"github.com/integr8ly/cloud-resource-operator/pkg/apis"
 	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1beta1"
 	"github.com/integr8ly/cloud-resource-operator/pkg/controller"
+	croAws "github.com/integr8ly/cloud-resource-operator/pkg/providers/aws"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	croWebhook "github.com/integr8ly/cloud-resource-operator/pkg/webhook"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 
@@ -27,12 +33,17 @@ import (
 	sdkVersion "github.com/operator-framework/operator-sdk/version"
 	"github.com/spf13/pflag"
 	v1 "k8s.io/api/core/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 // Change below variables to serve metrics on different host or port.
@@ -50,6 +61,13 @@ func printVersion() {
 }
 
 func main() {
+	var enableLeaderElection bool
+	var enableGrafanaDashboards bool
+	var enableTracing bool
+	var maxConcurrentReconciles int
+	var logFormat string
+	var logLevel string
+
 	// Add the zap logger flag set to the CLI. The flag set must
 	// be added before calling pflag.Parse().
 	pflag.CommandLine.AddFlagSet(zap.FlagSet())
@@ -58,6 +76,18 @@ func main() {
 	// controller-runtime)
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 
+	pflag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
+		"Enable lease based leader election, allowing multiple operator replicas to run with a single active leader")
+	pflag.BoolVar(&enableGrafanaDashboards, "enable-grafana-dashboards", false,
+		"Create GrafanaDashboard resources rendering the health of provisioned cloud resources, requires the grafana operator")
+	pflag.BoolVar(&enableTracing, "enable-tracing", false,
+		"Record spans for each reconcile, provider action and cloud api call, spans are logged and can be exported to a tracing backend through a registered exporter")
+	pflag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"Maximum number of concurrent reconciles per controller")
+	pflag.StringVar(&logFormat, "log-format", "json", "Log output format, one of json or text")
+	pflag.StringVar(&logLevel, "log-level", "debug",
+		fmt.Sprintf("Log level, can also be changed at runtime through the %s key in the %s config map", resources.EnvLogLevel, resources.DefaultOperatorConfigMapName))
+
 	pflag.Parse()
 
 	// Use a zap logr.Logger implementation. If none of the zap
@@ -72,9 +102,19 @@ func main() {
 
 	printVersion()
 
-	// Setup logrus settings
-	logrus.SetFormatter(&logrus.TextFormatter{})
-	logrus.SetLevel(logrus.DebugLevel)
+	// Setup logrus settings, structured json output is the default so log lines carry the
+	// correlation fields attached by the controllers
+	if logFormat == "text" {
+		logrus.SetFormatter(&logrus.TextFormatter{})
+	} else {
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	}
+	resources.SetLogLevel(logLevel)
+
+	// enable span sampling for reconciles, provider actions and cloud api calls
+	if enableTracing {
+		resources.EnableTracing()
+	}
 
 	namespace, err := k8sutil.GetWatchNamespace()
 	if err != nil {
@@ -89,20 +129,41 @@ func main() {
 		os.Exit(1)
 	}
 
-	ctx := context.TODO()
-	// Become the leader before proceeding
-	err = leader.Become(ctx, "cloud-resource-operator-lock")
-	if err != nil {
-		log.Error(err, "")
+	// propagate the concurrency flag to the controllers
+	if err := os.Setenv(resources.EnvMaxConcurrentReconciles, strconv.Itoa(maxConcurrentReconciles)); err != nil {
+		log.Error(err, "Failed to set max concurrent reconciles")
 		os.Exit(1)
 	}
 
+	ctx := context.TODO()
+	// Become the leader before proceeding, skipped when lease based leader election is enabled
+	// as leadership is then handled by the manager
+	if !enableLeaderElection {
+		err = leader.Become(ctx, "cloud-resource-operator-lock")
+		if err != nil {
+			log.Error(err, "")
+			os.Exit(1)
+		}
+	}
+
 	// Create a new Cmd to provide shared dependencies and start components
-	mgr, err := manager.New(cfg, manager.Options{
+	options := manager.Options{
 		Namespace:          namespace,
 		MapperProvider:     apiutil.NewDiscoveryRESTMapper,
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
-	})
+		LeaderElection:     enableLeaderElection,
+		LeaderElectionID:   "cloud-resource-operator-leader",
+	}
+
+	// WATCH_NAMESPACE can hold a comma separated list of namespaces, in that case configure the
+	// manager cache to watch each listed namespace instead of a single namespace or the cluster
+	if strings.Contains(namespace, ",") {
+		log.Info(fmt.Sprintf("configuring manager to watch namespaces %s", namespace))
+		options.Namespace = ""
+		options.NewCache = cache.MultiNamespacedCacheBuilder(strings.Split(namespace, ","))
+	}
+
+	mgr, err := manager.New(cfg, options)
 	if err != nil {
 		log.Error(err, "")
 		os.Exit(1)
@@ -122,6 +183,71 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Serve the crd conversion webhook translating between the v1alpha1 storage version and
+	// v1beta1, opt in through the environment as the webhook server requires serving
+	// certificates to be mounted into the operator pod
+	if os.Getenv("ENABLE_CONVERSION_WEBHOOK") == "true" {
+		for _, apiType := range []k8sruntime.Object{&v1beta1.Postgres{}, &v1beta1.Redis{}, &v1beta1.BlobStorage{}} {
+			if err := builder.WebhookManagedBy(mgr).For(apiType).Complete(); err != nil {
+				log.Error(err, "Failed to register conversion webhook")
+				os.Exit(1)
+			}
+		}
+	}
+
+	// Serve the mutating webhook defaulting spec.tier and spec.type from namespace labels or
+	// the operator config when omitted on a cr, opt in as above since the webhook server
+	// requires serving certificates to be mounted into the operator pod
+	if os.Getenv("ENABLE_DEFAULTING_WEBHOOK") == "true" {
+		mgr.GetWebhookServer().Register("/mutate-cloud-resources", &webhook.Admission{Handler: &croWebhook.ResourceDefaulter{Client: mgr.GetClient()}})
+	}
+
+	// Register the vault secret publisher when a vault server is configured, crs can then opt
+	// in to publishing their credentials there through the secretPublisher annotation
+	if vaultAddr := os.Getenv(resources.EnvVaultAddr); vaultAddr != "" {
+		resources.RegisterSecretPublisher(resources.NewVaultSecretPublisher(vaultAddr, os.Getenv(resources.EnvVaultToken)))
+		log.Info(fmt.Sprintf("registered vault secret publisher for %s", vaultAddr))
+	}
+
+	// Reload operator configuration from the operator config map so timing and tagging
+	// settings can be changed without restarting the operator
+	configNamespace := strings.Split(namespace, ",")[0]
+	if err := mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		return resources.WatchOperatorConfig(mgr.GetClient(), configNamespace, stop)
+	})); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
+	// Persist the audit trail of cloud mutations to a config map when enabled through the
+	// operator configuration
+	if err := mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		return resources.WatchAuditConfigMap(mgr.GetClient(), configNamespace, stop)
+	})); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
+	// Persist the ledger of provisioned cloud resources and report cloud resources whose
+	// owning cr no longer exists, e.g. after a namespace deletion while cloud teardown was
+	// failing
+	if err := mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		return resources.WatchResourceLedger(mgr.GetClient(), configNamespace, stop)
+	})); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
+	// Periodically scan the cloud provider for resources tagged for this cluster whose owning
+	// cr no longer exists, the scan is a dry run maintaining the orphaned resource report and
+	// metric, nothing is deleted
+	if err := mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		return croAws.WatchOrphanedResources(mgr.GetClient(), configNamespace, stop)
+	})); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
 	// Add monitoring resources
 	if err := monitoringv1.AddToScheme(mgr.GetScheme()); err != nil {
 		log.Error(err, "")
@@ -161,6 +287,19 @@ func main() {
 		}
 	}
 
+	// optionally create grafana dashboards rendering the health of provisioned resources, a
+	// direct client is used as the manager cache has not been started yet
+	if enableGrafanaDashboards {
+		directClient, err := runtimeClient.New(cfg, runtimeClient.Options{Scheme: mgr.GetScheme()})
+		if err != nil {
+			log.Error(err, "Failed to create client for grafana dashboards")
+			os.Exit(1)
+		}
+		if err := resources.ReconcileGrafanaDashboards(ctx, directClient, operatorNs); err != nil {
+			log.Info("Could not create GrafanaDashboard object, install the grafana operator to create dashboards", "error", err.Error())
+		}
+	}
+
 	log.Info("Starting the Cmd.")
 
 	// Start the Cmd
@@ -0,0 +1,65 @@
+// policygen prints the iam policy document the operator requires as json, run through
+// make gen/policy. The default output matches the broad policy requested through the
+// cloud credential operator, --least-privilege scopes every action that supports
+// resource level permissions to the resources derived from the given cluster identifier
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers/aws"
+	v1 "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
+)
+
+// policyDocument an aws iam policy document
+type policyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []policyStatement `json:"Statement"`
+}
+
+// policyStatement a single statement of an aws iam policy document
+type policyStatement struct {
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource string   `json:"Resource"`
+}
+
+func main() {
+	var leastPrivilege bool
+	var clusterID string
+	var partition string
+	flag.BoolVar(&leastPrivilege, "least-privilege", false, "Scope mutating actions to resources carrying the cluster identifier prefix")
+	flag.StringVar(&clusterID, "cluster-id", "", "Cluster identifier used as the resource prefix, required with -least-privilege")
+	flag.StringVar(&partition, "partition", "aws", "Arn partition of the target cloud, e.g. aws or aws-us-gov")
+	flag.Parse()
+
+	if leastPrivilege && clusterID == "" {
+		fmt.Fprintln(os.Stderr, "error: -cluster-id is required with -least-privilege")
+		os.Exit(1)
+	}
+
+	doc := buildPolicyDocument(aws.BuildOperatorStatementEntries(leastPrivilege, clusterID, partition))
+	out, err := json.MarshalIndent(doc, "", "    ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error: failed to marshal policy document:", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(out))
+}
+
+// buildPolicyDocument maps the statement entries requested through the cloud credential
+// operator onto the iam policy document format
+func buildPolicyDocument(entries []v1.StatementEntry) policyDocument {
+	doc := policyDocument{Version: "2012-10-17"}
+	for _, entry := range entries {
+		doc.Statement = append(doc.Statement, policyStatement{
+			Effect:   entry.Effect,
+			Action:   entry.Action,
+			Resource: entry.Resource,
+		})
+	}
+	return doc
+}
@@ -0,0 +1,13 @@
+package v1alpha1
+
+// v1alpha1 is the storage version for the resource crds so it acts as the conversion hub,
+// newer api versions implement Convertible against these types
+
+// Hub marks Postgres as the conversion hub type
+func (p *Postgres) Hub() {}
+
+// Hub marks Redis as the conversion hub type
+func (r *Redis) Hub() {}
+
+// Hub marks BlobStorage as the conversion hub type
+func (b *BlobStorage) Hub() {}
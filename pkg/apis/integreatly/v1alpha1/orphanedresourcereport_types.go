@@ -0,0 +1,56 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OrphanedResource a cloud resource carrying the cluster tag whose owning cr no longer
+// exists, typically left behind by a failed delete or a namespace deletion while cloud
+// teardown was failing
+// +k8s:openapi-gen=true
+type OrphanedResource struct {
+	// ResourceType the cro resource type of the cloud resource
+	ResourceType string `json:"resourceType"`
+	// ResourceID the cloud native identifier of the orphaned resource, the handle needed to
+	// clean the resource up manually
+	ResourceID string `json:"resourceID"`
+	// ResourceName the name of the owning cr recorded on the resource-name tag of the cloud
+	// resource
+	ResourceName string `json:"resourceName,omitempty"`
+}
+
+// OrphanedResourceReportStatus defines the observed state of OrphanedResourceReport
+// +k8s:openapi-gen=true
+type OrphanedResourceReportStatus struct {
+	// LastScanTime when the cloud provider was last scanned for orphaned resources
+	LastScanTime metav1.Time `json:"lastScanTime,omitempty"`
+	// Orphans the cloud resources carrying the cluster tag without a matching cr
+	Orphans []OrphanedResource `json:"orphans,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OrphanedResourceReport is the Schema for the orphanedresourcereports API, the report is
+// maintained by the periodic orphan scan and lists cloud resources tagged for this cluster
+// whose owning cr no longer exists
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type OrphanedResourceReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status OrphanedResourceReportStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OrphanedResourceReportList contains a list of OrphanedResourceReport
+type OrphanedResourceReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrphanedResourceReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OrphanedResourceReport{}, &OrphanedResourceReportList{})
+}
@@ -0,0 +1,48 @@
+package v1alpha1
+
+import (
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// PostgresDatabaseSpec defines the desired state of PostgresDatabase
+// +k8s:openapi-gen=true
+type PostgresDatabaseSpec struct {
+	// ResourceName the name of the Postgres cr the database is created on
+	ResourceName string `json:"resourceName"`
+	// Owner the PostgresUser cr name that owns the database, defaults to the admin user
+	Owner string `json:"owner,omitempty"`
+}
+
+// PostgresDatabaseStatus defines the observed state of PostgresDatabase
+// +k8s:openapi-gen=true
+type PostgresDatabaseStatus types.ResourceTypeStatus
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PostgresDatabase is the Schema for the postgresdatabases API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type PostgresDatabase struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PostgresDatabaseSpec   `json:"spec,omitempty"`
+	Status PostgresDatabaseStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PostgresDatabaseList contains a list of PostgresDatabase
+type PostgresDatabaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PostgresDatabase `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PostgresDatabase{}, &PostgresDatabaseList{})
+}
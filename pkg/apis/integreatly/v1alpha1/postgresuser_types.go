@@ -0,0 +1,53 @@
+package v1alpha1
+
+import (
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// PostgresUserSpec defines the desired state of PostgresUser
+// +k8s:openapi-gen=true
+type PostgresUserSpec struct {
+	// ResourceName the name of the Postgres cr the user is created on
+	ResourceName string `json:"resourceName"`
+	// Databases the PostgresDatabase cr names the user is granted access to
+	Databases []string `json:"databases,omitempty"`
+	// Privileges the privileges granted to the user on the listed databases, defaults to
+	// CONNECT and TEMPORARY
+	Privileges []string `json:"privileges,omitempty"`
+	// SecretRef the secret the user credentials are published to
+	SecretRef *types.SecretRef `json:"secretRef"`
+}
+
+// PostgresUserStatus defines the observed state of PostgresUser
+// +k8s:openapi-gen=true
+type PostgresUserStatus types.ResourceTypeStatus
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PostgresUser is the Schema for the postgresusers API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type PostgresUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PostgresUserSpec   `json:"spec,omitempty"`
+	Status PostgresUserStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PostgresUserList contains a list of PostgresUser
+type PostgresUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PostgresUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PostgresUser{}, &PostgresUserList{})
+}
@@ -0,0 +1,24 @@
+package v1alpha1
+
+import (
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+)
+
+// the status blocks are named types rather than aliases so the SetStage helper on
+// types.ResourceTypeStatus is not promoted, these wrappers keep provider call sites free
+// of conversions
+
+// SetStage records the provisioning stage the resource is in, see types.ResourceTypeStatus.SetStage
+func (s *PostgresStatus) SetStage(stage types.StatusStage) {
+	(*types.ResourceTypeStatus)(s).SetStage(stage)
+}
+
+// SetStage records the provisioning stage the resource is in, see types.ResourceTypeStatus.SetStage
+func (s *RedisStatus) SetStage(stage types.StatusStage) {
+	(*types.ResourceTypeStatus)(s).SetStage(stage)
+}
+
+// SetStage records the provisioning stage the resource is in, see types.ResourceTypeStatus.SetStage
+func (s *BlobStorageStatus) SetStage(stage types.StatusStage) {
+	(*types.ResourceTypeStatus)(s).SetStage(stage)
+}
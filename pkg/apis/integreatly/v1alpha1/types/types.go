@@ -1,7 +1,10 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
@@ -25,14 +28,129 @@ type SecretRef struct {
 // ResourceTypeSpec Represents the basic information required to provision a resource type
 // +k8s:openapi-gen=true
 type ResourceTypeSpec struct {
-	Type       string     `json:"type"`
-	Tier       string     `json:"tier"`
-	SkipCreate bool       `json:"skipCreate,omitempty"`
-	SecretRef  *SecretRef `json:"secretRef"`
+	Type       string `json:"type"`
+	Tier       string `json:"tier"`
+	SkipCreate bool   `json:"skipCreate,omitempty"`
+	AutoHeal   bool   `json:"autoHeal,omitempty"`
+	// DryRun makes providers compute and report what they would create in the status message
+	// and an event without calling any mutating cloud apis
+	DryRun    bool       `json:"dryRun,omitempty"`
+	SecretRef *SecretRef `json:"secretRef"`
+	// Size an abstract size (small/medium/large) mapped to a provider instance class through the
+	// strategy config map for the tier, an explicit provider instance class is also accepted
+	Size string `json:"size,omitempty"`
+	// ProviderConfig is a raw json block of provider specific creation parameters, values set here
+	// take precedence over the equivalent values in the strategy config map for the tier
+	ProviderConfig json.RawMessage `json:"providerConfig,omitempty"`
+	// CredentialRotation configures periodic rotation of the master credentials for the resource
+	CredentialRotation *CredentialRotationSpec `json:"credentialRotation,omitempty"`
+	// RestoreFrom hydrates a newly created resource from an existing backup, either a snapshot
+	// identifier or a point in time on a source resource
+	RestoreFrom *RestoreFromSpec `json:"restoreFrom,omitempty"`
+	// Schedule stops the resource outside the configured working window, used by dev and test
+	// tiers to cut cost for environments that are idle outside working hours
+	Schedule *ScheduleSpec `json:"schedule,omitempty"`
+	// TTL deletes the resource automatically once the duration has elapsed since creation,
+	// parsed as a go duration e.g. 72h, used by preview environments so short lived resources
+	// are not left running after the environment is gone
+	TTL string `json:"ttl,omitempty"`
+	// DeletionPolicy controls how the data held by the cloud resource is treated when the cr
+	// is deleted, the blobstorage providers default to DeleteEmptyOnly and the postgres and
+	// redis providers default to Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// Extensions database extensions created on the provisioned database once it is
+	// available, requests not in the engine's allowed extension list are surfaced through
+	// the UnsupportedExtensions status condition, only used by the postgres providers
+	Extensions []string `json:"extensions,omitempty"`
+	// InitSQLRef references a config map or secret holding bootstrap sql applied exactly
+	// once after the database is first provisioned, the checksum of the applied sql is
+	// recorded on the cr so changed sql is not silently re-run, only used by the postgres
+	// providers
+	InitSQLRef *InitSQLRefSpec `json:"initSQLRef,omitempty"`
+}
+
+// InitSQLRefSpec references the source of the bootstrap sql applied after first
+// provisioning, exactly one of ConfigMap or Secret must name an object in the cr namespace
+type InitSQLRefSpec struct {
+	// ConfigMap the name of a config map holding the bootstrap sql
+	ConfigMap string `json:"configMap,omitempty"`
+	// Secret the name of a secret holding the bootstrap sql, used when the sql contains
+	// sensitive values such as role passwords
+	Secret string `json:"secret,omitempty"`
+	// Key the key holding the sql in the referenced object, defaults to init.sql
+	Key string `json:"key,omitempty"`
+}
+
+// DeletionPolicy controls how providers treat the data held by a cloud resource on deletion
+type DeletionPolicy string
+
+var (
+	// DeletionPolicyDelete deletes the cloud resource along with the cr
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain leaves the cloud resource in place, only operator owned
+	// credentials and kubernetes objects are removed
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyDeleteEmptyOnly deletes the cloud resource only when it holds no data
+	DeletionPolicyDeleteEmptyOnly DeletionPolicy = "DeleteEmptyOnly"
+	// DeletionPolicyForceDeleteObjects removes the data held by the cloud resource before
+	// deleting it
+	DeletionPolicyForceDeleteObjects DeletionPolicy = "ForceDeleteObjects"
+)
+
+// CredentialRotationSpec Represents the configuration of periodic credential rotation
+// +k8s:openapi-gen=true
+type CredentialRotationSpec struct {
+	// Interval between credential rotations, parsed as a go duration e.g. 720h
+	Interval string `json:"interval"`
+}
+
+// RestoreFromSpec Represents the backup a newly created resource is hydrated from
+// +k8s:openapi-gen=true
+type RestoreFromSpec struct {
+	// SnapshotIdentifier the identifier of an existing snapshot to restore from
+	SnapshotIdentifier string `json:"snapshotIdentifier,omitempty"`
+	// Timestamp a point in time to restore to, parsed as RFC3339, requires SourceResourceID
+	Timestamp string `json:"timestamp,omitempty"`
+	// SourceResourceID the identifier of the source resource for a point in time restore
+	SourceResourceID string `json:"sourceResourceID,omitempty"`
+}
+
+// ScheduleSpec Represents a recurring working window for a resource, the resource is started
+// at the start expression and stopped at the stop expression
+// +k8s:openapi-gen=true
+type ScheduleSpec struct {
+	// Start cron expression (minute hour day-of-month month day-of-week) for when the
+	// resource is started
+	Start string `json:"start"`
+	// Stop cron expression for when the resource is stopped
+	Stop string `json:"stop"`
 }
 
 type StatusPhase string
 
+// StatusStage a finer grained view of provisioning than the phase, distinguishing network
+// work from waiting on the cloud resource so dashboards can show where time is spent
+type StatusStage string
+
+var (
+	// StagePending the cr has been accepted but provisioning has not started
+	StagePending StatusStage = "Pending"
+	// StageModifyingNetwork the network the resource lives in is being created or peered
+	StageModifyingNetwork StatusStage = "ModifyingNetwork"
+	// StageProvisioning the cloud resource has been requested and is not yet available
+	StageProvisioning StatusStage = "Provisioning"
+	// StageReady the cloud resource is available and the connection secret is reconciled
+	StageReady StatusStage = "Ready"
+	// StageDeleting the cloud resource is being torn down
+	StageDeleting StatusStage = "Deleting"
+)
+
+// StageTransition records when a provisioning stage was entered
+type StageTransition struct {
+	Stage     StatusStage `json:"stage"`
+	StartedAt metav1.Time `json:"startedAt,omitempty"`
+}
+
 type StatusMessage string
 
 func (sm StatusMessage) WrapError(err error) StatusMessage {
@@ -42,6 +160,34 @@ func (sm StatusMessage) WrapError(err error) StatusMessage {
 	return StatusMessage(fmt.Sprintf("%s: %s", sm, err.Error()))
 }
 
+// StatusCondition Represents a single observed condition of a provisioned resource
+// +k8s:openapi-gen=true
+type StatusCondition struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"`
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// SetCondition adds or updates a condition in the provided condition list, the transition time
+// is only updated when the status of the condition changes
+func SetCondition(conditions []StatusCondition, condition StatusCondition) []StatusCondition {
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+	for i := range conditions {
+		if conditions[i].Type != condition.Type {
+			continue
+		}
+		if conditions[i].Status == condition.Status {
+			condition.LastTransitionTime = conditions[i].LastTransitionTime
+		}
+		conditions[i] = condition
+		return conditions
+	}
+	return append(conditions, condition)
+}
+
 // ResourceTypeStatus Represents the basic status information provided by a resource provider
 // +k8s:openapi-gen=true
 type ResourceTypeStatus struct {
@@ -50,6 +196,49 @@ type ResourceTypeStatus struct {
 	SecretRef *SecretRef    `json:"secretRef,omitempty"`
 	Phase     StatusPhase   `json:"phase,omitempty"`
 	Message   StatusMessage `json:"message,omitempty"`
+	// ResourceID the cloud native identifier of the provisioned resource, e.g. an rds instance
+	// arn, an elasticache replication group id or an s3 bucket arn, exposed so external tooling
+	// can locate the resource without relying on naming conventions
+	ResourceID string            `json:"resourceID,omitempty"`
+	Conditions []StatusCondition `json:"conditions,omitempty"`
+	// EarliestRestorableTime the earliest point in time the resource can be restored to, only
+	// set for resource types that support point in time recovery
+	EarliestRestorableTime *metav1.Time `json:"earliestRestorableTime,omitempty"`
+	// NextMaintenanceWindow the start of the next preferred maintenance window of the
+	// resource, only set for resource types where the provider applies pending changes in a
+	// maintenance window
+	NextMaintenanceWindow *metav1.Time `json:"nextMaintenanceWindow,omitempty"`
+	// StandbyEndpoint the connection endpoint of the disaster recovery standby, only set when
+	// the strategy for the tier maintains a cross region standby
+	StandbyEndpoint string `json:"standbyEndpoint,omitempty"`
+	// PerformanceInsightsID the identifier the provider console uses for the performance
+	// dashboard of the resource, only set when the strategy for the tier enables performance
+	// insights
+	PerformanceInsightsID string `json:"performanceInsightsId,omitempty"`
+	// AllocatedStorageGB the storage currently allocated to the resource in gibibytes, only
+	// set for resource types with provisioned storage
+	AllocatedStorageGB int64 `json:"allocatedStorageGB,omitempty"`
+	// MaxAllocatedStorageGB the upper limit storage autoscaling can grow the resource to in
+	// gibibytes, only set when the strategy for the tier enables storage autoscaling
+	MaxAllocatedStorageGB int64 `json:"maxAllocatedStorageGB,omitempty"`
+	// FailureCount the number of consecutive failed reconciles, used by controllers to back
+	// off requeues of failing resources
+	FailureCount int64 `json:"failureCount,omitempty"`
+	// Stage the provisioning stage the resource is currently in, a finer grained view than
+	// the phase
+	Stage StatusStage `json:"stage,omitempty"`
+	// StageHistory the time each stage was entered, newest last
+	StageHistory []StageTransition `json:"stageHistory,omitempty"`
+}
+
+// SetStage records the stage the resource is in, appending to the stage history when the
+// stage changes
+func (s *ResourceTypeStatus) SetStage(stage StatusStage) {
+	if stage == "" || s.Stage == stage {
+		return
+	}
+	s.Stage = stage
+	s.StageHistory = append(s.StageHistory, StageTransition{Stage: stage, StartedAt: metav1.Now()})
 }
 
 // ResourceTypeSnapshotStatus Represents the basic status information provided by snapshot controller
@@ -0,0 +1,31 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BlobStorage is the Schema for the blobstorages API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type BlobStorage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ResourceSpec   `json:"spec,omitempty"`
+	Status ResourceStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BlobStorageList contains a list of BlobStorage
+type BlobStorageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BlobStorage `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BlobStorage{}, &BlobStorageList{})
+}
@@ -0,0 +1,260 @@
+package v1beta1
+
+import (
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	errorUtil "github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// v1alpha1 is the storage version so it acts as the conversion hub, the v1beta1 types
+// implement Convertible and are translated to and from v1alpha1 by the conversion webhook
+
+var _ conversion.Convertible = (*Postgres)(nil)
+var _ conversion.Convertible = (*Redis)(nil)
+var _ conversion.Convertible = (*BlobStorage)(nil)
+
+// phaseToAlpha maps the CamelCase v1beta1 phases onto the free text v1alpha1 phases
+var phaseToAlpha = map[StatusPhase]croType.StatusPhase{
+	PhaseInProgress:         croType.PhaseInProgress,
+	PhaseDeletionInProgress: croType.PhaseDeleteInProgress,
+	PhaseComplete:           croType.PhaseComplete,
+	PhasePaused:             croType.PhasePaused,
+	PhaseFailed:             croType.PhaseFailed,
+}
+
+// phaseFromAlpha is the inverse of phaseToAlpha
+var phaseFromAlpha = map[croType.StatusPhase]StatusPhase{
+	croType.PhaseInProgress:       PhaseInProgress,
+	croType.PhaseDeleteInProgress: PhaseDeletionInProgress,
+	croType.PhaseComplete:         PhaseComplete,
+	croType.PhasePaused:           PhasePaused,
+	croType.PhaseFailed:           PhaseFailed,
+}
+
+// ConvertTo converts this Postgres to the hub version
+func (p *Postgres) ConvertTo(dst conversion.Hub) error {
+	out, ok := dst.(*v1alpha1.Postgres)
+	if !ok {
+		return errorUtil.Errorf("unexpected conversion hub type %T for postgres", dst)
+	}
+	out.ObjectMeta = p.ObjectMeta
+	out.Spec = v1alpha1.PostgresSpec(specToAlpha(&p.Spec))
+	out.Status = v1alpha1.PostgresStatus(statusToAlpha(&p.Status))
+	return nil
+}
+
+// ConvertFrom converts from the hub version to this Postgres
+func (p *Postgres) ConvertFrom(src conversion.Hub) error {
+	in, ok := src.(*v1alpha1.Postgres)
+	if !ok {
+		return errorUtil.Errorf("unexpected conversion hub type %T for postgres", src)
+	}
+	p.ObjectMeta = in.ObjectMeta
+	p.Spec = specFromAlpha(croType.ResourceTypeSpec(in.Spec))
+	p.Status = statusFromAlpha(croType.ResourceTypeStatus(in.Status))
+	return nil
+}
+
+// ConvertTo converts this Redis to the hub version
+func (r *Redis) ConvertTo(dst conversion.Hub) error {
+	out, ok := dst.(*v1alpha1.Redis)
+	if !ok {
+		return errorUtil.Errorf("unexpected conversion hub type %T for redis", dst)
+	}
+	out.ObjectMeta = r.ObjectMeta
+	out.Spec = v1alpha1.RedisSpec(specToAlpha(&r.Spec))
+	out.Status = v1alpha1.RedisStatus(statusToAlpha(&r.Status))
+	return nil
+}
+
+// ConvertFrom converts from the hub version to this Redis
+func (r *Redis) ConvertFrom(src conversion.Hub) error {
+	in, ok := src.(*v1alpha1.Redis)
+	if !ok {
+		return errorUtil.Errorf("unexpected conversion hub type %T for redis", src)
+	}
+	r.ObjectMeta = in.ObjectMeta
+	r.Spec = specFromAlpha(croType.ResourceTypeSpec(in.Spec))
+	r.Status = statusFromAlpha(croType.ResourceTypeStatus(in.Status))
+	return nil
+}
+
+// ConvertTo converts this BlobStorage to the hub version
+func (b *BlobStorage) ConvertTo(dst conversion.Hub) error {
+	out, ok := dst.(*v1alpha1.BlobStorage)
+	if !ok {
+		return errorUtil.Errorf("unexpected conversion hub type %T for blobstorage", dst)
+	}
+	out.ObjectMeta = b.ObjectMeta
+	out.Spec = v1alpha1.BlobStorageSpec(specToAlpha(&b.Spec))
+	out.Status = v1alpha1.BlobStorageStatus(statusToAlpha(&b.Status))
+	return nil
+}
+
+// ConvertFrom converts from the hub version to this BlobStorage
+func (b *BlobStorage) ConvertFrom(src conversion.Hub) error {
+	in, ok := src.(*v1alpha1.BlobStorage)
+	if !ok {
+		return errorUtil.Errorf("unexpected conversion hub type %T for blobstorage", src)
+	}
+	b.ObjectMeta = in.ObjectMeta
+	b.Spec = specFromAlpha(croType.ResourceTypeSpec(in.Spec))
+	b.Status = statusFromAlpha(croType.ResourceTypeStatus(in.Status))
+	return nil
+}
+
+// specToAlpha maps a v1beta1 spec onto the v1alpha1 shape, the typed provider config is
+// flattened back to the single v1alpha1 block preferring the default parameters over the
+// provider scoped ones
+func specToAlpha(in *ResourceSpec) croType.ResourceTypeSpec {
+	out := croType.ResourceTypeSpec{
+		Type:           in.Type,
+		Tier:           in.Tier,
+		SkipCreate:     in.SkipCreate,
+		AutoHeal:       in.AutoHeal,
+		DryRun:         in.DryRun,
+		Size:           in.Size,
+		TTL:            in.TTL,
+		DeletionPolicy: croType.DeletionPolicy(in.DeletionPolicy),
+		Extensions:     in.Extensions,
+	}
+	if in.SecretRef != nil {
+		out.SecretRef = &croType.SecretRef{Name: in.SecretRef.Name, Namespace: in.SecretRef.Namespace}
+	}
+	if in.ProviderConfig != nil {
+		switch {
+		case len(in.ProviderConfig.Default) > 0:
+			out.ProviderConfig = in.ProviderConfig.Default
+		case len(in.ProviderConfig.AWS) > 0:
+			out.ProviderConfig = in.ProviderConfig.AWS
+		case len(in.ProviderConfig.Openshift) > 0:
+			out.ProviderConfig = in.ProviderConfig.Openshift
+		}
+	}
+	if in.CredentialRotation != nil {
+		out.CredentialRotation = &croType.CredentialRotationSpec{Interval: in.CredentialRotation.Interval}
+	}
+	if in.RestoreFrom != nil {
+		out.RestoreFrom = &croType.RestoreFromSpec{
+			SnapshotIdentifier: in.RestoreFrom.SnapshotIdentifier,
+			Timestamp:          in.RestoreFrom.Timestamp,
+			SourceResourceID:   in.RestoreFrom.SourceResourceID,
+		}
+	}
+	if in.Schedule != nil {
+		out.Schedule = &croType.ScheduleSpec{Start: in.Schedule.Start, Stop: in.Schedule.Stop}
+	}
+	if in.InitSQLRef != nil {
+		out.InitSQLRef = &croType.InitSQLRefSpec{ConfigMap: in.InitSQLRef.ConfigMap, Secret: in.InitSQLRef.Secret, Key: in.InitSQLRef.Key}
+	}
+	return out
+}
+
+// specFromAlpha maps a v1alpha1 spec onto the v1beta1 shape, the untyped provider config
+// block becomes the default parameters of the typed provider config
+func specFromAlpha(in croType.ResourceTypeSpec) ResourceSpec {
+	out := ResourceSpec{
+		Type:           in.Type,
+		Tier:           in.Tier,
+		SkipCreate:     in.SkipCreate,
+		AutoHeal:       in.AutoHeal,
+		DryRun:         in.DryRun,
+		Size:           in.Size,
+		TTL:            in.TTL,
+		DeletionPolicy: DeletionPolicy(in.DeletionPolicy),
+		Extensions:     in.Extensions,
+	}
+	if in.SecretRef != nil {
+		out.SecretRef = &SecretRef{Name: in.SecretRef.Name, Namespace: in.SecretRef.Namespace}
+	}
+	if len(in.ProviderConfig) > 0 {
+		out.ProviderConfig = &ProviderConfig{Default: in.ProviderConfig}
+	}
+	if in.CredentialRotation != nil {
+		out.CredentialRotation = &CredentialRotationSpec{Interval: in.CredentialRotation.Interval}
+	}
+	if in.RestoreFrom != nil {
+		out.RestoreFrom = &RestoreFromSpec{
+			SnapshotIdentifier: in.RestoreFrom.SnapshotIdentifier,
+			Timestamp:          in.RestoreFrom.Timestamp,
+			SourceResourceID:   in.RestoreFrom.SourceResourceID,
+		}
+	}
+	if in.Schedule != nil {
+		out.Schedule = &ScheduleSpec{Start: in.Schedule.Start, Stop: in.Schedule.Stop}
+	}
+	if in.InitSQLRef != nil {
+		out.InitSQLRef = &InitSQLRefSpec{ConfigMap: in.InitSQLRef.ConfigMap, Secret: in.InitSQLRef.Secret, Key: in.InitSQLRef.Key}
+	}
+	return out
+}
+
+// statusToAlpha maps a v1beta1 status onto the v1alpha1 shape, the condition reason has
+// no v1alpha1 equivalent and is dropped
+func statusToAlpha(in *ResourceStatus) croType.ResourceTypeStatus {
+	out := croType.ResourceTypeStatus{
+		Strategy:               in.Strategy,
+		Provider:               in.Provider,
+		Phase:                  phaseToAlpha[in.Phase],
+		Message:                croType.StatusMessage(in.Message),
+		ResourceID:             in.ResourceID,
+		EarliestRestorableTime: in.EarliestRestorableTime,
+		NextMaintenanceWindow:  in.NextMaintenanceWindow,
+		StandbyEndpoint:        in.StandbyEndpoint,
+		PerformanceInsightsID:  in.PerformanceInsightsID,
+		AllocatedStorageGB:     in.AllocatedStorageGB,
+		MaxAllocatedStorageGB:  in.MaxAllocatedStorageGB,
+		FailureCount:           in.FailureCount,
+		Stage:                  croType.StatusStage(in.Stage),
+	}
+	if in.SecretRef != nil {
+		out.SecretRef = &croType.SecretRef{Name: in.SecretRef.Name, Namespace: in.SecretRef.Namespace}
+	}
+	for _, t := range in.StageHistory {
+		out.StageHistory = append(out.StageHistory, croType.StageTransition{Stage: croType.StatusStage(t.Stage), StartedAt: t.StartedAt})
+	}
+	for _, c := range in.Conditions {
+		out.Conditions = append(out.Conditions, croType.StatusCondition{
+			Type:               c.Type,
+			Status:             c.Status,
+			Message:            c.Message,
+			LastTransitionTime: c.LastTransitionTime,
+		})
+	}
+	return out
+}
+
+// statusFromAlpha maps a v1alpha1 status onto the v1beta1 shape
+func statusFromAlpha(in croType.ResourceTypeStatus) ResourceStatus {
+	out := ResourceStatus{
+		Strategy:               in.Strategy,
+		Provider:               in.Provider,
+		Phase:                  phaseFromAlpha[in.Phase],
+		Message:                string(in.Message),
+		ResourceID:             in.ResourceID,
+		EarliestRestorableTime: in.EarliestRestorableTime,
+		NextMaintenanceWindow:  in.NextMaintenanceWindow,
+		StandbyEndpoint:        in.StandbyEndpoint,
+		PerformanceInsightsID:  in.PerformanceInsightsID,
+		AllocatedStorageGB:     in.AllocatedStorageGB,
+		MaxAllocatedStorageGB:  in.MaxAllocatedStorageGB,
+		FailureCount:           in.FailureCount,
+		Stage:                  StatusStage(in.Stage),
+	}
+	if in.SecretRef != nil {
+		out.SecretRef = &SecretRef{Name: in.SecretRef.Name, Namespace: in.SecretRef.Namespace}
+	}
+	for _, t := range in.StageHistory {
+		out.StageHistory = append(out.StageHistory, StageTransition{Stage: StatusStage(t.Stage), StartedAt: t.StartedAt})
+	}
+	for _, c := range in.Conditions {
+		out.Conditions = append(out.Conditions, Condition{
+			Type:               c.Type,
+			Status:             c.Status,
+			Message:            c.Message,
+			LastTransitionTime: c.LastTransitionTime,
+		})
+	}
+	return out
+}
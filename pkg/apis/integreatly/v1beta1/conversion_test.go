@@ -0,0 +1,116 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+)
+
+func TestPostgres_ConvertRoundTrip(t *testing.T) {
+	in := &Postgres{
+		ObjectMeta: controllerruntime.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: ResourceSpec{
+			Type: "managed",
+			Tier: "production",
+			SecretRef: &SecretRef{
+				Name:      "test-sec",
+				Namespace: "test",
+			},
+			Size: "large",
+			ProviderConfig: &ProviderConfig{
+				Default: json.RawMessage(`{"region":"eu-west-1"}`),
+			},
+			DeletionPolicy: DeletionPolicyRetain,
+		},
+		Status: ResourceStatus{
+			Strategy:   "aws",
+			Provider:   "aws-rds",
+			Phase:      PhaseComplete,
+			Message:    "creation successful",
+			ResourceID: "arn:aws:rds:eu-west-1:123456789012:db:test",
+			Conditions: []Condition{
+				{
+					Type:    "Ready",
+					Status:  "True",
+					Message: "creation successful",
+				},
+			},
+			FailureCount: 2,
+		},
+	}
+	hub := &v1alpha1.Postgres{}
+	if err := in.ConvertTo(hub); err != nil {
+		t.Fatal("failed to convert to hub version", err)
+	}
+	if hub.Spec.DeletionPolicy != croType.DeletionPolicyRetain {
+		t.Fatalf("unexpected deletion policy on hub version, got %s", hub.Spec.DeletionPolicy)
+	}
+	if string(hub.Spec.ProviderConfig) != `{"region":"eu-west-1"}` {
+		t.Fatalf("unexpected provider config on hub version, got %s", hub.Spec.ProviderConfig)
+	}
+	if hub.Status.Phase != croType.PhaseComplete {
+		t.Fatalf("unexpected phase on hub version, got %s", hub.Status.Phase)
+	}
+	out := &Postgres{}
+	if err := out.ConvertFrom(hub); err != nil {
+		t.Fatal("failed to convert from hub version", err)
+	}
+	if out.Spec.ProviderConfig == nil || string(out.Spec.ProviderConfig.Default) != `{"region":"eu-west-1"}` {
+		t.Fatal("provider config did not survive the round trip")
+	}
+	if out.Status.Phase != PhaseComplete {
+		t.Fatalf("unexpected phase after round trip, got %s", out.Status.Phase)
+	}
+	if len(out.Status.Conditions) != 1 || out.Status.Conditions[0].Type != "Ready" {
+		t.Fatal("conditions did not survive the round trip")
+	}
+	if out.Status.FailureCount != in.Status.FailureCount {
+		t.Fatalf("unexpected failure count after round trip, got %d", out.Status.FailureCount)
+	}
+}
+
+func TestResourceSpec_ProviderConfigToAlpha(t *testing.T) {
+	cases := []struct {
+		name   string
+		config *ProviderConfig
+		want   string
+	}{
+		{
+			name:   "test missing provider config maps to an empty block",
+			config: nil,
+			want:   "",
+		},
+		{
+			name:   "test default parameters map to the v1alpha1 block",
+			config: &ProviderConfig{Default: json.RawMessage(`{"a":"b"}`)},
+			want:   `{"a":"b"}`,
+		},
+		{
+			name: "test default parameters win over provider scoped ones",
+			config: &ProviderConfig{
+				Default: json.RawMessage(`{"a":"b"}`),
+				AWS:     json.RawMessage(`{"c":"d"}`),
+			},
+			want: `{"a":"b"}`,
+		},
+		{
+			name:   "test provider scoped parameters are used when no defaults are set",
+			config: &ProviderConfig{AWS: json.RawMessage(`{"c":"d"}`)},
+			want:   `{"c":"d"}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := specToAlpha(&ResourceSpec{ProviderConfig: tc.config})
+			if string(out.ProviderConfig) != tc.want {
+				t.Fatalf("unexpected provider config, got %s but expected %s", out.ProviderConfig, tc.want)
+			}
+		})
+	}
+}
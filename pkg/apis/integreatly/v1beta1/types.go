@@ -0,0 +1,199 @@
+package v1beta1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StatusPhase the high level lifecycle phase of a resource, v1beta1 uses CamelCase values
+// in line with upstream kubernetes conventions, the v1alpha1 free text phases are mapped
+// to these values by the conversion webhook
+type StatusPhase string
+
+const (
+	PhaseInProgress         StatusPhase = "InProgress"
+	PhaseDeletionInProgress StatusPhase = "DeletionInProgress"
+	PhaseComplete           StatusPhase = "Complete"
+	PhasePaused             StatusPhase = "Paused"
+	PhaseFailed             StatusPhase = "Failed"
+)
+
+// DeletionPolicy controls how providers treat the data held by a cloud resource on deletion
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the cloud resource along with the cr
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain leaves the cloud resource in place, only operator owned
+	// credentials and kubernetes objects are removed
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyDeleteEmptyOnly deletes the cloud resource only when it holds no data
+	DeletionPolicyDeleteEmptyOnly DeletionPolicy = "DeleteEmptyOnly"
+	// DeletionPolicyForceDeleteObjects removes the data held by the cloud resource before
+	// deleting it
+	DeletionPolicyForceDeleteObjects DeletionPolicy = "ForceDeleteObjects"
+)
+
+// SecretRef Represents a namespace-scoped Secret
+type SecretRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ProviderConfig provider specific creation parameters keyed by the provider they apply
+// to, replacing the single untyped providerConfig block in v1alpha1. Values set here take
+// precedence over the equivalent values in the strategy config map for the tier
+type ProviderConfig struct {
+	// Default parameters applied whichever provider serves the cr, equivalent to the
+	// v1alpha1 providerConfig block
+	Default json.RawMessage `json:"default,omitempty"`
+	// AWS parameters applied only when the aws provider serves the cr
+	AWS json.RawMessage `json:"aws,omitempty"`
+	// Openshift parameters applied only when the openshift provider serves the cr
+	Openshift json.RawMessage `json:"openshift,omitempty"`
+}
+
+// CredentialRotationSpec Represents the configuration of periodic credential rotation
+type CredentialRotationSpec struct {
+	// Interval between credential rotations, parsed as a go duration e.g. 720h
+	Interval string `json:"interval"`
+}
+
+// RestoreFromSpec Represents the backup a newly created resource is hydrated from
+type RestoreFromSpec struct {
+	// SnapshotIdentifier the identifier of an existing snapshot to restore from
+	SnapshotIdentifier string `json:"snapshotIdentifier,omitempty"`
+	// Timestamp a point in time to restore to, parsed as RFC3339, requires SourceResourceID
+	Timestamp string `json:"timestamp,omitempty"`
+	// SourceResourceID the identifier of the source resource for a point in time restore
+	SourceResourceID string `json:"sourceResourceID,omitempty"`
+}
+
+// ScheduleSpec Represents a recurring working window for a resource, the resource is started
+// at the start expression and stopped at the stop expression
+type ScheduleSpec struct {
+	// Start cron expression (minute hour day-of-month month day-of-week) for when the
+	// resource is started
+	Start string `json:"start"`
+	// Stop cron expression for when the resource is stopped
+	Stop string `json:"stop"`
+}
+
+// ResourceSpec Represents the information required to provision a resource type
+// +k8s:openapi-gen=true
+type ResourceSpec struct {
+	Type       string `json:"type"`
+	Tier       string `json:"tier"`
+	SkipCreate bool   `json:"skipCreate,omitempty"`
+	AutoHeal   bool   `json:"autoHeal,omitempty"`
+	// DryRun makes providers compute and report what they would create in the status message
+	// and an event without calling any mutating cloud apis
+	DryRun    bool       `json:"dryRun,omitempty"`
+	SecretRef *SecretRef `json:"secretRef"`
+	// Size an abstract size (small/medium/large) mapped to a provider instance class through the
+	// strategy config map for the tier, an explicit provider instance class is also accepted
+	Size string `json:"size,omitempty"`
+	// ProviderConfig provider specific creation parameters keyed by provider
+	ProviderConfig *ProviderConfig `json:"providerConfig,omitempty"`
+	// CredentialRotation configures periodic rotation of the master credentials for the resource
+	CredentialRotation *CredentialRotationSpec `json:"credentialRotation,omitempty"`
+	// RestoreFrom hydrates a newly created resource from an existing backup, either a snapshot
+	// identifier or a point in time on a source resource
+	RestoreFrom *RestoreFromSpec `json:"restoreFrom,omitempty"`
+	// Schedule stops the resource outside the configured working window, used by dev and test
+	// tiers to cut cost for environments that are idle outside working hours
+	Schedule *ScheduleSpec `json:"schedule,omitempty"`
+	// TTL deletes the resource automatically once the duration has elapsed since creation,
+	// parsed as a go duration e.g. 72h, used by preview environments so short lived resources
+	// are not left running after the environment is gone
+	TTL string `json:"ttl,omitempty"`
+	// DeletionPolicy controls how the data held by the cloud resource is treated when the cr
+	// is deleted, the blobstorage providers default to DeleteEmptyOnly and the postgres and
+	// redis providers default to Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// Extensions database extensions created on the provisioned database once it is
+	// available, requests not in the engine's allowed extension list are surfaced through
+	// the UnsupportedExtensions status condition, only used by the postgres providers
+	Extensions []string `json:"extensions,omitempty"`
+	// InitSQLRef references a config map or secret holding bootstrap sql applied exactly
+	// once after the database is first provisioned, the checksum of the applied sql is
+	// recorded on the cr so changed sql is not silently re-run, only used by the postgres
+	// providers
+	InitSQLRef *InitSQLRefSpec `json:"initSQLRef,omitempty"`
+}
+
+// InitSQLRefSpec references the source of the bootstrap sql applied after first
+// provisioning, exactly one of ConfigMap or Secret must name an object in the cr namespace
+type InitSQLRefSpec struct {
+	// ConfigMap the name of a config map holding the bootstrap sql
+	ConfigMap string `json:"configMap,omitempty"`
+	// Secret the name of a secret holding the bootstrap sql, used when the sql contains
+	// sensitive values such as role passwords
+	Secret string `json:"secret,omitempty"`
+	// Key the key holding the sql in the referenced object, defaults to init.sql
+	Key string `json:"key,omitempty"`
+}
+
+// Condition Represents a single observed condition of a provisioned resource, v1beta1
+// adds the machine readable Reason field missing from the v1alpha1 condition
+// +k8s:openapi-gen=true
+type Condition struct {
+	Type               string      `json:"type"`
+	Status             string      `json:"status"`
+	Reason             string      `json:"reason,omitempty"`
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// ResourceStatus Represents the status information provided by a resource provider
+// +k8s:openapi-gen=true
+type ResourceStatus struct {
+	Strategy  string      `json:"strategy,omitempty"`
+	Provider  string      `json:"provider,omitempty"`
+	SecretRef *SecretRef  `json:"secretRef,omitempty"`
+	Phase     StatusPhase `json:"phase,omitempty"`
+	Message   string      `json:"message,omitempty"`
+	// ResourceID the cloud native identifier of the provisioned resource, e.g. an rds instance
+	// arn, an elasticache replication group id or an s3 bucket arn, exposed so external tooling
+	// can locate the resource without relying on naming conventions
+	ResourceID string      `json:"resourceID,omitempty"`
+	Conditions []Condition `json:"conditions,omitempty"`
+	// EarliestRestorableTime the earliest point in time the resource can be restored to, only
+	// set for resource types that support point in time recovery
+	EarliestRestorableTime *metav1.Time `json:"earliestRestorableTime,omitempty"`
+	// NextMaintenanceWindow the start of the next preferred maintenance window of the
+	// resource, only set for resource types where the provider applies pending changes in a
+	// maintenance window
+	NextMaintenanceWindow *metav1.Time `json:"nextMaintenanceWindow,omitempty"`
+	// StandbyEndpoint the connection endpoint of the disaster recovery standby, only set when
+	// the strategy for the tier maintains a cross region standby
+	StandbyEndpoint string `json:"standbyEndpoint,omitempty"`
+	// PerformanceInsightsID the identifier the provider console uses for the performance
+	// dashboard of the resource, only set when the strategy for the tier enables performance
+	// insights
+	PerformanceInsightsID string `json:"performanceInsightsId,omitempty"`
+	// AllocatedStorageGB the storage currently allocated to the resource in gibibytes, only
+	// set for resource types with provisioned storage
+	AllocatedStorageGB int64 `json:"allocatedStorageGB,omitempty"`
+	// MaxAllocatedStorageGB the upper limit storage autoscaling can grow the resource to in
+	// gibibytes, only set when the strategy for the tier enables storage autoscaling
+	MaxAllocatedStorageGB int64 `json:"maxAllocatedStorageGB,omitempty"`
+	// FailureCount the number of consecutive failed reconciles, used by controllers to back
+	// off requeues of failing resources
+	FailureCount int64 `json:"failureCount,omitempty"`
+	// Stage the provisioning stage the resource is currently in, a finer grained view of Phase
+	Stage StatusStage `json:"stage,omitempty"`
+	// StageHistory the stages the resource has moved through and when each was entered
+	StageHistory []StageTransition `json:"stageHistory,omitempty"`
+}
+
+// StatusStage the provisioning stage a resource is in, see the v1alpha1 types package for the
+// full set of values
+type StatusStage string
+
+// StageTransition records when a resource entered a provisioning stage
+type StageTransition struct {
+	Stage     StatusStage `json:"stage"`
+	StartedAt metav1.Time `json:"startedAt,omitempty"`
+}
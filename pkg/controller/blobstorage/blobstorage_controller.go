@@ -2,10 +2,11 @@ package blobstorage
 
 import (
 	"context"
-	"fmt"
+	"time"
 
 	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
 	"github.com/integr8ly/cloud-resource-operator/pkg/providers/openshift"
+	"github.com/integr8ly/cloud-resource-operator/pkg/reconciler"
 	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
 
 	"github.com/sirupsen/logrus"
@@ -15,10 +16,7 @@ import (
 	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
 
 	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
-	errorUtil "github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -35,150 +33,87 @@ func Add(mgr manager.Manager) error {
 	return add(mgr, newReconciler(mgr))
 }
 
-// newReconciler returns a new reconcile.Reconciler
+// newReconciler returns a new reconcile.Reconciler, the shared reconcile loop lives in the
+// reconciler package, only the blob storage specific glue is defined here
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 	client := mgr.GetClient()
 	logger := logrus.WithFields(logrus.Fields{"controller": "controller_blobstorage"})
-	providerList := []providers.BlobStorageProvider{aws.NewAWSBlobStorageProvider(client, logger), openshift.NewBlobStorageProvider(client, logger)}
-	rp := resources.NewResourceProvider(client, mgr.GetScheme(), logger)
-	return &ReconcileBlobStorage{
-		client:           client,
-		scheme:           mgr.GetScheme(),
-		logger:           logger,
-		resourceProvider: rp,
-		providerList:     providerList,
+	// providers for disabled strategies are never constructed so no cloud clients exist for
+	// providers that cannot serve a cr on this cluster
+	var providerList []providers.BlobStorageProvider
+	if providers.StrategyEnabled(providers.AWSDeploymentStrategy) {
+		providerList = append(providerList, aws.NewAWSBlobStorageProvider(client, logger))
+	}
+	if providers.StrategyEnabled(providers.OpenShiftDeploymentStrategy) {
+		providerList = append(providerList, openshift.NewBlobStorageProvider(client, logger))
+	}
+	resourceProviders := make([]reconciler.ResourceProvider, 0, len(providerList))
+	for _, p := range providerList {
+		resourceProviders = append(resourceProviders, &blobStorageProviderAdapter{provider: p})
+	}
+	return &reconciler.Reconciler{
+		Client:           client,
+		Logger:           logger,
+		EventRecorder:    mgr.GetEventRecorderFor("blobstorage-controller"),
+		ResourceProvider: resources.NewResourceProvider(client, mgr.GetScheme(), logger),
+		ResourceType:     providers.BlobStorageResourceType,
+		Kind:             "BlobStorage",
+		FriendlyName:     "blob storage",
+		NewInstance:      func() runtime.Object { return &v1alpha1.BlobStorage{} },
+		StrategyForMapping: func(m *providers.DeploymentStrategyMapping) string {
+			return m.BlobStorage
+		},
+		Providers: resourceProviders,
 	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("blobstorage-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("blobstorage-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: resources.GetMaxConcurrentReconciles(1)})
 	if err != nil {
 		return err
 	}
 
 	// Watch for changes to primary resource BlobStorage
-	err = c.Watch(&source.Kind{Type: &v1alpha1.BlobStorage{}}, &handler.EnqueueRequestForObject{})
+	// build the label selector predicate used to restrict the crs served by the operator
+	watchPredicate, err := resources.BuildWatchLabelSelectorPredicate()
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Kind{Type: &v1alpha1.BlobStorage{}}, &handler.EnqueueRequestForObject{}, watchPredicate)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-// blank assignment to verify that ReconcileBlobStorage implements reconcile.Reconciler
-var _ reconcile.Reconciler = &ReconcileBlobStorage{}
-
-// ReconcileBlobStorage defines the struct consumed by the blobstorage reconciler
-type ReconcileBlobStorage struct {
-	// This client, initialized using mgr.Client() above, is a split client
-	// that reads objects from the cache and writes to the apiserver
-	client           client.Client
-	scheme           *runtime.Scheme
-	logger           *logrus.Entry
-	resourceProvider *resources.ReconcileResourceProvider
-	providerList     []providers.BlobStorageProvider
+// blobStorageProviderAdapter maps the typed blob storage provider interface onto the shared
+// reconcile loop
+type blobStorageProviderAdapter struct {
+	provider providers.BlobStorageProvider
 }
 
-func (r *ReconcileBlobStorage) Reconcile(request reconcile.Request) (reconcile.Result, error) {
-	r.logger.Info("reconciling BlobStorage")
-	ctx := context.TODO()
-	cfgMgr := providers.NewConfigManager(providers.DefaultProviderConfigMapName, request.Namespace, r.client)
-
-	// Fetch the BlobStorage instance
-	instance := &v1alpha1.BlobStorage{}
-	err := r.client.Get(ctx, request.NamespacedName, instance)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			// Request object not found, could have been deleted after reconcile request.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
-			// Return and don't requeue
-			return reconcile.Result{}, nil
-		}
-		// Error reading the object - requeue the request.
-		return reconcile.Result{}, err
-	}
+func (a *blobStorageProviderAdapter) GetName() string {
+	return a.provider.GetName()
+}
 
-	stratMap, err := cfgMgr.GetStrategyMappingForDeploymentType(ctx, instance.Spec.Type)
-	if err != nil {
-		if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, croType.StatusDeploymentConfigNotFound.WrapError(err)); updateErr != nil {
-			return reconcile.Result{}, updateErr
-		}
-		return reconcile.Result{}, err
-	}
+func (a *blobStorageProviderAdapter) SupportsStrategy(s string) bool {
+	return a.provider.SupportsStrategy(s)
+}
 
-	// Check the CR for existing Strategy
-	strategyToUse := stratMap.BlobStorage
-	if instance.Status.Strategy != "" {
-		strategyToUse = instance.Status.Strategy
-		if strategyToUse != stratMap.BlobStorage {
-			r.logger.Infof("strategy and provider already set, changing of cloud-resource-config config maps not allowed in existing installation. the existing strategy is '%s' , cloud-resource-config is now set to '%s'. operator will continue to use existing strategy", strategyToUse, stratMap.BlobStorage)
-		}
-	}
+func (a *blobStorageProviderAdapter) ReconcileTime(o runtime.Object) time.Duration {
+	return a.provider.GetReconcileTime(o.(*v1alpha1.BlobStorage))
+}
 
-	for _, p := range r.providerList {
-		if !p.SupportsStrategy(strategyToUse) {
-			continue
-		}
-		instance.Status.Strategy = strategyToUse
-		instance.Status.Provider = p.GetName()
-		if instance.Status.Strategy != strategyToUse || instance.Status.Provider != p.GetName() {
-			if err = r.client.Status().Update(ctx, instance); err != nil {
-				return reconcile.Result{}, errorUtil.Wrapf(err, "failed to update instance %s in namespace %s", instance.Name, instance.Namespace)
-			}
-		}
-
-		if instance.GetDeletionTimestamp() != nil {
-			msg, err := p.DeleteStorage(ctx, instance)
-			if err != nil {
-				if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
-					return reconcile.Result{}, updateErr
-				}
-				return reconcile.Result{}, errorUtil.Wrapf(err, "failed to perform provider-specific storage deletion")
-			}
-
-			r.logger.Info("waiting on blob storage to successfully delete")
-			if err = resources.UpdatePhase(ctx, r.client, instance, croType.PhaseDeleteInProgress, msg.WrapError(err)); err != nil {
-				return reconcile.Result{}, err
-			}
-			return reconcile.Result{Requeue: true, RequeueAfter: p.GetReconcileTime(instance)}, nil
-		}
-
-		bsi, msg, err := p.CreateStorage(ctx, instance)
-		if err != nil {
-			instance.Status.SecretRef = &croType.SecretRef{}
-			if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
-				return reconcile.Result{}, updateErr
-			}
-			return reconcile.Result{}, err
-		}
-		if bsi == nil {
-			r.logger.Info("secret data is still reconciling, blob storage is nil")
-			instance.Status.SecretRef = &croType.SecretRef{}
-			if err = resources.UpdatePhase(ctx, r.client, instance, croType.PhaseInProgress, msg); err != nil {
-				return reconcile.Result{}, err
-			}
-			return reconcile.Result{Requeue: true, RequeueAfter: p.GetReconcileTime(instance)}, nil
-		}
-
-		if err := r.resourceProvider.ReconcileResultSecret(ctx, instance, bsi.DeploymentDetails.Data()); err != nil {
-			return reconcile.Result{}, errorUtil.Wrap(err, "failed to reconcile secret")
-		}
-
-		instance.Status.Phase = croType.PhaseComplete
-		instance.Status.Message = msg
-		instance.Status.SecretRef = instance.Spec.SecretRef
-		instance.Status.Strategy = strategyToUse
-		instance.Status.Provider = p.GetName()
-		if err = r.client.Status().Update(ctx, instance); err != nil {
-			return reconcile.Result{}, errorUtil.Wrapf(err, "failed to update instance %s in namespace %s", instance.Name, instance.Namespace)
-		}
-		return reconcile.Result{Requeue: true, RequeueAfter: p.GetReconcileTime(instance)}, nil
+func (a *blobStorageProviderAdapter) Create(ctx context.Context, o runtime.Object) (providers.DeploymentDetails, croType.StatusMessage, error) {
+	instance, msg, err := a.provider.CreateStorage(ctx, o.(*v1alpha1.BlobStorage))
+	if instance == nil {
+		return nil, msg, err
 	}
+	return instance.DeploymentDetails, msg, err
+}
 
-	// unsupported strategy
-	if err = resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, croType.StatusUnsupportedType.WrapError(err)); err != nil {
-		return reconcile.Result{}, err
-	}
-	return reconcile.Result{}, errorUtil.New(fmt.Sprintf("unsupported deployment strategy %s", stratMap.BlobStorage))
+func (a *blobStorageProviderAdapter) Delete(ctx context.Context, o runtime.Object) (croType.StatusMessage, error) {
+	return a.provider.DeleteStorage(ctx, o.(*v1alpha1.BlobStorage))
 }
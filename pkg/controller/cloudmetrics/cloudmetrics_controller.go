@@ -28,11 +28,14 @@ const (
 	postgresFreeStorageAverage    = "cro_postgres_free_storage_average"
 	postgresCPUUtilizationAverage = "cro_postgres_cpu_utilization_average"
 	postgresFreeableMemoryAverage = "cro_postgres_freeable_memory_average"
+	postgresConnectionsAverage    = "cro_postgres_database_connections_average"
 
 	redisMemoryUsagePercentageAverage = "cro_redis_memory_usage_percentage_average"
 	redisFreeableMemoryAverage        = "cro_redis_freeable_memory_average"
 	redisCPUUtilizationAverage        = "cro_redis_cpu_utilization_average"
 	redisEngineCPUUtilizationAverage  = "cro_redis_engine_cpu_utilization_average"
+	redisConnectionsAverage           = "cro_redis_current_connections_average"
+	redisReplicationLagAverage        = "cro_redis_replication_lag_average"
 
 	labelClusterIDKey   = "clusterID"
 	labelResourceIDKey  = "resourceID"
@@ -110,6 +113,22 @@ var postgresGaugeMetrics = []CroGaugeMetric{
 			},
 		},
 	},
+	{
+		Name: postgresConnectionsAverage,
+		GaugeVec: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: postgresConnectionsAverage,
+				Help: "The number of database connections in use. Units: Count",
+			},
+			labels),
+		ProviderType: map[string]providers.CloudProviderMetricType{
+			providers.AWSDeploymentStrategy: {
+				PromethuesMetricName: postgresConnectionsAverage,
+				ProviderMetricName:   "DatabaseConnections",
+				Statistic:            cloudwatch.StatisticAverage,
+			},
+		},
+	},
 }
 
 // redisGaugeMetrics stores a mapping between an exposed (redis) prometheus metric and multiple cloud provider specific metric
@@ -180,6 +199,38 @@ var redisGaugeMetrics = []CroGaugeMetric{
 			},
 		},
 	},
+	{
+		Name: redisConnectionsAverage,
+		GaugeVec: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: redisConnectionsAverage,
+				Help: "The number of client connections, excluding connections from read replicas. Units: Count",
+			},
+			labels),
+		ProviderType: map[string]providers.CloudProviderMetricType{
+			providers.AWSDeploymentStrategy: {
+				PromethuesMetricName: redisConnectionsAverage,
+				ProviderMetricName:   "CurrConnections",
+				Statistic:            cloudwatch.StatisticAverage,
+			},
+		},
+	},
+	{
+		Name: redisReplicationLagAverage,
+		GaugeVec: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: redisReplicationLagAverage,
+				Help: "How far behind, in seconds, the replica is in applying changes from the primary node. Units: Seconds",
+			},
+			labels),
+		ProviderType: map[string]providers.CloudProviderMetricType{
+			providers.AWSDeploymentStrategy: {
+				PromethuesMetricName: redisReplicationLagAverage,
+				ProviderMetricName:   "ReplicationLag",
+				Statistic:            cloudwatch.StatisticAverage,
+			},
+		},
+	},
 }
 
 // Add creates a new CloudMetrics Controller and adds it to the Manager. The Manager will set fields on the Controller
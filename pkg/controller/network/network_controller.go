@@ -0,0 +1,152 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	integreatlyv1alpha1 "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	croAws "github.com/integr8ly/cloud-resource-operator/pkg/providers/aws"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	errorUtil "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Add creates a new Network Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	logger := logrus.WithFields(logrus.Fields{"controller": "controller_network"})
+	provider := croAws.NewAWSNetworkProvider(mgr.GetClient(), logger)
+	return &ReconcileNetwork{
+		client:        mgr.GetClient(),
+		scheme:        mgr.GetScheme(),
+		logger:        logger,
+		eventRecorder: mgr.GetEventRecorderFor("network-controller"),
+		provider:      provider,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("network-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: resources.GetMaxConcurrentReconciles(1)})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource Network
+	// build the label selector predicate used to restrict the crs served by the operator
+	watchPredicate, err := resources.BuildWatchLabelSelectorPredicate()
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Kind{Type: &integreatlyv1alpha1.Network{}}, &handler.EnqueueRequestForObject{}, watchPredicate)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// blank assignment to verify that ReconcileNetwork implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileNetwork{}
+
+// ReconcileNetwork reconciles a Network object
+type ReconcileNetwork struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client        client.Client
+	scheme        *runtime.Scheme
+	logger        *logrus.Entry
+	eventRecorder record.EventRecorder
+	provider      providers.NetworkProvider
+}
+
+// Reconcile reads that state of the cluster for a Network object and makes changes based on the state read
+// and what is in the Network.Spec
+func (r *ReconcileNetwork) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	r.logger.Info("reconciling network")
+	ctx := context.TODO()
+
+	// Fetch the Network instance
+	instance := &integreatlyv1alpha1.Network{}
+	err := r.client.Get(ctx, request.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	if instance.Spec.Tier == "" {
+		errMsg := fmt.Sprintf("no tier set on network %s, a tier is required to look up the _network strategy", instance.Name)
+		if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, croType.StatusMessage(errMsg)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{}, errorUtil.New(errMsg)
+	}
+
+	// delete the network if the deletion timestamp exists
+	if instance.DeletionTimestamp != nil {
+		if instance.Status.Phase != croType.PhaseDeleteInProgress {
+			r.eventRecorder.Event(instance, corev1.EventTypeNormal, resources.EventReasonDeleting, "network deletion started")
+		}
+		msg, err := r.provider.DeleteNetwork(ctx, instance)
+		if err != nil {
+			resources.RecordFailureEvent(r.eventRecorder, instance, err)
+			if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
+				return reconcile.Result{}, updateErr
+			}
+			return reconcile.Result{}, errorUtil.Wrapf(err, "failed to perform provider-specific network deletion")
+		}
+
+		r.logger.Info("waiting on network to successfully delete")
+		if err = resources.UpdatePhase(ctx, r.client, instance, croType.PhaseDeleteInProgress, msg); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: r.provider.GetReconcileTime(instance)}, nil
+	}
+
+	// reconcile the network and return the phase
+	msg, err := r.provider.ReconcileNetwork(ctx, instance)
+	if err != nil {
+		instance.Status.Conditions = croType.SetCondition(instance.Status.Conditions, resources.BuildFailureCondition(err))
+		if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		resources.SetFailureMetric(string(providers.NetworkResourceType), instance.Name, instance.Namespace, err)
+		resources.RecordFailureEvent(r.eventRecorder, instance, err)
+		r.logger.Errorf("failed to reconcile network: %v", err)
+		// requeue based on the error class, failures that will not resolve without user
+		// intervention back off to the max instead of using the controller backoff
+		return reconcile.Result{Requeue: true, RequeueAfter: resources.GetErrorReconcileTime(err, instance.Status.FailureCount)}, nil
+	}
+
+	if instance.Status.Phase != croType.PhaseComplete {
+		r.eventRecorder.Event(instance, corev1.EventTypeNormal, resources.EventReasonProvisioned, "standalone network successfully reconciled")
+	}
+	if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseComplete, msg); updateErr != nil {
+		return reconcile.Result{}, updateErr
+	}
+	return reconcile.Result{Requeue: true, RequeueAfter: r.provider.GetReconcileTime(instance)}, nil
+}
@@ -2,12 +2,13 @@ package postgres
 
 import (
 	"context"
-	"fmt"
+	"time"
 
 	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/integr8ly/cloud-resource-operator/pkg/providers/aws"
+	"github.com/integr8ly/cloud-resource-operator/pkg/reconciler"
 	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
 
 	"github.com/integr8ly/cloud-resource-operator/pkg/providers/openshift"
@@ -19,9 +20,7 @@ import (
 	errorUtil "github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 
-	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -42,32 +41,56 @@ func Add(mgr manager.Manager) error {
 	return add(mgr, newReconciler(mgr, clientSet))
 }
 
-// newReconciler returns a new reconcile.Reconciler
+// newReconciler returns a new reconcile.Reconciler, the shared reconcile loop lives in the
+// reconciler package, only the postgres specific glue is defined here
 func newReconciler(mgr manager.Manager, cs *kubernetes.Clientset) reconcile.Reconciler {
 	client := mgr.GetClient()
 
 	logger := logrus.WithFields(logrus.Fields{"controller": "controller_postgres"})
-	providerList := []providers.PostgresProvider{openshift.NewOpenShiftPostgresProvider(client, cs, logger), aws.NewAWSPostgresProvider(client, logger)}
-	rp := resources.NewResourceProvider(client, mgr.GetScheme(), logger)
-	return &ReconcilePostgres{
-		client:           client,
-		scheme:           mgr.GetScheme(),
-		logger:           logger,
-		resourceProvider: rp,
-		providerList:     providerList,
+	// providers for disabled strategies are never constructed so no cloud clients exist for
+	// providers that cannot serve a cr on this cluster
+	var providerList []providers.PostgresProvider
+	if providers.StrategyEnabled(providers.OpenShiftDeploymentStrategy) {
+		providerList = append(providerList, openshift.NewOpenShiftPostgresProvider(client, cs, logger))
+	}
+	if providers.StrategyEnabled(providers.AWSDeploymentStrategy) {
+		providerList = append(providerList, aws.NewAWSPostgresProvider(client, logger))
+	}
+	resourceProviders := make([]reconciler.ResourceProvider, 0, len(providerList))
+	for _, p := range providerList {
+		resourceProviders = append(resourceProviders, &postgresProviderAdapter{provider: p})
+	}
+	return &reconciler.Reconciler{
+		Client:           client,
+		Logger:           logger,
+		EventRecorder:    mgr.GetEventRecorderFor("postgres-controller"),
+		ResourceProvider: resources.NewResourceProvider(client, mgr.GetScheme(), logger),
+		ResourceType:     providers.PostgresResourceType,
+		Kind:             "Postgres",
+		FriendlyName:     "postgres instance",
+		NewInstance:      func() runtime.Object { return &v1alpha1.Postgres{} },
+		StrategyForMapping: func(m *providers.DeploymentStrategyMapping) string {
+			return m.Postgres
+		},
+		Providers: resourceProviders,
 	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("postgres-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("postgres-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: resources.GetMaxConcurrentReconciles(1)})
 	if err != nil {
 		return err
 	}
 
 	// Watch for changes to primary resource Postgres
-	err = c.Watch(&source.Kind{Type: &v1alpha1.Postgres{}}, &handler.EnqueueRequestForObject{})
+	// build the label selector predicate used to restrict the crs served by the operator
+	watchPredicate, err := resources.BuildWatchLabelSelectorPredicate()
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Kind{Type: &v1alpha1.Postgres{}}, &handler.EnqueueRequestForObject{}, watchPredicate)
 	if err != nil {
 		return err
 	}
@@ -84,135 +107,32 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	return nil
 }
 
-// blank assignment to verify that ReconcilePostgres implements reconcile.Reconciler
-var _ reconcile.Reconciler = &ReconcilePostgres{}
-
-// ReconcilePostgres defines the struct consumed by the postgres reconciler
-type ReconcilePostgres struct {
-	// This client, initialized using mgr.Client() above, is a split client
-	// that reads objects from the cache and writes to the apiserver
-	client           client.Client
-	scheme           *runtime.Scheme
-	logger           *logrus.Entry
-	resourceProvider *resources.ReconcileResourceProvider
-	providerList     []providers.PostgresProvider
+// postgresProviderAdapter maps the typed postgres provider interface onto the shared
+// reconcile loop
+type postgresProviderAdapter struct {
+	provider providers.PostgresProvider
 }
 
-// Reconcile reads that state of the cluster for a Postgres object and makes changes based on the state read
-// and what is in the Postgres.Spec
-// The Controller will requeue the Request to be processed again if the returned error is non-nil or
-// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
-func (r *ReconcilePostgres) Reconcile(request reconcile.Request) (reconcile.Result, error) {
-	r.logger.Info("reconciling Postgres")
-	ctx := context.TODO()
-	cfgMgr := providers.NewConfigManager(providers.DefaultProviderConfigMapName, request.Namespace, r.client)
-
-	// Fetch the Postgres instance
-	instance := &v1alpha1.Postgres{}
-	err := r.client.Get(context.TODO(), request.NamespacedName, instance)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			// Request object not found, could have been deleted after reconcile request.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
-			// Return and don't requeue
-			return reconcile.Result{}, nil
-		}
-		// Error reading the object - requeue the request.
-		return reconcile.Result{}, err
-	}
+func (a *postgresProviderAdapter) GetName() string {
+	return a.provider.GetName()
+}
 
-	stratMap, err := cfgMgr.GetStrategyMappingForDeploymentType(ctx, instance.Spec.Type)
-	if err != nil {
-		if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, croType.StatusDeploymentConfigNotFound.WrapError(err)); updateErr != nil {
-			return reconcile.Result{}, updateErr
-		}
-		return reconcile.Result{}, errorUtil.Wrapf(err, "failed to read deployment type config for deployment %s", instance.Spec.Type)
-	}
+func (a *postgresProviderAdapter) SupportsStrategy(s string) bool {
+	return a.provider.SupportsStrategy(s)
+}
 
-	// Check the CR for existing Strategy
-	strategyToUse := stratMap.Postgres
-	if instance.Status.Strategy != "" {
-		strategyToUse = instance.Status.Strategy
-		if strategyToUse != stratMap.Postgres {
-			r.logger.Infof("strategy and provider already set, changing of cloud-resource-config config maps not allowed in existing installation. the existing strategy is '%s' , cloud-resource-config is now set to '%s'. operator will continue to use existing strategy", strategyToUse, stratMap.Postgres)
-		}
-	}
+func (a *postgresProviderAdapter) ReconcileTime(o runtime.Object) time.Duration {
+	return a.provider.GetReconcileTime(o.(*v1alpha1.Postgres))
+}
 
-	for _, p := range r.providerList {
-		if !p.SupportsStrategy(strategyToUse) {
-			continue
-		}
-		instance.Status.Strategy = strategyToUse
-		instance.Status.Provider = p.GetName()
-		if instance.Status.Strategy != strategyToUse || instance.Status.Provider != p.GetName() {
-			if err = r.client.Status().Update(ctx, instance); err != nil {
-				return reconcile.Result{}, errorUtil.Wrapf(err, "failed to update instance %s in namespace %s", instance.Name, instance.Namespace)
-			}
-		}
-
-		// delete the postgres if the deletion timestamp exists
-		if instance.DeletionTimestamp != nil {
-			msg, err := p.DeletePostgres(ctx, instance)
-			if err != nil {
-				if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
-					return reconcile.Result{}, updateErr
-				}
-				return reconcile.Result{}, errorUtil.Wrapf(err, "failed to perform provider-specific storage deletion")
-			}
-
-			r.logger.Info("waiting on Postgres to successfully delete")
-			if err = resources.UpdatePhase(ctx, r.client, instance, croType.PhaseDeleteInProgress, msg); err != nil {
-				return reconcile.Result{}, err
-			}
-			return reconcile.Result{Requeue: true, RequeueAfter: p.GetReconcileTime(instance)}, nil
-		}
-
-		// handle skip create
-		if instance.Spec.SkipCreate {
-			r.logger.Info("skipCreate found, skipping postgres reconcile")
-			if err := resources.UpdatePhase(ctx, r.client, instance, croType.PhasePaused, croType.StatusSkipCreate); err != nil {
-				return reconcile.Result{}, err
-			}
-			return reconcile.Result{Requeue: true, RequeueAfter: p.GetReconcileTime(instance)}, nil
-		}
-
-		// create the postgres instance
-		ps, msg, err := p.CreatePostgres(ctx, instance)
-		if err != nil {
-			instance.Status.SecretRef = &croType.SecretRef{}
-			if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
-				return reconcile.Result{}, updateErr
-			}
-			return reconcile.Result{}, err
-		}
-		if ps == nil {
-			r.logger.Info("secret data is still reconciling, postgres instance is nil")
-			instance.Status.SecretRef = &croType.SecretRef{}
-			if err = resources.UpdatePhase(ctx, r.client, instance, croType.PhaseInProgress, msg); err != nil {
-				return reconcile.Result{}, err
-			}
-			return reconcile.Result{Requeue: true, RequeueAfter: p.GetReconcileTime(instance)}, nil
-		}
-
-		// return the connection secret
-		if err := r.resourceProvider.ReconcileResultSecret(ctx, instance, ps.DeploymentDetails.Data()); err != nil {
-			return reconcile.Result{}, errorUtil.Wrap(err, "failed to reconcile secret")
-		}
-
-		instance.Status.Phase = croType.PhaseComplete
-		instance.Status.Message = msg
-		instance.Status.SecretRef = instance.Spec.SecretRef
-		instance.Status.Strategy = strategyToUse
-		instance.Status.Provider = p.GetName()
-		if err = r.client.Status().Update(ctx, instance); err != nil {
-			return reconcile.Result{}, errorUtil.Wrapf(err, "failed to update instance %s in namespace %s", instance.Name, instance.Namespace)
-		}
-		return reconcile.Result{Requeue: true, RequeueAfter: p.GetReconcileTime(instance)}, nil
+func (a *postgresProviderAdapter) Create(ctx context.Context, o runtime.Object) (providers.DeploymentDetails, croType.StatusMessage, error) {
+	instance, msg, err := a.provider.CreatePostgres(ctx, o.(*v1alpha1.Postgres))
+	if instance == nil {
+		return nil, msg, err
 	}
+	return instance.DeploymentDetails, msg, err
+}
 
-	// unsupported strategy
-	if err = resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, croType.StatusUnsupportedType.WrapError(err)); err != nil {
-		return reconcile.Result{}, err
-	}
-	return reconcile.Result{}, errorUtil.New(fmt.Sprintf("unsupported deployment strategy %s", stratMap.Postgres))
+func (a *postgresProviderAdapter) Delete(ctx context.Context, o runtime.Object) (croType.StatusMessage, error) {
+	return a.provider.DeletePostgres(ctx, o.(*v1alpha1.Postgres))
 }
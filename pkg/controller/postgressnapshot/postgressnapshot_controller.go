@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -42,6 +43,7 @@ func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 		client:            mgr.GetClient(),
 		scheme:            mgr.GetScheme(),
 		logger:            logger,
+		eventRecorder:     mgr.GetEventRecorderFor("postgressnapshot-controller"),
 		provider:          provider,
 		ConfigManager:     croAws.NewDefaultConfigMapConfigManager(mgr.GetClient()),
 		CredentialManager: croAws.NewCredentialMinterCredentialManager(mgr.GetClient()),
@@ -51,13 +53,18 @@ func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("postgressnapshot-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("postgressnapshot-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: resources.GetMaxConcurrentReconciles(1)})
 	if err != nil {
 		return err
 	}
 
 	// Watch for changes to primary resource PostgresSnapshot
-	err = c.Watch(&source.Kind{Type: &integreatlyv1alpha1.PostgresSnapshot{}}, &handler.EnqueueRequestForObject{})
+	// build the label selector predicate used to restrict the crs served by the operator
+	watchPredicate, err := resources.BuildWatchLabelSelectorPredicate()
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Kind{Type: &integreatlyv1alpha1.PostgresSnapshot{}}, &handler.EnqueueRequestForObject{}, watchPredicate)
 	if err != nil {
 		return err
 	}
@@ -84,6 +91,7 @@ type ReconcilePostgresSnapshot struct {
 	client            client.Client
 	scheme            *runtime.Scheme
 	logger            *logrus.Entry
+	eventRecorder     record.EventRecorder
 	provider          providers.PostgresSnapshotProvider
 	ConfigManager     croAws.ConfigManager
 	CredentialManager croAws.CredentialManager
@@ -135,6 +143,7 @@ func (r *ReconcilePostgresSnapshot) Reconcile(request reconcile.Request) (reconc
 	if instance.DeletionTimestamp != nil {
 		msg, err := r.provider.DeletePostgresSnapshot(ctx, instance, postgresCr)
 		if err != nil {
+			resources.RecordFailureEvent(r.eventRecorder, instance, err)
 			if updateErr := resources.UpdateSnapshotPhase(ctx, r.client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
 				return reconcile.Result{}, updateErr
 			}
@@ -159,6 +168,7 @@ func (r *ReconcilePostgresSnapshot) Reconcile(request reconcile.Request) (reconc
 
 	// error trying to create snapshot
 	if err != nil {
+		resources.RecordFailureEvent(r.eventRecorder, instance, err)
 		if updateErr := resources.UpdateSnapshotPhase(ctx, r.client, instance, croType.PhaseFailed, msg); updateErr != nil {
 			return reconcile.Result{}, updateErr
 		}
@@ -174,6 +184,7 @@ func (r *ReconcilePostgresSnapshot) Reconcile(request reconcile.Request) (reconc
 	}
 
 	// no error, snapshot exists
+	r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, resources.EventReasonSnapshotCreated, "snapshot %s successfully created", snap.Name)
 	if updateErr := resources.UpdateSnapshotPhase(ctx, r.client, instance, croType.PhaseComplete, msg); updateErr != nil {
 		return reconcile.Result{}, updateErr
 	}
@@ -0,0 +1,166 @@
+package postgresuser
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+
+	integreatlyv1alpha1 "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	croAws "github.com/integr8ly/cloud-resource-operator/pkg/providers/aws"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	errorUtil "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Add creates a new PostgresUser Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	logger := logrus.WithFields(logrus.Fields{"controller": "controller_postgres_user"})
+	provider := croAws.NewAWSPostgresUserProvider(mgr.GetClient(), logger)
+	return &ReconcilePostgresUser{
+		client:        mgr.GetClient(),
+		scheme:        mgr.GetScheme(),
+		logger:        logger,
+		eventRecorder: mgr.GetEventRecorderFor("postgresuser-controller"),
+		provider:      provider,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("postgresuser-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: resources.GetMaxConcurrentReconciles(1)})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource PostgresUser
+	// build the label selector predicate used to restrict the crs served by the operator
+	watchPredicate, err := resources.BuildWatchLabelSelectorPredicate()
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Kind{Type: &integreatlyv1alpha1.PostgresUser{}}, &handler.EnqueueRequestForObject{}, watchPredicate)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// blank assignment to verify that ReconcilePostgresUser implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcilePostgresUser{}
+
+// ReconcilePostgresUser reconciles a PostgresUser object
+type ReconcilePostgresUser struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client        client.Client
+	scheme        *runtime.Scheme
+	logger        *logrus.Entry
+	eventRecorder record.EventRecorder
+	provider      providers.PostgresUserProvider
+}
+
+// Reconcile reads that state of the cluster for a PostgresUser object and makes changes based on the state read
+// and what is in the PostgresUser.Spec
+func (r *ReconcilePostgresUser) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	r.logger.Info("reconciling postgres user")
+	ctx := context.TODO()
+
+	// Fetch the PostgresUser instance
+	instance := &integreatlyv1alpha1.PostgresUser{}
+	err := r.client.Get(ctx, request.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	// get postgres cr
+	postgresCr := &integreatlyv1alpha1.Postgres{}
+	err = r.client.Get(ctx, types.NamespacedName{Name: instance.Spec.ResourceName, Namespace: instance.Namespace}, postgresCr)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to get postgres resource: %s", err.Error())
+		if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, croType.StatusMessage(errMsg)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{}, errorUtil.New(errMsg)
+	}
+
+	// check postgres deployment strategy is aws
+	if !r.provider.SupportsStrategy(postgresCr.Status.Strategy) {
+		errMsg := fmt.Sprintf("the resource %s uses an unsupported provider strategy %s, only resources using the aws provider are valid", instance.Spec.ResourceName, postgresCr.Status.Strategy)
+		if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, croType.StatusMessage(errMsg)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{}, errorUtil.New(errMsg)
+	}
+
+	if instance.DeletionTimestamp != nil {
+		msg, err := r.provider.DeletePostgresUser(ctx, instance, postgresCr)
+		if err != nil {
+			resources.RecordFailureEvent(r.eventRecorder, instance, err)
+			if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
+				return reconcile.Result{}, updateErr
+			}
+			return reconcile.Result{}, errorUtil.Wrapf(err, "failed to delete postgres user")
+		}
+
+		r.logger.Info("waiting on postgres user to successfully delete")
+		if err = resources.UpdatePhase(ctx, r.client, instance, croType.PhaseDeleteInProgress, msg); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: r.provider.GetReconcileTime(instance)}, nil
+	}
+
+	// wait for the postgres instance to be provisioned before managing users on it
+	if postgresCr.Status.Phase != croType.PhaseComplete {
+		msg := fmt.Sprintf("waiting for postgres resource %s to be provisioned", instance.Spec.ResourceName)
+		if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseInProgress, croType.StatusMessage(msg)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: r.provider.GetReconcileTime(instance)}, nil
+	}
+
+	// reconcile the user and return the phase
+	msg, err := r.provider.ReconcilePostgresUser(ctx, instance, postgresCr)
+	if err != nil {
+		resources.RecordFailureEvent(r.eventRecorder, instance, err)
+		if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{}, err
+	}
+
+	if instance.Status.Phase != croType.PhaseComplete {
+		r.eventRecorder.Event(instance, corev1.EventTypeNormal, resources.EventReasonProvisioned, "postgres user successfully reconciled")
+	}
+	if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseComplete, msg); updateErr != nil {
+		return reconcile.Result{}, updateErr
+	}
+	return reconcile.Result{Requeue: true, RequeueAfter: r.provider.GetReconcileTime(instance)}, nil
+}
@@ -2,21 +2,19 @@ package redis
 
 import (
 	"context"
-	"fmt"
+	"time"
 
 	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	"github.com/integr8ly/cloud-resource-operator/pkg/reconciler"
 	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
 
 	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
 	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
 	"github.com/integr8ly/cloud-resource-operator/pkg/providers/aws"
 	"github.com/integr8ly/cloud-resource-operator/pkg/providers/openshift"
-	errorUtil "github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -33,31 +31,55 @@ func Add(mgr manager.Manager) error {
 	return add(mgr, newReconciler(mgr))
 }
 
-// newReconciler returns a new reconcile.Reconciler
+// newReconciler returns a new reconcile.Reconciler, the shared reconcile loop lives in the
+// reconciler package, only the redis specific glue is defined here
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 	client := mgr.GetClient()
 	logger := logrus.WithFields(logrus.Fields{"controller": "controller_redis"})
-	providerList := []providers.RedisProvider{aws.NewAWSRedisProvider(client, logger), openshift.NewOpenShiftRedisProvider(client, logger)}
-	rp := resources.NewResourceProvider(client, mgr.GetScheme(), logger)
-	return &ReconcileRedis{
-		client:           mgr.GetClient(),
-		scheme:           mgr.GetScheme(),
-		logger:           logger,
-		resourceProvider: rp,
-		providerList:     providerList,
+	// providers for disabled strategies are never constructed so no cloud clients exist for
+	// providers that cannot serve a cr on this cluster
+	var providerList []providers.RedisProvider
+	if providers.StrategyEnabled(providers.AWSDeploymentStrategy) {
+		providerList = append(providerList, aws.NewAWSRedisProvider(client, logger))
+	}
+	if providers.StrategyEnabled(providers.OpenShiftDeploymentStrategy) {
+		providerList = append(providerList, openshift.NewOpenShiftRedisProvider(client, logger))
+	}
+	resourceProviders := make([]reconciler.ResourceProvider, 0, len(providerList))
+	for _, p := range providerList {
+		resourceProviders = append(resourceProviders, &redisProviderAdapter{provider: p})
+	}
+	return &reconciler.Reconciler{
+		Client:           client,
+		Logger:           logger,
+		EventRecorder:    mgr.GetEventRecorderFor("redis-controller"),
+		ResourceProvider: resources.NewResourceProvider(client, mgr.GetScheme(), logger),
+		ResourceType:     providers.RedisResourceType,
+		Kind:             "Redis",
+		FriendlyName:     "redis cluster",
+		NewInstance:      func() runtime.Object { return &v1alpha1.Redis{} },
+		StrategyForMapping: func(m *providers.DeploymentStrategyMapping) string {
+			return m.Redis
+		},
+		Providers: resourceProviders,
 	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("redis-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("redis-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: resources.GetMaxConcurrentReconciles(1)})
 	if err != nil {
 		return err
 	}
 
 	// Watch for changes to primary resource Redis
-	err = c.Watch(&source.Kind{Type: &v1alpha1.Redis{}}, &handler.EnqueueRequestForObject{})
+	// build the label selector predicate used to restrict the crs served by the operator
+	watchPredicate, err := resources.BuildWatchLabelSelectorPredicate()
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Kind{Type: &v1alpha1.Redis{}}, &handler.EnqueueRequestForObject{}, watchPredicate)
 	if err != nil {
 		return err
 	}
@@ -74,136 +96,32 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	return nil
 }
 
-// blank assignment to verify that ReconcileRedis implements reconcile.Reconciler
-var _ reconcile.Reconciler = &ReconcileRedis{}
-
-// ReconcileRedis defines the struct consumed by the redis reconciler
-type ReconcileRedis struct {
-	// This client, initialized using mgr.Client() above, is a split client
-	// that reads objects from the cache and writes to the apiserver
-	client           client.Client
-	scheme           *runtime.Scheme
-	logger           *logrus.Entry
-	resourceProvider *resources.ReconcileResourceProvider
-	providerList     []providers.RedisProvider
+// redisProviderAdapter maps the typed redis provider interface onto the shared reconcile
+// loop
+type redisProviderAdapter struct {
+	provider providers.RedisProvider
 }
 
-// Reconcile reads that state of the cluster for a Redis object and makes changes based on the state read
-// and what is in the Redis.Spec
-// The Controller will requeue the Request to be processed again if the returned error is non-nil or
-// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
-func (r *ReconcileRedis) Reconcile(request reconcile.Request) (reconcile.Result, error) {
-	r.logger.Info("reconciling Redis")
-	ctx := context.TODO()
-	cfgMgr := providers.NewConfigManager(providers.DefaultProviderConfigMapName, request.Namespace, r.client)
-
-	// Fetch the Redis instance
-	instance := &v1alpha1.Redis{}
-	err := r.client.Get(ctx, request.NamespacedName, instance)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			// Request object not found, could have been deleted after reconcile request.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
-			// Return and don't requeue
-			return reconcile.Result{}, nil
-		}
-		// Error reading the object - requeue the request.
-		return reconcile.Result{}, err
-	}
+func (a *redisProviderAdapter) GetName() string {
+	return a.provider.GetName()
+}
 
-	stratMap, err := cfgMgr.GetStrategyMappingForDeploymentType(ctx, instance.Spec.Type)
-	if err != nil {
-		if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, croType.StatusDeploymentConfigNotFound.WrapError(err)); updateErr != nil {
-			return reconcile.Result{}, updateErr
-		}
-		return reconcile.Result{}, errorUtil.Wrapf(err, "failed to read deployment type config for deployment %s", instance.Spec.Type)
-	}
+func (a *redisProviderAdapter) SupportsStrategy(s string) bool {
+	return a.provider.SupportsStrategy(s)
+}
 
-	// Check the CR for existing Strategy
-	strategyToUse := stratMap.Redis
-	if instance.Status.Strategy != "" {
-		strategyToUse = instance.Status.Strategy
-		if strategyToUse != stratMap.Redis {
-			r.logger.Infof("strategy and provider already set, changing of cloud-resource-config config maps not allowed in existing installation. the existing strategy is '%s' , cloud-resource-config is now set to '%s'. operator will continue to use existing strategy", strategyToUse, stratMap.Redis)
-		}
-	}
+func (a *redisProviderAdapter) ReconcileTime(o runtime.Object) time.Duration {
+	return a.provider.GetReconcileTime(o.(*v1alpha1.Redis))
+}
 
-	for _, p := range r.providerList {
-		if !p.SupportsStrategy(strategyToUse) {
-			continue
-		}
-		instance.Status.Strategy = strategyToUse
-		instance.Status.Provider = p.GetName()
-		if instance.Status.Strategy != strategyToUse || instance.Status.Provider != p.GetName() {
-			if err = r.client.Status().Update(ctx, instance); err != nil {
-				return reconcile.Result{}, errorUtil.Wrapf(err, "failed to update instance %s in namespace %s", instance.Name, instance.Namespace)
-			}
-		}
-
-		// handle deletion of redis and remove any finalizers added
-		if instance.GetDeletionTimestamp() != nil {
-			msg, err := p.DeleteRedis(ctx, instance)
-			if err != nil {
-				if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
-					return reconcile.Result{}, updateErr
-				}
-				return reconcile.Result{}, errorUtil.Wrapf(err, "failed to perform provider specific cluster deletion")
-			}
-
-			r.logger.Info("waiting for redis cluster to successfully delete")
-			if err = resources.UpdatePhase(ctx, r.client, instance, croType.PhaseDeleteInProgress, msg); err != nil {
-				return reconcile.Result{}, err
-			}
-			return reconcile.Result{Requeue: true, RequeueAfter: p.GetReconcileTime(instance)}, nil
-		}
-
-		// handle skip create
-		if instance.Spec.SkipCreate {
-			r.logger.Info("skipCreate found, skipping redis reconcile")
-			if err := resources.UpdatePhase(ctx, r.client, instance, croType.PhasePaused, croType.StatusSkipCreate); err != nil {
-				return reconcile.Result{}, err
-			}
-			return reconcile.Result{Requeue: true, RequeueAfter: p.GetReconcileTime(instance)}, nil
-		}
-
-		// handle creation of redis and apply any finalizers to instance required for deletion
-		redis, msg, err := p.CreateRedis(ctx, instance)
-		if err != nil {
-			instance.Status.SecretRef = &croType.SecretRef{}
-			if updateErr := resources.UpdatePhase(ctx, r.client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
-				return reconcile.Result{}, updateErr
-			}
-			return reconcile.Result{}, err
-		}
-		if redis == nil {
-			instance.Status.SecretRef = &croType.SecretRef{}
-			r.logger.Info("waiting for redis cluster to become available")
-			if err = resources.UpdatePhase(ctx, r.client, instance, croType.PhaseInProgress, msg); err != nil {
-				return reconcile.Result{}, err
-			}
-			return reconcile.Result{Requeue: true, RequeueAfter: p.GetReconcileTime(instance)}, nil
-		}
-
-		// create the secret with the redis cluster connection details
-		if err := r.resourceProvider.ReconcileResultSecret(ctx, instance, redis.DeploymentDetails.Data()); err != nil {
-			return reconcile.Result{}, errorUtil.Wrap(err, "failed to reconcile secret")
-		}
-
-		// update the redis custom resource
-		instance.Status.Phase = croType.PhaseComplete
-		instance.Status.Message = msg
-		instance.Status.SecretRef = instance.Spec.SecretRef
-		instance.Status.Strategy = strategyToUse
-		instance.Status.Provider = p.GetName()
-		if err = r.client.Status().Update(ctx, instance); err != nil {
-			return reconcile.Result{}, errorUtil.Wrapf(err, "failed to update instance %s in namespace %s", instance.Name, instance.Namespace)
-		}
-		return reconcile.Result{Requeue: true, RequeueAfter: p.GetReconcileTime(instance)}, nil
+func (a *redisProviderAdapter) Create(ctx context.Context, o runtime.Object) (providers.DeploymentDetails, croType.StatusMessage, error) {
+	cluster, msg, err := a.provider.CreateRedis(ctx, o.(*v1alpha1.Redis))
+	if cluster == nil {
+		return nil, msg, err
 	}
+	return cluster.DeploymentDetails, msg, err
+}
 
-	// unsupported strategy
-	if err = resources.UpdatePhase(ctx, r.client, instance, croType.PhaseInProgress, croType.StatusUnsupportedType.WrapError(err)); err != nil {
-		return reconcile.Result{}, err
-	}
-	return reconcile.Result{}, errorUtil.New(fmt.Sprintf("unsupported deployment strategy %s", stratMap.Redis))
+func (a *redisProviderAdapter) Delete(ctx context.Context, o runtime.Object) (croType.StatusMessage, error) {
+	return a.provider.DeleteRedis(ctx, o.(*v1alpha1.Redis))
 }
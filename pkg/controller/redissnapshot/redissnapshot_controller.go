@@ -17,6 +17,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -43,6 +44,7 @@ func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 		client:            mgr.GetClient(),
 		scheme:            mgr.GetScheme(),
 		logger:            logger,
+		eventRecorder:     mgr.GetEventRecorderFor("redissnapshot-controller"),
 		provider:          provider,
 		ConfigManager:     croAws.NewDefaultConfigMapConfigManager(mgr.GetClient()),
 		CredentialManager: croAws.NewCredentialMinterCredentialManager(mgr.GetClient()),
@@ -52,13 +54,18 @@ func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("redissnapshot-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("redissnapshot-controller", mgr, controller.Options{Reconciler: r, MaxConcurrentReconciles: resources.GetMaxConcurrentReconciles(1)})
 	if err != nil {
 		return err
 	}
 
 	// Watch for changes to primary resource RedisSnapshot
-	err = c.Watch(&source.Kind{Type: &integreatlyv1alpha1.RedisSnapshot{}}, &handler.EnqueueRequestForObject{})
+	// build the label selector predicate used to restrict the crs served by the operator
+	watchPredicate, err := resources.BuildWatchLabelSelectorPredicate()
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Kind{Type: &integreatlyv1alpha1.RedisSnapshot{}}, &handler.EnqueueRequestForObject{}, watchPredicate)
 	if err != nil {
 		return err
 	}
@@ -85,6 +92,7 @@ type ReconcileRedisSnapshot struct {
 	client            client.Client
 	scheme            *runtime.Scheme
 	logger            *logrus.Entry
+	eventRecorder     record.EventRecorder
 	provider          providers.RedisSnapshotProvider
 	ConfigManager     croAws.ConfigManager
 	CredentialManager croAws.CredentialManager
@@ -138,6 +146,7 @@ func (r *ReconcileRedisSnapshot) Reconcile(request reconcile.Request) (reconcile
 	if instance.DeletionTimestamp != nil {
 		msg, err := r.provider.DeleteRedisSnapshot(ctx, instance, redisCr)
 		if err != nil {
+			resources.RecordFailureEvent(r.eventRecorder, instance, err)
 			if updateErr := resources.UpdateSnapshotPhase(ctx, r.client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
 				return reconcile.Result{}, updateErr
 			}
@@ -162,6 +171,7 @@ func (r *ReconcileRedisSnapshot) Reconcile(request reconcile.Request) (reconcile
 
 	// error trying to create snapshot
 	if err != nil {
+		resources.RecordFailureEvent(r.eventRecorder, instance, err)
 		if updateErr := resources.UpdateSnapshotPhase(ctx, r.client, instance, croType.PhaseFailed, msg); updateErr != nil {
 			return reconcile.Result{}, updateErr
 		}
@@ -177,6 +187,7 @@ func (r *ReconcileRedisSnapshot) Reconcile(request reconcile.Request) (reconcile
 	}
 
 	// no error, snapshot exists
+	r.eventRecorder.Eventf(instance, corev1.EventTypeNormal, resources.EventReasonSnapshotCreated, "snapshot %s successfully created", snap.Name)
 	if updateErr := resources.UpdateSnapshotPhase(ctx, r.client, instance, croType.PhaseComplete, msg); updateErr != nil {
 		return reconcile.Result{}, updateErr
 	}
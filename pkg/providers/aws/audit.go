@@ -0,0 +1,59 @@
+package aws
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+)
+
+// operations with these prefixes only read cloud state and are not recorded in the audit trail
+var readOnlyOperationPrefixes = []string{
+	"Describe",
+	"List",
+	"Get",
+	"Head",
+}
+
+// addAuditHandler installs a handler recording every mutating api call made through the
+// session in the audit trail, calls are attributed to the cr the session was created for
+func addAuditHandler(sess *session.Session, auditResource *resources.AuditResource) {
+	sess.Handlers.Complete.PushBack(func(r *request.Request) {
+		if !isMutatingOperation(r.Operation.Name) {
+			return
+		}
+		result := "success"
+		if r.Error != nil {
+			result = fmt.Sprintf("error: %s", r.Error.Error())
+		}
+		resources.RecordAuditEntry(resources.AuditEntry{
+			Provider:   "aws",
+			Service:    r.ClientInfo.ServiceName,
+			Operation:  r.Operation.Name,
+			ParamsHash: hashOperationParams(r.Params),
+			Result:     result,
+			Resource:   auditResource,
+		})
+	})
+}
+
+// isMutatingOperation reports whether an api operation changes cloud state
+func isMutatingOperation(operation string) bool {
+	for _, prefix := range readOnlyOperationPrefixes {
+		if strings.HasPrefix(operation, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// hashOperationParams hashes the call parameters so the audit trail can show what changed
+// without recording credentials or other sensitive values
+func hashOperationParams(params interface{}) string {
+	paramsHash := sha256.Sum256([]byte(fmt.Sprintf("%+v", params)))
+	return hex.EncodeToString(paramsHash[:])
+}
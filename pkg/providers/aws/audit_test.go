@@ -0,0 +1,51 @@
+package aws
+
+import (
+	"testing"
+)
+
+func TestIsMutatingOperation(t *testing.T) {
+	cases := []struct {
+		name      string
+		operation string
+		expected  bool
+	}{
+		{
+			name:      "test create operation is mutating",
+			operation: "CreateDBInstance",
+			expected:  true,
+		},
+		{
+			name:      "test delete operation is mutating",
+			operation: "DeleteReplicationGroup",
+			expected:  true,
+		},
+		{
+			name:      "test modify operation is mutating",
+			operation: "ModifyDBInstance",
+			expected:  true,
+		},
+		{
+			name:      "test describe operation is read only",
+			operation: "DescribeDBInstances",
+			expected:  false,
+		},
+		{
+			name:      "test list operation is read only",
+			operation: "ListTagsForResource",
+			expected:  false,
+		},
+		{
+			name:      "test get operation is read only",
+			operation: "GetCallerIdentity",
+			expected:  false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isMutatingOperation(tc.operation); got != tc.expected {
+				t.Fatalf("unexpected result for %s, expected %v but got %v", tc.operation, tc.expected, got)
+			}
+		})
+	}
+}
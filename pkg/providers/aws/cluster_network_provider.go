@@ -28,7 +28,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
-	"reflect"
 	"sort"
 	"strings"
 
@@ -63,6 +62,15 @@ const (
 	// see https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeVpcPeeringConnections.html
 	filterVpcPeeringRequesterId = "requester-vpc-info.vpc-id"
 	filterVpcPeeringAccepterId  = "accepter-vpc-info.vpc-id"
+	//transit gateway attachments, used as an alternative to vpc peering for organisations that disallow peering
+	tagTransitGatewayAttachmentNameValue = "RHMI Cloud Resource Transit Gateway Attachment"
+	// filter names for transit gateway vpc attachments
+	// see https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeTransitGatewayVpcAttachments.html
+	filterTransitGatewayId    = "transit-gateway-id"
+	filterTransitGatewayVpcId = "vpc-id"
+	// connection types selectable through the _network strategy
+	networkConnectionTypePeering        = "peering"
+	networkConnectionTypeTransitGateway = "transitGateway"
 )
 
 // wrapper for ec2 vpcs, to allow for extensibility
@@ -94,11 +102,15 @@ func (np *NetworkPeering) IsReady() bool {
 type NetworkManager interface {
 	CreateNetwork(context.Context, *net.IPNet) (*Network, error)
 	DeleteNetwork(context.Context) error
+	ReconcileExistingNetwork(context.Context, string, []string) (*Network, error)
+	DeleteExistingNetwork(context.Context, string) error
 	CreateNetworkConnection(context.Context, *Network) (*NetworkConnection, error)
 	DeleteNetworkConnection(context.Context, *NetworkPeering) error
 	CreateNetworkPeering(context.Context, *Network) (*NetworkPeering, error)
 	GetClusterNetworkPeering(context.Context) (*NetworkPeering, error)
 	DeleteNetworkPeering(*NetworkPeering) error
+	CreateNetworkTransitGatewayAttachment(context.Context, *Network, string) (*NetworkConnection, error)
+	DeleteNetworkTransitGatewayAttachment(context.Context, string) error
 	IsEnabled(context.Context) (bool, error)
 	DeleteBundledCloudResources(context.Context) error
 }
@@ -309,6 +321,203 @@ func (n *NetworkProvider) DeleteNetwork(ctx context.Context) error {
 	return nil
 }
 
+//ReconcileExistingNetwork validates and reuses a vpc and subnets provisioned outside of the operator
+//
+//enterprises with pre-provisioned landing zones can reference an existing vpc and subnet ids in the
+//_network strategy to keep the operator out of network creation entirely
+//
+//the referenced network is validated rather than reconciled :
+//	  * the vpc cidr block must be a valid range and must not overlap with the cluster vpc cidr block
+//	  * the subnets must belong to the vpc and cover at least two availability zones
+//
+//the vpc and subnets are never tagged as operator owned so they are not removed on teardown, only the
+//rds and elasticache subnet groups are created from the referenced subnets
+func (n *NetworkProvider) ReconcileExistingNetwork(ctx context.Context, vpcID string, subnetIDs []string) (*Network, error) {
+	logger := n.Logger.WithField("action", "ReconcileExistingNetwork")
+	logger.Infof("validating existing vpc %s", vpcID)
+
+	describeVpcOutput, err := n.Ec2Api.DescribeVpcs(&ec2.DescribeVpcsInput{
+		VpcIds: []*string{aws.String(vpcID)},
+	})
+	if err != nil {
+		return nil, errorUtil.Wrapf(err, "failed to describe existing vpc %s", vpcID)
+	}
+	if len(describeVpcOutput.Vpcs) == 0 {
+		return nil, errorUtil.New(fmt.Sprintf("existing vpc %s referenced in _network strategy not found", vpcID))
+	}
+	foundVpc := describeVpcOutput.Vpcs[0]
+
+	// the existing vpc must meet the same cidr requirements as an operator created vpc, an
+	// overlapping cidr block would fail on peering with an `overlapping CIDR range` error
+	_, vpcCidr, err := net.ParseCIDR(aws.StringValue(foundVpc.CidrBlock))
+	if err != nil {
+		return nil, errorUtil.Wrapf(err, "failed to parse existing vpc cidr block %s", aws.StringValue(foundVpc.CidrBlock))
+	}
+	if err := validateStandaloneCidrBlock(ctx, n.Client, n.Ec2Api, logger, vpcCidr); err != nil {
+		return nil, errorUtil.Wrap(err, "existing vpc validation failure")
+	}
+	logger.Infof("cidr %s is valid 👍", vpcCidr.String())
+
+	// the referenced subnets must belong to the referenced vpc and must cover at least two
+	// availability zones so multi-az rds and elasticache resources can be provisioned in them
+	describeSubnetsOutput, err := n.Ec2Api.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice(subnetIDs),
+	})
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to describe existing subnets")
+	}
+	subnets := describeSubnetsOutput.Subnets
+	if len(subnets) != len(subnetIDs) {
+		return nil, errorUtil.New(fmt.Sprintf("expected to find %d subnets referenced in _network strategy, found %d", len(subnetIDs), len(subnets)))
+	}
+	foundAzs := map[string]bool{}
+	for _, subnet := range subnets {
+		if aws.StringValue(subnet.VpcId) != vpcID {
+			return nil, errorUtil.New(fmt.Sprintf("subnet %s referenced in _network strategy does not belong to vpc %s", aws.StringValue(subnet.SubnetId), vpcID))
+		}
+		foundAzs[aws.StringValue(subnet.AvailabilityZone)] = true
+	}
+	if len(foundAzs) < defaultNumberOfExpectedSubnets {
+		return nil, errorUtil.New(fmt.Sprintf("subnets referenced in _network strategy must cover at least %d availability zones, found %d", defaultNumberOfExpectedSubnets, len(foundAzs)))
+	}
+
+	// create rds subnet group from the referenced subnets
+	if err = n.reconcileRDSVpcConfiguration(ctx, subnets); err != nil {
+		return nil, errorUtil.Wrap(err, "unexpected error reconciling existing rds vpc networking")
+	}
+
+	// create elasticache subnet groups from the referenced subnets
+	if err = n.reconcileElasticacheVPCConfiguration(ctx, subnets); err != nil {
+		return nil, errorUtil.Wrap(err, "unexpected error reconciling existing elasticache vpc networking")
+	}
+
+	return &Network{
+		Vpc:     foundVpc,
+		Subnets: subnets,
+	}, nil
+}
+
+//DeleteExistingNetwork removes the resources the operator created around an existing vpc
+//
+//the vpc and subnets referenced in the _network strategy are provisioned outside of the operator and
+//are left untouched, removal covers :
+//	  * the standalone security group
+//	  * the cluster vpc routes and the peering connection to the existing vpc
+//	  * both subnet groups (rds and elasticache)
+func (n *NetworkProvider) DeleteExistingNetwork(ctx context.Context, vpcID string) error {
+	logger := n.Logger.WithField("action", "DeleteExistingNetwork")
+
+	// build security group name
+	standaloneSecurityGroupName, err := BuildInfraName(ctx, n.Client, defaultSecurityGroupPostfix, DefaultAwsIdentifierLength)
+	logger.Info(fmt.Sprintf("setting resource security group %s", standaloneSecurityGroupName))
+	if err != nil {
+		return errorUtil.Wrap(err, "error building subnet group name")
+	}
+
+	// get standalone security group
+	standaloneSecGroup, err := getSecurityGroup(n.Ec2Api, standaloneSecurityGroupName)
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to find standalone security group")
+	}
+	if standaloneSecGroup != nil {
+		if _, err := n.Ec2Api.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{
+			GroupId: standaloneSecGroup.GroupId,
+		}); err != nil {
+			return errorUtil.Wrap(err, "failed to delete standalone security group")
+		}
+	}
+
+	// the existing vpc is expected to still be in place, if it has been removed out of band there is
+	// nothing left for the operator to clean up around it
+	describeVpcOutput, err := n.Ec2Api.DescribeVpcs(&ec2.DescribeVpcsInput{
+		VpcIds: []*string{aws.String(vpcID)},
+	})
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to describe existing vpc %s", vpcID)
+	}
+	if len(describeVpcOutput.Vpcs) > 0 {
+		foundVpc := describeVpcOutput.Vpcs[0]
+
+		// find and remove the peering connection to the existing vpc and its cluster vpc routes
+		peeringConnection, err := n.getNetworkPeering(ctx, &Network{Vpc: foundVpc})
+		if err != nil {
+			return errorUtil.Wrap(err, "failed to get peering connection")
+		}
+		if peeringConnection != nil {
+			// a tag for identifying cluster owned vpc resources
+			clusterVpcRouteTableTag, err := getClusterOwnerTag(ctx, n.Client)
+			if err != nil {
+				return errorUtil.Wrap(err, "error building cluster owner tag")
+			}
+
+			// find cluster vpc route tables using cluster vpc route table tag
+			// multiple route tables can exist for a single vpc (main and secondary)
+			logger.Info("finding cluster route table(s)")
+			clusterVpcRouteTables, err := n.getVPCRouteTable(clusterVpcRouteTableTag)
+			if err != nil {
+				return errorUtil.Wrap(err, "failure while getting vpc route table")
+			}
+			logger.Infof("found %d cluster vpc route tables", len(clusterVpcRouteTables))
+
+			// as more than one route table may exist we need to ensure that the cluster vpc route is deleted for each
+			for _, routeTable := range clusterVpcRouteTables {
+				if routeExists(routeTable.Routes, &ec2.Route{
+					DestinationCidrBlock:   foundVpc.CidrBlock,
+					VpcPeeringConnectionId: peeringConnection.VpcPeeringConnectionId,
+				}) {
+					logger.Infof("deleting route for existing vpc id %s in route table %s", aws.StringValue(foundVpc.VpcId), aws.StringValue(routeTable.RouteTableId))
+					if _, err := n.Ec2Api.DeleteRoute(&ec2.DeleteRouteInput{
+						DestinationCidrBlock: foundVpc.CidrBlock,
+						RouteTableId:         routeTable.RouteTableId,
+					}); err != nil {
+						return errorUtil.Wrap(err, "failure while deleting route from route table")
+					}
+				}
+			}
+
+			if err := n.DeleteNetworkPeering(&NetworkPeering{PeeringConnection: peeringConnection}); err != nil {
+				return errorUtil.Wrap(err, "failed to delete peering connection to existing vpc")
+			}
+		}
+	}
+
+	subnetGroupName, err := BuildInfraName(ctx, n.Client, defaultSubnetPostfix, DefaultAwsIdentifierLength)
+	if err != nil {
+		return errorUtil.Wrap(err, "error building subnet group name")
+	}
+
+	// remove rds subnet group created by cro
+	rdsSubnetGroup, err := getRDSSubnetGroup(n.RdsApi, subnetGroupName)
+	if err != nil {
+		return errorUtil.Wrap(err, "error getting subnet group on delete")
+	}
+	if rdsSubnetGroup != nil {
+		logger.Infof("attempting to delete subnetgroup name: %s for clusterID: %s", *rdsSubnetGroup.DBSubnetGroupName, *rdsSubnetGroup.VpcId)
+		_, err := n.RdsApi.DeleteDBSubnetGroup(&rds.DeleteDBSubnetGroupInput{
+			DBSubnetGroupName: rdsSubnetGroup.DBSubnetGroupName,
+		})
+		if err != nil {
+			return errorUtil.Wrap(err, "error deleting subnet group")
+		}
+	}
+
+	// remove elasticache subnet group created by cro
+	elasticacheSubnetGroup, err := getElasticacheSubnetByGroup(n.ElasticacheApi, subnetGroupName)
+	if err != nil {
+		return errorUtil.Wrap(err, "error getting subnet group on delete")
+	}
+	if elasticacheSubnetGroup != nil {
+		_, err := n.ElasticacheApi.DeleteCacheSubnetGroup(&elasticache.DeleteCacheSubnetGroupInput{
+			CacheSubnetGroupName: aws.String(*elasticacheSubnetGroup.CacheSubnetGroupName),
+		})
+		if err != nil {
+			return errorUtil.Wrap(err, "error deleting subnet group")
+		}
+	}
+	logger.Infof("operator created resources around existing vpc %s deleted successfully", vpcID)
+	return nil
+}
+
 // CreateNetworkConnection handles the creation of a connection from the vpc provisioned by cro to the cluster vpc
 // here we handle :
 // 		* the standalone security group
@@ -319,7 +528,7 @@ func (n *NetworkProvider) CreateNetworkConnection(ctx context.Context, network *
 	logger.Info("preparing to configure network connection")
 
 	// reconcile standalone vpc security groups
-	securityGroup, err := n.reconcileStandaloneSecurityGroup(ctx, logger)
+	securityGroup, err := n.reconcileStandaloneSecurityGroup(ctx, network.Vpc, logger)
 	if err != nil {
 		return nil, errorUtil.Wrap(err, "failure while reconciling standalone security group")
 	}
@@ -463,6 +672,10 @@ func (n *NetworkProvider) DeleteNetworkConnection(ctx context.Context, networkPe
 	if err != nil {
 		return errorUtil.Wrap(err, "could not find standalone vpc")
 	}
+	if standaloneVpc == nil {
+		logger.Info("no standalone vpc found, skipping cluster vpc route deletion")
+		return nil
+	}
 
 	// we expect a peering connection to be in place to remove routes
 	// if none exists return an error and re-reconcile to avoid nil pointer
@@ -642,6 +855,297 @@ func (n *NetworkProvider) DeleteNetworkPeering(peering *NetworkPeering) error {
 	return nil
 }
 
+// CreateNetworkTransitGatewayAttachment attaches the standalone vpc to an existing transit gateway,
+// used as an alternative to vpc peering for organisations that disallow peering connections
+// here we handle :
+//		* the standalone security group
+//		* the transit gateway vpc attachment
+//		* cro standalone vpc route table
+//		* cluster vpc route table
+//
+// the transit gateway is expected to be provisioned outside of this operator with the cluster vpc
+// already attached to it, its id is provided through the _network strategy. route propagation on the
+// transit gateway itself is handled by the gateway default route table association and propagation
+func (n *NetworkProvider) CreateNetworkTransitGatewayAttachment(ctx context.Context, network *Network, transitGatewayID string) (*NetworkConnection, error) {
+	logger := n.Logger.WithField("action", "CreateNetworkTransitGatewayAttachment")
+	logger.Infof("preparing to attach standalone network to transit gateway %s", transitGatewayID)
+
+	// reconcile standalone vpc security groups, the same ingress rules are required as in peering mode
+	securityGroup, err := n.reconcileStandaloneSecurityGroup(ctx, network.Vpc, logger)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failure while reconciling standalone security group")
+	}
+
+	// check for an existing attachment between the standalone vpc and the transit gateway
+	attachment, err := n.getTransitGatewayAttachment(transitGatewayID, network.Vpc.VpcId)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failure while getting transit gateway attachment")
+	}
+	if attachment == nil {
+		// the attachment requires the standalone vpc subnets, when reconciling an existing vpc they are
+		// provided on the network, fall back to looking them up
+		subnets := network.Subnets
+		if len(subnets) == 0 {
+			subnets, err = getVPCAssociatedSubnets(n.Ec2Api, logger, network.Vpc)
+			if err != nil {
+				return nil, errorUtil.Wrap(err, "failed to get standalone vpc subnets")
+			}
+		}
+		var subnetIds []*string
+		for _, subnet := range subnets {
+			subnetIds = append(subnetIds, subnet.SubnetId)
+		}
+		logger.Infof("creating transit gateway attachment for vpc %s", aws.StringValue(network.Vpc.VpcId))
+		createAttachmentOutput, err := n.Ec2Api.CreateTransitGatewayVpcAttachment(&ec2.CreateTransitGatewayVpcAttachmentInput{
+			TransitGatewayId: aws.String(transitGatewayID),
+			VpcId:            network.Vpc.VpcId,
+			SubnetIds:        subnetIds,
+		})
+		if err != nil {
+			return nil, errorUtil.Wrap(err, "failed to create transit gateway attachment")
+		}
+		attachment = createAttachmentOutput.TransitGatewayVpcAttachment
+		logger.Infof("created transit gateway attachment %s", aws.StringValue(attachment.TransitGatewayAttachmentId))
+	}
+
+	// once we have the attachment, tag it so it's identifiable as belonging to this operator
+	// this helps with cleaning up resources
+	logger.Info("getting cluster identifier")
+	clusterID, err := resources.GetClusterID(ctx, n.Client)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to get cluster id")
+	}
+	organizationTag := resources.GetOrganizationTag()
+	clusterIDTagName := fmt.Sprintf("%sclusterID", organizationTag)
+
+	logger.Infof("checking tags on transit gateway attachment")
+	attachmentTags := ec2TagsToGeneric(attachment.Tags)
+	if !tagsContains(attachmentTags, tagDisplayName, tagTransitGatewayAttachmentNameValue) ||
+		!tagsContains(attachmentTags, clusterIDTagName, clusterID) {
+		logger.Info("creating tags on transit gateway attachment")
+		_, err = n.Ec2Api.CreateTags(&ec2.CreateTagsInput{
+			Resources: []*string{attachment.TransitGatewayAttachmentId},
+			Tags: []*ec2.Tag{
+				{
+					Key:   aws.String(tagDisplayName),
+					Value: aws.String(tagTransitGatewayAttachmentNameValue),
+				},
+				{
+					Key:   aws.String(clusterIDTagName),
+					Value: aws.String(clusterID),
+				},
+			},
+		})
+		if err != nil {
+			return nil, errorUtil.Wrap(err, "failed to tag transit gateway attachment")
+		}
+	} else {
+		logger.Info("expected tags found on transit gateway attachment")
+	}
+
+	// routes to the transit gateway can only be created once the attachment is available
+	// if the attachment is still pending return an error and re-reconcile
+	if aws.StringValue(attachment.State) != ec2.TransitGatewayAttachmentStateAvailable {
+		return nil, errorUtil.New(fmt.Sprintf("transit gateway attachment %s is not yet available, current state '%s'", aws.StringValue(attachment.TransitGatewayAttachmentId), aws.StringValue(attachment.State)))
+	}
+
+	// a tag for identifying cluster owned vpc resources
+	clusterVpcRouteTableTag, err := getClusterOwnerTag(ctx, n.Client)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "error building cluster owner tag")
+	}
+
+	// find cluster vpc route tables using cluster vpc route table tag
+	// multiple route tables can exist for a single vpc (main and secondary)
+	logger.Info("finding cluster route table(s)")
+	clusterVpcRouteTables, err := n.getVPCRouteTable(clusterVpcRouteTableTag)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failure while getting vpc route table")
+	}
+	logger.Infof("found %d cluster vpc route tables", len(clusterVpcRouteTables))
+
+	// declare cluster vpc route
+	// we require the destination cidr block to that of the standalone vpc cidr block
+	// we require the transit gateway id to be that of the attached transit gateway
+	clusterVpcRoute := &ec2.Route{
+		TransitGatewayId:     aws.String(transitGatewayID),
+		DestinationCidrBlock: network.Vpc.CidrBlock,
+	}
+
+	// as more than one route table may exist we need to ensure that the cluster vpc route exists for each
+	for _, routeTable := range clusterVpcRouteTables {
+		logger.Infof("checking if route already exists for transit gateway id %s in route table %s", transitGatewayID, aws.StringValue(routeTable.RouteTableId))
+		if !transitGatewayRouteExists(routeTable.Routes, clusterVpcRoute) {
+			logger.Infof("creating route for transit gateway id %s in route table %s", transitGatewayID, aws.StringValue(routeTable.RouteTableId))
+			if _, err := n.Ec2Api.CreateRoute(&ec2.CreateRouteInput{
+				TransitGatewayId:     clusterVpcRoute.TransitGatewayId,
+				DestinationCidrBlock: clusterVpcRoute.DestinationCidrBlock,
+				RouteTableId:         routeTable.RouteTableId,
+			}); err != nil {
+				return nil, errorUtil.Wrap(err, "failure while adding route to route table")
+			}
+		}
+	}
+
+	// get croOwner tag to use in getting standalone vpc route tables
+	croOwnerTag, err := getCloudResourceOperatorOwnerTag(ctx, n.Client)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "error generating cloud resource owner tag")
+	}
+
+	// get standalone vpc route table using cro owner tag
+	logger.Info("finding standalone route table(s)")
+	standAloneVpcRouteTables, err := n.getVPCRouteTable(genericToEc2Tag(croOwnerTag))
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "error getting standalone vpc route tables")
+	}
+
+	// we require the cluster vpc cidr block for standalone vpc route
+	clusterVpc, err := getClusterVpc(ctx, n.Client, n.Ec2Api, logger)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "error getting standalone vpc route tables")
+	}
+
+	// declare standalone vpc route
+	// we require the destination cidr block to that of the cluster vpc cidr block
+	// we require the transit gateway id to be that of the attached transit gateway
+	standaloneVpcRoute := &ec2.Route{
+		TransitGatewayId:     aws.String(transitGatewayID),
+		DestinationCidrBlock: clusterVpc.CidrBlock,
+	}
+
+	// we expect a single route table to exist for the standalone vpc
+	// to handle the case where there is more than a single route found, loop through all them all and add the route
+	for _, routeTable := range standAloneVpcRouteTables {
+		logger.Infof("checking if route already exists for transit gateway id %s in route table %s", transitGatewayID, aws.StringValue(routeTable.RouteTableId))
+		if !transitGatewayRouteExists(routeTable.Routes, standaloneVpcRoute) {
+			logger.Infof("creating route for transit gateway id %s in route table %s", transitGatewayID, aws.StringValue(routeTable.RouteTableId))
+			if _, err := n.Ec2Api.CreateRoute(&ec2.CreateRouteInput{
+				TransitGatewayId:     standaloneVpcRoute.TransitGatewayId,
+				DestinationCidrBlock: standaloneVpcRoute.DestinationCidrBlock,
+				RouteTableId:         routeTable.RouteTableId,
+			}); err != nil {
+				return nil, errorUtil.Wrap(err, "failure while adding route to route table")
+			}
+		}
+	}
+
+	return &NetworkConnection{
+		StandaloneSecurityGroup: securityGroup,
+	}, nil
+}
+
+// DeleteNetworkTransitGatewayAttachment removes the security group, the cluster vpc routes and the
+// transit gateway attachment created for the standalone network
+func (n *NetworkProvider) DeleteNetworkTransitGatewayAttachment(ctx context.Context, transitGatewayID string) error {
+	logger := n.Logger.WithField("action", "DeleteNetworkTransitGatewayAttachment")
+	// build security group name
+	standaloneSecurityGroupName, err := BuildInfraName(ctx, n.Client, defaultSecurityGroupPostfix, DefaultAwsIdentifierLength)
+	logger.Info(fmt.Sprintf("setting resource security group %s", standaloneSecurityGroupName))
+	if err != nil {
+		return errorUtil.Wrap(err, "error building subnet group name")
+	}
+
+	// get standalone security group
+	standaloneSecGroup, err := getSecurityGroup(n.Ec2Api, standaloneSecurityGroupName)
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to find standalone security group")
+	}
+	if standaloneSecGroup != nil {
+		if _, err := n.Ec2Api.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{
+			GroupId: standaloneSecGroup.GroupId,
+		}); err != nil {
+			return errorUtil.Wrap(err, "failed to delete standalone security group")
+		}
+	}
+
+	// a tag for identifying cluster owned vpc resources
+	clusterVpcRouteTableTag, err := getClusterOwnerTag(ctx, n.Client)
+	if err != nil {
+		return errorUtil.Wrap(err, "error building cluster owner tag")
+	}
+
+	// find cluster vpc route tables using cluster vpc route table tag
+	// multiple route tables can exist for a single vpc (main and secondary)
+	logger.Info("finding cluster route table(s)")
+	clusterVpcRouteTables, err := n.getVPCRouteTable(clusterVpcRouteTableTag)
+	if err != nil {
+		return errorUtil.Wrap(err, "failure while getting vpc route table")
+	}
+	logger.Infof("found %d cluster vpc route tables", len(clusterVpcRouteTables))
+
+	standaloneVpc, err := getStandaloneVpc(ctx, n.Client, n.Ec2Api, logger)
+	if err != nil {
+		return errorUtil.Wrap(err, "could not find standalone vpc")
+	}
+	if standaloneVpc == nil {
+		logger.Info("no standalone vpc found, skipping transit gateway attachment deletion")
+		return nil
+	}
+
+	// as more than one route table may exist we need to ensure that the cluster vpc route is deleted for each
+	for _, routeTable := range clusterVpcRouteTables {
+		logger.Infof("checking if route exists for standalone vpc id %s in route table %s", aws.StringValue(standaloneVpc.VpcId), aws.StringValue(routeTable.RouteTableId))
+		if transitGatewayRouteExists(routeTable.Routes, &ec2.Route{
+			DestinationCidrBlock: standaloneVpc.CidrBlock,
+			TransitGatewayId:     aws.String(transitGatewayID),
+		}) {
+			logger.Infof("deleting route for standalone vpc id %s in route table %s", aws.StringValue(standaloneVpc.VpcId), aws.StringValue(routeTable.RouteTableId))
+			if _, err := n.Ec2Api.DeleteRoute(&ec2.DeleteRouteInput{
+				DestinationCidrBlock: standaloneVpc.CidrBlock,
+				RouteTableId:         routeTable.RouteTableId,
+			}); err != nil {
+				return errorUtil.Wrap(err, "failure while deleting route from route table")
+			}
+		}
+	}
+
+	// find the attachment, if it is already in a deleting/deleted state aws will handle it
+	attachment, err := n.getTransitGatewayAttachment(transitGatewayID, standaloneVpc.VpcId)
+	if err != nil {
+		return errorUtil.Wrap(err, "failure while getting transit gateway attachment")
+	}
+	if attachment == nil {
+		logger.Info("could not find transit gateway attachment, assuming already removed")
+		return nil
+	}
+	logger.Infof("deleting transit gateway attachment %s", aws.StringValue(attachment.TransitGatewayAttachmentId))
+	if _, err := n.Ec2Api.DeleteTransitGatewayVpcAttachment(&ec2.DeleteTransitGatewayVpcAttachmentInput{
+		TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+	}); err != nil {
+		return errorUtil.Wrap(err, "failed to delete transit gateway attachment")
+	}
+	logger.Info("standalone security group, cluster vpc routes and transit gateway attachment deleted")
+	return nil
+}
+
+// returns the transit gateway vpc attachment between the provided transit gateway and vpc
+// attachments in a deleting or deleted state are ignored as they can stay around for quite a while
+func (n *NetworkProvider) getTransitGatewayAttachment(transitGatewayID string, vpcID *string) (*ec2.TransitGatewayVpcAttachment, error) {
+	describeAttachmentsOutput, err := n.Ec2Api.DescribeTransitGatewayVpcAttachments(&ec2.DescribeTransitGatewayVpcAttachmentsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String(filterTransitGatewayId),
+				Values: []*string{aws.String(transitGatewayID)},
+			},
+			{
+				Name:   aws.String(filterTransitGatewayVpcId),
+				Values: []*string{vpcID},
+			},
+		},
+	})
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to describe transit gateway attachments")
+	}
+	for attachmentIdx, attachment := range describeAttachmentsOutput.TransitGatewayVpcAttachments {
+		if aws.StringValue(attachment.State) != ec2.TransitGatewayAttachmentStateDeleted &&
+			aws.StringValue(attachment.State) != ec2.TransitGatewayAttachmentStateDeleting {
+			return describeAttachmentsOutput.TransitGatewayVpcAttachments[attachmentIdx], nil
+		}
+	}
+	return nil, nil
+}
+
 //IsEnabled returns true when no bundled subnets are found in the openshift cluster vpc.
 //
 //All subnets created by the cloud resource operator are identified by having a tag with the name `<organizationTag>/clusterID`.
@@ -801,7 +1305,7 @@ func (n *NetworkProvider) getNetworkPeering(ctx context.Context, network *Networ
 // this security group should allow all ingress traffic from the cluster
 // as the cluster vpc and the standalone vpc are peered we need to use the cluster cidr block as an ip permission to allow ingress traffic
 // see -> https://docs.aws.amazon.com/vpc/latest/peering/vpc-peering-security-groups.html
-func (n *NetworkProvider) reconcileStandaloneSecurityGroup(ctx context.Context, logger *logrus.Entry) (*ec2.SecurityGroup, error) {
+func (n *NetworkProvider) reconcileStandaloneSecurityGroup(ctx context.Context, standaloneVpc *ec2.Vpc, logger *logrus.Entry) (*ec2.SecurityGroup, error) {
 	// build security group name
 	standaloneSecurityGroupName, err := BuildInfraName(ctx, n.Client, defaultSecurityGroupPostfix, DefaultAwsIdentifierLength)
 	logger.Info(fmt.Sprintf("setting resource security group %s", standaloneSecurityGroupName))
@@ -815,11 +1319,8 @@ func (n *NetworkProvider) reconcileStandaloneSecurityGroup(ctx context.Context,
 		return nil, errorUtil.Wrap(err, "failed to find standalone security group")
 	}
 
-	// get the cro standalone vpc
-	standaloneVpc, err := getStandaloneVpc(ctx, n.Client, n.Ec2Api, logger)
-	if err != nil {
-		return nil, errorUtil.Wrap(err, "failed to get standalone vpc")
-	}
+	// the vpc the security group is provisioned into, either the cro standalone vpc or an existing
+	// vpc referenced in the _network strategy
 	if standaloneVpc == nil {
 		return nil, errorUtil.New("standalone vpc can not be nil")
 	}
@@ -892,36 +1393,15 @@ func (n *NetworkProvider) reconcileStandaloneSecurityGroup(ctx context.Context,
 	// we need to ensure permissions for standalone security group will accept traffic from the cluster vpc
 	// currently we can not use the cluster vpc security group, this is a limitation from aws
 	// see for more -> https://docs.aws.amazon.com/vpc/latest/peering/vpc-peering-security-groups.html
-	// it is recommended by aws docs to use the cidr block from the peered vpc
-
-	// build ip permission
-	ipPermission := &ec2.IpPermission{
-		IpProtocol: aws.String("-1"),
-		IpRanges: []*ec2.IpRange{
-			{
-				CidrIp: clusterVpc.CidrBlock,
-			},
-		},
-	}
-
-	// ensure ip permission correct and valid in the standalone security group
-	for _, perm := range standaloneSecGroup.IpPermissions {
-		if reflect.DeepEqual(perm, ipPermission) {
-			logger.Infof("ip permissions are correct for security group %s", *standaloneSecGroup.GroupName)
-			return standaloneSecGroup, nil
-		}
+	// it is recommended by aws docs to use the cidr block from the peered vpc, ingress is
+	// scoped to the database ports and drifted rules are revoked
+	ingressPermissions, err := buildDatabaseIngressPermissions(aws.StringValue(clusterVpc.CidrBlock))
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "error building database ingress permissions")
 	}
-
-	// authorize the seucrity group ingres if it is not as expected
-	if _, err := n.Ec2Api.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
-		GroupId: aws.String(*standaloneSecGroup.GroupId),
-		IpPermissions: []*ec2.IpPermission{
-			ipPermission,
-		},
-	}); err != nil {
-		return nil, errorUtil.Wrap(err, "error authorizing security group ingress")
+	if err := reconcileSecurityGroupIngress(n.Ec2Api, standaloneSecGroup, ingressPermissions, logger); err != nil {
+		return nil, err
 	}
-	logger.Infof("ip permissions have been updated to expected permissions for security group %s", *standaloneSecGroup.GroupName)
 	return standaloneSecGroup, nil
 }
 
@@ -1500,6 +1980,92 @@ func getNetworkProviderConfig(ctx context.Context, configManager ConfigManager,
 	return vpcCidr, nil
 }
 
+// networkConnectionConfig is the strategy-selectable connection configuration from the _network
+// strategy, it determines how the standalone vpc is connected to the cluster vpc
+type networkConnectionConfig struct {
+	NetworkConnectionType *string
+	TransitGatewayId      *string
+}
+
+//getNetworkConnectionConfig returns the parsed connection config from the _network strategy for a tier
+//
+//the connection type defaults to a vpc peering connection to remain backwards compatible, organisations
+//that disallow vpc peering can select the transit gateway connection type and provide the id of an
+//existing transit gateway to attach the standalone vpc to
+func getNetworkConnectionConfig(ctx context.Context, configManager ConfigManager, tier string, logger *logrus.Entry) (*networkConnectionConfig, error) {
+	logger.Infof("fetching _network strategy connection config for tier %s", tier)
+
+	stratCfg, err := configManager.ReadStorageStrategy(ctx, providers.NetworkResourceType, tier)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to read _network strategy config")
+	}
+
+	connectionConfig := &networkConnectionConfig{}
+	if err := json.Unmarshal(stratCfg.CreateStrategy, connectionConfig); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to unmarshal aws network connection config")
+	}
+
+	if connectionConfig.NetworkConnectionType == nil {
+		connectionConfig.NetworkConnectionType = aws.String(networkConnectionTypePeering)
+	}
+
+	switch aws.StringValue(connectionConfig.NetworkConnectionType) {
+	case networkConnectionTypePeering:
+	case networkConnectionTypeTransitGateway:
+		if aws.StringValue(connectionConfig.TransitGatewayId) == "" {
+			return nil, errorUtil.New(fmt.Sprintf("TransitGatewayId in _network strategy tier %s can not be empty when the %s connection type is used", tier, networkConnectionTypeTransitGateway))
+		}
+	default:
+		return nil, errorUtil.New(fmt.Sprintf("unsupported NetworkConnectionType %s in _network strategy tier %s", aws.StringValue(connectionConfig.NetworkConnectionType), tier))
+	}
+
+	logger.Infof("found network connection type %s in network strategy tier %s", aws.StringValue(connectionConfig.NetworkConnectionType), tier)
+	return connectionConfig, nil
+}
+
+//isTransitGatewayConnection returns true when the _network strategy selects the transit gateway connection type
+func isTransitGatewayConnection(connectionConfig *networkConnectionConfig) bool {
+	return connectionConfig != nil && aws.StringValue(connectionConfig.NetworkConnectionType) == networkConnectionTypeTransitGateway
+}
+
+// existingNetworkConfig references a vpc and subnets provisioned outside of the operator
+type existingNetworkConfig struct {
+	VpcId     *string
+	SubnetIds []*string
+}
+
+//getExistingNetworkConfig returns the existing network referenced in the _network strategy for a tier
+//or nil when the strategy does not reference one
+//
+//enterprises with pre-provisioned landing zones can set VpcId and SubnetIds in the _network strategy
+//to have the operator validate and reuse the referenced network instead of creating one
+func getExistingNetworkConfig(ctx context.Context, configManager ConfigManager, tier string, logger *logrus.Entry) (*existingNetworkConfig, error) {
+	logger.Infof("fetching _network strategy existing network config for tier %s", tier)
+
+	stratCfg, err := configManager.ReadStorageStrategy(ctx, providers.NetworkResourceType, tier)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to read _network strategy config")
+	}
+
+	existingConfig := &existingNetworkConfig{}
+	if err := json.Unmarshal(stratCfg.CreateStrategy, existingConfig); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to unmarshal aws existing network config")
+	}
+
+	if existingConfig.VpcId == nil {
+		return nil, nil
+	}
+	if aws.StringValue(existingConfig.VpcId) == "" {
+		return nil, errorUtil.New(fmt.Sprintf("VpcId in _network strategy tier %s can not be empty", tier))
+	}
+	if len(existingConfig.SubnetIds) < defaultNumberOfExpectedSubnets {
+		return nil, errorUtil.New(fmt.Sprintf("at least %d SubnetIds must be provided in _network strategy tier %s when an existing VpcId is referenced", defaultNumberOfExpectedSubnets, tier))
+	}
+
+	logger.Infof("found existing vpc %s in network strategy tier %s", aws.StringValue(existingConfig.VpcId), tier)
+	return existingConfig, nil
+}
+
 //subnetExists is a helper function for checking if a subnet exists with a specific cidr block
 func subnetExists(subnets []*ec2.Subnet, cidr string) bool {
 	for _, subnet := range subnets {
@@ -1593,6 +2159,19 @@ func routeExists(routes []*ec2.Route, checkRoute *ec2.Route) bool {
 	return false
 }
 
+//transitGatewayRouteExists is a helper function for checking if a transit gateway route exists in a route table
+func transitGatewayRouteExists(routes []*ec2.Route, checkRoute *ec2.Route) bool {
+	for _, route := range routes {
+		if route.DestinationCidrBlock == nil || route.TransitGatewayId == nil {
+			continue
+		}
+		if aws.StringValue(route.DestinationCidrBlock) == aws.StringValue(checkRoute.DestinationCidrBlock) && aws.StringValue(route.TransitGatewayId) == aws.StringValue(checkRoute.TransitGatewayId) {
+			return true
+		}
+	}
+	return false
+}
+
 func contains(strs []*string, str *string) bool {
 	for _, s := range strs {
 		if aws.StringValue(str) == aws.StringValue(s) {
@@ -10,15 +10,19 @@ import (
 )
 
 var (
-	lockNetworkManagerMockCreateNetwork               sync.RWMutex
-	lockNetworkManagerMockCreateNetworkConnection     sync.RWMutex
-	lockNetworkManagerMockCreateNetworkPeering        sync.RWMutex
-	lockNetworkManagerMockDeleteBundledCloudResources sync.RWMutex
-	lockNetworkManagerMockDeleteNetwork               sync.RWMutex
-	lockNetworkManagerMockDeleteNetworkConnection     sync.RWMutex
-	lockNetworkManagerMockDeleteNetworkPeering        sync.RWMutex
-	lockNetworkManagerMockGetClusterNetworkPeering    sync.RWMutex
-	lockNetworkManagerMockIsEnabled                   sync.RWMutex
+	lockNetworkManagerMockCreateNetwork                         sync.RWMutex
+	lockNetworkManagerMockCreateNetworkConnection               sync.RWMutex
+	lockNetworkManagerMockCreateNetworkPeering                  sync.RWMutex
+	lockNetworkManagerMockCreateNetworkTransitGatewayAttachment sync.RWMutex
+	lockNetworkManagerMockDeleteBundledCloudResources           sync.RWMutex
+	lockNetworkManagerMockDeleteExistingNetwork                 sync.RWMutex
+	lockNetworkManagerMockDeleteNetwork                         sync.RWMutex
+	lockNetworkManagerMockDeleteNetworkConnection               sync.RWMutex
+	lockNetworkManagerMockDeleteNetworkPeering                  sync.RWMutex
+	lockNetworkManagerMockDeleteNetworkTransitGatewayAttachment sync.RWMutex
+	lockNetworkManagerMockGetClusterNetworkPeering              sync.RWMutex
+	lockNetworkManagerMockIsEnabled                             sync.RWMutex
+	lockNetworkManagerMockReconcileExistingNetwork              sync.RWMutex
 )
 
 // Ensure, that NetworkManagerMock does implement NetworkManager.
@@ -40,9 +44,15 @@ var _ NetworkManager = &NetworkManagerMock{}
 //             CreateNetworkPeeringFunc: func(in1 context.Context, in2 *Network) (*NetworkPeering, error) {
 // 	               panic("mock out the CreateNetworkPeering method")
 //             },
+//             CreateNetworkTransitGatewayAttachmentFunc: func(in1 context.Context, in2 *Network, in3 string) (*NetworkConnection, error) {
+// 	               panic("mock out the CreateNetworkTransitGatewayAttachment method")
+//             },
 //             DeleteBundledCloudResourcesFunc: func(in1 context.Context) error {
 // 	               panic("mock out the DeleteBundledCloudResources method")
 //             },
+//             DeleteExistingNetworkFunc: func(in1 context.Context, in2 string) error {
+// 	               panic("mock out the DeleteExistingNetwork method")
+//             },
 //             DeleteNetworkFunc: func(in1 context.Context) error {
 // 	               panic("mock out the DeleteNetwork method")
 //             },
@@ -52,12 +62,18 @@ var _ NetworkManager = &NetworkManagerMock{}
 //             DeleteNetworkPeeringFunc: func(in1 *NetworkPeering) error {
 // 	               panic("mock out the DeleteNetworkPeering method")
 //             },
+//             DeleteNetworkTransitGatewayAttachmentFunc: func(in1 context.Context, in2 string) error {
+// 	               panic("mock out the DeleteNetworkTransitGatewayAttachment method")
+//             },
 //             GetClusterNetworkPeeringFunc: func(in1 context.Context) (*NetworkPeering, error) {
 // 	               panic("mock out the GetClusterNetworkPeering method")
 //             },
 //             IsEnabledFunc: func(in1 context.Context) (bool, error) {
 // 	               panic("mock out the IsEnabled method")
 //             },
+//             ReconcileExistingNetworkFunc: func(in1 context.Context, in2 string, in3 []string) (*Network, error) {
+// 	               panic("mock out the ReconcileExistingNetwork method")
+//             },
 //         }
 //
 //         // use mockedNetworkManager in code that requires NetworkManager
@@ -74,9 +90,15 @@ type NetworkManagerMock struct {
 	// CreateNetworkPeeringFunc mocks the CreateNetworkPeering method.
 	CreateNetworkPeeringFunc func(in1 context.Context, in2 *Network) (*NetworkPeering, error)
 
+	// CreateNetworkTransitGatewayAttachmentFunc mocks the CreateNetworkTransitGatewayAttachment method.
+	CreateNetworkTransitGatewayAttachmentFunc func(in1 context.Context, in2 *Network, in3 string) (*NetworkConnection, error)
+
 	// DeleteBundledCloudResourcesFunc mocks the DeleteBundledCloudResources method.
 	DeleteBundledCloudResourcesFunc func(in1 context.Context) error
 
+	// DeleteExistingNetworkFunc mocks the DeleteExistingNetwork method.
+	DeleteExistingNetworkFunc func(in1 context.Context, in2 string) error
+
 	// DeleteNetworkFunc mocks the DeleteNetwork method.
 	DeleteNetworkFunc func(in1 context.Context) error
 
@@ -86,12 +108,18 @@ type NetworkManagerMock struct {
 	// DeleteNetworkPeeringFunc mocks the DeleteNetworkPeering method.
 	DeleteNetworkPeeringFunc func(in1 *NetworkPeering) error
 
+	// DeleteNetworkTransitGatewayAttachmentFunc mocks the DeleteNetworkTransitGatewayAttachment method.
+	DeleteNetworkTransitGatewayAttachmentFunc func(in1 context.Context, in2 string) error
+
 	// GetClusterNetworkPeeringFunc mocks the GetClusterNetworkPeering method.
 	GetClusterNetworkPeeringFunc func(in1 context.Context) (*NetworkPeering, error)
 
 	// IsEnabledFunc mocks the IsEnabled method.
 	IsEnabledFunc func(in1 context.Context) (bool, error)
 
+	// ReconcileExistingNetworkFunc mocks the ReconcileExistingNetwork method.
+	ReconcileExistingNetworkFunc func(in1 context.Context, in2 string, in3 []string) (*Network, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateNetwork holds details about calls to the CreateNetwork method.
@@ -115,11 +143,27 @@ type NetworkManagerMock struct {
 			// In2 is the in2 argument value.
 			In2 *Network
 		}
+		// CreateNetworkTransitGatewayAttachment holds details about calls to the CreateNetworkTransitGatewayAttachment method.
+		CreateNetworkTransitGatewayAttachment []struct {
+			// In1 is the in1 argument value.
+			In1 context.Context
+			// In2 is the in2 argument value.
+			In2 *Network
+			// In3 is the in3 argument value.
+			In3 string
+		}
 		// DeleteBundledCloudResources holds details about calls to the DeleteBundledCloudResources method.
 		DeleteBundledCloudResources []struct {
 			// In1 is the in1 argument value.
 			In1 context.Context
 		}
+		// DeleteExistingNetwork holds details about calls to the DeleteExistingNetwork method.
+		DeleteExistingNetwork []struct {
+			// In1 is the in1 argument value.
+			In1 context.Context
+			// In2 is the in2 argument value.
+			In2 string
+		}
 		// DeleteNetwork holds details about calls to the DeleteNetwork method.
 		DeleteNetwork []struct {
 			// In1 is the in1 argument value.
@@ -137,6 +181,13 @@ type NetworkManagerMock struct {
 			// In1 is the in1 argument value.
 			In1 *NetworkPeering
 		}
+		// DeleteNetworkTransitGatewayAttachment holds details about calls to the DeleteNetworkTransitGatewayAttachment method.
+		DeleteNetworkTransitGatewayAttachment []struct {
+			// In1 is the in1 argument value.
+			In1 context.Context
+			// In2 is the in2 argument value.
+			In2 string
+		}
 		// GetClusterNetworkPeering holds details about calls to the GetClusterNetworkPeering method.
 		GetClusterNetworkPeering []struct {
 			// In1 is the in1 argument value.
@@ -147,6 +198,15 @@ type NetworkManagerMock struct {
 			// In1 is the in1 argument value.
 			In1 context.Context
 		}
+		// ReconcileExistingNetwork holds details about calls to the ReconcileExistingNetwork method.
+		ReconcileExistingNetwork []struct {
+			// In1 is the in1 argument value.
+			In1 context.Context
+			// In2 is the in2 argument value.
+			In2 string
+			// In3 is the in3 argument value.
+			In3 []string
+		}
 	}
 }
 
@@ -255,6 +315,45 @@ func (mock *NetworkManagerMock) CreateNetworkPeeringCalls() []struct {
 	return calls
 }
 
+// CreateNetworkTransitGatewayAttachment calls CreateNetworkTransitGatewayAttachmentFunc.
+func (mock *NetworkManagerMock) CreateNetworkTransitGatewayAttachment(in1 context.Context, in2 *Network, in3 string) (*NetworkConnection, error) {
+	if mock.CreateNetworkTransitGatewayAttachmentFunc == nil {
+		panic("NetworkManagerMock.CreateNetworkTransitGatewayAttachmentFunc: method is nil but NetworkManager.CreateNetworkTransitGatewayAttachment was just called")
+	}
+	callInfo := struct {
+		In1 context.Context
+		In2 *Network
+		In3 string
+	}{
+		In1: in1,
+		In2: in2,
+		In3: in3,
+	}
+	lockNetworkManagerMockCreateNetworkTransitGatewayAttachment.Lock()
+	mock.calls.CreateNetworkTransitGatewayAttachment = append(mock.calls.CreateNetworkTransitGatewayAttachment, callInfo)
+	lockNetworkManagerMockCreateNetworkTransitGatewayAttachment.Unlock()
+	return mock.CreateNetworkTransitGatewayAttachmentFunc(in1, in2, in3)
+}
+
+// CreateNetworkTransitGatewayAttachmentCalls gets all the calls that were made to CreateNetworkTransitGatewayAttachment.
+// Check the length with:
+//     len(mockedNetworkManager.CreateNetworkTransitGatewayAttachmentCalls())
+func (mock *NetworkManagerMock) CreateNetworkTransitGatewayAttachmentCalls() []struct {
+	In1 context.Context
+	In2 *Network
+	In3 string
+} {
+	var calls []struct {
+		In1 context.Context
+		In2 *Network
+		In3 string
+	}
+	lockNetworkManagerMockCreateNetworkTransitGatewayAttachment.RLock()
+	calls = mock.calls.CreateNetworkTransitGatewayAttachment
+	lockNetworkManagerMockCreateNetworkTransitGatewayAttachment.RUnlock()
+	return calls
+}
+
 // DeleteBundledCloudResources calls DeleteBundledCloudResourcesFunc.
 func (mock *NetworkManagerMock) DeleteBundledCloudResources(in1 context.Context) error {
 	if mock.DeleteBundledCloudResourcesFunc == nil {
@@ -286,6 +385,41 @@ func (mock *NetworkManagerMock) DeleteBundledCloudResourcesCalls() []struct {
 	return calls
 }
 
+// DeleteExistingNetwork calls DeleteExistingNetworkFunc.
+func (mock *NetworkManagerMock) DeleteExistingNetwork(in1 context.Context, in2 string) error {
+	if mock.DeleteExistingNetworkFunc == nil {
+		panic("NetworkManagerMock.DeleteExistingNetworkFunc: method is nil but NetworkManager.DeleteExistingNetwork was just called")
+	}
+	callInfo := struct {
+		In1 context.Context
+		In2 string
+	}{
+		In1: in1,
+		In2: in2,
+	}
+	lockNetworkManagerMockDeleteExistingNetwork.Lock()
+	mock.calls.DeleteExistingNetwork = append(mock.calls.DeleteExistingNetwork, callInfo)
+	lockNetworkManagerMockDeleteExistingNetwork.Unlock()
+	return mock.DeleteExistingNetworkFunc(in1, in2)
+}
+
+// DeleteExistingNetworkCalls gets all the calls that were made to DeleteExistingNetwork.
+// Check the length with:
+//     len(mockedNetworkManager.DeleteExistingNetworkCalls())
+func (mock *NetworkManagerMock) DeleteExistingNetworkCalls() []struct {
+	In1 context.Context
+	In2 string
+} {
+	var calls []struct {
+		In1 context.Context
+		In2 string
+	}
+	lockNetworkManagerMockDeleteExistingNetwork.RLock()
+	calls = mock.calls.DeleteExistingNetwork
+	lockNetworkManagerMockDeleteExistingNetwork.RUnlock()
+	return calls
+}
+
 // DeleteNetwork calls DeleteNetworkFunc.
 func (mock *NetworkManagerMock) DeleteNetwork(in1 context.Context) error {
 	if mock.DeleteNetworkFunc == nil {
@@ -383,6 +517,41 @@ func (mock *NetworkManagerMock) DeleteNetworkPeeringCalls() []struct {
 	return calls
 }
 
+// DeleteNetworkTransitGatewayAttachment calls DeleteNetworkTransitGatewayAttachmentFunc.
+func (mock *NetworkManagerMock) DeleteNetworkTransitGatewayAttachment(in1 context.Context, in2 string) error {
+	if mock.DeleteNetworkTransitGatewayAttachmentFunc == nil {
+		panic("NetworkManagerMock.DeleteNetworkTransitGatewayAttachmentFunc: method is nil but NetworkManager.DeleteNetworkTransitGatewayAttachment was just called")
+	}
+	callInfo := struct {
+		In1 context.Context
+		In2 string
+	}{
+		In1: in1,
+		In2: in2,
+	}
+	lockNetworkManagerMockDeleteNetworkTransitGatewayAttachment.Lock()
+	mock.calls.DeleteNetworkTransitGatewayAttachment = append(mock.calls.DeleteNetworkTransitGatewayAttachment, callInfo)
+	lockNetworkManagerMockDeleteNetworkTransitGatewayAttachment.Unlock()
+	return mock.DeleteNetworkTransitGatewayAttachmentFunc(in1, in2)
+}
+
+// DeleteNetworkTransitGatewayAttachmentCalls gets all the calls that were made to DeleteNetworkTransitGatewayAttachment.
+// Check the length with:
+//     len(mockedNetworkManager.DeleteNetworkTransitGatewayAttachmentCalls())
+func (mock *NetworkManagerMock) DeleteNetworkTransitGatewayAttachmentCalls() []struct {
+	In1 context.Context
+	In2 string
+} {
+	var calls []struct {
+		In1 context.Context
+		In2 string
+	}
+	lockNetworkManagerMockDeleteNetworkTransitGatewayAttachment.RLock()
+	calls = mock.calls.DeleteNetworkTransitGatewayAttachment
+	lockNetworkManagerMockDeleteNetworkTransitGatewayAttachment.RUnlock()
+	return calls
+}
+
 // GetClusterNetworkPeering calls GetClusterNetworkPeeringFunc.
 func (mock *NetworkManagerMock) GetClusterNetworkPeering(in1 context.Context) (*NetworkPeering, error) {
 	if mock.GetClusterNetworkPeeringFunc == nil {
@@ -444,3 +613,42 @@ func (mock *NetworkManagerMock) IsEnabledCalls() []struct {
 	lockNetworkManagerMockIsEnabled.RUnlock()
 	return calls
 }
+
+// ReconcileExistingNetwork calls ReconcileExistingNetworkFunc.
+func (mock *NetworkManagerMock) ReconcileExistingNetwork(in1 context.Context, in2 string, in3 []string) (*Network, error) {
+	if mock.ReconcileExistingNetworkFunc == nil {
+		panic("NetworkManagerMock.ReconcileExistingNetworkFunc: method is nil but NetworkManager.ReconcileExistingNetwork was just called")
+	}
+	callInfo := struct {
+		In1 context.Context
+		In2 string
+		In3 []string
+	}{
+		In1: in1,
+		In2: in2,
+		In3: in3,
+	}
+	lockNetworkManagerMockReconcileExistingNetwork.Lock()
+	mock.calls.ReconcileExistingNetwork = append(mock.calls.ReconcileExistingNetwork, callInfo)
+	lockNetworkManagerMockReconcileExistingNetwork.Unlock()
+	return mock.ReconcileExistingNetworkFunc(in1, in2, in3)
+}
+
+// ReconcileExistingNetworkCalls gets all the calls that were made to ReconcileExistingNetwork.
+// Check the length with:
+//     len(mockedNetworkManager.ReconcileExistingNetworkCalls())
+func (mock *NetworkManagerMock) ReconcileExistingNetworkCalls() []struct {
+	In1 context.Context
+	In2 string
+	In3 []string
+} {
+	var calls []struct {
+		In1 context.Context
+		In2 string
+		In3 []string
+	}
+	lockNetworkManagerMockReconcileExistingNetwork.RLock()
+	calls = mock.calls.ReconcileExistingNetwork
+	lockNetworkManagerMockReconcileExistingNetwork.RUnlock()
+	return calls
+}
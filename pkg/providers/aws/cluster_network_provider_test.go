@@ -1170,6 +1170,234 @@ func Test_getNetworkProviderConfig(t *testing.T) {
 	}
 }
 
+func Test_getNetworkConnectionConfig(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		configManager ConfigManager
+		logger        *logrus.Entry
+		tier          string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *networkConnectionConfig
+		wantErr bool
+	}{
+		{
+			name: "verify connection type defaults to peering",
+			args: args{
+				ctx: context.TODO(),
+				configManager: buildTestConfigManager(func(m *ConfigManagerMock) {
+					m.ReadStorageStrategyFunc = func(ctx context.Context, rt providers.ResourceType, tier string) (*StrategyConfig, error) {
+						return &StrategyConfig{
+							CreateStrategy: json.RawMessage("{ \"CidrBlock\": \"10.0.0.0/16\" }"),
+						}, nil
+					}
+				}),
+				logger: logrus.NewEntry(logrus.StandardLogger()),
+				tier:   "test",
+			},
+			wantErr: false,
+			want: &networkConnectionConfig{
+				NetworkConnectionType: aws.String(networkConnectionTypePeering),
+			},
+		},
+		{
+			name: "verify successful parse of transit gateway connection type",
+			args: args{
+				ctx: context.TODO(),
+				configManager: buildTestConfigManager(func(m *ConfigManagerMock) {
+					m.ReadStorageStrategyFunc = func(ctx context.Context, rt providers.ResourceType, tier string) (*StrategyConfig, error) {
+						return &StrategyConfig{
+							CreateStrategy: json.RawMessage("{ \"NetworkConnectionType\": \"transitGateway\", \"TransitGatewayId\": \"tgw-123\" }"),
+						}, nil
+					}
+				}),
+				logger: logrus.NewEntry(logrus.StandardLogger()),
+				tier:   "test",
+			},
+			wantErr: false,
+			want: &networkConnectionConfig{
+				NetworkConnectionType: aws.String(networkConnectionTypeTransitGateway),
+				TransitGatewayId:      aws.String("tgw-123"),
+			},
+		},
+		{
+			name: "verify error on transit gateway connection type without a transit gateway id",
+			args: args{
+				ctx: context.TODO(),
+				configManager: buildTestConfigManager(func(m *ConfigManagerMock) {
+					m.ReadStorageStrategyFunc = func(ctx context.Context, rt providers.ResourceType, tier string) (*StrategyConfig, error) {
+						return &StrategyConfig{
+							CreateStrategy: json.RawMessage("{ \"NetworkConnectionType\": \"transitGateway\" }"),
+						}, nil
+					}
+				}),
+				logger: logrus.NewEntry(logrus.StandardLogger()),
+				tier:   "test",
+			},
+			wantErr: true,
+		},
+		{
+			name: "verify error on unsupported connection type",
+			args: args{
+				ctx: context.TODO(),
+				configManager: buildTestConfigManager(func(m *ConfigManagerMock) {
+					m.ReadStorageStrategyFunc = func(ctx context.Context, rt providers.ResourceType, tier string) (*StrategyConfig, error) {
+						return &StrategyConfig{
+							CreateStrategy: json.RawMessage("{ \"NetworkConnectionType\": \"directConnect\" }"),
+						}, nil
+					}
+				}),
+				logger: logrus.NewEntry(logrus.StandardLogger()),
+				tier:   "test",
+			},
+			wantErr: true,
+		},
+		{
+			name: "verify unmarshal error",
+			args: args{
+				ctx: context.TODO(),
+				configManager: buildTestConfigManager(func(m *ConfigManagerMock) {
+					m.ReadStorageStrategyFunc = func(ctx context.Context, rt providers.ResourceType, tier string) (*StrategyConfig, error) {
+						return &StrategyConfig{
+							CreateStrategy: json.RawMessage(""),
+						}, nil
+					}
+				}),
+				logger: logrus.NewEntry(logrus.StandardLogger()),
+				tier:   "test",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getNetworkConnectionConfig(tt.args.ctx, tt.args.configManager, tt.args.tier, tt.args.logger)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getNetworkConnectionConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getNetworkConnectionConfig() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getExistingNetworkConfig(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		configManager ConfigManager
+		logger        *logrus.Entry
+		tier          string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *existingNetworkConfig
+		wantErr bool
+	}{
+		{
+			name: "verify nil config when no vpc id is provided",
+			args: args{
+				ctx: context.TODO(),
+				configManager: buildTestConfigManager(func(m *ConfigManagerMock) {
+					m.ReadStorageStrategyFunc = func(ctx context.Context, rt providers.ResourceType, tier string) (*StrategyConfig, error) {
+						return &StrategyConfig{
+							CreateStrategy: json.RawMessage("{ \"CidrBlock\": \"10.0.0.0/16\" }"),
+						}, nil
+					}
+				}),
+				logger: logrus.NewEntry(logrus.StandardLogger()),
+				tier:   "test",
+			},
+			wantErr: false,
+			want:    nil,
+		},
+		{
+			name: "verify successful parse of existing vpc and subnet ids",
+			args: args{
+				ctx: context.TODO(),
+				configManager: buildTestConfigManager(func(m *ConfigManagerMock) {
+					m.ReadStorageStrategyFunc = func(ctx context.Context, rt providers.ResourceType, tier string) (*StrategyConfig, error) {
+						return &StrategyConfig{
+							CreateStrategy: json.RawMessage("{ \"VpcId\": \"vpc-123\", \"SubnetIds\": [\"subnet-1\", \"subnet-2\"] }"),
+						}, nil
+					}
+				}),
+				logger: logrus.NewEntry(logrus.StandardLogger()),
+				tier:   "test",
+			},
+			wantErr: false,
+			want: &existingNetworkConfig{
+				VpcId:     aws.String("vpc-123"),
+				SubnetIds: []*string{aws.String("subnet-1"), aws.String("subnet-2")},
+			},
+		},
+		{
+			name: "verify error on empty vpc id",
+			args: args{
+				ctx: context.TODO(),
+				configManager: buildTestConfigManager(func(m *ConfigManagerMock) {
+					m.ReadStorageStrategyFunc = func(ctx context.Context, rt providers.ResourceType, tier string) (*StrategyConfig, error) {
+						return &StrategyConfig{
+							CreateStrategy: json.RawMessage("{ \"VpcId\": \"\", \"SubnetIds\": [\"subnet-1\", \"subnet-2\"] }"),
+						}, nil
+					}
+				}),
+				logger: logrus.NewEntry(logrus.StandardLogger()),
+				tier:   "test",
+			},
+			wantErr: true,
+		},
+		{
+			name: "verify error on fewer than two subnet ids",
+			args: args{
+				ctx: context.TODO(),
+				configManager: buildTestConfigManager(func(m *ConfigManagerMock) {
+					m.ReadStorageStrategyFunc = func(ctx context.Context, rt providers.ResourceType, tier string) (*StrategyConfig, error) {
+						return &StrategyConfig{
+							CreateStrategy: json.RawMessage("{ \"VpcId\": \"vpc-123\", \"SubnetIds\": [\"subnet-1\"] }"),
+						}, nil
+					}
+				}),
+				logger: logrus.NewEntry(logrus.StandardLogger()),
+				tier:   "test",
+			},
+			wantErr: true,
+		},
+		{
+			name: "verify unmarshal error",
+			args: args{
+				ctx: context.TODO(),
+				configManager: buildTestConfigManager(func(m *ConfigManagerMock) {
+					m.ReadStorageStrategyFunc = func(ctx context.Context, rt providers.ResourceType, tier string) (*StrategyConfig, error) {
+						return &StrategyConfig{
+							CreateStrategy: json.RawMessage(""),
+						}, nil
+					}
+				}),
+				logger: logrus.NewEntry(logrus.StandardLogger()),
+				tier:   "test",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getExistingNetworkConfig(tt.args.ctx, tt.args.configManager, tt.args.tier, tt.args.logger)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getExistingNetworkConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getExistingNetworkConfig() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNetworkProvider_CreateNetworkPeering(t *testing.T) {
 	scheme, err := buildTestScheme()
 	if err != nil {
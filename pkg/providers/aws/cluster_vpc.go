@@ -23,7 +23,6 @@ import (
 	"context"
 	"fmt"
 	"net"
-	"reflect"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -43,6 +42,9 @@ const (
 	defaultSecurityGroupPostfix   = "security-group"
 	defaultAWSPrivateSubnetTagKey = "kubernetes.io/role/internal-elb"
 	defaultSubnetGroupDesc        = "Subnet group created and managed by the Cloud Resource Operator"
+	// ConditionTypeSubnetGroupStale surfaces a db or cache subnet group that no longer matches
+	// the cluster subnet topology and could not be extended to it
+	ConditionTypeSubnetGroupStale = "SubnetGroupStale"
 	// In AWS this must be between 16 and 28
 	// Note: The larger the mask, the less hosts available in the network
 	// We want to use the least host addresses possible, so that we can support clusters provisioned in VPCs with small
@@ -79,50 +81,85 @@ func configureSecurityGroup(ctx context.Context, c client.Client, ec2Svc ec2ifac
 		return errorUtil.Wrap(err, "error get security group")
 	}
 
+	clusterIDTag := &ec2.Tag{
+		Key:   aws.String(resources.GetOrganizationTag() + "clusterID"),
+		Value: aws.String(clusterID),
+	}
 	if foundSecGroup == nil {
 		// create security group
 		logger.Infof("creating security group from cluster %s", clusterID)
-		if _, err := ec2Svc.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{
+		createOutput, err := ec2Svc.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{
 			Description: aws.String(fmt.Sprintf("security group for cluster %s", clusterID)),
 			GroupName:   aws.String(secName),
 			VpcId:       aws.String(vpcID),
-		}); err != nil {
+		})
+		if err != nil {
 			return errorUtil.Wrap(err, "error creating security group")
 		}
+		if _, err := ec2Svc.CreateTags(&ec2.CreateTagsInput{
+			Resources: []*string{createOutput.GroupId},
+			Tags:      []*ec2.Tag{clusterIDTag},
+		}); err != nil {
+			return errorUtil.Wrap(err, "unable to tag security group")
+		}
 		return nil
 	}
 	logger.Infof("found security group %s for cluster %s", *foundSecGroup.GroupId, clusterID)
 
-	// build ip permission
-	ipPermission := &ec2.IpPermission{
-		IpProtocol: aws.String("-1"),
-		IpRanges: []*ec2.IpRange{
-			{
-				CidrIp: aws.String(cidr),
-			},
-		},
+	// refuse to manage a group created for a different cluster in the same account
+	owned, err := verifyClusterOwnership(ec2TagsToGeneric(foundSecGroup.Tags), clusterID, fmt.Sprintf("security group %s", aws.StringValue(foundSecGroup.GroupId)))
+	if err != nil {
+		return err
 	}
-
-	// check if correct permissions are in place
-	for _, perm := range foundSecGroup.IpPermissions {
-		if reflect.DeepEqual(perm, ipPermission) {
-			logger.Infof("ip permissions are correct for security group %s", *foundSecGroup.GroupName)
-			return nil
+	if !owned {
+		logger.Infof("tagging security group %s with cluster id %s", aws.StringValue(foundSecGroup.GroupId), clusterID)
+		if _, err := ec2Svc.CreateTags(&ec2.CreateTagsInput{
+			Resources: []*string{foundSecGroup.GroupId},
+			Tags:      []*ec2.Tag{clusterIDTag},
+		}); err != nil {
+			return errorUtil.Wrap(err, "unable to tag security group")
 		}
 	}
 
-	// authorize ingress
-	logger.Infof("setting ingress ip permissions for %s", *foundSecGroup.GroupName)
-	if _, err := ec2Svc.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
-		GroupId: aws.String(*foundSecGroup.GroupId),
-		IpPermissions: []*ec2.IpPermission{
-			ipPermission,
-		},
-	}); err != nil {
-		return errorUtil.Wrap(err, "error authorizing security group ingress")
+	// converge the group on least privilege ingress, database ports from the cluster network
+	// and any whitelisted cidr blocks only, drifted rules are revoked
+	ingressPermissions, err := buildDatabaseIngressPermissions(cidr)
+	if err != nil {
+		return errorUtil.Wrap(err, "error building database ingress permissions")
 	}
+	return reconcileSecurityGroupIngress(ec2Svc, foundSecGroup, ingressPermissions, logger)
+}
 
-	return nil
+// subnetIdsMatch compares two sets of subnet ids ignoring order
+func subnetIdsMatch(a []*string, b []*string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ids := map[string]bool{}
+	for _, id := range a {
+		ids[aws.StringValue(id)] = true
+	}
+	for _, id := range b {
+		if !ids[aws.StringValue(id)] {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyClusterOwnership ensures a shared sub-resource found by name belongs to this
+// cluster before the operator manages it, shortened infra names can collide when two
+// clusters share an aws account. it returns whether the resource is already tagged for
+// this cluster, untagged resources are adopted and tagged by the caller
+func verifyClusterOwnership(tags []*tag, clusterID, resourceDesc string) (bool, error) {
+	ownerID := tagValue(tags, resources.GetOrganizationTag()+"clusterID")
+	if ownerID == "" {
+		return false, nil
+	}
+	if ownerID != clusterID {
+		return false, errorUtil.New(fmt.Sprintf("%s is owned by cluster %s, refusing to manage it for cluster %s", resourceDesc, ownerID, clusterID))
+	}
+	return true, nil
 }
 
 // GetVPCSubnets returns a list of subnets associated with cluster VPC
@@ -3,6 +3,7 @@ package aws
 import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
 	"github.com/sirupsen/logrus"
 	"reflect"
 	"testing"
@@ -101,3 +102,108 @@ func Test_buildSubnetAddress(t *testing.T) {
 		})
 	}
 }
+
+func Test_subnetIdsMatch(t *testing.T) {
+	type args struct {
+		a []*string
+		b []*string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "test matching sets in a different order match",
+			args: args{
+				a: []*string{aws.String("subnet-1"), aws.String("subnet-2")},
+				b: []*string{aws.String("subnet-2"), aws.String("subnet-1")},
+			},
+			want: true,
+		},
+		{
+			name: "test a missing subnet does not match",
+			args: args{
+				a: []*string{aws.String("subnet-1")},
+				b: []*string{aws.String("subnet-1"), aws.String("subnet-2")},
+			},
+			want: false,
+		},
+		{
+			name: "test different subnets do not match",
+			args: args{
+				a: []*string{aws.String("subnet-1"), aws.String("subnet-2")},
+				b: []*string{aws.String("subnet-1"), aws.String("subnet-3")},
+			},
+			want: false,
+		},
+		{
+			name: "test empty sets match",
+			args: args{},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subnetIdsMatch(tt.args.a, tt.args.b); got != tt.want {
+				t.Errorf("subnetIdsMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_verifyClusterOwnership(t *testing.T) {
+	clusterIDTagKey := resources.GetOrganizationTag() + "clusterID"
+	type args struct {
+		tags      []*tag
+		clusterID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "test a resource tagged for this cluster is owned",
+			args: args{
+				tags: []*tag{
+					{key: clusterIDTagKey, value: "test-cluster"},
+				},
+				clusterID: "test-cluster",
+			},
+			want: true,
+		},
+		{
+			name: "test a resource tagged for another cluster is rejected",
+			args: args{
+				tags: []*tag{
+					{key: clusterIDTagKey, value: "other-cluster"},
+				},
+				clusterID: "test-cluster",
+			},
+			wantErr: true,
+		},
+		{
+			name: "test an untagged resource is adopted",
+			args: args{
+				tags: []*tag{
+					{key: tagDisplayName, value: "test"},
+				},
+				clusterID: "test-cluster",
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifyClusterOwnership(tt.args.tags, tt.args.clusterID, "test resource")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyClusterOwnership() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("verifyClusterOwnership() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
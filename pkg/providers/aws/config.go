@@ -4,11 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/sirupsen/logrus"
 
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 
@@ -31,6 +35,33 @@ const (
 	defaultReconcileTime = time.Second * 30
 
 	ResourceIdentifierAnnotation = "resourceIdentifier"
+
+	// CredentialLastRotationAnnotation records when the master credentials of a resource were last rotated
+	CredentialLastRotationAnnotation = "credentialLastRotation"
+
+	// PromoteStandbyAnnotation when set to true on a cr the disaster recovery standby is
+	// promoted to a standalone instance for failover drills
+	PromoteStandbyAnnotation = "promoteStandby"
+
+	// AdoptResourceIDAnnotation points a new cr at an existing cloud resource, the provider
+	// adopts the resource instead of provisioning one under a derived name
+	AdoptResourceIDAnnotation = "integreatly.org/adopt-resource-id"
+
+	// AdoptCredentialsSecretAnnotation names a secret in the cr namespace holding the master
+	// credentials of an adopted resource, copied into the operator credential secret
+	AdoptCredentialsSecretAnnotation = "integreatly.org/adopt-credentials-secret"
+
+	// InitSQLChecksumAnnotation records the checksum of the bootstrap sql applied after
+	// first provisioning, its presence marks the init sql as applied so it is not re-run
+	InitSQLChecksumAnnotation = "integreatly.org/init-sql-checksum"
+
+	// ApplyMaintenanceImmediatelyAnnotation when set to "true" on a cr the pending cloud
+	// maintenance actions of the resource are opted in for immediate application instead of
+	// waiting for the forced maintenance window
+	ApplyMaintenanceImmediatelyAnnotation = "integreatly.org/apply-maintenance-immediately"
+
+	// session name used when assuming the operator role on sts clusters
+	defaultSTSSessionName = "cloud-resource-operator"
 )
 
 //DefaultConfigMapNamespace is the default namespace that Configmaps will be created in
@@ -46,6 +77,130 @@ type StrategyConfig struct {
 	Region         string          `json:"region"`
 	CreateStrategy json.RawMessage `json:"createStrategy"`
 	DeleteStrategy json.RawMessage `json:"deleteStrategy"`
+	// CreateApplicationUser when true the postgres provider creates a scoped application user
+	// and database on the provisioned instance and publishes those credentials to applications,
+	// the admin credentials are kept in the operator-only credential secret
+	CreateApplicationUser bool `json:"createApplicationUser,omitempty"`
+	// SizeClasses maps the abstract sizes that can be set on a cr (small/medium/large) to
+	// provider instance classes
+	SizeClasses map[string]string `json:"sizeClasses,omitempty"`
+	// BackupRetentionDays the number of days automated backups are retained for, applied over
+	// the equivalent value in the create strategy
+	BackupRetentionDays *int64 `json:"backupRetentionDays,omitempty"`
+	// PointInTimeRecovery toggles point in time recovery through automated backups, when
+	// disabled the backup retention is forced to zero
+	PointInTimeRecovery *bool `json:"pointInTimeRecovery,omitempty"`
+	// AlertThresholds thresholds for the prometheus rules generated per provisioned resource,
+	// unset thresholds fall back to defaults
+	AlertThresholds *resources.AlertThresholds `json:"alertThresholds,omitempty"`
+	// RoleARN an optional iam role to assume before provisioning, used with Region to target
+	// a shared services account different from the cluster's own, the operator credentials
+	// only need sts:AssumeRole on the target role
+	RoleARN string `json:"roleArn,omitempty"`
+	// DisasterRecovery maintains a cross region standby of provisioned instances for failover
+	// drills, only used by the postgres provider
+	DisasterRecovery *DisasterRecoveryConfig `json:"disasterRecovery,omitempty"`
+	// Parameters engine configuration parameters applied through a dedicated parameter group
+	// managed per cr, only used by the postgres and redis providers
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// LogicalReplication enables logical replication on provisioned instances and maintains a
+	// replication role whose credentials are published in a secondary secret, so change data
+	// capture tooling can attach to the database, only used by the postgres provider
+	LogicalReplication bool `json:"logicalReplication,omitempty"`
+	// ConnectionPooling fronts provisioned instances with an rds proxy, serving the proxy
+	// endpoint in the connection secret, only used by the postgres provider
+	ConnectionPooling *ConnectionPoolingConfig `json:"connectionPooling,omitempty"`
+	// PerformanceInsights enables rds performance insights on provisioned instances, only
+	// used by the postgres provider
+	PerformanceInsights *PerformanceInsightsConfig `json:"performanceInsights,omitempty"`
+	// CORSRules cross origin resource sharing rules applied to provisioned buckets, only used
+	// by the blobstorage provider
+	CORSRules []CORSRule `json:"corsRules,omitempty"`
+	// StaticWebsite enables static website hosting with public object reads on provisioned
+	// buckets, only used by the blobstorage provider
+	StaticWebsite *StaticWebsiteConfig `json:"staticWebsite,omitempty"`
+}
+
+// DisasterRecoveryConfig configures the cross region standby maintained for a resource
+type DisasterRecoveryConfig struct {
+	// Region the standby is maintained in, must differ from the primary region
+	Region string `json:"region"`
+	// InstanceClass an optional instance class for the standby, defaults to the primary's
+	InstanceClass string `json:"instanceClass,omitempty"`
+	// KmsKeyID the kms key in the standby region used to encrypt the standby, required when
+	// the primary is encrypted
+	KmsKeyID string `json:"kmsKeyId,omitempty"`
+}
+
+// ConnectionPoolingConfig configures the rds proxy placed in front of a resource
+type ConnectionPoolingConfig struct {
+	// RoleARN the iam role the proxy assumes to read the auth secret
+	RoleARN string `json:"roleArn"`
+	// AuthSecretARN a secrets manager secret holding the database credentials the proxy
+	// authenticates with
+	AuthSecretARN string `json:"authSecretArn"`
+	// MaxConnectionsPercent an optional cap on the database connections the proxy opens,
+	// as a percentage of the instance connection limit
+	MaxConnectionsPercent *int64 `json:"maxConnectionsPercent,omitempty"`
+}
+
+// PerformanceInsightsConfig configures rds performance insights on a resource
+type PerformanceInsightsConfig struct {
+	// RetentionPeriod how long the performance data is kept, in days, defaults to the aws
+	// free tier of 7 days
+	RetentionPeriod *int64 `json:"retentionPeriod,omitempty"`
+	// KmsKeyID an optional kms key used to encrypt the performance data
+	KmsKeyID string `json:"kmsKeyId,omitempty"`
+}
+
+// CORSRule a cross origin resource sharing rule applied to a bucket
+type CORSRule struct {
+	// AllowedOrigins the origins requests are allowed from
+	AllowedOrigins []string `json:"allowedOrigins"`
+	// AllowedMethods the http methods the origins are allowed to use
+	AllowedMethods []string `json:"allowedMethods"`
+	// AllowedHeaders optional headers allowed in preflight requests
+	AllowedHeaders []string `json:"allowedHeaders,omitempty"`
+	// ExposeHeaders optional response headers exposed to browser clients
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+	// MaxAgeSeconds optional time browsers may cache the preflight response
+	MaxAgeSeconds *int64 `json:"maxAgeSeconds,omitempty"`
+}
+
+// StaticWebsiteConfig configures static website hosting on a bucket
+type StaticWebsiteConfig struct {
+	// IndexDocument the object served for directory requests, defaults to index.html
+	IndexDocument string `json:"indexDocument,omitempty"`
+	// ErrorDocument an optional object served for 4xx responses
+	ErrorDocument string `json:"errorDocument,omitempty"`
+}
+
+// ResolveSizeClass maps an abstract size set on a cr to a provider instance class using the
+// size classes on the strategy config for the tier, falling back to the provided defaults, an
+// explicit provider instance class is returned as-is
+func (s *StrategyConfig) ResolveSizeClass(size string, defaultSizeClasses map[string]string) (string, error) {
+	if s.SizeClasses[size] != "" {
+		return s.SizeClasses[size], nil
+	}
+	if defaultSizeClasses[size] != "" {
+		return defaultSizeClasses[size], nil
+	}
+	// instance classes are dot separated (e.g. db.t3.small), anything else is an unknown size
+	if strings.Contains(size, ".") {
+		return size, nil
+	}
+	return "", errorUtil.New(fmt.Sprintf("unsupported size %s, expected small/medium/large or a provider instance class", size))
+}
+
+// ApplyProviderConfig merges a per-cr provider config over the create strategy, values set on
+// the cr take precedence over the equivalent values in the strategy config map for the tier
+func (s *StrategyConfig) ApplyProviderConfig(providerConfig json.RawMessage) error {
+	mergedStrategy, err := resources.MergeRawJSON(s.CreateStrategy, providerConfig)
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to merge provider config into create strategy")
+	}
+	s.CreateStrategy = mergedStrategy
+	return nil
 }
 
 //go:generate moq -out config_moq.go . ConfigManager
@@ -132,6 +287,11 @@ func BuildInfraName(ctx context.Context, c client.Client, postfix string, n int)
 }
 
 func BuildInfraNameFromObject(ctx context.Context, c client.Client, om controllerruntime.ObjectMeta, n int) (string, error) {
+	// an adopt annotation points the cr at an existing resource, use its identifier rather
+	// than deriving one from the cluster and cr
+	if adoptedID := om.Annotations[AdoptResourceIDAnnotation]; adoptedID != "" {
+		return adoptedID, nil
+	}
 	clusterID, err := resources.GetClusterID(ctx, c)
 	if err != nil {
 		return "", errorUtil.Wrap(err, "failed to retrieve cluster identifier")
@@ -156,18 +316,51 @@ func BuildTimestampedInfraNameFromObjectCreation(ctx context.Context, c client.C
 	return resources.ShortenString(fmt.Sprintf("%s-%s-%s-%s", clusterID, om.Namespace, om.Name, om.GetObjectMeta().GetCreationTimestamp()), n), nil
 }
 
-func CreateSessionFromStrategy(ctx context.Context, c client.Client, keyID, secretKey string, strategy *StrategyConfig) (*session.Session, error) {
+func CreateSessionFromStrategy(ctx context.Context, c client.Client, providerCreds *Credentials, strategy *StrategyConfig) (*session.Session, error) {
 	region, err := GetRegionFromStrategyOrDefault(ctx, c, strategy)
 	if err != nil {
 		return nil, errorUtil.Wrap(err, "failed to get region from strategy while creating aws session")
 	}
-	sess, err := session.NewSession(&aws.Config{
-		Region:      aws.String(region),
-		Credentials: credentials.NewStaticCredentials(keyID, secretKey, ""),
-	})
+	// honour the cluster wide proxy object and the standard proxy environment variables so
+	// cloud api calls work in restricted egress environments
+	httpClient, err := resources.BuildProxiedHTTPClient(ctx, c)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to build http client for aws session")
+	}
+	awsConfig := &aws.Config{
+		Region:     aws.String(region),
+		HTTPClient: httpClient,
+	}
+	// in fips mode services with dedicated fips endpoints are pinned to them
+	if resources.IsFIPSEnabled() {
+		awsConfig.EndpointResolver = fipsEndpointResolver()
+	}
+	sess, err := session.NewSession(awsConfig)
 	if err != nil {
-		return nil, errorUtil.Wrapf(err, "failed to create aws session from strategy, region=%s keyID=%s", region, keyID)
+		return nil, errorUtil.Wrapf(err, "failed to create aws session from strategy, region=%s", region)
+	}
+	if providerCreds.IsSTS() {
+		// on sts clusters short lived credentials are assumed from the role using the web
+		// identity token and refreshed by the sdk before they expire, the assume call itself
+		// is unauthenticated so the sts client uses anonymous credentials
+		stsSess := sess.Copy(&aws.Config{Credentials: credentials.AnonymousCredentials})
+		sess.Config.Credentials = stscreds.NewWebIdentityCredentials(stsSess, providerCreds.RoleARN, defaultSTSSessionName, providerCreds.TokenFilePath)
+	} else {
+		sess.Config.Credentials = credentials.NewStaticCredentials(providerCreds.AccessKeyID, providerCreds.SecretAccessKey, "")
 	}
+	// a strategy can target a shared services account by specifying a role to assume, the
+	// assumed credentials are refreshed by the sdk before they expire
+	if strategy.RoleARN != "" {
+		sess.Config.Credentials = stscreds.NewCredentials(sess.Copy(), strategy.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+			p.RoleSessionName = defaultSTSSessionName
+		})
+	}
+	// throttle outgoing api calls against the shared per service request budget
+	addRateLimitHandler(sess)
+	// record a span per outgoing api call
+	addTracingHandler(sess)
+	// record mutating api calls in the audit trail, attributed to the cr when set on the context
+	addAuditHandler(sess, resources.AuditResourceFromContext(ctx))
 	return sess, nil
 }
 
@@ -180,6 +373,11 @@ func GetRegionFromStrategyOrDefault(ctx context.Context, c client.Client, strate
 	if region == "" {
 		region = defaultRegion
 	}
+	// gov and china regions resolve to their own partitions, anything the sdk does not know
+	// is assumed to be a new commercial region
+	if !isKnownRegion(region) {
+		logrus.Warnf("region %s is not known to the aws sdk, assuming the %s partition", region, endpoints.AwsPartitionID)
+	}
 	return region, nil
 }
 
@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -13,6 +14,7 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
 	v1 "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
 	errorUtil "github.com/pkg/errors"
 	v12 "k8s.io/api/core/v1"
@@ -26,6 +28,13 @@ const (
 	defaultCredentialsKeyIDName = "aws_access_key_id"
 	// #nosec G101
 	defaultCredentialsSecretKeyName = "aws_secret_access_key"
+
+	// on sts clusters the cloud credential operator runs in manual mode and publishes an aws
+	// credentials file referencing a role arn and web identity token instead of static keys
+	// #nosec G101
+	defaultCredentialsSTSKeyName = "credentials"
+	stsCredentialsRoleARNKey     = "role_arn"
+	stsCredentialsTokenFileKey   = "web_identity_token_file"
 )
 
 var (
@@ -40,6 +49,7 @@ var (
 				"s3:GetObject",
 				"s3:DeleteObject",
 				"s3:PutBucketTagging",
+				"s3:GetBucketTagging",
 				"s3:PutBucketPublicAccessBlock",
 				"s3:PutEncryptionConfiguration",
 				"ec2:DescribeVpcs",
@@ -49,6 +59,7 @@ var (
 				"ec2:DeleteSecurityGroup",
 				"ec2:AuthorizeSecurityGroupIngress",
 				"ec2:AuthorizeSecurityGroupEgress",
+				"ec2:RevokeSecurityGroupIngress",
 				"ec2:DescribeAvailabilityZones",
 				"ec2:CreateSubnet",
 				"ec2:CreateTags",
@@ -67,7 +78,10 @@ var (
 				"elasticache:DeleteReplicationGroup",
 				"elasticache:DescribeReplicationGroups",
 				"elasticache:DescribeServiceUpdates",
+				"elasticache:DescribeEvents",
+				"elasticache:DescribeUpdateActions",
 				"elasticache:AddTagsToResource",
+				"elasticache:ListTagsForResource",
 				"elasticache:DescribeSnapshots",
 				"elasticache:CreateSnapshot",
 				"elasticache:DeleteSnapshot",
@@ -86,6 +100,9 @@ var (
 				"rds:CreateDBSnapshot",
 				"rds:DeleteDBSnapshot",
 				"rds:DescribePendingMaintenanceActions",
+				"rds:ApplyPendingMaintenanceAction",
+				"rds:StopDBInstance",
+				"rds:StartDBInstance",
 				"rds:CreateDBSubnetGroup",
 				"rds:DescribeDBSubnetGroups",
 				"rds:DeleteDBSubnetGroup",
@@ -111,21 +128,140 @@ var (
 	}
 )
 
-func buildPutBucketObjectEntries(bucket string) []v1.StatementEntry {
+// LeastPrivilegeConfigKey enables the least privilege operator policy through the operator
+// config map or environment, mutating actions are then scoped to resources carrying the
+// cluster identifier prefix instead of being granted on every resource
+const LeastPrivilegeConfigKey = "IAM_LEAST_PRIVILEGE"
+
+// BuildOperatorStatementEntries returns the policy statements the operator requires, in
+// least privilege mode the actions are unchanged but every action that supports resource
+// level permissions is scoped to the resources the operator derives from the cluster
+// identifier, describe and list actions do not support resource scoping on aws and stay
+// granted on every resource
+func BuildOperatorStatementEntries(leastPrivilege bool, clusterID, partition string) []v1.StatementEntry {
+	if !leastPrivilege {
+		return operatorEntries
+	}
+	return []v1.StatementEntry{
+		{
+			Effect: "Allow",
+			Action: []string{
+				"s3:CreateBucket",
+				"s3:DeleteBucket",
+				"s3:ListBucket",
+				"s3:PutBucketTagging",
+				"s3:GetBucketTagging",
+				"s3:PutBucketPublicAccessBlock",
+				"s3:PutEncryptionConfiguration",
+			},
+			Resource: fmt.Sprintf("arn:%s:s3:::%s*", partition, clusterID),
+		},
+		{
+			Effect: "Allow",
+			Action: []string{
+				"s3:GetObject",
+				"s3:DeleteObject",
+			},
+			Resource: fmt.Sprintf("arn:%s:s3:::%s*/*", partition, clusterID),
+		},
+		{
+			Effect: "Allow",
+			Action: []string{
+				"rds:CreateDBInstance",
+				"rds:DeleteDBInstance",
+				"rds:ModifyDBInstance",
+				"rds:AddTagsToResource",
+				"rds:CreateDBSnapshot",
+				"rds:DeleteDBSnapshot",
+				"rds:CreateDBSubnetGroup",
+				"rds:DeleteDBSubnetGroup",
+				"rds:ModifyDBSubnetGroup",
+				"rds:ListTagsForResource",
+				"rds:RemoveTagsFromResource",
+				"rds:ApplyPendingMaintenanceAction",
+				"rds:StopDBInstance",
+				"rds:StartDBInstance",
+			},
+			Resource: fmt.Sprintf("arn:%s:rds:*:*:*:%s*", partition, clusterID),
+		},
+		{
+			Effect: "Allow",
+			Action: []string{
+				"elasticache:CreateReplicationGroup",
+				"elasticache:DeleteReplicationGroup",
+				"elasticache:ModifyReplicationGroup",
+				"elasticache:AddTagsToResource",
+				"elasticache:ListTagsForResource",
+				"elasticache:CreateSnapshot",
+				"elasticache:DeleteSnapshot",
+				"elasticache:CreateCacheSubnetGroup",
+				"elasticache:ModifyCacheSubnetGroup",
+				"elasticache:DeleteCacheSubnetGroup",
+			},
+			Resource: fmt.Sprintf("arn:%s:elasticache:*:*:*:%s*", partition, clusterID),
+		},
+		{
+			Effect: "Allow",
+			Action: []string{
+				"s3:ListAllMyBuckets",
+				"rds:DescribeDBInstances",
+				"rds:DescribeDBSnapshots",
+				"rds:DescribePendingMaintenanceActions",
+				"rds:DescribeDBSubnetGroups",
+				"elasticache:DescribeReplicationGroups",
+				"elasticache:DescribeServiceUpdates",
+				"elasticache:DescribeEvents",
+				"elasticache:DescribeUpdateActions",
+				"elasticache:DescribeSnapshots",
+				"elasticache:DescribeCacheClusters",
+				"elasticache:DescribeCacheSubnetGroups",
+				"ec2:DescribeVpcs",
+				"ec2:DescribeSubnets",
+				"ec2:DescribeSecurityGroups",
+				"ec2:CreateSecurityGroup",
+				"ec2:DeleteSecurityGroup",
+				"ec2:AuthorizeSecurityGroupIngress",
+				"ec2:AuthorizeSecurityGroupEgress",
+				"ec2:RevokeSecurityGroupIngress",
+				"ec2:DescribeAvailabilityZones",
+				"ec2:CreateSubnet",
+				"ec2:CreateTags",
+				"ec2:CreateVpc",
+				"ec2:DeleteVpc",
+				"ec2:DeleteSubnet",
+				"ec2:CreateVpcPeeringConnection",
+				"ec2:DescribeVpcPeeringConnections",
+				"ec2:AcceptVpcPeeringConnection",
+				"ec2:DeleteVpcPeeringConnection",
+				"ec2:DescribeRouteTables",
+				"ec2:CreateRoute",
+				"ec2:DeleteRoute",
+				"ec2:DescribeInstanceTypeOfferings",
+				"sts:GetCallerIdentity",
+				"iam:CreateServiceLinkedRole",
+				"cloudwatch:ListMetrics",
+				"cloudwatch:GetMetricData",
+			},
+			Resource: "*",
+		},
+	}
+}
+
+func buildPutBucketObjectEntries(partition, bucket string) []v1.StatementEntry {
 	return []v1.StatementEntry{
 		{
 			Effect: "Allow",
 			Action: []string{
 				"s3:*",
 			},
-			Resource: fmt.Sprintf("arn:aws:s3:::%s", bucket),
+			Resource: fmt.Sprintf("arn:%s:s3:::%s", partition, bucket),
 		},
 		{
 			Effect: "Allow",
 			Action: []string{
 				"s3:*",
 			},
-			Resource: fmt.Sprintf("arn:aws:s3:::%s/*", bucket),
+			Resource: fmt.Sprintf("arn:%s:s3:::%s/*", partition, bucket),
 		},
 	}
 }
@@ -135,6 +271,15 @@ type Credentials struct {
 	PolicyName      string
 	AccessKeyID     string
 	SecretAccessKey string
+	// RoleARN and TokenFilePath are set instead of static keys on sts clusters, short lived
+	// credentials are assumed from the role using the mounted web identity token
+	RoleARN       string
+	TokenFilePath string
+}
+
+// IsSTS reports whether the credentials use sts web identity federation rather than static keys
+func (c *Credentials) IsSTS() bool {
+	return c.RoleARN != "" && c.TokenFilePath != ""
 }
 
 //go:generate moq -out credentials_moq.go . CredentialManager
@@ -162,7 +307,15 @@ func NewCredentialMinterCredentialManager(client client.Client) *CredentialMinte
 
 //ReconcileProviderCredentials Ensure the credentials the AWS provider requires are available
 func (m *CredentialMinterCredentialManager) ReconcileProviderCredentials(ctx context.Context, ns string) (*Credentials, error) {
-	_, creds, err := m.ReconcileCredentials(ctx, m.ProviderCredentialName, ns, operatorEntries)
+	entries := operatorEntries
+	if enabled, ok := resources.LookupConfig(LeastPrivilegeConfigKey); ok && enabled == "true" {
+		clusterID, err := resources.GetClusterID(ctx, m.Client)
+		if err != nil {
+			return nil, errorUtil.Wrap(err, "failed to retrieve cluster identifier for least privilege policy")
+		}
+		entries = BuildOperatorStatementEntries(true, clusterID, arnPartitionFromCluster(ctx, m.Client))
+	}
+	_, creds, err := m.ReconcileCredentials(ctx, m.ProviderCredentialName, ns, entries)
 	if err != nil {
 		return nil, err
 	}
@@ -178,7 +331,9 @@ func (m *CredentialMinterCredentialManager) ReconcileSESCredentials(ctx context.
 }
 
 func (m *CredentialMinterCredentialManager) ReoncileBucketOwnerCredentials(ctx context.Context, name, ns, bucket string) (*Credentials, *v1.CredentialsRequest, error) {
-	cr, creds, err := m.ReconcileCredentials(ctx, name, ns, buildPutBucketObjectEntries(bucket))
+	// bucket arns carry the partition of the region the bucket lives in (e.g. aws-us-gov on
+	// govcloud clusters)
+	cr, creds, err := m.ReconcileCredentials(ctx, name, ns, buildPutBucketObjectEntries(arnPartitionFromCluster(ctx, m.Client), bucket))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -211,16 +366,13 @@ func (m *CredentialMinterCredentialManager) ReconcileCredentials(ctx context.Con
 	if err = codec.DecodeProviderSpec(cr.Status.ProviderStatus, awsProvStatus); err != nil {
 		return nil, nil, errorUtil.Wrapf(err, "failed to decode credentials request %s", cr.Name)
 	}
-	accessKeyID, secAccessKey, err := m.reconcileAWSCredentials(ctx, cr)
+	creds, err := m.reconcileAWSCredentials(ctx, cr)
 	if err != nil {
 		return nil, nil, errorUtil.Wrapf(err, "failed to reconcile aws credentials from credential request %s", cr.Name)
 	}
-	return cr, &Credentials{
-		Username:        awsProvStatus.User,
-		PolicyName:      awsProvStatus.Policy,
-		AccessKeyID:     accessKeyID,
-		SecretAccessKey: secAccessKey,
-	}, nil
+	creds.Username = awsProvStatus.User
+	creds.PolicyName = awsProvStatus.Policy
+	return cr, creds, nil
 }
 
 func (m *CredentialMinterCredentialManager) reconcileCredentialRequest(ctx context.Context, name string, ns string, entries []v1.StatementEntry) (*v1.CredentialsRequest, error) {
@@ -257,19 +409,52 @@ func (m *CredentialMinterCredentialManager) reconcileCredentialRequest(ctx conte
 	return cr, nil
 }
 
-func (m *CredentialMinterCredentialManager) reconcileAWSCredentials(ctx context.Context, cr *v1.CredentialsRequest) (string, string, error) {
+func (m *CredentialMinterCredentialManager) reconcileAWSCredentials(ctx context.Context, cr *v1.CredentialsRequest) (*Credentials, error) {
 	sec := &v12.Secret{}
 	err := m.Client.Get(ctx, types.NamespacedName{Name: cr.Spec.SecretRef.Name, Namespace: cr.Spec.SecretRef.Namespace}, sec)
 	if err != nil {
-		return "", "", errorUtil.Wrapf(err, "failed to get aws credentials secret %s", cr.Spec.SecretRef.Name)
+		return nil, errorUtil.Wrapf(err, "failed to get aws credentials secret %s", cr.Spec.SecretRef.Name)
 	}
 	awsAccessKeyID := string(sec.Data[defaultCredentialsKeyIDName])
 	awsSecretAccessKey := string(sec.Data[defaultCredentialsSecretKeyName])
+	if awsAccessKeyID != "" && awsSecretAccessKey != "" {
+		return &Credentials{
+			AccessKeyID:     awsAccessKeyID,
+			SecretAccessKey: awsSecretAccessKey,
+		}, nil
+	}
+	// no static keys, on sts clusters the secret holds a credentials file instead
+	if stsCredsFile := string(sec.Data[defaultCredentialsSTSKeyName]); stsCredsFile != "" {
+		roleARN, tokenFilePath := parseSTSCredentialsFile(stsCredsFile)
+		if roleARN == "" || tokenFilePath == "" {
+			return nil, errorUtil.New(fmt.Sprintf("sts credentials in secret %s are missing a role arn or web identity token file", sec.Name))
+		}
+		return &Credentials{
+			RoleARN:       roleARN,
+			TokenFilePath: tokenFilePath,
+		}, nil
+	}
 	if awsAccessKeyID == "" {
-		return "", "", errorUtil.New(fmt.Sprintf("aws access key id is undefined in secret %s", sec.Name))
+		return nil, errorUtil.New(fmt.Sprintf("aws access key id is undefined in secret %s", sec.Name))
 	}
-	if awsSecretAccessKey == "" {
-		return "", "", errorUtil.New(fmt.Sprintf("aws secret access key is undefined in secret %s", sec.Name))
+	return nil, errorUtil.New(fmt.Sprintf("aws secret access key is undefined in secret %s", sec.Name))
+}
+
+// parseSTSCredentialsFile extracts the role arn and web identity token file from an aws
+// credentials file published by the cloud credential operator in manual/sts mode
+func parseSTSCredentialsFile(credsFile string) (string, string) {
+	var roleARN, tokenFilePath string
+	for _, line := range strings.Split(credsFile, "\n") {
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		switch strings.TrimSpace(parts[0]) {
+		case stsCredentialsRoleARNKey:
+			roleARN = strings.TrimSpace(parts[1])
+		case stsCredentialsTokenFileKey:
+			tokenFilePath = strings.TrimSpace(parts[1])
+		}
 	}
-	return awsAccessKeyID, awsSecretAccessKey, nil
+	return roleARN, tokenFilePath
 }
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	v1 "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
@@ -21,13 +22,15 @@ func TestCredentialManager_ReconcileCredentials(t *testing.T) {
 		t.Fatal("failed to build scheme", err)
 	}
 	cases := []struct {
-		name                string
-		credName            string
-		credNS              string
-		entries             []v1.StatementEntry
-		expectedAccessKeyID string
-		expectedSecretKey   string
-		client              client.Client
+		name                  string
+		credName              string
+		credNS                string
+		entries               []v1.StatementEntry
+		expectedAccessKeyID   string
+		expectedSecretKey     string
+		expectedRoleARN       string
+		expectedTokenFilePath string
+		client                client.Client
 	}{
 		{
 			name:                "test credentials are reconciled successfully",
@@ -64,6 +67,40 @@ func TestCredentialManager_ReconcileCredentials(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name:                  "test sts credentials are reconciled successfully",
+			credName:              "test",
+			credNS:                "test",
+			entries:               []v1.StatementEntry{},
+			expectedRoleARN:       "arn:aws:iam::123456789012:role/test-role",
+			expectedTokenFilePath: "/var/run/secrets/openshift/serviceaccount/token",
+			client: fake.NewFakeClientWithScheme(scheme, &v1.CredentialsRequest{
+				ObjectMeta: controllerruntime.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+				},
+				Spec: v1.CredentialsRequestSpec{
+					SecretRef: v12.ObjectReference{
+						Name:      "test",
+						Namespace: "test",
+					},
+				},
+				Status: v1.CredentialsRequestStatus{
+					Provisioned: true,
+					ProviderStatus: &runtime.RawExtension{
+						Raw: []byte("{ \"user\":\"test\", \"policy\":\"test\" }"),
+					},
+				},
+			}, &v12.Secret{
+				ObjectMeta: controllerruntime.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+				},
+				Data: map[string][]byte{
+					defaultCredentialsSTSKeyName: []byte("[default]\nrole_arn = arn:aws:iam::123456789012:role/test-role\nweb_identity_token_file = /var/run/secrets/openshift/serviceaccount/token"),
+				},
+			}),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -78,6 +115,57 @@ func TestCredentialManager_ReconcileCredentials(t *testing.T) {
 			if awsCreds.SecretAccessKey != tc.expectedSecretKey {
 				t.Fatalf("unexpected secret access key, expected %s but got %s", tc.expectedSecretKey, awsCreds.SecretAccessKey)
 			}
+			if awsCreds.RoleARN != tc.expectedRoleARN {
+				t.Fatalf("unexpected role arn, expected %s but got %s", tc.expectedRoleARN, awsCreds.RoleARN)
+			}
+			if awsCreds.TokenFilePath != tc.expectedTokenFilePath {
+				t.Fatalf("unexpected token file path, expected %s but got %s", tc.expectedTokenFilePath, awsCreds.TokenFilePath)
+			}
 		})
 	}
 }
+
+func TestBuildOperatorStatementEntries(t *testing.T) {
+	actionSet := func(entries []v1.StatementEntry) map[string]bool {
+		actions := map[string]bool{}
+		for _, entry := range entries {
+			for _, action := range entry.Action {
+				actions[action] = true
+			}
+		}
+		return actions
+	}
+	t.Run("test broad mode returns the default operator entries", func(t *testing.T) {
+		entries := BuildOperatorStatementEntries(false, "", "aws")
+		if len(entries) != len(operatorEntries) {
+			t.Fatalf("expected the default operator entries, got %d statements", len(entries))
+		}
+	})
+	t.Run("test least privilege mode grants the same actions", func(t *testing.T) {
+		broad := actionSet(BuildOperatorStatementEntries(false, "", "aws"))
+		scoped := actionSet(BuildOperatorStatementEntries(true, "testcluster", "aws"))
+		for action := range broad {
+			if !scoped[action] {
+				t.Fatalf("action %s is missing from the least privilege policy", action)
+			}
+		}
+		for action := range scoped {
+			if !broad[action] {
+				t.Fatalf("action %s is not part of the default policy", action)
+			}
+		}
+	})
+	t.Run("test least privilege mode scopes resources to the cluster prefix", func(t *testing.T) {
+		for _, entry := range BuildOperatorStatementEntries(true, "testcluster", "aws-us-gov") {
+			if entry.Resource == "*" {
+				continue
+			}
+			if !strings.Contains(entry.Resource, "arn:aws-us-gov:") {
+				t.Fatalf("resource %s does not carry the requested partition", entry.Resource)
+			}
+			if !strings.Contains(entry.Resource, "testcluster") {
+				t.Fatalf("resource %s is not scoped to the cluster prefix", entry.Resource)
+			}
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	errorUtil "github.com/pkg/errors"
+)
+
+// wrapAWSError wraps an aws sdk error with a message, annotating it with a resource error class
+// when the aws error code maps to a known failure mode, unknown codes are wrapped unclassified
+// and treated as transient
+func wrapAWSError(err error, msg string) error {
+	awsErr, isAwsErr := err.(awserr.Error)
+	if !isAwsErr {
+		return errorUtil.Wrap(err, msg)
+	}
+	switch awsErr.Code() {
+	case rds.ErrCodeInstanceQuotaExceededFault,
+		rds.ErrCodeStorageQuotaExceededFault,
+		rds.ErrCodeSnapshotQuotaExceededFault,
+		elasticache.ErrCodeClusterQuotaForCustomerExceededFault,
+		elasticache.ErrCodeNodeQuotaForClusterExceededFault,
+		elasticache.ErrCodeNodeQuotaForCustomerExceededFault:
+		return resources.WrapWithClass(err, resources.ErrorClassQuotaExceeded, msg)
+	case "AccessDenied", "AccessDeniedException", "UnauthorizedOperation", "InvalidClientTokenId", "SignatureDoesNotMatch":
+		return resources.WrapWithClass(err, resources.ErrorClassAuth, msg)
+	case elasticache.ErrCodeInvalidParameterCombinationException,
+		elasticache.ErrCodeInvalidParameterValueException:
+		return resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, msg)
+	default:
+		return errorUtil.Wrap(err, msg)
+	}
+}
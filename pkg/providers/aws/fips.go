@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+)
+
+// fipsEndpointPrefixes the endpoint hostname prefixes of the services that publish dedicated
+// fips endpoints, keyed by the sdk endpoints id of the service. fips endpoints are only
+// published in the us commercial regions, the govcloud endpoints are fips compliant already
+var fipsEndpointPrefixes = map[string]string{
+	"rds":         "rds-fips",
+	"elasticache": "elasticache-fips",
+	"s3":          "s3-fips",
+	"sts":         "sts-fips",
+}
+
+// fipsEndpointResolver resolves service endpoints to their fips variants where one is
+// published for the service and region, everything else falls through to the default sdk
+// resolver
+func fipsEndpointResolver() endpoints.ResolverFunc {
+	return func(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+		prefix, ok := fipsEndpointPrefixes[service]
+		if ok && strings.HasPrefix(region, "us-") && !strings.HasPrefix(region, "us-gov-") {
+			return endpoints.ResolvedEndpoint{
+				URL:           fmt.Sprintf("https://%s.%s.amazonaws.com", prefix, region),
+				SigningRegion: region,
+				SigningName:   service,
+			}, nil
+		}
+		return endpoints.DefaultResolver().EndpointFor(service, region, optFns...)
+	}
+}
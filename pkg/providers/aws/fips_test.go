@@ -0,0 +1,54 @@
+package aws
+
+import (
+	"testing"
+)
+
+func TestFipsEndpointResolver(t *testing.T) {
+	type args struct {
+		service string
+		region  string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "test rds resolves to the fips endpoint in us commercial regions",
+			args: args{service: "rds", region: "us-east-1"},
+			want: "https://rds-fips.us-east-1.amazonaws.com",
+		},
+		{
+			name: "test elasticache resolves to the fips endpoint in us commercial regions",
+			args: args{service: "elasticache", region: "us-west-2"},
+			want: "https://elasticache-fips.us-west-2.amazonaws.com",
+		},
+		{
+			name: "test regions without fips endpoints fall through to the default resolver",
+			args: args{service: "rds", region: "eu-west-1"},
+			want: "https://rds.eu-west-1.amazonaws.com",
+		},
+		{
+			name: "test govcloud regions fall through to the default resolver",
+			args: args{service: "rds", region: "us-gov-west-1"},
+			want: "https://rds.us-gov-west-1.amazonaws.com",
+		},
+		{
+			name: "test services without fips endpoints fall through to the default resolver",
+			args: args{service: "ec2", region: "us-east-1"},
+			want: "https://ec2.us-east-1.amazonaws.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fipsEndpointResolver()(tt.args.service, tt.args.region)
+			if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+			if got.URL != tt.want {
+				t.Errorf("fipsEndpointResolver() = %v, want %v", got.URL, tt.want)
+			}
+		})
+	}
+}
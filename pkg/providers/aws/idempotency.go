@@ -0,0 +1,117 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	errorUtil "github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// the rds and elasticache create apis do not support client tokens, idempotency comes from
+// the deterministic resource identifiers derived from the cluster and cr identity. the
+// helpers here close the remaining gaps, an already exists failure from a create that raced a
+// previous reconcile is treated as the create being in progress, and before a create is
+// issued the existing resources are checked for one already carrying the cro identity tags of
+// the cr under a different identifier
+
+// isAlreadyExistsError returns true when a create call failed because a resource with the
+// same identifier already exists, which happens when two reconciles race or the operator
+// restarts after issuing a create that is not yet visible in a describe
+func isAlreadyExistsError(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch awsErr.Code() {
+		case rds.ErrCodeDBInstanceAlreadyExistsFault, elasticache.ErrCodeReplicationGroupAlreadyExistsFault, s3.ErrCodeBucketAlreadyOwnedByYou:
+			return true
+		}
+	}
+	return false
+}
+
+// buildIdentityTags builds the tag values that uniquely identify the cloud resource
+// provisioned for a cr, the same values are applied by the providers through
+// resources.BuildDefaultResourceTags
+func buildIdentityTags(ctx context.Context, c client.Client, specType string, om metav1.ObjectMeta) (map[string]string, error) {
+	clusterID, err := resources.GetClusterID(ctx, c)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to get cluster id")
+	}
+	organizationTag := resources.GetOrganizationTag()
+	return map[string]string{
+		organizationTag + "clusterID":     clusterID,
+		organizationTag + "resource-type": specType,
+		organizationTag + "resource-name": om.Name,
+	}, nil
+}
+
+func tagsContainAll(tags []*tag, wanted map[string]string) bool {
+	for key, value := range wanted {
+		if !tagsContains(tags, key, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// findRDSInstanceWithCROTags returns an rds instance from the provided list carrying the cro
+// identity tags for the cr, used as a pre create guard so an instance already provisioned for
+// the cr under a different identifier is found before a duplicate is created
+func findRDSInstanceWithCROTags(ctx context.Context, c client.Client, rdsSvc rdsiface.RDSAPI, specType string, om metav1.ObjectMeta, instances []*rds.DBInstance) (*rds.DBInstance, error) {
+	identityTags, err := buildIdentityTags(ctx, c, specType, om)
+	if err != nil {
+		return nil, err
+	}
+	for _, instance := range instances {
+		tagsOutput, err := rdsSvc.ListTagsForResource(&rds.ListTagsForResourceInput{
+			ResourceName: instance.DBInstanceArn,
+		})
+		if err != nil {
+			return nil, errorUtil.Wrapf(err, "failed to list tags for rds instance %s", aws.StringValue(instance.DBInstanceIdentifier))
+		}
+		if tagsContainAll(rdsTagstoGeneric(tagsOutput.TagList), identityTags) {
+			return instance, nil
+		}
+	}
+	return nil, nil
+}
+
+// findReplicationGroupWithCROTags returns an elasticache replication group from the provided
+// list carrying the cro identity tags for the cr, used as a pre create guard so a replication
+// group already provisioned for the cr under a different identifier is found before a
+// duplicate is created
+func findReplicationGroupWithCROTags(ctx context.Context, c client.Client, cacheSvc elasticacheiface.ElastiCacheAPI, stsSvc stsiface.STSAPI, region string, specType string, om metav1.ObjectMeta, replicationGroups []*elasticache.ReplicationGroup) (*elasticache.ReplicationGroup, error) {
+	identityTags, err := buildIdentityTags(ctx, c, specType, om)
+	if err != nil {
+		return nil, err
+	}
+	id, err := stsSvc.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to get account identity")
+	}
+	for _, replicationGroup := range replicationGroups {
+		// replication groups do not expose their arn in the describe output, build it from the
+		// account identity, the partition differs on govcloud and china clusters
+		arn := fmt.Sprintf("arn:%s:elasticache:%s:%s:replicationgroup:%s", arnPartitionForRegion(region), region, aws.StringValue(id.Account), aws.StringValue(replicationGroup.ReplicationGroupId))
+		tagsOutput, err := cacheSvc.ListTagsForResource(&elasticache.ListTagsForResourceInput{
+			ResourceName: aws.String(arn),
+		})
+		if err != nil {
+			return nil, errorUtil.Wrapf(err, "failed to list tags for elasticache replication group %s", aws.StringValue(replicationGroup.ReplicationGroupId))
+		}
+		if tagsContainAll(elasticacheTagsToGeneric(tagsOutput.TagList), identityTags) {
+			return replicationGroup, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,125 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/rds"
+	errorUtil "github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestIsAlreadyExistsError(t *testing.T) {
+	type args struct {
+		err error
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "test rds db instance already exists fault is recognised",
+			args: args{err: awserr.New(rds.ErrCodeDBInstanceAlreadyExistsFault, "already exists", nil)},
+			want: true,
+		},
+		{
+			name: "test elasticache replication group already exists fault is recognised",
+			args: args{err: awserr.New(elasticache.ErrCodeReplicationGroupAlreadyExistsFault, "already exists", nil)},
+			want: true,
+		},
+		{
+			name: "test other aws errors are not treated as already exists",
+			args: args{err: awserr.New(rds.ErrCodeDBInstanceNotFoundFault, "not found", nil)},
+			want: false,
+		},
+		{
+			name: "test non aws errors are not treated as already exists",
+			args: args{err: errorUtil.New("generic failure")},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAlreadyExistsError(tt.args.err); got != tt.want {
+				t.Errorf("isAlreadyExistsError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindRDSInstanceWithCROTags(t *testing.T) {
+	scheme, err := buildTestSchemePostgresql()
+	if err != nil {
+		t.Fatal("failed to build scheme", err)
+	}
+	identityTagList := []*rds.Tag{
+		{Key: aws.String("integreatly.org/clusterID"), Value: aws.String(dafaultInfraName)},
+		{Key: aws.String("integreatly.org/resource-type"), Value: aws.String("managed")},
+		{Key: aws.String("integreatly.org/resource-name"), Value: aws.String("test")},
+	}
+	type args struct {
+		tagList   []*rds.Tag
+		instances []*rds.DBInstance
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantName string
+	}{
+		{
+			name: "test instance carrying the cro identity tags is found under a different identifier",
+			args: args{
+				tagList: identityTagList,
+				instances: []*rds.DBInstance{
+					{
+						DBInstanceIdentifier: aws.String("legacy-identifier"),
+						DBInstanceArn:        aws.String("arn:aws:rds:eu-west-1:123456789012:db:legacy-identifier"),
+					},
+				},
+			},
+			wantName: "legacy-identifier",
+		},
+		{
+			name: "test instance without the cro identity tags is not returned",
+			args: args{
+				tagList: []*rds.Tag{
+					{Key: aws.String("integreatly.org/clusterID"), Value: aws.String("another-cluster")},
+				},
+				instances: []*rds.DBInstance{
+					{
+						DBInstanceIdentifier: aws.String("legacy-identifier"),
+						DBInstanceArn:        aws.String("arn:aws:rds:eu-west-1:123456789012:db:legacy-identifier"),
+					},
+				},
+			},
+			wantName: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClientWithScheme(scheme, buildTestInfra())
+			mockSvc := &mockRdsClient{
+				listTagsForResourceFn: func(input *rds.ListTagsForResourceInput) (*rds.ListTagsForResourceOutput, error) {
+					return &rds.ListTagsForResourceOutput{TagList: tt.args.tagList}, nil
+				},
+			}
+			cr := buildTestPostgresCR()
+			cr.Spec.Type = "managed"
+			got, err := findRDSInstanceWithCROTags(context.TODO(), fakeClient, mockSvc, cr.Spec.Type, cr.ObjectMeta, tt.args.instances)
+			if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+			gotName := ""
+			if got != nil {
+				gotName = aws.StringValue(got.DBInstanceIdentifier)
+			}
+			if gotName != tt.wantName {
+				t.Errorf("findRDSInstanceWithCROTags() = %v, want %v", gotName, tt.wantName)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	configv1 "github.com/integr8ly/cloud-resource-operator/pkg/apis/config/v1"
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	errorUtil "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	orphanScannerName = "aws orphan scanner"
+
+	// DefaultOrphanedResourceReportName the report cr maintained by the periodic orphan scan
+	DefaultOrphanedResourceReportName = "cloud-resource-orphans"
+
+	orphanScanInterval = time.Hour
+)
+
+// OrphanScanner periodically lists the aws resources carrying the cluster id tag and reports
+// those whose owning cr no longer exists, catching cloud resources leaked by failed deletes
+// and namespace deletions that the ledger did not observe
+type OrphanScanner struct {
+	Client            client.Client
+	Logger            *logrus.Entry
+	CredentialManager CredentialManager
+	ConfigManager     ConfigManager
+}
+
+func NewAWSOrphanScanner(client client.Client, logger *logrus.Entry) *OrphanScanner {
+	return &OrphanScanner{
+		Client:            client,
+		Logger:            logger.WithFields(logrus.Fields{"providers": orphanScannerName}),
+		CredentialManager: NewCredentialMinterCredentialManager(client),
+		ConfigManager:     NewDefaultConfigMapConfigManager(client),
+	}
+}
+
+// ScanForOrphanedResources lists the rds instances, elasticache clusters and s3 buckets
+// carrying the cluster id tag and returns those whose owning cr no longer exists
+func (s *OrphanScanner) ScanForOrphanedResources(ctx context.Context, namespace string) ([]v1alpha1.OrphanedResource, error) {
+	providerCreds, err := s.CredentialManager.ReconcileProviderCredentials(ctx, namespace)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to reconcile aws credentials for orphan scan")
+	}
+	sess, err := CreateSessionFromStrategy(ctx, s.Client, providerCreds, &StrategyConfig{})
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to create aws session for orphan scan")
+	}
+	region, err := GetRegionFromStrategyOrDefault(ctx, s.Client, &StrategyConfig{})
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to get region for orphan scan")
+	}
+	clusterID, err := resources.GetClusterID(ctx, s.Client)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to get cluster id for orphan scan")
+	}
+	organizationTag := resources.GetOrganizationTag()
+	clusterTagKey := organizationTag + "clusterID"
+	nameTagKey := organizationTag + "resource-name"
+
+	orphans := []v1alpha1.OrphanedResource{}
+	postgresNames, err := s.getPostgresNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rdsOrphans, err := s.scanRDSInstances(rds.New(sess), postgresNames, clusterTagKey, clusterID, nameTagKey)
+	if err != nil {
+		return nil, err
+	}
+	orphans = append(orphans, rdsOrphans...)
+
+	redisNames, err := s.getRedisNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	elasticacheOrphans, err := s.scanElasticacheClusters(elasticache.New(sess), sts.New(sess), region, redisNames, clusterTagKey, clusterID, nameTagKey)
+	if err != nil {
+		return nil, err
+	}
+	orphans = append(orphans, elasticacheOrphans...)
+
+	blobStorageNames, err := s.getBlobStorageNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s3Orphans, err := s.scanS3Buckets(s3.New(sess), blobStorageNames, clusterTagKey, clusterID, nameTagKey)
+	if err != nil {
+		return nil, err
+	}
+	orphans = append(orphans, s3Orphans...)
+	return orphans, nil
+}
+
+// scanRDSInstances returns the rds instances carrying the cluster id tag whose owning
+// postgres cr no longer exists. Tag reads denied by a scoped credential policy are skipped
+// rather than failing the scan
+func (s *OrphanScanner) scanRDSInstances(rdsSvc rdsiface.RDSAPI, ownedNames map[string]bool, clusterTagKey string, clusterID string, nameTagKey string) ([]v1alpha1.OrphanedResource, error) {
+	listOutput, err := rdsSvc.DescribeDBInstances(&rds.DescribeDBInstancesInput{})
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to list rds instances during orphan scan")
+	}
+	orphans := []v1alpha1.OrphanedResource{}
+	for _, foundInstance := range listOutput.DBInstances {
+		tagsOutput, err := rdsSvc.ListTagsForResource(&rds.ListTagsForResourceInput{
+			ResourceName: foundInstance.DBInstanceArn,
+		})
+		if err != nil {
+			s.Logger.Debugf("skipping rds instance %s during orphan scan, failed to read tags: %v", aws.StringValue(foundInstance.DBInstanceIdentifier), err)
+			continue
+		}
+		tags := rdsTagstoGeneric(tagsOutput.TagList)
+		if !tagsContains(tags, clusterTagKey, clusterID) {
+			continue
+		}
+		name := tagValue(tags, nameTagKey)
+		if name != "" && ownedNames[name] {
+			continue
+		}
+		orphans = append(orphans, v1alpha1.OrphanedResource{
+			ResourceType: string(providers.PostgresResourceType),
+			ResourceID:   aws.StringValue(foundInstance.DBInstanceArn),
+			ResourceName: name,
+		})
+	}
+	return orphans, nil
+}
+
+// scanElasticacheClusters returns the elasticache clusters carrying the cluster id tag whose
+// owning redis cr no longer exists, clusters belonging to the same replication group are
+// reported once
+func (s *OrphanScanner) scanElasticacheClusters(cacheSvc elasticacheiface.ElastiCacheAPI, stsSvc stsiface.STSAPI, region string, ownedNames map[string]bool, clusterTagKey string, clusterID string, nameTagKey string) ([]v1alpha1.OrphanedResource, error) {
+	listOutput, err := cacheSvc.DescribeCacheClusters(&elasticache.DescribeCacheClustersInput{})
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to list elasticache clusters during orphan scan")
+	}
+	identity, err := stsSvc.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to get account identity during orphan scan")
+	}
+	orphans := []v1alpha1.OrphanedResource{}
+	seenReplicationGroups := map[string]bool{}
+	for _, foundCluster := range listOutput.CacheClusters {
+		// tags are applied at the cache cluster level, see createElasticacheCluster
+		clusterArn := fmt.Sprintf("arn:%s:elasticache:%s:%s:cluster:%s", arnPartitionForRegion(region), region, aws.StringValue(identity.Account), aws.StringValue(foundCluster.CacheClusterId))
+		tagsOutput, err := cacheSvc.ListTagsForResource(&elasticache.ListTagsForResourceInput{
+			ResourceName: aws.String(clusterArn),
+		})
+		if err != nil {
+			s.Logger.Debugf("skipping elasticache cluster %s during orphan scan, failed to read tags: %v", aws.StringValue(foundCluster.CacheClusterId), err)
+			continue
+		}
+		tags := elasticacheTagsToGeneric(tagsOutput.TagList)
+		if !tagsContains(tags, clusterTagKey, clusterID) {
+			continue
+		}
+		name := tagValue(tags, nameTagKey)
+		if name != "" && ownedNames[name] {
+			continue
+		}
+		// report the replication group the cluster belongs to, that is the handle needed for
+		// manual cleanup, standalone clusters are reported by their own identifier
+		resourceID := aws.StringValue(foundCluster.ReplicationGroupId)
+		if resourceID == "" {
+			resourceID = aws.StringValue(foundCluster.CacheClusterId)
+		}
+		if seenReplicationGroups[resourceID] {
+			continue
+		}
+		seenReplicationGroups[resourceID] = true
+		orphans = append(orphans, v1alpha1.OrphanedResource{
+			ResourceType: string(providers.RedisResourceType),
+			ResourceID:   resourceID,
+			ResourceName: name,
+		})
+	}
+	return orphans, nil
+}
+
+// scanS3Buckets returns the s3 buckets carrying the cluster id tag whose owning blobstorage
+// cr no longer exists. Buckets without tags or with tags the credential policy cannot read
+// are skipped rather than failing the scan
+func (s *OrphanScanner) scanS3Buckets(s3Svc s3iface.S3API, ownedNames map[string]bool, clusterTagKey string, clusterID string, nameTagKey string) ([]v1alpha1.OrphanedResource, error) {
+	listOutput, err := s3Svc.ListBuckets(&s3.ListBucketsInput{})
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to list s3 buckets during orphan scan")
+	}
+	orphans := []v1alpha1.OrphanedResource{}
+	for _, foundBucket := range listOutput.Buckets {
+		tagsOutput, err := s3Svc.GetBucketTagging(&s3.GetBucketTaggingInput{
+			Bucket: foundBucket.Name,
+		})
+		if err != nil {
+			// buckets without a tag set return an error rather than an empty tag set
+			s.Logger.Debugf("skipping s3 bucket %s during orphan scan, failed to read tags: %v", aws.StringValue(foundBucket.Name), err)
+			continue
+		}
+		tags := s3TagsToGeneric(tagsOutput.TagSet)
+		if !tagsContains(tags, clusterTagKey, clusterID) {
+			continue
+		}
+		name := tagValue(tags, nameTagKey)
+		if name != "" && ownedNames[name] {
+			continue
+		}
+		orphans = append(orphans, v1alpha1.OrphanedResource{
+			ResourceType: string(providers.BlobStorageResourceType),
+			ResourceID:   aws.StringValue(foundBucket.Name),
+			ResourceName: name,
+		})
+	}
+	return orphans, nil
+}
+
+func (s *OrphanScanner) getPostgresNames(ctx context.Context) (map[string]bool, error) {
+	list := &v1alpha1.PostgresList{}
+	if err := s.Client.List(ctx, list); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to list postgres crs during orphan scan")
+	}
+	names := map[string]bool{}
+	for _, item := range list.Items {
+		names[item.Name] = true
+	}
+	return names, nil
+}
+
+func (s *OrphanScanner) getRedisNames(ctx context.Context) (map[string]bool, error) {
+	list := &v1alpha1.RedisList{}
+	if err := s.Client.List(ctx, list); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to list redis crs during orphan scan")
+	}
+	names := map[string]bool{}
+	for _, item := range list.Items {
+		names[item.Name] = true
+	}
+	return names, nil
+}
+
+func (s *OrphanScanner) getBlobStorageNames(ctx context.Context) (map[string]bool, error) {
+	list := &v1alpha1.BlobStorageList{}
+	if err := s.Client.List(ctx, list); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to list blobstorage crs during orphan scan")
+	}
+	names := map[string]bool{}
+	for _, item := range list.Items {
+		names[item.Name] = true
+	}
+	return names, nil
+}
+
+// ReconcileOrphanedResourceReport runs a scan and writes the result to the orphaned resource
+// report cr and the orphaned resource metric, the scan is a dry run only, nothing is deleted
+func (s *OrphanScanner) ReconcileOrphanedResourceReport(ctx context.Context, namespace string) error {
+	orphans, err := s.ScanForOrphanedResources(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	report := &v1alpha1.OrphanedResourceReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DefaultOrphanedResourceReportName,
+			Namespace: namespace,
+		},
+	}
+	if err := s.Client.Get(ctx, types.NamespacedName{Name: report.Name, Namespace: report.Namespace}, report); err != nil {
+		if !errors.IsNotFound(err) {
+			return errorUtil.Wrapf(err, "failed to get orphaned resource report %s", report.Name)
+		}
+		if err := s.Client.Create(ctx, report); err != nil {
+			return errorUtil.Wrapf(err, "failed to create orphaned resource report %s", report.Name)
+		}
+	}
+	report.Status.LastScanTime = metav1.Now()
+	report.Status.Orphans = orphans
+	if err := s.Client.Status().Update(ctx, report); err != nil {
+		return errorUtil.Wrapf(err, "failed to update orphaned resource report %s", report.Name)
+	}
+	for _, orphan := range orphans {
+		s.Logger.Warnf("found orphaned %s %s tagged for this cluster, the owning cr %q no longer exists and the cloud resource needs manual cleanup", orphan.ResourceType, orphan.ResourceID, orphan.ResourceName)
+		resources.SetMetric(resources.DefaultResourceOrphanedMetricName, map[string]string{
+			"resourceType": orphan.ResourceType,
+			"name":         orphan.ResourceName,
+			"namespace":    "",
+			"resourceID":   orphan.ResourceID,
+		}, 1)
+	}
+	return nil
+}
+
+// WatchOrphanedResources periodically scans the cloud provider for resources tagged for this
+// cluster whose owning cr no longer exists, maintaining the orphaned resource report and
+// metric, the loop returns when the stop channel is closed. The scan only runs on aws
+// clusters
+func WatchOrphanedResources(c client.Client, namespace string, stop <-chan struct{}) error {
+	scanner := NewAWSOrphanScanner(c, logrus.NewEntry(logrus.StandardLogger()))
+	for {
+		ctx := context.TODO()
+		infra, err := resources.GetClusterInfrastructure(ctx, c)
+		if err != nil {
+			scanner.Logger.Errorf("failed to get cluster infrastructure for orphan scan: %v", err)
+		} else if infra.Status.PlatformStatus != nil && infra.Status.PlatformStatus.Type == configv1.AWSPlatformType {
+			if err := scanner.ReconcileOrphanedResourceReport(ctx, namespace); err != nil {
+				scanner.Logger.Errorf("failed to reconcile orphaned resource report: %v", err)
+			}
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(orphanScanInterval):
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+func TestOrphanScanner_scanRDSInstances(t *testing.T) {
+	buildTagList := func(clusterID string, resourceName string) []*rds.Tag {
+		return []*rds.Tag{
+			{Key: aws.String("integreatly.org/clusterID"), Value: aws.String(clusterID)},
+			{Key: aws.String("integreatly.org/resource-name"), Value: aws.String(resourceName)},
+		}
+	}
+	type args struct {
+		rdsSvc     *mockRdsClient
+		ownedNames map[string]bool
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantOrphans int
+	}{
+		{
+			name: "test a tagged instance without a matching cr is reported",
+			args: args{
+				rdsSvc: buildMockRdsClient(func(rdsClient *mockRdsClient) {
+					rdsClient.dbInstances = []*rds.DBInstance{
+						{
+							DBInstanceIdentifier: aws.String("test-id"),
+							DBInstanceArn:        aws.String("arn:test"),
+						},
+					}
+					rdsClient.listTagsForResourceFn = func(input *rds.ListTagsForResourceInput) (*rds.ListTagsForResourceOutput, error) {
+						return &rds.ListTagsForResourceOutput{TagList: buildTagList("test-cluster", "test")}, nil
+					}
+				}),
+				ownedNames: map[string]bool{},
+			},
+			wantOrphans: 1,
+		},
+		{
+			name: "test a tagged instance with a matching cr is not reported",
+			args: args{
+				rdsSvc: buildMockRdsClient(func(rdsClient *mockRdsClient) {
+					rdsClient.dbInstances = []*rds.DBInstance{
+						{
+							DBInstanceIdentifier: aws.String("test-id"),
+							DBInstanceArn:        aws.String("arn:test"),
+						},
+					}
+					rdsClient.listTagsForResourceFn = func(input *rds.ListTagsForResourceInput) (*rds.ListTagsForResourceOutput, error) {
+						return &rds.ListTagsForResourceOutput{TagList: buildTagList("test-cluster", "test")}, nil
+					}
+				}),
+				ownedNames: map[string]bool{"test": true},
+			},
+			wantOrphans: 0,
+		},
+		{
+			name: "test an instance tagged for a different cluster is not reported",
+			args: args{
+				rdsSvc: buildMockRdsClient(func(rdsClient *mockRdsClient) {
+					rdsClient.dbInstances = []*rds.DBInstance{
+						{
+							DBInstanceIdentifier: aws.String("test-id"),
+							DBInstanceArn:        aws.String("arn:test"),
+						},
+					}
+					rdsClient.listTagsForResourceFn = func(input *rds.ListTagsForResourceInput) (*rds.ListTagsForResourceOutput, error) {
+						return &rds.ListTagsForResourceOutput{TagList: buildTagList("other-cluster", "test")}, nil
+					}
+				}),
+				ownedNames: map[string]bool{},
+			},
+			wantOrphans: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &OrphanScanner{
+				Logger: testLogger,
+			}
+			orphans, err := s.scanRDSInstances(tt.args.rdsSvc, tt.args.ownedNames, "integreatly.org/clusterID", "test-cluster", "integreatly.org/resource-name")
+			if err != nil {
+				t.Fatalf("scanRDSInstances() unexpected error %v", err)
+			}
+			if len(orphans) != tt.wantOrphans {
+				t.Errorf("scanRDSInstances() returned %d orphans, want %d", len(orphans), tt.wantOrphans)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// arnPartitionForRegion returns the arn partition a region belongs to (aws, aws-us-gov or
+// aws-cn), unknown regions are assumed to be new commercial regions
+func arnPartitionForRegion(region string) string {
+	if partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		return partition.ID()
+	}
+	return endpoints.AwsPartitionID
+}
+
+// isKnownRegion reports whether a region is known to the aws sdk
+func isKnownRegion(region string) bool {
+	_, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	return ok
+}
+
+// arnPartitionFromCluster returns the arn partition for the region the cluster runs in,
+// defaulting to the commercial partition when the region cannot be determined
+func arnPartitionFromCluster(ctx context.Context, c client.Client) string {
+	region, err := getDefaultRegion(ctx, c)
+	if err != nil {
+		logrus.Warnf("failed to get cluster region while resolving arn partition, defaulting to %s: %v", endpoints.AwsPartitionID, err)
+		return endpoints.AwsPartitionID
+	}
+	return arnPartitionForRegion(region)
+}
@@ -0,0 +1,41 @@
+package aws
+
+import (
+	"testing"
+)
+
+func TestArnPartitionForRegion(t *testing.T) {
+	cases := []struct {
+		name              string
+		region            string
+		expectedPartition string
+	}{
+		{
+			name:              "test commercial region resolves to the aws partition",
+			region:            "us-east-1",
+			expectedPartition: "aws",
+		},
+		{
+			name:              "test govcloud region resolves to the aws-us-gov partition",
+			region:            "us-gov-west-1",
+			expectedPartition: "aws-us-gov",
+		},
+		{
+			name:              "test china region resolves to the aws-cn partition",
+			region:            "cn-north-1",
+			expectedPartition: "aws-cn",
+		},
+		{
+			name:              "test unknown region defaults to the aws partition",
+			region:            "moon-base-1",
+			expectedPartition: "aws",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := arnPartitionForRegion(tc.region); got != tc.expectedPartition {
+				t.Fatalf("unexpected partition for region %s, expected %s but got %s", tc.region, tc.expectedPartition, got)
+			}
+		})
+	}
+}
@@ -10,7 +10,6 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 
 	v1 "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -51,6 +50,9 @@ const (
 
 	// bucket encryption defaults
 	defaultEncryptionSSEAlgorithm = s3.ServerSideEncryptionAes256
+
+	// static website hosting defaults
+	defaultStaticWebsiteIndexDocument = "index.html"
 )
 
 // BlobStorageDeploymentDetails Provider-specific details about the AWS S3 bucket created
@@ -98,10 +100,13 @@ func (p *BlobStorageProvider) SupportsStrategy(d string) bool {
 }
 
 func (p *BlobStorageProvider) GetReconcileTime(bs *v1alpha1.BlobStorage) time.Duration {
+	if bs.Status.Phase == croType.PhaseFailed {
+		return resources.GetErrorBackoffTime(bs.Status.FailureCount)
+	}
 	if bs.Status.Phase != croType.PhaseComplete {
 		return time.Second * 60
 	}
-	return resources.GetForcedReconcileTimeOrDefault(defaultReconcileTime)
+	return resources.GetHealthyReconcileTimeOrDefault(defaultReconcileTime)
 }
 
 //S3DeleteStrat custom s3 delete strat
@@ -113,6 +118,10 @@ type S3DeleteStrat struct {
 
 // CreateStorage Create S3 bucket from strategy config and credentials to interact with it
 func (p *BlobStorageProvider) CreateStorage(ctx context.Context, bs *v1alpha1.BlobStorage) (*providers.BlobStorageInstance, croType.StatusMessage, error) {
+	ctx, span := resources.StartSpan(ctx, "aws.CreateStorage")
+	defer span.End()
+	ctx = resources.WithAuditResource(ctx, "BlobStorage", bs.Namespace, bs.Name)
+
 	// handle provider-specific finalizer
 	if err := resources.CreateFinalizer(ctx, p.Client, bs, DefaultFinalizer); err != nil {
 		return nil, "failed to set finalizer", err
@@ -126,6 +135,11 @@ func (p *BlobStorageProvider) CreateStorage(ctx context.Context, bs *v1alpha1.Bl
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
 
+	// dry run crs report what would be created without calling any mutating cloud apis
+	if bs.Spec.DryRun {
+		return nil, croType.StatusMessage(fmt.Sprintf("dry run: would create s3 bucket %s in region %s", *bucketCreateCfg.Bucket, stratCfg.Region)), nil
+	}
+
 	// create the credentials to be used by the end-user, whoever created the blobstorage instance
 	endUserCredsName := buildEndUserCredentialsNameFromBucket(*bucketCreateCfg.Bucket)
 	p.Logger.Infof("creating end-user credentials with name %s for managing s3 bucket %s", endUserCredsName, *bucketCreateCfg.Bucket)
@@ -145,7 +159,7 @@ func (p *BlobStorageProvider) CreateStorage(ctx context.Context, bs *v1alpha1.Bl
 
 	// setup aws s3 sdk session
 	p.Logger.Infof("creating new aws sdk session in region %s", stratCfg.Region)
-	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds.AccessKeyID, providerCreds.SecretAccessKey, stratCfg)
+	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds, stratCfg)
 	if err != nil {
 		errMsg := "failed to create aws session to create s3 bucket"
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
@@ -154,11 +168,16 @@ func (p *BlobStorageProvider) CreateStorage(ctx context.Context, bs *v1alpha1.Bl
 
 	// create bucket if it doesn't already exist, if it does exist then use the existing bucket
 	p.Logger.Infof("reconciling aws s3 bucket %s", *bucketCreateCfg.Bucket)
-	msg, err := p.reconcileBucketCreate(ctx, bs, s3Client, bucketCreateCfg)
+	bs.Status.SetStage(croType.StageProvisioning)
+	msg, err := p.reconcileBucketCreate(ctx, bs, s3Client, bucketCreateCfg, stratCfg)
 	if err != nil {
 		return nil, msg, errorUtil.Wrapf(err, string(msg))
 	}
 
+	// expose the cloud native identifier of the bucket on the cr, the arn partition differs
+	// on govcloud and china clusters
+	bs.Status.ResourceID = fmt.Sprintf("arn:%s:s3:::%s", arnPartitionForRegion(aws.StringValue(sess.Config.Region)), *bucketCreateCfg.Bucket)
+
 	// blobstorageinstance that will be returned if everything is successful
 	bsi := &providers.BlobStorageInstance{
 		DeploymentDetails: &BlobStorageDeploymentDetails{
@@ -183,36 +202,13 @@ func (p *BlobStorageProvider) CreateStorage(ctx context.Context, bs *v1alpha1.Bl
 func (p *BlobStorageProvider) TagBlobStorage(ctx context.Context, bucketName string, bs *v1alpha1.BlobStorage, stratCfgRegion string, s3svc s3iface.S3API) (croType.StatusMessage, error) {
 	p.Logger.Infof("bucket %s found, Adding tags to bucket", bucketName)
 
-	// set tag values that will always be added
-	defaultOrganizationTag := resources.GetOrganizationTag()
-	clusterID, err := resources.GetClusterID(ctx, p.Client)
+	// build the cross provider tag set applied to the s3 bucket
+	resourceTags, err := resources.BuildDefaultResourceTags(ctx, p.Client, bs.Spec.Type, bs.ObjectMeta)
 	if err != nil {
-		errMsg := "failed to get cluster id"
-		return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
-	}
-	bucketTags := []*s3.Tag{
-		{
-			Key:   aws.String(defaultOrganizationTag + "clusterID"),
-			Value: aws.String(clusterID),
-		},
-		{
-			Key:   aws.String(defaultOrganizationTag + "resource-type"),
-			Value: aws.String(bs.Spec.Type),
-		},
-		{
-			Key:   aws.String(defaultOrganizationTag + "resource-name"),
-			Value: aws.String(bs.Name),
-		},
-	}
-
-	// check if product name exists and append label
-	if bs.ObjectMeta.Labels["productName"] != "" {
-		productTag := &s3.Tag{
-			Key:   aws.String(defaultOrganizationTag + "product-name"),
-			Value: aws.String(bs.ObjectMeta.Labels["productName"]),
-		}
-		bucketTags = append(bucketTags, productTag)
+		errMsg := "failed to build resource tags"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
+	bucketTags := resourceTagsToS3Tags(resourceTags)
 
 	// adding the tags to S3
 	_, err = s3svc.PutBucketTagging(&s3.PutBucketTaggingInput{
@@ -232,6 +228,9 @@ func (p *BlobStorageProvider) TagBlobStorage(ctx context.Context, bucketName str
 
 // DeleteStorage Delete S3 bucket and credentials to add objects to it
 func (p *BlobStorageProvider) DeleteStorage(ctx context.Context, bs *v1alpha1.BlobStorage) (croType.StatusMessage, error) {
+	ctx, span := resources.StartSpan(ctx, "aws.DeleteStorage")
+	defer span.End()
+	ctx = resources.WithAuditResource(ctx, "BlobStorage", bs.Namespace, bs.Name)
 	p.Logger.Infof("deleting blob storage instance %s via aws s3", bs.Name)
 
 	// resolve bucket information for bucket created by provider
@@ -252,7 +251,7 @@ func (p *BlobStorageProvider) DeleteStorage(ctx context.Context, bs *v1alpha1.Bl
 
 	// create new s3 session
 	p.Logger.Infof("creating new aws sdk session in region %s", stratCfg.Region)
-	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds.AccessKeyID, providerCreds.SecretAccessKey, stratCfg)
+	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds, stratCfg)
 	if err != nil {
 		errMsg := "failed to create aws session to delete s3 bucket"
 		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
@@ -284,21 +283,49 @@ func (p *BlobStorageProvider) reconcileBucketDelete(ctx context.Context, bs *v1a
 		}
 	}
 
-	bucketSize, err := getBucketSize(s3svc, bucketCfg)
-	if err != nil {
-		errMsg := fmt.Sprintf("unable to get bucket size : %s", *bucketCfg.Bucket)
-		return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
+	// the deletion policy on the cr controls how the bucket contents are treated, force
+	// deletion through the _aws delete strategy is kept for backwards compatibility
+	deletionPolicy := bs.Spec.DeletionPolicy
+	if deletionPolicy == "" {
+		deletionPolicy = croType.DeletionPolicyDeleteEmptyOnly
+	}
+	if *bucketDeleteCfg.ForceBucketDeletion {
+		deletionPolicy = croType.DeletionPolicyForceDeleteObjects
 	}
 
-	if *bucketDeleteCfg.ForceBucketDeletion || bucketSize == 0 {
-		if err := emptyBucket(s3svc, bucketCfg); err != nil {
-			errMsg := fmt.Sprintf("unable to empty bucket : %q", *bucketCfg.Bucket)
-			return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
-		}
-
-		if err := deleteBucket(s3svc, bucketCfg); err != nil {
-			errMsg := fmt.Sprintf("unable to delete bucket : %s", *bucketCfg.Bucket)
-			return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
+	if foundBucket != nil {
+		switch deletionPolicy {
+		case croType.DeletionPolicyRetain:
+			p.Logger.Infof("deletion policy is %s, s3 bucket %s is left in place", croType.DeletionPolicyRetain, *bucketCfg.Bucket)
+		case croType.DeletionPolicyForceDeleteObjects:
+			// large buckets are emptied one page of objects per reconcile so progress can be
+			// reported through the cr status
+			emptied, deleted, err := emptyBucketBatch(s3svc, bucketCfg)
+			if err != nil {
+				errMsg := fmt.Sprintf("unable to empty bucket : %q", *bucketCfg.Bucket)
+				return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
+			}
+			if !emptied {
+				return croType.StatusMessage(fmt.Sprintf("emptying of bucket %s in progress, deleted %d objects this reconcile", *bucketCfg.Bucket, deleted)), nil
+			}
+			if err := deleteBucket(s3svc, bucketCfg); err != nil {
+				errMsg := fmt.Sprintf("unable to delete bucket : %s", *bucketCfg.Bucket)
+				return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
+			}
+		default:
+			bucketSize, err := getBucketSize(s3svc, bucketCfg)
+			if err != nil {
+				errMsg := fmt.Sprintf("unable to get bucket size : %s", *bucketCfg.Bucket)
+				return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
+			}
+			if bucketSize > 0 {
+				errMsg := fmt.Sprintf("deletion policy is %s and bucket %s still contains objects, empty the bucket or set the deletion policy to %s or %s", croType.DeletionPolicyDeleteEmptyOnly, *bucketCfg.Bucket, croType.DeletionPolicyRetain, croType.DeletionPolicyForceDeleteObjects)
+				return croType.StatusMessage(errMsg), errorUtil.New(errMsg)
+			}
+			if err := deleteBucket(s3svc, bucketCfg); err != nil {
+				errMsg := fmt.Sprintf("unable to delete bucket : %s", *bucketCfg.Bucket)
+				return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
+			}
 		}
 	}
 
@@ -353,28 +380,28 @@ func deleteBucket(s3svc s3iface.S3API, bucketCfg *s3.CreateBucketInput) error {
 	return nil
 }
 
-func emptyBucket(s3svc s3iface.S3API, bucketCfg *s3.CreateBucketInput) error {
-	size, err := getBucketSize(s3svc, bucketCfg)
+// emptyBucketBatch deletes one page of objects from the bucket, returning true once the
+// bucket is empty along with the number of objects deleted in this batch
+func emptyBucketBatch(s3svc s3iface.S3API, bucketCfg *s3.CreateBucketInput) (bool, int, error) {
+	listOutput, err := s3svc.ListObjectsV2(&s3.ListObjectsV2Input{Bucket: bucketCfg.Bucket})
 	if err != nil {
-		return err
+		return false, 0, errorUtil.Wrapf(err, "unable to list items in bucket %q", *bucketCfg.Bucket)
 	}
-
-	if size == 0 {
-		return nil
+	if len(listOutput.Contents) == 0 {
+		return true, 0, nil
 	}
 
-	// Setup BatchDeleteIterator to iterate through a list of objects.
-	iter := s3manager.NewDeleteListIterator(s3svc, &s3.ListObjectsInput{
-		Bucket: aws.String(*bucketCfg.Bucket),
-	})
-
-	// Traverse iterator deleting each object
-	if err := s3manager.NewBatchDeleteWithClient(s3svc).Delete(aws.BackgroundContext(), iter); err != nil {
-		errMsg := fmt.Sprintf("unable to delete objects from bucket")
-		return errorUtil.Wrapf(err, errMsg)
+	var objects []*s3.ObjectIdentifier
+	for _, object := range listOutput.Contents {
+		objects = append(objects, &s3.ObjectIdentifier{Key: object.Key})
 	}
-
-	return nil
+	if _, err := s3svc.DeleteObjects(&s3.DeleteObjectsInput{
+		Bucket: bucketCfg.Bucket,
+		Delete: &s3.Delete{Objects: objects},
+	}); err != nil {
+		return false, 0, errorUtil.Wrapf(err, "unable to delete objects from bucket %q", *bucketCfg.Bucket)
+	}
+	return !aws.BoolValue(listOutput.IsTruncated), len(objects), nil
 }
 
 func getBucketSize(s3svc s3iface.S3API, bucketCfg *s3.CreateBucketInput) (int, error) {
@@ -387,7 +414,7 @@ func getBucketSize(s3svc s3iface.S3API, bucketCfg *s3.CreateBucketInput) (int, e
 	return len(resp.Contents), nil
 }
 
-func (p *BlobStorageProvider) reconcileBucketCreate(ctx context.Context, bs *v1alpha1.BlobStorage, s3svc s3iface.S3API, bucketCfg *s3.CreateBucketInput) (croType.StatusMessage, error) {
+func (p *BlobStorageProvider) reconcileBucketCreate(ctx context.Context, bs *v1alpha1.BlobStorage, s3svc s3iface.S3API, bucketCfg *s3.CreateBucketInput, stratCfg *StrategyConfig) (croType.StatusMessage, error) {
 	// the aws access key can sometimes still not be registered in aws on first try, so loop
 	p.Logger.Infof("listing existing aws s3 buckets")
 	buckets, err := getS3buckets(s3svc)
@@ -409,7 +436,16 @@ func (p *BlobStorageProvider) reconcileBucketCreate(ctx context.Context, bs *v1a
 	defer p.exposeBlobStorageMetrics(ctx, bs)
 
 	if foundBucket != nil {
-		if err = reconcileS3BucketSettings(aws.StringValue(foundBucket.Name), s3svc); err != nil {
+		// mark the takeover of an adopted bucket as complete with the resource identifier annotation
+		if annotations.Has(bs, AdoptResourceIDAnnotation) && !annotations.Has(bs, ResourceIdentifierAnnotation) {
+			p.Logger.Infof("adopting existing s3 bucket %s", *foundBucket.Name)
+			annotations.Add(bs, ResourceIdentifierAnnotation, *foundBucket.Name)
+			if err := p.Client.Update(ctx, bs); err != nil {
+				errMsg := "failed to add annotation"
+				return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
+			}
+		}
+		if err = reconcileS3BucketSettings(aws.StringValue(foundBucket.Name), s3svc, stratCfg); err != nil {
 			errMsg := fmt.Sprintf("failed to set s3 bucket settings %s", *foundBucket.Name)
 			return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
 		}
@@ -417,6 +453,12 @@ func (p *BlobStorageProvider) reconcileBucketCreate(ctx context.Context, bs *v1a
 		return croType.StatusMessage(msg), nil
 	}
 
+	// an adopted bucket must already exist, never create one under the adopted name
+	if annotations.Has(bs, AdoptResourceIDAnnotation) {
+		errMsg := fmt.Sprintf("no s3 bucket named %s found to adopt", *bucketCfg.Bucket)
+		return croType.StatusMessage(errMsg), errorUtil.New(errMsg)
+	}
+
 	// foundBucket == nil at this point, so if the CR already has a resourceIdentifier
 	// annotation, then we expect it to be there. We shouldn't create it again, it will require
 	// manual intervention to restore from a backup.
@@ -430,8 +472,13 @@ func (p *BlobStorageProvider) reconcileBucketCreate(ctx context.Context, bs *v1a
 	p.Logger.Infof("bucket %s not found, creating bucket", *bucketCfg.Bucket)
 	_, err = s3svc.CreateBucket(bucketCfg)
 	if err != nil {
-		errMsg := fmt.Sprintf("failed to create s3 bucket %s", *bucketCfg.Bucket)
-		return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
+		// the create is idempotent by bucket name, a bucket already owned by this account
+		// means a create issued by a racing reconcile or a previous pod already succeeded
+		if !isAlreadyExistsError(err) {
+			errMsg := fmt.Sprintf("failed to create s3 bucket %s", *bucketCfg.Bucket)
+			return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
+		}
+		p.Logger.Infof("s3 bucket %s already exists, create was issued by a previous reconcile", *bucketCfg.Bucket)
 	}
 
 	annotations.Add(bs, ResourceIdentifierAnnotation, *bucketCfg.Bucket)
@@ -440,7 +487,7 @@ func (p *BlobStorageProvider) reconcileBucketCreate(ctx context.Context, bs *v1a
 		return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
 	}
 
-	if err = reconcileS3BucketSettings(aws.StringValue(bucketCfg.Bucket), s3svc); err != nil {
+	if err = reconcileS3BucketSettings(aws.StringValue(bucketCfg.Bucket), s3svc, stratCfg); err != nil {
 		errMsg := fmt.Sprintf("failed to set s3 bucket settings on bucket creation %s", aws.StringValue(bucketCfg.Bucket))
 		return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
 	}
@@ -465,14 +512,22 @@ func getS3buckets(s3svc s3iface.S3API) ([]*s3.Bucket, error) {
 	return existingBuckets, nil
 }
 
-func reconcileS3BucketSettings(bucket string, s3svc s3iface.S3API) error {
+func reconcileS3BucketSettings(bucket string, s3svc s3iface.S3API, stratCfg *StrategyConfig) error {
+	// buckets serving a static website need to allow the public read policy placed on their
+	// objects, all other public access remains blocked
+	blockPublicPolicy := defaultBlockPublicPolicy
+	restrictPublicBuckets := defaultRestrictPublicBuckets
+	if stratCfg.StaticWebsite != nil {
+		blockPublicPolicy = false
+		restrictPublicBuckets = false
+	}
 	_, err := s3svc.PutPublicAccessBlock(&s3.PutPublicAccessBlockInput{
 		Bucket: aws.String(bucket),
 		PublicAccessBlockConfiguration: &s3.PublicAccessBlockConfiguration{
 			BlockPublicAcls:       aws.Bool(defaultBlockPublicAcls),
-			BlockPublicPolicy:     aws.Bool(defaultBlockPublicPolicy),
+			BlockPublicPolicy:     aws.Bool(blockPublicPolicy),
 			IgnorePublicAcls:      aws.Bool(defaultIgnorePublicAcls),
-			RestrictPublicBuckets: aws.Bool(defaultRestrictPublicBuckets),
+			RestrictPublicBuckets: aws.Bool(restrictPublicBuckets),
 		},
 	})
 	if err != nil {
@@ -493,6 +548,79 @@ func reconcileS3BucketSettings(bucket string, s3svc s3iface.S3API) error {
 	if err != nil {
 		return errorUtil.Wrapf(err, "failed to set encryption settings on bucket %s", bucket)
 	}
+	if err = reconcileBucketCORS(bucket, s3svc, stratCfg.CORSRules); err != nil {
+		return err
+	}
+	if stratCfg.StaticWebsite != nil {
+		if err = reconcileBucketWebsite(bucket, s3svc, stratCfg.StaticWebsite, stratCfg.Region); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileBucketCORS applies the cors rules from the strategy for the tier to the bucket,
+// nothing is changed when the strategy declares no rules
+func reconcileBucketCORS(bucket string, s3svc s3iface.S3API, corsRules []CORSRule) error {
+	if len(corsRules) == 0 {
+		return nil
+	}
+	var rules []*s3.CORSRule
+	for _, rule := range corsRules {
+		corsRule := &s3.CORSRule{
+			AllowedOrigins: aws.StringSlice(rule.AllowedOrigins),
+			AllowedMethods: aws.StringSlice(rule.AllowedMethods),
+		}
+		if len(rule.AllowedHeaders) > 0 {
+			corsRule.AllowedHeaders = aws.StringSlice(rule.AllowedHeaders)
+		}
+		if len(rule.ExposeHeaders) > 0 {
+			corsRule.ExposeHeaders = aws.StringSlice(rule.ExposeHeaders)
+		}
+		if rule.MaxAgeSeconds != nil {
+			corsRule.MaxAgeSeconds = rule.MaxAgeSeconds
+		}
+		rules = append(rules, corsRule)
+	}
+	_, err := s3svc.PutBucketCors(&s3.PutBucketCorsInput{
+		Bucket: aws.String(bucket),
+		CORSConfiguration: &s3.CORSConfiguration{
+			CORSRules: rules,
+		},
+	})
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to set cors rules on bucket %s", bucket)
+	}
+	return nil
+}
+
+// reconcileBucketWebsite enables static website hosting on the bucket and opens its objects
+// up for public read, buckets serving static assets are read without credentials
+func reconcileBucketWebsite(bucket string, s3svc s3iface.S3API, websiteConfig *StaticWebsiteConfig, region string) error {
+	indexDocument := websiteConfig.IndexDocument
+	if indexDocument == "" {
+		indexDocument = defaultStaticWebsiteIndexDocument
+	}
+	websiteInput := &s3.PutBucketWebsiteInput{
+		Bucket: aws.String(bucket),
+		WebsiteConfiguration: &s3.WebsiteConfiguration{
+			IndexDocument: &s3.IndexDocument{Suffix: aws.String(indexDocument)},
+		},
+	}
+	if websiteConfig.ErrorDocument != "" {
+		websiteInput.WebsiteConfiguration.ErrorDocument = &s3.ErrorDocument{Key: aws.String(websiteConfig.ErrorDocument)}
+	}
+	if _, err := s3svc.PutBucketWebsite(websiteInput); err != nil {
+		return errorUtil.Wrapf(err, "failed to set website configuration on bucket %s", bucket)
+	}
+	policy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Sid":"PublicReadGetObject","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:%s:s3:::%s/*"}]}`, arnPartitionForRegion(region), bucket)
+	_, err := s3svc.PutBucketPolicy(&s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucket),
+		Policy: aws.String(policy),
+	})
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to set public read policy on bucket %s", bucket)
+	}
 	return nil
 }
 
@@ -527,6 +655,13 @@ func (p *BlobStorageProvider) getS3BucketConfig(ctx context.Context, bs *v1alpha
 		return nil, nil, nil, errorUtil.Wrap(err, "failed to read aws strategy config")
 	}
 
+	// apply any per-cr provider config overrides to the create strategy
+	if bs.Spec.ProviderConfig != nil {
+		if err := stratCfg.ApplyProviderConfig(bs.Spec.ProviderConfig); err != nil {
+			return nil, nil, nil, errorUtil.Wrap(err, "failed to apply provider config overrides")
+		}
+	}
+
 	defRegion, err := GetRegionFromStrategyOrDefault(ctx, p.Client, stratCfg)
 	if err != nil {
 		return nil, nil, nil, errorUtil.Wrap(err, "failed to get default region")
@@ -37,6 +37,7 @@ type mockS3Svc struct {
 	wantErrDelete     bool
 	wantErrWaitDelete bool
 	bucketNames       []string
+	objectKeys        []string
 }
 
 func buildTestScheme() (*runtime.Scheme, error) {
@@ -103,7 +104,19 @@ func (s *mockS3Svc) DeleteBucket(dbi *s3.DeleteBucketInput) (*s3.DeleteBucketOut
 }
 
 func (s *mockS3Svc) ListObjectsV2(*s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
-	return &s3.ListObjectsV2Output{}, nil
+	objects := make([]*s3.Object, 0)
+	for _, key := range s.objectKeys {
+		objects = append(objects, &s3.Object{
+			Key: aws.String(key),
+		})
+	}
+	return &s3.ListObjectsV2Output{
+		Contents: objects,
+	}, nil
+}
+
+func (s *mockS3Svc) DeleteObjects(*s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+	return &s3.DeleteObjectsOutput{}, nil
 }
 
 func (s *mockS3Svc) PutBucketTagging(*s3.PutBucketTaggingInput) (*s3.PutBucketTaggingOutput, error) {
@@ -118,6 +131,18 @@ func (s *mockS3Svc) PutBucketEncryption(input *s3.PutBucketEncryptionInput) (*s3
 	return &s3.PutBucketEncryptionOutput{}, nil
 }
 
+func (s *mockS3Svc) PutBucketCors(*s3.PutBucketCorsInput) (*s3.PutBucketCorsOutput, error) {
+	return &s3.PutBucketCorsOutput{}, nil
+}
+
+func (s *mockS3Svc) PutBucketWebsite(*s3.PutBucketWebsiteInput) (*s3.PutBucketWebsiteOutput, error) {
+	return &s3.PutBucketWebsiteOutput{}, nil
+}
+
+func (s *mockS3Svc) PutBucketPolicy(*s3.PutBucketPolicyInput) (*s3.PutBucketPolicyOutput, error) {
+	return &s3.PutBucketPolicyOutput{}, nil
+}
+
 func buildTestBlobStorageCR() *v1alpha1.BlobStorage {
 	return &v1alpha1.BlobStorage{
 		ObjectMeta: v1.ObjectMeta{
@@ -142,6 +167,7 @@ func TestBlobStorageProvider_reconcileBucket(t *testing.T) {
 		ctx       context.Context
 		s3svc     s3iface.S3API
 		bucketCfg *s3.CreateBucketInput
+		stratCfg  *StrategyConfig
 	}
 	tests := []struct {
 		name    string
@@ -165,6 +191,7 @@ func TestBlobStorageProvider_reconcileBucket(t *testing.T) {
 				bucketCfg: &s3.CreateBucketInput{
 					Bucket: aws.String("test"),
 				},
+				stratCfg: &StrategyConfig{},
 			},
 			wantErr: false,
 		},
@@ -184,6 +211,38 @@ func TestBlobStorageProvider_reconcileBucket(t *testing.T) {
 				bucketCfg: &s3.CreateBucketInput{
 					Bucket: aws.String("test2"),
 				},
+				stratCfg: &StrategyConfig{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "test cors rules and static website hosting are applied",
+			fields: fields{
+				Client:            fake.NewFakeClientWithScheme(scheme, buildTestBlobStorageCR(), buildTestCredentialsRequest()),
+				Logger:            logrus.WithFields(logrus.Fields{}),
+				CredentialManager: &CredentialManagerMock{},
+				ConfigManager:     &ConfigManagerMock{},
+			},
+			args: args{
+				ctx: context.TODO(),
+				s3svc: &mockS3Svc{
+					bucketNames: []string{"test"},
+				},
+				bucketCfg: &s3.CreateBucketInput{
+					Bucket: aws.String("test"),
+				},
+				stratCfg: &StrategyConfig{
+					Region: "eu-west-1",
+					CORSRules: []CORSRule{
+						{
+							AllowedOrigins: []string{"https://example.com"},
+							AllowedMethods: []string{"GET"},
+						},
+					},
+					StaticWebsite: &StaticWebsiteConfig{
+						IndexDocument: "index.html",
+					},
+				},
 			},
 			wantErr: false,
 		},
@@ -197,7 +256,7 @@ func TestBlobStorageProvider_reconcileBucket(t *testing.T) {
 				ConfigManager:     tt.fields.ConfigManager,
 			}
 			dummyBlobStorage := &v1alpha1.BlobStorage{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
-			if _, err := p.reconcileBucketCreate(tt.args.ctx, dummyBlobStorage, tt.args.s3svc, tt.args.bucketCfg); (err != nil) != tt.wantErr {
+			if _, err := p.reconcileBucketCreate(tt.args.ctx, dummyBlobStorage, tt.args.s3svc, tt.args.bucketCfg, tt.args.stratCfg); (err != nil) != tt.wantErr {
 				t.Errorf("reconcileBucket() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -274,6 +333,86 @@ func TestBlobStorageProvider_reconcileBucketDelete(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "test delete empty only policy fails when the bucket contains objects",
+			fields: fields{
+				Client:            fake.NewFakeClientWithScheme(scheme, buildTestBlobStorageCR(), buildTestCredentialsRequest()),
+				Logger:            logrus.WithFields(logrus.Fields{}),
+				CredentialManager: &CredentialManagerMock{},
+				ConfigManager:     &ConfigManagerMock{},
+			},
+			args: args{
+				ctx: context.TODO(),
+				s3svc: &mockS3Svc{
+					bucketNames: []string{"test"},
+					objectKeys:  []string{"asset.png"},
+				},
+				bucketCfg: &s3.CreateBucketInput{
+					Bucket: aws.String("test"),
+				},
+				bucketDeleteCfg: &S3DeleteStrat{
+					ForceBucketDeletion: aws.Bool(false),
+				},
+				bs: buildTestBlobStorageCR(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "test retain policy leaves a bucket with objects in place",
+			fields: fields{
+				Client:            fake.NewFakeClientWithScheme(scheme, buildTestBlobStorageCR(), buildTestCredentialsRequest()),
+				Logger:            logrus.WithFields(logrus.Fields{}),
+				CredentialManager: &CredentialManagerMock{},
+				ConfigManager:     &ConfigManagerMock{},
+			},
+			args: args{
+				ctx: context.TODO(),
+				s3svc: &mockS3Svc{
+					bucketNames: []string{"test"},
+					objectKeys:  []string{"asset.png"},
+				},
+				bucketCfg: &s3.CreateBucketInput{
+					Bucket: aws.String("test"),
+				},
+				bucketDeleteCfg: &S3DeleteStrat{
+					ForceBucketDeletion: aws.Bool(false),
+				},
+				bs: func() *v1alpha1.BlobStorage {
+					cr := buildTestBlobStorageCR()
+					cr.Spec.DeletionPolicy = types.DeletionPolicyRetain
+					return cr
+				}(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "test force delete objects policy empties a bucket with objects",
+			fields: fields{
+				Client:            fake.NewFakeClientWithScheme(scheme, buildTestBlobStorageCR(), buildTestCredentialsRequest()),
+				Logger:            logrus.WithFields(logrus.Fields{}),
+				CredentialManager: &CredentialManagerMock{},
+				ConfigManager:     &ConfigManagerMock{},
+			},
+			args: args{
+				ctx: context.TODO(),
+				s3svc: &mockS3Svc{
+					bucketNames: []string{"test"},
+					objectKeys:  []string{"asset.png"},
+				},
+				bucketCfg: &s3.CreateBucketInput{
+					Bucket: aws.String("test"),
+				},
+				bucketDeleteCfg: &S3DeleteStrat{
+					ForceBucketDeletion: aws.Bool(false),
+				},
+				bs: func() *v1alpha1.BlobStorage {
+					cr := buildTestBlobStorageCR()
+					cr.Spec.DeletionPolicy = types.DeletionPolicyForceDeleteObjects
+					return cr
+				}(),
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
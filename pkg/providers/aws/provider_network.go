@@ -0,0 +1,289 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	errorUtil "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	networkProviderName = "aws-network"
+)
+
+var _ providers.NetworkProvider = (*StandaloneNetworkProvider)(nil)
+
+// StandaloneNetworkProvider reconciles a Network cr by driving the standalone network manager,
+// making the vpc, subnets, peering connection, route tables and security groups independently
+// observable instead of being bundled into the postgres and redis create paths
+type StandaloneNetworkProvider struct {
+	Client            client.Client
+	Logger            *logrus.Entry
+	CredentialManager CredentialManager
+	ConfigManager     ConfigManager
+}
+
+func NewAWSNetworkProvider(client client.Client, logger *logrus.Entry) *StandaloneNetworkProvider {
+	return &StandaloneNetworkProvider{
+		Client:            client,
+		Logger:            logger.WithFields(logrus.Fields{"provider": networkProviderName}),
+		CredentialManager: NewCredentialMinterCredentialManager(client),
+		ConfigManager:     NewDefaultConfigMapConfigManager(client),
+	}
+}
+
+func (p *StandaloneNetworkProvider) GetName() string {
+	return networkProviderName
+}
+
+func (p *StandaloneNetworkProvider) SupportsStrategy(d string) bool {
+	return d == providers.AWSDeploymentStrategy
+}
+
+func (p *StandaloneNetworkProvider) GetReconcileTime(n *v1alpha1.Network) time.Duration {
+	if n.Status.Phase == croType.PhaseFailed {
+		return resources.GetErrorBackoffTime(n.Status.FailureCount)
+	}
+	if n.Status.Phase != croType.PhaseComplete {
+		return time.Second * 60
+	}
+	return resources.GetHealthyReconcileTimeOrDefault(defaultReconcileTime)
+}
+
+// ReconcileNetwork creates and maintains the standalone vpc, subnets, peering connection, route
+// table entries and security groups required for rds and elasticache isolation
+func (p *StandaloneNetworkProvider) ReconcileNetwork(ctx context.Context, n *v1alpha1.Network) (croType.StatusMessage, error) {
+	logger := p.Logger.WithField("action", "ReconcileNetwork")
+	logger.Infof("reconciling network %s", n.Name)
+
+	if err := resources.CreateFinalizer(ctx, p.Client, n, DefaultFinalizer); err != nil {
+		return "failed to set finalizer", err
+	}
+
+	// create the credentials to be used by the aws resource providers, not to be used by end-user
+	providerCreds, err := p.CredentialManager.ReconcileProviderCredentials(ctx, n.Namespace)
+	if err != nil {
+		msg := "failed to reconcile network provider credentials"
+		return croType.StatusMessage(msg), resources.WrapWithClass(err, resources.ErrorClassAuth, msg)
+	}
+
+	// the region for the session is read from the _network strategy for the tier
+	stratCfg, err := p.ConfigManager.ReadStorageStrategy(ctx, providers.NetworkResourceType, n.Spec.Tier)
+	if err != nil {
+		msg := "failed to read aws network strategy config"
+		return croType.StatusMessage(msg), resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, msg)
+	}
+
+	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds, stratCfg)
+	if err != nil {
+		msg := "failed to create aws session to reconcile network"
+		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+	}
+
+	networkManager := NewNetworkManager(sess, p.Client, logger)
+	isEnabled, err := networkManager.IsEnabled(ctx)
+	if err != nil {
+		msg := "failed to check cluster vpc subnets"
+		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+	}
+	if !isEnabled {
+		// bundled resources exist in the cluster vpc, there is no standalone network to manage
+		return "standalone network not required, cluster vpc is in use", nil
+	}
+
+	// when the _network strategy references an existing vpc and subnets the network is validated
+	// and reused instead of being created by the operator
+	existingNetworkConfig, err := getExistingNetworkConfig(ctx, p.ConfigManager, n.Spec.Tier, logger)
+	if err != nil {
+		msg := "failed to get existing network config from _network strategy"
+		return croType.StatusMessage(msg), resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, msg)
+	}
+
+	var network *Network
+	if existingNetworkConfig != nil {
+		logger.Debug("existing network referenced in _network strategy, validating and reusing it")
+		network, err = networkManager.ReconcileExistingNetwork(ctx, aws.StringValue(existingNetworkConfig.VpcId), aws.StringValueSlice(existingNetworkConfig.SubnetIds))
+		if err != nil {
+			msg := "failed to reconcile existing resource network"
+			return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+		}
+	} else {
+		// get cidr block from _network strat map, based on tier from the network cr
+		vpcCidrBlock, err := getNetworkProviderConfig(ctx, p.ConfigManager, n.Spec.Tier, logger)
+		if err != nil {
+			msg := "failed to get _network strategy config"
+			return croType.StatusMessage(msg), resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, msg)
+		}
+
+		// create the standalone vpc, subnets and subnet groups
+		network, err = networkManager.CreateNetwork(ctx, vpcCidrBlock)
+		if err != nil {
+			msg := "failed to create resource network"
+			return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+		}
+	}
+	logger.Infof("reconciled standalone network %s", aws.StringValue(network.Vpc.VpcId))
+	n.Status.ResourceID = aws.StringValue(network.Vpc.VpcId)
+
+	// the connection type between the standalone vpc and the cluster vpc is selectable through the
+	// _network strategy, defaulting to a vpc peering connection
+	connConfig, err := getNetworkConnectionConfig(ctx, p.ConfigManager, n.Spec.Tier, logger)
+	if err != nil {
+		msg := "failed to get network connection config from _network strategy"
+		return croType.StatusMessage(msg), resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, msg)
+	}
+
+	if isTransitGatewayConnection(connConfig) {
+		// organisations that disallow vpc peering can attach the standalone vpc to an existing
+		// transit gateway instead, the attachment provides the same connectivity as the peering connection
+		networkConnection, err := networkManager.CreateNetworkTransitGatewayAttachment(ctx, network, aws.StringValue(connConfig.TransitGatewayId))
+		if err != nil {
+			msg := "failed to attach standalone network to transit gateway"
+			return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+		}
+		logger.Infof("reconciled security group %s", aws.StringValue(networkConnection.StandaloneSecurityGroup.GroupName))
+		return "standalone network successfully reconciled", nil
+	}
+
+	// we've created the standalone vpc, now we peer it to the cluster vpc
+	networkPeering, err := networkManager.CreateNetworkPeering(ctx, network)
+	if err != nil {
+		msg := "failed to peer standalone network"
+		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+	}
+	logger.Infof("reconciled network peering %s", aws.StringValue(networkPeering.PeeringConnection.VpcPeeringConnectionId))
+
+	// we have created the peering connection we must now create the security groups and update the route tables
+	networkConnection, err := networkManager.CreateNetworkConnection(ctx, network)
+	if err != nil {
+		msg := "failed to create standalone network connection"
+		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+	}
+	logger.Infof("reconciled security group %s", aws.StringValue(networkConnection.StandaloneSecurityGroup.GroupName))
+
+	return "standalone network successfully reconciled", nil
+}
+
+// DeleteNetwork tears down the standalone network once no postgres or redis resources remain in
+// the namespace, resources deployed into the vpc must be removed first
+func (p *StandaloneNetworkProvider) DeleteNetwork(ctx context.Context, n *v1alpha1.Network) (croType.StatusMessage, error) {
+	logger := p.Logger.WithField("action", "DeleteNetwork")
+	logger.Infof("reconciling delete of network %s", n.Name)
+
+	// block deletion while postgres or redis resources could still be using the network
+	inUse, err := p.networkInUse(ctx, n.Namespace)
+	if err != nil {
+		msg := "failed to check for resources using the network"
+		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+	}
+	if inUse {
+		return "deletion blocked, postgres or redis resources are still deployed in the network", nil
+	}
+
+	providerCreds, err := p.CredentialManager.ReconcileProviderCredentials(ctx, n.Namespace)
+	if err != nil {
+		msg := "failed to reconcile network provider credentials"
+		return croType.StatusMessage(msg), resources.WrapWithClass(err, resources.ErrorClassAuth, msg)
+	}
+
+	stratCfg, err := p.ConfigManager.ReadStorageStrategy(ctx, providers.NetworkResourceType, n.Spec.Tier)
+	if err != nil {
+		msg := "failed to read aws network strategy config"
+		return croType.StatusMessage(msg), resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, msg)
+	}
+
+	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds, stratCfg)
+	if err != nil {
+		msg := "failed to create aws session to delete network"
+		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+	}
+
+	networkManager := NewNetworkManager(sess, p.Client, logger)
+	isEnabled, err := networkManager.IsEnabled(ctx)
+	if err != nil {
+		msg := "failed to check cluster vpc subnets"
+		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+	}
+
+	if isEnabled {
+		// when the _network strategy references an existing vpc, only the resources the operator
+		// created around it are removed, the vpc and subnets themselves are left untouched
+		existingNetworkConfig, err := getExistingNetworkConfig(ctx, p.ConfigManager, n.Spec.Tier, logger)
+		if err != nil {
+			msg := "failed to get existing network config from _network strategy"
+			return croType.StatusMessage(msg), resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, msg)
+		}
+
+		if existingNetworkConfig != nil {
+			if err := networkManager.DeleteExistingNetwork(ctx, aws.StringValue(existingNetworkConfig.VpcId)); err != nil {
+				msg := "failed to delete network resources for existing vpc"
+				return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+			}
+		} else {
+			connConfig, err := getNetworkConnectionConfig(ctx, p.ConfigManager, n.Spec.Tier, logger)
+			if err != nil {
+				msg := "failed to get network connection config from _network strategy"
+				return croType.StatusMessage(msg), resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, msg)
+			}
+
+			if isTransitGatewayConnection(connConfig) {
+				if err := networkManager.DeleteNetworkTransitGatewayAttachment(ctx, aws.StringValue(connConfig.TransitGatewayId)); err != nil {
+					msg := "failed to delete transit gateway attachment"
+					return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+				}
+			} else {
+				networkPeering, err := networkManager.GetClusterNetworkPeering(ctx)
+				if err != nil {
+					msg := "failed to get cluster network peering"
+					return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+				}
+
+				if err = networkManager.DeleteNetworkConnection(ctx, networkPeering); err != nil {
+					msg := "failed to delete network connection"
+					return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+				}
+
+				if err = networkManager.DeleteNetworkPeering(networkPeering); err != nil {
+					msg := "failed to delete cluster network peering"
+					return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+				}
+			}
+
+			if err := networkManager.DeleteNetwork(ctx); err != nil {
+				msg := "failed to delete aws networking"
+				return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+			}
+		}
+	}
+
+	resources.RemoveFinalizer(&n.ObjectMeta, DefaultFinalizer)
+	if err := p.Client.Update(ctx, n); err != nil {
+		msg := "failed to update network as part of finalizer reconcile"
+		return croType.StatusMessage(msg), errorUtil.Wrapf(err, msg)
+	}
+	return croType.StatusEmpty, nil
+}
+
+// networkInUse returns true while postgres or redis resources exist in the namespace, the
+// standalone network can only be removed once the resources deployed into it are gone
+func (p *StandaloneNetworkProvider) networkInUse(ctx context.Context, namespace string) (bool, error) {
+	listOptions := client.ListOptions{
+		Namespace: namespace,
+	}
+	var postgresList = &v1alpha1.PostgresList{}
+	if err := p.Client.List(ctx, postgresList, &listOptions); err != nil {
+		return false, errorUtil.Wrap(err, "failed to retrieve postgres crs")
+	}
+	var redisList = &v1alpha1.RedisList{}
+	if err := p.Client.List(ctx, redisList, &listOptions); err != nil {
+		return false, errorUtil.Wrap(err, "failed to retrieve redis crs")
+	}
+	return len(postgresList.Items) > 0 || len(redisList.Items) > 0, nil
+}
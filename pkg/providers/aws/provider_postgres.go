@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 
@@ -60,10 +63,22 @@ const (
 	defaultPostgresUserKey               = "user"
 	defaultPostgresPasswordKey           = "password"
 	defaultStorageEncrypted              = true
+	// the performance insights free tier retains seven days of performance data
+	defaultPerformanceInsightsRetention = 7
+
+	// condition type surfacing parameter group changes that only take effect after a reboot
+	postgresPendingRebootConditionType = "PendingReboot"
 )
 
 var (
 	defaultSupportedEngineVersions = []string{"10.6", "9.6", "9.5"}
+	// defaultPostgresSizeClasses maps the abstract sizes that can be set on a cr to rds instance
+	// classes, used when the strategy config for the tier does not define its own mapping
+	defaultPostgresSizeClasses = map[string]string{
+		"small":  "db.t3.small",
+		"medium": "db.m5.large",
+		"large":  "db.m5.xlarge",
+	}
 	healthyAWSDBInstanceStatuses   = []string{
 		"backtracking",
 		"available",
@@ -114,14 +129,20 @@ func (p *PostgresProvider) SupportsStrategy(d string) bool {
 }
 
 func (p *PostgresProvider) GetReconcileTime(pg *v1alpha1.Postgres) time.Duration {
+	if pg.Status.Phase == croType.PhaseFailed {
+		return resources.GetErrorBackoffTime(pg.Status.FailureCount)
+	}
 	if pg.Status.Phase != croType.PhaseComplete {
 		return time.Second * 60
 	}
-	return resources.GetForcedReconcileTimeOrDefault(defaultReconcileTime)
+	return resources.GetHealthyReconcileTimeOrDefault(defaultReconcileTime)
 }
 
 // CreatePostgres creates an RDS Instance from strategy config
 func (p *PostgresProvider) CreatePostgres(ctx context.Context, pg *v1alpha1.Postgres) (*providers.PostgresInstance, croType.StatusMessage, error) {
+	ctx, span := resources.StartSpan(ctx, "aws.CreatePostgres")
+	defer span.End()
+	ctx = resources.WithAuditResource(ctx, "Postgres", pg.Namespace, pg.Name)
 	logger := p.Logger.WithField("action", "CreatePostgres")
 	logger.Infof("reconciling postgres %s", pg.Name)
 
@@ -141,11 +162,20 @@ func (p *PostgresProvider) CreatePostgres(ctx context.Context, pg *v1alpha1.Post
 	providerCreds, err := p.CredentialManager.ReconcileProviderCredentials(ctx, pg.Namespace)
 	if err != nil {
 		msg := "failed to reconcile rds credentials"
-		return nil, croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+		return nil, croType.StatusMessage(msg), resources.WrapWithClass(err, resources.ErrorClassAuth, msg)
+	}
+
+	// adopted instances reuse the master credentials supplied by the user, seed the operator
+	// credential secret from the referenced secret so no new password is generated
+	if annotations.Has(pg, AdoptResourceIDAnnotation) {
+		if err := p.seedAdoptedCredentials(ctx, pg); err != nil {
+			errMsg := "failed to seed credentials for adopted rds instance"
+			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
 	}
 
 	// create credentials secret
-	sec := buildDefaultRDSSecret(pg)
+	sec := buildDefaultRDSSecret(pg, rdsCfg)
 	or, err := controllerutil.CreateOrUpdate(ctx, p.Client, sec, func() error {
 		return nil
 	})
@@ -155,7 +185,7 @@ func (p *PostgresProvider) CreatePostgres(ctx context.Context, pg *v1alpha1.Post
 	}
 
 	// setup aws RDS instance sdk session
-	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds.AccessKeyID, providerCreds.SecretAccessKey, strategyConfig)
+	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds, strategyConfig)
 	if err != nil {
 		errMsg := "failed to create aws session to create rds db instance"
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
@@ -169,6 +199,11 @@ func (p *PostgresProvider) CreatePostgres(ctx context.Context, pg *v1alpha1.Post
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
 
+	// dry run crs report what would be created without calling any mutating cloud apis
+	if pg.Spec.DryRun {
+		return nil, p.buildPostgresPlan(ctx, pg, rdsCfg, isEnabled), nil
+	}
+
 	//networkManager isEnabled checks for the presence of valid RHMI subnets in the cluster vpc
 	//when rhmi subnets are present in a cluster vpc it indicates that the vpc configuration
 	//was created in a cluster with a cluster version <= 4.4.5
@@ -176,42 +211,161 @@ func (p *PostgresProvider) CreatePostgres(ctx context.Context, pg *v1alpha1.Post
 	//when rhmi subnets are absent in a cluster vpc it indicates that the vpc configuration has not been created
 	//and a new vpc is created for all resources to be deployed in and peered with the cluster vpc
 	if isEnabled {
-		// get cidr block from _network strat map, based on tier from postgres cr
-		vpcCidrBlock, err := getNetworkProviderConfig(ctx, p.ConfigManager, pg.Spec.Tier, logger)
+		// surface the network work on the cr so time spent peering is distinguishable from
+		// waiting on the cloud resource
+		pg.Status.SetStage(croType.StageModifyingNetwork)
+		// when the _network strategy references an existing vpc and subnets the network is validated
+		// and reused instead of being created by the operator
+		existingNetworkConfig, err := getExistingNetworkConfig(ctx, p.ConfigManager, pg.Spec.Tier, logger)
 		if err != nil {
-			errMsg := "failed to get _network strategy config"
+			errMsg := "failed to get existing network config from _network strategy"
 			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 		}
-		logger.Debug("standalone network provider enabled, reconciling standalone vpc")
 
-		// create the standalone vpc, subnets and subnet groups
-		standaloneNetwork, err := networkManager.CreateNetwork(ctx, vpcCidrBlock)
+		var standaloneNetwork *Network
+		if existingNetworkConfig != nil {
+			logger.Debug("existing network referenced in _network strategy, validating and reusing it")
+			standaloneNetwork, err = networkManager.ReconcileExistingNetwork(ctx, aws.StringValue(existingNetworkConfig.VpcId), aws.StringValueSlice(existingNetworkConfig.SubnetIds))
+			if err != nil {
+				errMsg := "failed to reconcile existing resource network"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+		} else {
+			// get cidr block from _network strat map, based on tier from postgres cr
+			vpcCidrBlock, err := getNetworkProviderConfig(ctx, p.ConfigManager, pg.Spec.Tier, logger)
+			if err != nil {
+				errMsg := "failed to get _network strategy config"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+			logger.Debug("standalone network provider enabled, reconciling standalone vpc")
+
+			// create the standalone vpc, subnets and subnet groups
+			standaloneNetwork, err = networkManager.CreateNetwork(ctx, vpcCidrBlock)
+			if err != nil {
+				errMsg := "failed to create resource network"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+		}
+		logger.Infof("created standalone network %s", aws.StringValue(standaloneNetwork.Vpc.VpcId))
+
+		// the connection type between the standalone vpc and the cluster vpc is selectable through the
+		// _network strategy, defaulting to a vpc peering connection
+		connConfig, err := getNetworkConnectionConfig(ctx, p.ConfigManager, pg.Spec.Tier, logger)
 		if err != nil {
-			errMsg := "failed to create resource network"
+			errMsg := "failed to get network connection config from _network strategy"
 			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 		}
-		logger.Infof("created standalone network %s", aws.StringValue(standaloneNetwork.Vpc.VpcId))
 
-		// we've created the standalone vpc, now we peer it to the cluster vpc
-		logger.Infof("creating network peering")
-		networkPeering, err := networkManager.CreateNetworkPeering(ctx, standaloneNetwork)
+		if isTransitGatewayConnection(connConfig) {
+			// organisations that disallow vpc peering can attach the standalone vpc to an existing
+			// transit gateway instead, the attachment provides the same connectivity as the peering connection
+			logger.Infof("creating transit gateway attachment")
+			networkConnection, err := networkManager.CreateNetworkTransitGatewayAttachment(ctx, standaloneNetwork, aws.StringValue(connConfig.TransitGatewayId))
+			if err != nil {
+				errMsg := "failed to attach standalone network to transit gateway"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+			logger.Infof("created security group %s", aws.StringValue(networkConnection.StandaloneSecurityGroup.GroupName))
+		} else {
+			// we've created the standalone vpc, now we peer it to the cluster vpc
+			logger.Infof("creating network peering")
+			networkPeering, err := networkManager.CreateNetworkPeering(ctx, standaloneNetwork)
+			if err != nil {
+				errMsg := "failed to peer standalone network"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+			logger.Infof("created network peering %s", aws.StringValue(networkPeering.PeeringConnection.VpcPeeringConnectionId))
+
+			// we have created the peering connection we must now create the security groups and update the route tables
+			securityGroup, err := networkManager.CreateNetworkConnection(ctx, standaloneNetwork)
+			if err != nil {
+				errMsg := "failed to create standalone network"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+			logger.Infof("created security group %s", aws.StringValue(securityGroup.StandaloneSecurityGroup.GroupName))
+		}
+	}
+
+	// maintain the dedicated db parameter group when the strategy for the tier declares
+	// postgres parameters, the group is threaded through the create and update strategies
+	if err := p.reconcileDBParameterGroup(ctx, pg, rds.New(sess), rdsCfg, strategyConfig); err != nil {
+		errMsg := "failed to reconcile rds parameter group"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// create the aws RDS instance
+	pg.Status.SetStage(croType.StageProvisioning)
+	ps, msg, err := p.createRDSInstance(ctx, pg, rds.New(sess), ec2.New(sess), rdsCfg, isEnabled)
+	if err != nil || ps == nil {
+		return ps, msg, err
+	}
+
+	// maintain the per cr prometheus rules, thresholds come from the strategy config for the tier
+	if err := resources.ReconcilePostgresAlerts(ctx, p.Client, pg, strategyConfig.AlertThresholds); err != nil {
+		errMsg := "failed to reconcile postgres alerts"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// maintain the cross region disaster recovery standby when the strategy for the tier
+	// configures one
+	if strategyConfig.DisasterRecovery != nil {
+		if err := p.reconcileStandbyInstance(ctx, pg, providerCreds, strategyConfig); err != nil {
+			errMsg := "failed to reconcile disaster recovery standby"
+			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+	}
+
+	// front the instance with an rds proxy when the strategy for the tier opts into connection
+	// pooling, the proxy endpoint replaces the instance endpoint in the connection secret
+	if strategyConfig.ConnectionPooling != nil {
+		proxyEndpoint, proxyMsg, err := p.reconcileRDSProxy(ctx, pg, rds.New(sess), strategyConfig)
 		if err != nil {
-			errMsg := "failed to peer standalone network"
+			errMsg := "failed to reconcile rds proxy"
+			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+		if proxyEndpoint == "" {
+			return nil, proxyMsg, nil
+		}
+		ps.DeploymentDetails.(*providers.PostgresDeploymentDetails).Host = proxyEndpoint
+	}
+
+	// the admin details are needed for the database level reconciles below even when the
+	// published connection details are replaced with scoped application credentials
+	adminDetails := ps.DeploymentDetails.(*providers.PostgresDeploymentDetails)
+
+	// maintain the replication role and its secondary credential secret when the strategy for
+	// the tier enables logical replication, so cdc tooling can attach to the database
+	if strategyConfig.LogicalReplication {
+		if err := p.reconcileReplicationUser(ctx, pg, adminDetails); err != nil {
+			errMsg := "failed to reconcile replication user"
 			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 		}
-		logger.Infof("created network peering %s", aws.StringValue(networkPeering.PeeringConnection.VpcPeeringConnectionId))
+	}
 
-		// we have created the peering connection we must now create the security groups and update the route tables
-		securityGroup, err := networkManager.CreateNetworkConnection(ctx, standaloneNetwork)
+	// when enabled on the strategy, publish a scoped application user to applications instead of
+	// the admin user, the admin credentials remain only in the operator credential secret
+	if strategyConfig.CreateApplicationUser {
+		appDetails, err := p.reconcileApplicationUser(ctx, pg, ps.DeploymentDetails.(*providers.PostgresDeploymentDetails))
 		if err != nil {
-			errMsg := "failed to create standalone network"
+			errMsg := "failed to reconcile application user"
 			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 		}
-		logger.Infof("created security group %s", aws.StringValue(securityGroup.StandaloneSecurityGroup.GroupName))
+		ps.DeploymentDetails = appDetails
 	}
 
-	// create the aws RDS instance
-	return p.createRDSInstance(ctx, pg, rds.New(sess), ec2.New(sess), rdsCfg, isEnabled)
+	// create the extensions requested on the cr in the served database, unsupported requests
+	// are reported through a status condition rather than failing the reconcile
+	if err := p.reconcilePostgresExtensions(ctx, pg, adminDetails, ps.DeploymentDetails.(*providers.PostgresDeploymentDetails).Database); err != nil {
+		errMsg := "failed to reconcile postgres extensions"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// apply the bootstrap sql referenced on the cr exactly once after first provisioning
+	if err := p.reconcileInitSQL(ctx, pg, adminDetails, ps.DeploymentDetails.(*providers.PostgresDeploymentDetails).Database); err != nil {
+		errMsg := "failed to reconcile init sql"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	return ps, msg, err
 }
 
 func (p *PostgresProvider) createRDSInstance(ctx context.Context, cr *v1alpha1.Postgres, rdsSvc rdsiface.RDSAPI, ec2Svc ec2iface.EC2API, rdsCfg *rds.CreateDBInstanceInput, standaloneNetworkExists bool) (*providers.PostgresInstance, croType.StatusMessage, error) {
@@ -231,7 +385,7 @@ func (p *PostgresProvider) createRDSInstance(ctx context.Context, cr *v1alpha1.P
 	// standaloneNetworkExists if no bundled resources are found in the cluster vpc
 	if !standaloneNetworkExists {
 		// setup networking in cluster vpc rds vpc
-		if err := p.configureRDSVpc(ctx, rdsSvc, ec2Svc); err != nil {
+		if err := p.configureRDSVpc(ctx, cr, rdsSvc, ec2Svc); err != nil {
 			msg := "error setting up resource vpc"
 			return nil, croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
 		}
@@ -255,6 +409,12 @@ func (p *PostgresProvider) createRDSInstance(ctx context.Context, cr *v1alpha1.P
 		msg := "unable to retrieve rds password"
 		return nil, croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
 	}
+	// user supplied credentials can violate the rds master password restrictions, validate
+	// before the password reaches the api so the failure is actionable
+	if err := validateRDSMasterPassword(postgresPass); err != nil {
+		errMsg := "invalid rds master password in credential secret"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
 
 	// verify and build rds create config
 	if err := p.buildRDSCreateStrategy(ctx, cr, ec2Svc, rdsCfg, postgresPass); err != nil {
@@ -282,15 +442,64 @@ func (p *PostgresProvider) createRDSInstance(ctx context.Context, cr *v1alpha1.P
 
 	// create rds instance if it doesn't exist
 	if foundInstance == nil {
+		// an adopted instance must already exist, never create one under the adopted name
+		if annotations.Has(cr, AdoptResourceIDAnnotation) {
+			errMsg := fmt.Sprintf("no rds instance named %s found to adopt", *rdsCfg.DBInstanceIdentifier)
+			return nil, croType.StatusMessage(errMsg), errorUtil.New(errMsg)
+		}
+
 		if annotations.Has(cr, ResourceIdentifierAnnotation) {
 			errMsg := fmt.Sprintf("Postgres CR %s in %s namespace has %s annotation with value %s, but no corresponding RDS instance was found",
 				cr.Name, cr.Namespace, ResourceIdentifierAnnotation, cr.ObjectMeta.Annotations[ResourceIdentifierAnnotation])
 			return nil, croType.StatusMessage(errMsg), fmt.Errorf(errMsg)
 		}
 
-		logger.Info("creating rds instance")
-		if _, err := rdsSvc.CreateDBInstance(rdsCfg); err != nil {
-			return nil, croType.StatusMessage(fmt.Sprintf("error creating rds instance %s", err)), err
+		// guard against provisioning a duplicate, an instance already carrying the cro
+		// identity tags of the cr under a different identifier is never recreated under the
+		// expected name
+		taggedInstance, err := findRDSInstanceWithCROTags(ctx, p.Client, rdsSvc, cr.Spec.Type, cr.ObjectMeta, pi)
+		if err != nil {
+			errMsg := "failed to check existing rds instances for cro identity tags"
+			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+		if taggedInstance != nil {
+			errMsg := fmt.Sprintf("rds instance %s already carries the tags for postgres cr %s, refusing to create a duplicate under identifier %s, use the %s annotation to manage the existing instance", aws.StringValue(taggedInstance.DBInstanceIdentifier), cr.Name, *rdsCfg.DBInstanceIdentifier, AdoptResourceIDAnnotation)
+			return nil, croType.StatusMessage(errMsg), errorUtil.New(errMsg)
+		}
+
+		// cap the number of simultaneous expensive rds operations so a bulk rollout of crs
+		// does not trip cloud api throttles, the cr requeues until a slot is free
+		if !resources.AcquireProvisioningSlot("rds", fmt.Sprintf("%s/%s", cr.Namespace, cr.Name)) {
+			msg := fmt.Sprintf("waiting for an rds provisioning slot, %d operations are already in flight", resources.ProvisioningConcurrency("rds"))
+			logger.Info(msg)
+			return nil, croType.StatusMessage(msg), nil
+		}
+
+		// hydrate the new instance from an existing backup when a restore source is set on the
+		// cr, otherwise create a blank instance. the operation is checkpointed on the cr first
+		// so a restarted operator resumes polling the instance instead of losing track of it
+		if cr.Spec.RestoreFrom != nil {
+			if err := resources.SetInFlightOperation(ctx, p.Client, cr, "rds:RestoreDBInstanceFromDBSnapshot", *rdsCfg.DBInstanceIdentifier); err != nil {
+				return nil, croType.StatusMessage("failed to checkpoint rds restore"), err
+			}
+			logger.Info("restoring rds instance from existing backup")
+			if err := p.restoreRDSInstance(cr, rdsSvc, rdsCfg, pi); err != nil {
+				errMsg := "failed to restore rds instance"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+		} else {
+			if err := resources.SetInFlightOperation(ctx, p.Client, cr, "rds:CreateDBInstance", *rdsCfg.DBInstanceIdentifier); err != nil {
+				return nil, croType.StatusMessage("failed to checkpoint rds create"), err
+			}
+			logger.Info("creating rds instance")
+			if _, err := rdsSvc.CreateDBInstance(rdsCfg); err != nil {
+				// the create is idempotent by identifier, an already exists failure means a
+				// create issued by a racing reconcile or a previous pod is still settling
+				if !isAlreadyExistsError(err) {
+					return nil, croType.StatusMessage(fmt.Sprintf("error creating rds instance %s", err)), wrapAWSError(err, "error creating rds instance")
+				}
+				logger.Infof("rds instance %s already exists, create was issued by a previous reconcile", *rdsCfg.DBInstanceIdentifier)
+			}
 		}
 
 		annotations.Add(cr, ResourceIdentifierAnnotation, *rdsCfg.DBInstanceIdentifier)
@@ -300,12 +509,42 @@ func (p *PostgresProvider) createRDSInstance(ctx context.Context, cr *v1alpha1.P
 		return nil, "started rds provision", nil
 	}
 
+	// stop the instance outside the working window configured on the cr and start it again
+	// before the window opens, evaluated before the availability gate so a stopped instance
+	// reports its scheduled state instead of an endless create in progress
+	if handled, scheduleMsg, err := p.reconcileScheduledState(cr, rdsSvc, foundInstance); err != nil {
+		errMsg := "failed to reconcile stop/start schedule for rds instance"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	} else if handled {
+		logger.Info(scheduleMsg)
+		return nil, scheduleMsg, nil
+	}
+
 	// check rds instance phase
 	if *foundInstance.DBInstanceStatus != "available" {
+		if operation, ok := resources.InFlightOperationFrom(cr); ok {
+			logger.Infof("resuming in-flight %s operation for %s started at %s", operation.Operation, operation.Resource, operation.StartedAt.Format(time.RFC3339))
+		}
 		logger.Infof("found instance %s current status %s", *foundInstance.DBInstanceIdentifier, *foundInstance.DBInstanceStatus)
 		return nil, croType.StatusMessage(fmt.Sprintf("createRDSInstance() in progress, current aws rds resource status is %s", *foundInstance.DBInstanceStatus)), nil
 	}
 
+	// validate an adopted instance is compatible before the operator takes over management,
+	// the resource identifier annotation marks the takeover as complete
+	if annotations.Has(cr, AdoptResourceIDAnnotation) {
+		if aws.StringValue(foundInstance.Engine) != defaultAwsEngine {
+			errMsg := fmt.Sprintf("cannot adopt instance %s, engine %s does not match expected engine %s", *foundInstance.DBInstanceIdentifier, aws.StringValue(foundInstance.Engine), defaultAwsEngine)
+			return nil, croType.StatusMessage(errMsg), errorUtil.New(errMsg)
+		}
+		if !annotations.Has(cr, ResourceIdentifierAnnotation) {
+			logger.Infof("adopting existing rds instance %s", *foundInstance.DBInstanceIdentifier)
+			annotations.Add(cr, ResourceIdentifierAnnotation, *foundInstance.DBInstanceIdentifier)
+			if err := p.Client.Update(ctx, cr); err != nil {
+				return nil, croType.StatusMessage("failed to add annotation"), err
+			}
+		}
+	}
+
 	// check if found instance and user strategy differs, and modify instance
 	logger.Infof("found existing rds instance: %s", *foundInstance.DBInstanceIdentifier)
 	mi, err := buildRDSUpdateStrategy(rdsCfg, foundInstance)
@@ -314,15 +553,80 @@ func (p *PostgresProvider) createRDSInstance(ctx context.Context, cr *v1alpha1.P
 		errMsg := fmt.Sprintf("error building update config for rds instance: %s", *foundInstance.DBInstanceIdentifier)
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
+
+	// expose any drift between the expected strategy and the found instance as a status condition
+	// and metric, the found instance is only reverted when auto heal is enabled on the cr
+	driftItems := buildRDSDriftItems(mi, foundInstance)
+	p.exposePostgresDriftMetric(ctx, cr, driftItems)
+	cr.Status.Conditions = croType.SetCondition(cr.Status.Conditions, resources.BuildDriftCondition(driftItems))
+
+	// surface pending reboots on the cr, changes to static parameters only take effect after
+	// the next reboot or maintenance window
+	cr.Status.Conditions = croType.SetCondition(cr.Status.Conditions, buildPendingRebootCondition(foundInstance))
+
+	// the performance insights console identifies the instance dashboard by the dbi resource
+	// id rather than the instance identifier, expose it so users can link to the dashboard
+	if aws.BoolValue(foundInstance.PerformanceInsightsEnabled) {
+		cr.Status.PerformanceInsightsID = aws.StringValue(foundInstance.DbiResourceId)
+	}
+
+	// expose allocated against maximum storage so capacity can be planned from the cr,
+	// allocated grows towards the maximum when rds storage autoscaling kicks in
+	cr.Status.AllocatedStorageGB = aws.Int64Value(foundInstance.AllocatedStorage)
+	cr.Status.MaxAllocatedStorageGB = aws.Int64Value(foundInstance.MaxAllocatedStorage)
+
 	if mi == nil {
 		logger.Infof("rds instance %s is as expected", *foundInstance.DBInstanceIdentifier)
 	}
+	modificationIssued := false
 	if mi != nil {
-		if _, err = rdsSvc.ModifyDBInstance(mi); err != nil {
-			errMsg := fmt.Sprintf("error experienced trying to modify db instance: %s", *foundInstance.DBInstanceIdentifier)
-			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		applyModification := cr.Spec.AutoHeal
+		// an instance class change requested through the size field on the cr is an explicit
+		// request to scale rather than drift, so it is applied even when auto heal is disabled
+		if !applyModification && cr.Spec.Size != "" && mi.DBInstanceClass != nil {
+			mi = &rds.ModifyDBInstanceInput{
+				DBInstanceIdentifier: mi.DBInstanceIdentifier,
+				DBInstanceClass:      mi.DBInstanceClass,
+			}
+			applyModification = true
+		}
+		if !applyModification {
+			logger.Infof("drift detected on rds instance %s, auto heal is disabled on the cr so skipping modification", *foundInstance.DBInstanceIdentifier)
+		} else {
+			// modifications contend for the same provisioning slots as creates, skip this
+			// pass when the cap is reached and apply the modification on a later reconcile
+			if !resources.AcquireProvisioningSlot("rds", fmt.Sprintf("%s/%s", cr.Namespace, cr.Name)) {
+				msg := fmt.Sprintf("waiting for an rds provisioning slot to modify instance %s, %d operations are already in flight", *foundInstance.DBInstanceIdentifier, resources.ProvisioningConcurrency("rds"))
+				logger.Info(msg)
+				return nil, croType.StatusMessage(msg), nil
+			}
+			if err := resources.SetInFlightOperation(ctx, p.Client, cr, "rds:ModifyDBInstance", *foundInstance.DBInstanceIdentifier); err != nil {
+				return nil, croType.StatusMessage("failed to checkpoint rds modification"), err
+			}
+			if _, err = rdsSvc.ModifyDBInstance(mi); err != nil {
+				errMsg := fmt.Sprintf("error experienced trying to modify db instance: %s", *foundInstance.DBInstanceIdentifier)
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+			logger.Infof("set pending modifications for rds instance: %s", *foundInstance.DBInstanceIdentifier)
+			modificationIssued = true
+		}
+	}
+
+	// report scaling progress while an instance class change is pending on the instance
+	if foundInstance.PendingModifiedValues != nil && foundInstance.PendingModifiedValues.DBInstanceClass != nil {
+		statusMessage := fmt.Sprintf("scaling in progress, instance class %s pending for rds instance %s", aws.StringValue(foundInstance.PendingModifiedValues.DBInstanceClass), aws.StringValue(foundInstance.DBInstanceIdentifier))
+		logger.Info(statusMessage)
+		return nil, croType.StatusMessage(statusMessage), nil
+	}
+
+	// the instance is available with no pending work, release the provisioning slot and remove
+	// any checkpointed operation, a modification issued in this reconcile keeps both until the
+	// instance settles
+	if !modificationIssued {
+		resources.ReleaseProvisioningSlot("rds", fmt.Sprintf("%s/%s", cr.Namespace, cr.Name))
+		if err := resources.ClearInFlightOperation(ctx, p.Client, cr); err != nil {
+			return nil, croType.StatusMessage("failed to clear in-flight operation checkpoint"), err
 		}
-		logger.Infof("set pending modifications for rds instance: %s", *foundInstance.DBInstanceIdentifier)
 	}
 
 	// Add Tags to Aws Postgres resources
@@ -332,6 +636,31 @@ func (p *PostgresProvider) createRDSInstance(ctx context.Context, cr *v1alpha1.P
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
 
+	// rotate the master credentials if rotation is enabled on the cr and the interval has elapsed
+	rotated, err := p.rotateCredentials(ctx, cr, rdsSvc, foundInstance)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to rotate credentials for rds instance: %s", *foundInstance.DBInstanceIdentifier)
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	if rotated {
+		logger.Infof("master credentials rotated for rds instance %s", *foundInstance.DBInstanceIdentifier)
+		return nil, "rotating master credentials, modifyDBInstance() in progress", nil
+	}
+
+	// surface pending maintenance on the cr and apply it immediately when approved through
+	// the apply maintenance annotation
+	if err := p.reconcilePendingMaintenance(ctx, cr, rdsSvc, foundInstance); err != nil {
+		errMsg := fmt.Sprintf("failed to reconcile pending maintenance for rds instance %s", *foundInstance.DBInstanceIdentifier)
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// expose the cloud native identifier of the instance on the cr
+	cr.Status.ResourceID = aws.StringValue(foundInstance.DBInstanceArn)
+
+	// surface the earliest restorable time of the instance so consumers of the cr know how far
+	// back a point in time restore can go
+	p.setPostgresEarliestRestorableTime(cr, rdsSvc, foundInstance)
+
 	pdd := &providers.PostgresDeploymentDetails{
 		Username: *foundInstance.MasterUsername,
 		Password: postgresPass,
@@ -340,46 +669,314 @@ func (p *PostgresProvider) createRDSInstance(ctx context.Context, cr *v1alpha1.P
 		Port:     int(*foundInstance.Endpoint.Port),
 	}
 
+	// publish the regional rds ca bundle and a ready made verify-full connection string so
+	// consumers can verify the server certificate without sourcing the bundle themselves, the
+	// bundle is best effort so a truststore outage does not block the credential secret
+	if parsedArn, err := arn.Parse(aws.StringValue(foundInstance.DBInstanceArn)); err == nil {
+		caBundle, err := rdsCABundles.get(ctx, p.Client, parsedArn.Region)
+		if err != nil {
+			logger.Warnf("failed to fetch rds ca bundle for region %s, the credential secret will not carry a ca bundle: %v", parsedArn.Region, err)
+		} else {
+			pdd.CertificateAuthority = caBundle
+		}
+	}
+
 	// return secret information
 	return &providers.PostgresInstance{DeploymentDetails: pdd}, croType.StatusMessage(fmt.Sprintf("%s, aws rds status is %s", msg, *foundInstance.DBInstanceStatus)), nil
 }
 
-// TagRDSPostgres Tags RDS resources
-func (p *PostgresProvider) TagRDSPostgres(ctx context.Context, cr *v1alpha1.Postgres, rdsSvc rdsiface.RDSAPI, foundInstance *rds.DBInstance) (croType.StatusMessage, error) {
-	logger := p.Logger.WithField("action", "TagRDSPostgres")
-	logger.Infof("adding tags to rds instance %s", *foundInstance.DBInstanceIdentifier)
-	// get the environment from the CR
-	// set the tag values that will always be added
-	defaultOrganizationTag := resources.GetOrganizationTag()
+// rotateCredentials rotates the master password of an rds instance when rotation is enabled on
+// the cr and the configured interval has elapsed since the last rotation, the new password is
+// written to the credential secret before the last rotation annotation is updated so a failure
+// part way through results in another rotation attempt rather than a stale secret
+func (p *PostgresProvider) rotateCredentials(ctx context.Context, cr *v1alpha1.Postgres, rdsSvc rdsiface.RDSAPI, foundInstance *rds.DBInstance) (bool, error) {
+	if cr.Spec.CredentialRotation == nil {
+		return false, nil
+	}
+	logger := p.Logger.WithField("action", "rotateCredentials")
+	interval, err := time.ParseDuration(cr.Spec.CredentialRotation.Interval)
+	if err != nil {
+		return false, errorUtil.Wrapf(err, "failed to parse credential rotation interval %s", cr.Spec.CredentialRotation.Interval)
+	}
+	lastRotation := cr.CreationTimestamp.Time
+	if annotations.Has(cr, CredentialLastRotationAnnotation) {
+		parsedRotation, err := time.Parse(time.RFC3339, cr.ObjectMeta.Annotations[CredentialLastRotationAnnotation])
+		if err == nil {
+			lastRotation = parsedRotation
+		}
+	}
+	if time.Since(lastRotation) < interval {
+		return false, nil
+	}
+	logger.Infof("credential rotation interval %s elapsed, rotating master password for rds instance %s", interval, *foundInstance.DBInstanceIdentifier)
 
-	//get Cluster Id
-	clusterID, _ := resources.GetClusterID(ctx, p.Client)
-	// Set the Tag values
+	password, err := resources.GeneratePassword()
+	if err != nil {
+		return false, errorUtil.Wrap(err, "failed to generate new master password")
+	}
+	if _, err := rdsSvc.ModifyDBInstance(&rds.ModifyDBInstanceInput{
+		DBInstanceIdentifier: foundInstance.DBInstanceIdentifier,
+		MasterUserPassword:   aws.String(password),
+		ApplyImmediately:     aws.Bool(true),
+	}); err != nil {
+		return false, errorUtil.Wrap(err, "failed to modify master password on rds instance")
+	}
 
-	rdsTag := []*rds.Tag{
-		{
-			Key:   aws.String(defaultOrganizationTag + "clusterID"),
-			Value: aws.String(clusterID),
-		},
-		{
-			Key:   aws.String(defaultOrganizationTag + "resource-type"),
-			Value: aws.String(cr.Spec.Type),
-		},
-		{
-			Key:   aws.String(defaultOrganizationTag + "resource-name"),
-			Value: aws.String(cr.Name),
+	// update the credential secret with the new password
+	sec := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + defaultCredSecSuffix,
+			Namespace: cr.Namespace,
 		},
 	}
-	if cr.ObjectMeta.Labels["productName"] != "" {
-		productTag := &rds.Tag{
-			Key:   aws.String(defaultOrganizationTag + "product-name"),
-			Value: aws.String(cr.ObjectMeta.Labels["productName"]),
+	if _, err := controllerutil.CreateOrUpdate(ctx, p.Client, sec, func() error {
+		if sec.Data == nil {
+			sec.Data = map[string][]byte{}
+		}
+		sec.Data[defaultPostgresPasswordKey] = []byte(password)
+		return nil
+	}); err != nil {
+		return false, errorUtil.Wrap(err, "failed to update credential secret with rotated password")
+	}
+
+	// record the rotation time on the cr
+	annotations.Add(cr, CredentialLastRotationAnnotation, time.Now().UTC().Format(time.RFC3339))
+	if err := p.Client.Update(ctx, cr); err != nil {
+		return false, errorUtil.Wrap(err, "failed to update cr with last rotation annotation")
+	}
+
+	// expose the rotation time as a metric
+	instanceName, err := p.buildInstanceName(ctx, cr)
+	if err != nil {
+		logrus.Errorf("error occurred while building instance name during credential rotation metric: %v", err)
+	}
+	clusterID, err := resources.GetClusterID(ctx, p.Client)
+	if err == nil {
+		resources.SetMetricCurrentTime(resources.DefaultCredentialRotationMetricName, buildPostgresGenericMetricLabels(cr, clusterID, instanceName))
+	}
+	return true, nil
+}
+
+// restoreRDSInstance hydrates a new rds instance from the restore source on the cr, either an
+// existing snapshot or a point in time on a source instance, the restored instance keeps the
+// networking and instance configuration from the verified create strategy
+// reconcileScheduledState stops and starts the rds instance following the schedule on the cr,
+// outside the working window the instance is stopped to cut cost for non production
+// environments. The schedule override annotation keeps the instance running without editing
+// the spec. Returns true when the schedule took over the reconcile, the returned message then
+// reports the scheduled state on the cr
+func (p *PostgresProvider) reconcileScheduledState(cr *v1alpha1.Postgres, rdsSvc rdsiface.RDSAPI, foundInstance *rds.DBInstance) (bool, croType.StatusMessage, error) {
+	if cr.Spec.Schedule == nil {
+		return false, "", nil
+	}
+	logger := p.Logger.WithField("action", "reconcileScheduledState")
+	desiredStopped := false
+	if resources.IsScheduleOverridden(cr.ObjectMeta.Annotations) {
+		logger.Infof("schedule override annotation set on %s, keeping rds instance running", cr.Name)
+	} else {
+		stopped, err := resources.ScheduleDesiredStopped(cr.Spec.Schedule, time.Now())
+		if err != nil {
+			return false, "", errorUtil.Wrap(err, "failed to evaluate schedule on cr")
+		}
+		desiredStopped = stopped
+	}
+	status := aws.StringValue(foundInstance.DBInstanceStatus)
+	switch {
+	case desiredStopped && status == "available":
+		logger.Infof("stopping rds instance %s per schedule", aws.StringValue(foundInstance.DBInstanceIdentifier))
+		if _, err := rdsSvc.StopDBInstance(&rds.StopDBInstanceInput{
+			DBInstanceIdentifier: foundInstance.DBInstanceIdentifier,
+		}); err != nil {
+			return false, "", errorUtil.Wrapf(err, "failed to stop rds instance %s", aws.StringValue(foundInstance.DBInstanceIdentifier))
+		}
+		return true, croType.StatusMessage(fmt.Sprintf("stopping rds instance %s per schedule", aws.StringValue(foundInstance.DBInstanceIdentifier))), nil
+	case desiredStopped && (status == "stopped" || status == "stopping"):
+		return true, croType.StatusMessage(fmt.Sprintf("rds instance %s is %s per schedule, next start follows the schedule on the cr", aws.StringValue(foundInstance.DBInstanceIdentifier), status)), nil
+	case !desiredStopped && status == "stopped":
+		logger.Infof("starting rds instance %s per schedule", aws.StringValue(foundInstance.DBInstanceIdentifier))
+		if _, err := rdsSvc.StartDBInstance(&rds.StartDBInstanceInput{
+			DBInstanceIdentifier: foundInstance.DBInstanceIdentifier,
+		}); err != nil {
+			return false, "", errorUtil.Wrapf(err, "failed to start rds instance %s", aws.StringValue(foundInstance.DBInstanceIdentifier))
+		}
+		return true, croType.StatusMessage(fmt.Sprintf("starting rds instance %s per schedule", aws.StringValue(foundInstance.DBInstanceIdentifier))), nil
+	}
+	return false, "", nil
+}
+
+// reconcilePendingMaintenance surfaces the pending maintenance actions of the rds instance as
+// a condition on the cr, bridged to kubernetes events by the shared reconciler. When the
+// apply maintenance annotation is set to "true" on the cr the pending actions are opted in
+// for immediate application instead of waiting for the forced maintenance window
+func (p *PostgresProvider) reconcilePendingMaintenance(ctx context.Context, cr *v1alpha1.Postgres, rdsSvc rdsiface.RDSAPI, foundInstance *rds.DBInstance) error {
+	logger := p.Logger.WithField("action", "reconcilePendingMaintenance")
+	output, err := rdsSvc.DescribePendingMaintenanceActions(&rds.DescribePendingMaintenanceActionsInput{
+		ResourceIdentifier: foundInstance.DBInstanceArn,
+	})
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to describe pending maintenance actions for rds instance %s", aws.StringValue(foundInstance.DBInstanceIdentifier))
+	}
+	var pending []*rds.PendingMaintenanceAction
+	for _, resourceActions := range output.PendingMaintenanceActions {
+		pending = append(pending, resourceActions.PendingMaintenanceActionDetails...)
+	}
+	cr.Status.Conditions = croType.SetCondition(cr.Status.Conditions, resources.BuildDisruptionCondition(
+		resources.ConditionTypePendingMaintenance,
+		buildPendingMaintenanceMessage(foundInstance, pending),
+		"no maintenance actions pending on the rds instance",
+	))
+	if len(pending) == 0 || cr.ObjectMeta.Annotations[ApplyMaintenanceImmediatelyAnnotation] != "true" {
+		return nil
+	}
+	for _, action := range pending {
+		// an action already opted in for immediate application is never opted in again
+		if aws.StringValue(action.OptInStatus) == "immediate" {
+			continue
+		}
+		logger.Infof("applying pending maintenance action %s immediately on rds instance %s", aws.StringValue(action.Action), aws.StringValue(foundInstance.DBInstanceIdentifier))
+		if _, err := rdsSvc.ApplyPendingMaintenanceAction(&rds.ApplyPendingMaintenanceActionInput{
+			ApplyAction:        action.Action,
+			OptInType:          aws.String("immediate"),
+			ResourceIdentifier: foundInstance.DBInstanceArn,
+		}); err != nil {
+			return errorUtil.Wrapf(err, "failed to apply pending maintenance action %s on rds instance %s", aws.StringValue(action.Action), aws.StringValue(foundInstance.DBInstanceIdentifier))
+		}
+	}
+	return nil
+}
+
+// buildPendingMaintenanceMessage summarises the pending maintenance actions of the rds
+// instance, an empty string when nothing is pending
+func buildPendingMaintenanceMessage(foundInstance *rds.DBInstance, pending []*rds.PendingMaintenanceAction) string {
+	if len(pending) == 0 {
+		return ""
+	}
+	items := make([]string, 0, len(pending))
+	for _, action := range pending {
+		item := fmt.Sprintf("%s (%s)", aws.StringValue(action.Action), aws.StringValue(action.Description))
+		if action.ForcedApplyDate != nil {
+			item = fmt.Sprintf("%s (%s, forced apply at %s)", aws.StringValue(action.Action), aws.StringValue(action.Description), action.ForcedApplyDate.Format(time.RFC3339))
+		} else if action.AutoAppliedAfterDate != nil {
+			item = fmt.Sprintf("%s (%s, auto applied after %s)", aws.StringValue(action.Action), aws.StringValue(action.Description), action.AutoAppliedAfterDate.Format(time.RFC3339))
+		}
+		items = append(items, item)
+	}
+	return fmt.Sprintf("maintenance actions pending on rds instance %s: %s", aws.StringValue(foundInstance.DBInstanceIdentifier), strings.Join(items, ", "))
+}
+
+func (p *PostgresProvider) restoreRDSInstance(cr *v1alpha1.Postgres, rdsSvc rdsiface.RDSAPI, rdsCfg *rds.CreateDBInstanceInput, foundInstances []*rds.DBInstance) error {
+	restoreFrom := cr.Spec.RestoreFrom
+	if restoreFrom.SnapshotIdentifier != "" && restoreFrom.Timestamp != "" {
+		return errorUtil.New("snapshotIdentifier and timestamp are mutually exclusive on restoreFrom")
+	}
+
+	// restore from an existing snapshot
+	if restoreFrom.SnapshotIdentifier != "" {
+		snapshots, err := rdsSvc.DescribeDBSnapshots(&rds.DescribeDBSnapshotsInput{
+			DBSnapshotIdentifier: aws.String(restoreFrom.SnapshotIdentifier),
+		})
+		if err != nil || len(snapshots.DBSnapshots) == 0 {
+			return errorUtil.Wrapf(err, "snapshot %s referenced by restoreFrom does not exist", restoreFrom.SnapshotIdentifier)
+		}
+		foundSnapshot := snapshots.DBSnapshots[0]
+		// the restored instance runs at the engine version of the snapshot, reject snapshots
+		// with an engine version newer than the expected strategy as they can not be downgraded
+		if rdsCfg.EngineVersion != nil && foundSnapshot.EngineVersion != nil {
+			snapshotVersionNewer, err := resources.VerifyVersionUpgradeNeeded(*rdsCfg.EngineVersion, *foundSnapshot.EngineVersion)
+			if err != nil {
+				return errorUtil.Wrap(err, "invalid postgres version on snapshot")
+			}
+			if snapshotVersionNewer {
+				return errorUtil.New(fmt.Sprintf("snapshot %s engine version %s is not compatible with expected engine version %s", restoreFrom.SnapshotIdentifier, *foundSnapshot.EngineVersion, *rdsCfg.EngineVersion))
+			}
+		}
+		if _, err := rdsSvc.RestoreDBInstanceFromDBSnapshot(&rds.RestoreDBInstanceFromDBSnapshotInput{
+			DBInstanceIdentifier: rdsCfg.DBInstanceIdentifier,
+			DBSnapshotIdentifier: aws.String(restoreFrom.SnapshotIdentifier),
+			DBInstanceClass:      rdsCfg.DBInstanceClass,
+			DBSubnetGroupName:    rdsCfg.DBSubnetGroupName,
+			VpcSecurityGroupIds:  rdsCfg.VpcSecurityGroupIds,
+			MultiAZ:              rdsCfg.MultiAZ,
+			DeletionProtection:   rdsCfg.DeletionProtection,
+			CopyTagsToSnapshot:   rdsCfg.CopyTagsToSnapshot,
+			PubliclyAccessible:   rdsCfg.PubliclyAccessible,
+		}); err != nil {
+			return errorUtil.Wrapf(err, "failed to restore rds instance from snapshot %s", restoreFrom.SnapshotIdentifier)
+		}
+		return nil
+	}
+
+	// restore to a point in time on a source instance
+	if restoreFrom.Timestamp == "" {
+		return errorUtil.New("restoreFrom requires a snapshotIdentifier or a timestamp")
+	}
+	restoreTime, err := time.Parse(time.RFC3339, restoreFrom.Timestamp)
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to parse restoreFrom timestamp %s", restoreFrom.Timestamp)
+	}
+	if restoreFrom.SourceResourceID == "" {
+		return errorUtil.New("sourceResourceID is required for a point in time restore")
+	}
+	sourceExists := false
+	for _, i := range foundInstances {
+		if *i.DBInstanceIdentifier == restoreFrom.SourceResourceID {
+			sourceExists = true
+			break
 		}
-		rdsTag = append(rdsTag, productTag)
 	}
+	if !sourceExists {
+		return errorUtil.New(fmt.Sprintf("source instance %s referenced by restoreFrom does not exist", restoreFrom.SourceResourceID))
+	}
+	if _, err := rdsSvc.RestoreDBInstanceToPointInTime(&rds.RestoreDBInstanceToPointInTimeInput{
+		SourceDBInstanceIdentifier: aws.String(restoreFrom.SourceResourceID),
+		TargetDBInstanceIdentifier: rdsCfg.DBInstanceIdentifier,
+		RestoreTime:                aws.Time(restoreTime),
+		DBInstanceClass:            rdsCfg.DBInstanceClass,
+		DBSubnetGroupName:          rdsCfg.DBSubnetGroupName,
+		VpcSecurityGroupIds:        rdsCfg.VpcSecurityGroupIds,
+		MultiAZ:                    rdsCfg.MultiAZ,
+		DeletionProtection:         rdsCfg.DeletionProtection,
+		CopyTagsToSnapshot:         rdsCfg.CopyTagsToSnapshot,
+		PubliclyAccessible:         rdsCfg.PubliclyAccessible,
+	}); err != nil {
+		return errorUtil.Wrapf(err, "failed to restore rds instance to point in time %s", restoreFrom.Timestamp)
+	}
+	return nil
+}
+
+// setPostgresEarliestRestorableTime sets the earliest restorable time of the rds instance on the
+// cr status from the restore window of its automated backups
+func (p *PostgresProvider) setPostgresEarliestRestorableTime(cr *v1alpha1.Postgres, rdsSvc rdsiface.RDSAPI, foundInstance *rds.DBInstance) {
+	backups, err := rdsSvc.DescribeDBInstanceAutomatedBackups(&rds.DescribeDBInstanceAutomatedBackupsInput{
+		DBInstanceIdentifier: foundInstance.DBInstanceIdentifier,
+	})
+	if err != nil {
+		logrus.Errorf("failed to describe automated backups while setting earliest restorable time for %s: %v", aws.StringValue(foundInstance.DBInstanceIdentifier), err)
+		return
+	}
+	for _, backup := range backups.DBInstanceAutomatedBackups {
+		if backup.RestoreWindow != nil && backup.RestoreWindow.EarliestTime != nil {
+			earliestRestorableTime := metav1.NewTime(*backup.RestoreWindow.EarliestTime)
+			cr.Status.EarliestRestorableTime = &earliestRestorableTime
+			return
+		}
+	}
+}
+
+// TagRDSPostgres Tags RDS resources
+func (p *PostgresProvider) TagRDSPostgres(ctx context.Context, cr *v1alpha1.Postgres, rdsSvc rdsiface.RDSAPI, foundInstance *rds.DBInstance) (croType.StatusMessage, error) {
+	logger := p.Logger.WithField("action", "TagRDSPostgres")
+	logger.Infof("adding tags to rds instance %s", *foundInstance.DBInstanceIdentifier)
+	// build the cross provider tag set applied to the rds instance and its snapshots
+	resourceTags, err := resources.BuildDefaultResourceTags(ctx, p.Client, cr.Spec.Type, cr.ObjectMeta)
+	if err != nil {
+		msg := "failed to build resource tags"
+		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+	}
+	rdsTag := resourceTagsToRDSTags(resourceTags)
 
 	// adding tags to rds postgres instance
-	_, err := rdsSvc.AddTagsToResource(&rds.AddTagsToResourceInput{
+	_, err = rdsSvc.AddTagsToResource(&rds.AddTagsToResourceInput{
 		ResourceName: aws.String(*foundInstance.DBInstanceArn),
 		Tags:         rdsTag,
 	})
@@ -417,10 +1014,20 @@ func (p *PostgresProvider) TagRDSPostgres(ctx context.Context, cr *v1alpha1.Post
 }
 
 func (p *PostgresProvider) DeletePostgres(ctx context.Context, r *v1alpha1.Postgres) (croType.StatusMessage, error) {
+	ctx, span := resources.StartSpan(ctx, "aws.DeletePostgres")
+	defer span.End()
+	ctx = resources.WithAuditResource(ctx, "Postgres", r.Namespace, r.Name)
 	logger := p.Logger.WithField("action", "DeletePostgres")
 	logger.Infof("reconciling postgres %s", r.Name)
 	p.setPostgresDeletionTimestampMetric(ctx, r)
 
+	// a retain policy leaves the rds instance in place so it can be adopted by another
+	// cluster, only the operator owned kubernetes objects are removed
+	if r.Spec.DeletionPolicy == croType.DeletionPolicyRetain {
+		logger.Infof("deletion policy is Retain, rds instance for %s is left in place", r.Name)
+		return p.removePostgresObjects(ctx, r)
+	}
+
 	// resolve postgres information for postgres created by provider
 	rdsCreateConfig, rdsDeleteConfig, stratCfg, err := p.getRDSConfig(ctx, r)
 	if err != nil {
@@ -434,35 +1041,374 @@ func (p *PostgresProvider) DeletePostgres(ctx context.Context, r *v1alpha1.Postg
 		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
 	}
 
-	// setup aws postgres instance sdk session
-	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds.AccessKeyID, providerCreds.SecretAccessKey, stratCfg)
-	if err != nil {
-		errMsg := "failed to create aws session to delete rds db instance"
-		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	// setup aws postgres instance sdk session
+	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds, stratCfg)
+	if err != nil {
+		errMsg := "failed to create aws session to delete rds db instance"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// network manager required for cleaning up network vpc, subnet and subnet groups.
+	networkManager := NewNetworkManager(sess, p.Client, logger)
+
+	isEnabled, err := networkManager.IsEnabled(ctx)
+	if err != nil {
+		errMsg := "failed to check cluster vpc subnets"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	namespace, err := k8sutil.GetWatchNamespace()
+	if err != nil {
+		errMsg := "Failed to get watch namespace"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	isLastResource, err := p.isLastResource(ctx, namespace)
+	if err != nil {
+		errMsg := "failed to check if this cr is the last cr of type postgres and redis"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// the standby is removed before the primary so the primary deletion is not blocked on
+	// cross region replication
+	if stratCfg.DisasterRecovery != nil {
+		standbyDeleted, err := p.deleteStandbyInstance(ctx, r, providerCreds, stratCfg)
+		if err != nil {
+			errMsg := "failed to delete disaster recovery standby"
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+		if !standbyDeleted {
+			return "delete detected, deletion of disaster recovery standby in progress", nil
+		}
+	}
+
+	// the proxy holds connections open against the instance, remove it first so instance
+	// deletion is not blocked on registered proxy targets
+	if stratCfg.ConnectionPooling != nil {
+		proxyDeleted, err := p.deleteRDSProxy(ctx, r, rds.New(sess))
+		if err != nil {
+			errMsg := "failed to delete rds proxy"
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+		if !proxyDeleted {
+			return "delete detected, deletion of rds proxy in progress", nil
+		}
+	}
+
+	return p.deleteRDSInstance(ctx, r, networkManager, rds.New(sess), rdsCreateConfig, rdsDeleteConfig, isEnabled, isLastResource)
+}
+
+// reconcileStandbyInstance maintains a cross region read replica of the provisioned instance
+// in the disaster recovery region configured on the strategy for the tier, the standby
+// endpoint is exposed on the cr status and the standby is promoted to a standalone instance
+// when the promote annotation is set on the cr
+func (p *PostgresProvider) reconcileStandbyInstance(ctx context.Context, cr *v1alpha1.Postgres, providerCreds *Credentials, stratCfg *StrategyConfig) error {
+	logger := p.Logger.WithField("action", "reconcileStandbyInstance")
+	drConfig := stratCfg.DisasterRecovery
+	primaryRegion, err := GetRegionFromStrategyOrDefault(ctx, p.Client, stratCfg)
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to get primary region for disaster recovery standby")
+	}
+	if drConfig.Region == "" || drConfig.Region == primaryRegion {
+		return errorUtil.New("disaster recovery region must be set and differ from the primary region")
+	}
+	instanceName, err := p.buildInstanceName(ctx, cr)
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to retrieve instance name for %s", cr.Name)
+	}
+
+	// the source of the cross region replica is referenced by arn, look it up in the primary region
+	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds, stratCfg)
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to create aws session in the primary region")
+	}
+	primaries, err := getRDSInstances(rds.New(sess))
+	if err != nil {
+		return errorUtil.Wrap(err, "error getting aws rds instances in the primary region")
+	}
+	var primaryInstance *rds.DBInstance
+	for _, i := range primaries {
+		if *i.DBInstanceIdentifier == instanceName {
+			primaryInstance = i
+			break
+		}
+	}
+	if primaryInstance == nil || *primaryInstance.DBInstanceStatus != "available" {
+		logger.Infof("primary instance %s is not available yet, skipping standby reconcile", instanceName)
+		return nil
+	}
+
+	drSvc, err := p.standbyRDSService(ctx, providerCreds, stratCfg)
+	if err != nil {
+		return err
+	}
+	standbyName := buildStandbyInstanceName(instanceName)
+	standbyInstance, err := findRDSInstance(drSvc, standbyName)
+	if err != nil {
+		return errorUtil.Wrap(err, "error getting aws rds instances in the disaster recovery region")
+	}
+
+	if standbyInstance == nil {
+		logger.Infof("creating disaster recovery standby %s in region %s", standbyName, drConfig.Region)
+		createInput := &rds.CreateDBInstanceReadReplicaInput{
+			DBInstanceIdentifier:       aws.String(standbyName),
+			SourceDBInstanceIdentifier: primaryInstance.DBInstanceArn,
+		}
+		if drConfig.InstanceClass != "" {
+			createInput.DBInstanceClass = aws.String(drConfig.InstanceClass)
+		}
+		// cross region replicas of encrypted instances are encrypted with a key in the
+		// standby region
+		if drConfig.KmsKeyID != "" {
+			createInput.KmsKeyId = aws.String(drConfig.KmsKeyID)
+		}
+		if _, err := drSvc.CreateDBInstanceReadReplica(createInput); err != nil {
+			return errorUtil.Wrapf(err, "failed to create disaster recovery standby %s", standbyName)
+		}
+		return nil
+	}
+
+	// promote the standby to a standalone instance for failover drills, only replicas can be
+	// promoted so repeat reconciles with the annotation in place are a no-op
+	if cr.ObjectMeta.Annotations[PromoteStandbyAnnotation] == "true" && standbyInstance.ReadReplicaSourceDBInstanceIdentifier != nil {
+		if *standbyInstance.DBInstanceStatus != "available" {
+			logger.Infof("standby %s is not available yet, promote deferred, current aws rds status is %s", standbyName, *standbyInstance.DBInstanceStatus)
+			return nil
+		}
+		logger.Infof("promoting disaster recovery standby %s", standbyName)
+		if _, err := drSvc.PromoteReadReplica(&rds.PromoteReadReplicaInput{
+			DBInstanceIdentifier: aws.String(standbyName),
+		}); err != nil {
+			return errorUtil.Wrapf(err, "failed to promote disaster recovery standby %s", standbyName)
+		}
+		return nil
+	}
+
+	// expose the standby endpoint so consumers know where to fail over to
+	if standbyInstance.Endpoint != nil {
+		cr.Status.StandbyEndpoint = fmt.Sprintf("%s:%d", aws.StringValue(standbyInstance.Endpoint.Address), aws.Int64Value(standbyInstance.Endpoint.Port))
+	}
+	return nil
+}
+
+// deleteStandbyInstance removes the disaster recovery standby, returning true once the standby
+// is gone from the disaster recovery region
+func (p *PostgresProvider) deleteStandbyInstance(ctx context.Context, cr *v1alpha1.Postgres, providerCreds *Credentials, stratCfg *StrategyConfig) (bool, error) {
+	logger := p.Logger.WithField("action", "deleteStandbyInstance")
+	instanceName, err := p.buildInstanceName(ctx, cr)
+	if err != nil {
+		return false, errorUtil.Wrapf(err, "failed to retrieve instance name for %s", cr.Name)
+	}
+	drSvc, err := p.standbyRDSService(ctx, providerCreds, stratCfg)
+	if err != nil {
+		return false, err
+	}
+	standbyName := buildStandbyInstanceName(instanceName)
+	standbyInstance, err := findRDSInstance(drSvc, standbyName)
+	if err != nil {
+		return false, errorUtil.Wrap(err, "error getting aws rds instances in the disaster recovery region")
+	}
+	if standbyInstance == nil {
+		return true, nil
+	}
+	if *standbyInstance.DBInstanceStatus != "available" {
+		logger.Infof("deletion of standby %s deferred, current aws rds status is %s", standbyName, *standbyInstance.DBInstanceStatus)
+		return false, nil
+	}
+	logger.Infof("deleting disaster recovery standby %s", standbyName)
+	_, err = drSvc.DeleteDBInstance(&rds.DeleteDBInstanceInput{
+		DBInstanceIdentifier: aws.String(standbyName),
+		SkipFinalSnapshot:    aws.Bool(true),
+	})
+	rdsErr, isAwsErr := err.(awserr.Error)
+	if err != nil && (!isAwsErr || rdsErr.Code() != rds.ErrCodeDBInstanceNotFoundFault) {
+		return false, errorUtil.Wrapf(err, "failed to delete disaster recovery standby %s", standbyName)
+	}
+	return false, nil
+}
+
+// standbyRDSService returns an rds client for the disaster recovery region on the strategy
+func (p *PostgresProvider) standbyRDSService(ctx context.Context, providerCreds *Credentials, stratCfg *StrategyConfig) (rdsiface.RDSAPI, error) {
+	drStrategy := *stratCfg
+	drStrategy.Region = stratCfg.DisasterRecovery.Region
+	drSess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds, &drStrategy)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to create aws session in the disaster recovery region")
+	}
+	return rds.New(drSess), nil
+}
+
+// findRDSInstance returns the rds instance with the provided identifier, nil when not found
+func findRDSInstance(rdsSvc rdsiface.RDSAPI, instanceName string) (*rds.DBInstance, error) {
+	instances, err := getRDSInstances(rdsSvc)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range instances {
+		if *i.DBInstanceIdentifier == instanceName {
+			return i, nil
+		}
+	}
+	return nil, nil
+}
+
+// buildStandbyInstanceName returns the identifier of the disaster recovery standby of an instance
+func buildStandbyInstanceName(instanceName string) string {
+	return fmt.Sprintf("%s-standby", instanceName)
+}
+
+// reconcileRDSProxy maintains the rds proxy fronting the provisioned instance when the strategy
+// for the tier opts into connection pooling, the proxy reuses the subnets and security groups
+// of the instance and authenticates through the secrets manager secret on the strategy. The
+// proxy endpoint is returned once the proxy is available and its target is registered, an empty
+// endpoint with a status message is returned while provisioning is still in progress
+func (p *PostgresProvider) reconcileRDSProxy(ctx context.Context, cr *v1alpha1.Postgres, rdsSvc rdsiface.RDSAPI, stratCfg *StrategyConfig) (string, croType.StatusMessage, error) {
+	logger := p.Logger.WithField("action", "reconcileRDSProxy")
+	poolingConfig := stratCfg.ConnectionPooling
+	if poolingConfig.RoleARN == "" || poolingConfig.AuthSecretARN == "" {
+		return "", "", errorUtil.New("connection pooling requires a role arn and an auth secret arn on the strategy")
+	}
+	instanceName, err := p.buildInstanceName(ctx, cr)
+	if err != nil {
+		return "", "", errorUtil.Wrapf(err, "failed to retrieve instance name for %s", cr.Name)
+	}
+	foundInstance, err := findRDSInstance(rdsSvc, instanceName)
+	if err != nil {
+		return "", "", errorUtil.Wrap(err, "error getting aws rds instances")
+	}
+	if foundInstance == nil {
+		return "", "instance not found yet, waiting before creating rds proxy", nil
+	}
+
+	proxyName := buildProxyName(instanceName)
+	foundProxy, err := findRDSProxy(rdsSvc, proxyName)
+	if err != nil {
+		return "", "", err
+	}
+	if foundProxy == nil {
+		logger.Infof("creating rds proxy %s", proxyName)
+		// the proxy is placed in the subnets and security groups of the instance so no
+		// additional networking is required to reach it
+		var subnetIds []*string
+		for _, subnet := range foundInstance.DBSubnetGroup.Subnets {
+			subnetIds = append(subnetIds, subnet.SubnetIdentifier)
+		}
+		var securityGroupIds []*string
+		for _, securityGroup := range foundInstance.VpcSecurityGroups {
+			securityGroupIds = append(securityGroupIds, securityGroup.VpcSecurityGroupId)
+		}
+		_, err := rdsSvc.CreateDBProxy(&rds.CreateDBProxyInput{
+			DBProxyName:  aws.String(proxyName),
+			EngineFamily: aws.String("POSTGRESQL"),
+			RoleArn:      aws.String(poolingConfig.RoleARN),
+			Auth: []*rds.UserAuthConfig{
+				{
+					AuthScheme: aws.String("SECRETS"),
+					SecretArn:  aws.String(poolingConfig.AuthSecretARN),
+				},
+			},
+			RequireTLS:          aws.Bool(true),
+			VpcSubnetIds:        subnetIds,
+			VpcSecurityGroupIds: securityGroupIds,
+		})
+		rdsErr, isAwsErr := err.(awserr.Error)
+		if err != nil && (!isAwsErr || rdsErr.Code() != rds.ErrCodeDBProxyAlreadyExistsFault) {
+			return "", "", errorUtil.Wrapf(err, "failed to create rds proxy %s", proxyName)
+		}
+		return "", "connection pooling enabled, creation of rds proxy in progress", nil
+	}
+	if aws.StringValue(foundProxy.Status) != "available" {
+		return "", croType.StatusMessage(fmt.Sprintf("rds proxy %s is not yet available, current aws status is %s", proxyName, aws.StringValue(foundProxy.Status))), nil
+	}
+
+	// register the instance as the proxy target, registration is repeated until the target is
+	// reported back by aws
+	targetOutput, err := rdsSvc.DescribeDBProxyTargets(&rds.DescribeDBProxyTargetsInput{
+		DBProxyName: aws.String(proxyName),
+	})
+	if err != nil {
+		return "", "", errorUtil.Wrapf(err, "failed to describe targets of rds proxy %s", proxyName)
+	}
+	if len(targetOutput.Targets) == 0 {
+		logger.Infof("registering instance %s as target of rds proxy %s", instanceName, proxyName)
+		_, err := rdsSvc.RegisterDBProxyTargets(&rds.RegisterDBProxyTargetsInput{
+			DBProxyName:           aws.String(proxyName),
+			DBInstanceIdentifiers: []*string{aws.String(instanceName)},
+		})
+		rdsErr, isAwsErr := err.(awserr.Error)
+		if err != nil && (!isAwsErr || rdsErr.Code() != rds.ErrCodeDBProxyTargetAlreadyRegisteredFault) {
+			return "", "", errorUtil.Wrapf(err, "failed to register target of rds proxy %s", proxyName)
+		}
+		return "", "registration of rds proxy target in progress", nil
+	}
+
+	// the connection cap is applied to the default target group the proxy is created with
+	if poolingConfig.MaxConnectionsPercent != nil {
+		_, err := rdsSvc.ModifyDBProxyTargetGroup(&rds.ModifyDBProxyTargetGroupInput{
+			DBProxyName:     aws.String(proxyName),
+			TargetGroupName: aws.String("default"),
+			ConnectionPoolConfig: &rds.ConnectionPoolConfiguration{
+				MaxConnectionsPercent: poolingConfig.MaxConnectionsPercent,
+			},
+		})
+		if err != nil {
+			return "", "", errorUtil.Wrapf(err, "failed to modify target group of rds proxy %s", proxyName)
+		}
 	}
+	return aws.StringValue(foundProxy.Endpoint), "", nil
+}
 
-	// network manager required for cleaning up network vpc, subnet and subnet groups.
-	networkManager := NewNetworkManager(sess, p.Client, logger)
-
-	isEnabled, err := networkManager.IsEnabled(ctx)
+// deleteRDSProxy removes the rds proxy fronting the instance, returning true once the proxy
+// is no longer found
+func (p *PostgresProvider) deleteRDSProxy(ctx context.Context, cr *v1alpha1.Postgres, rdsSvc rdsiface.RDSAPI) (bool, error) {
+	logger := p.Logger.WithField("action", "deleteRDSProxy")
+	instanceName, err := p.buildInstanceName(ctx, cr)
 	if err != nil {
-		errMsg := "failed to check cluster vpc subnets"
-		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		return false, errorUtil.Wrapf(err, "failed to retrieve instance name for %s", cr.Name)
 	}
-
-	namespace, err := k8sutil.GetWatchNamespace()
+	proxyName := buildProxyName(instanceName)
+	foundProxy, err := findRDSProxy(rdsSvc, proxyName)
 	if err != nil {
-		errMsg := "Failed to get watch namespace"
-		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		return false, err
+	}
+	if foundProxy == nil {
+		return true, nil
+	}
+	if aws.StringValue(foundProxy.Status) == "deleting" {
+		return false, nil
+	}
+	logger.Infof("deleting rds proxy %s", proxyName)
+	_, err = rdsSvc.DeleteDBProxy(&rds.DeleteDBProxyInput{
+		DBProxyName: aws.String(proxyName),
+	})
+	rdsErr, isAwsErr := err.(awserr.Error)
+	if err != nil && (!isAwsErr || rdsErr.Code() != rds.ErrCodeDBProxyNotFoundFault) {
+		return false, errorUtil.Wrapf(err, "failed to delete rds proxy %s", proxyName)
 	}
+	return false, nil
+}
 
-	isLastResource, err := p.isLastResource(ctx, namespace)
+// findRDSProxy returns the rds proxy with the provided name, nil when not found
+func findRDSProxy(rdsSvc rdsiface.RDSAPI, proxyName string) (*rds.DBProxy, error) {
+	proxyOutput, err := rdsSvc.DescribeDBProxies(&rds.DescribeDBProxiesInput{
+		DBProxyName: aws.String(proxyName),
+	})
+	rdsErr, isAwsErr := err.(awserr.Error)
+	if err != nil && isAwsErr && rdsErr.Code() == rds.ErrCodeDBProxyNotFoundFault {
+		return nil, nil
+	}
 	if err != nil {
-		errMsg := "failed to check if this cr is the last cr of type postgres and redis"
-		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		return nil, errorUtil.Wrapf(err, "failed to describe rds proxy %s", proxyName)
 	}
+	if len(proxyOutput.DBProxies) == 0 {
+		return nil, nil
+	}
+	return proxyOutput.DBProxies[0], nil
+}
 
-	return p.deleteRDSInstance(ctx, r, networkManager, rds.New(sess), rdsCreateConfig, rdsDeleteConfig, isEnabled, isLastResource)
+// buildProxyName returns the name of the rds proxy fronting an instance
+func buildProxyName(instanceName string) string {
+	return fmt.Sprintf("%s-proxy", instanceName)
 }
 
 func (p *PostgresProvider) deleteRDSInstance(ctx context.Context, pg *v1alpha1.Postgres, networkManager NetworkManager, instanceSvc rdsiface.RDSAPI, rdsCreateConfig *rds.CreateDBInstanceInput, rdsDeleteConfig *rds.DeleteDBInstanceInput, standaloneNetworkExists bool, isLastResource bool) (croType.StatusMessage, error) {
@@ -526,28 +1472,67 @@ func (p *PostgresProvider) deleteRDSInstance(ctx context.Context, pg *v1alpha1.P
 		return croType.StatusMessage(fmt.Sprintf("deletion protection detected, modifyDBInstance() in progress, current aws rds status is %s", *foundInstance.DBInstanceStatus)), nil
 	}
 
+	// remove the dedicated parameter group once the instance is gone, the group only exists
+	// when the strategy for the tier declares postgres parameters
+	_, err = instanceSvc.DeleteDBParameterGroup(&rds.DeleteDBParameterGroupInput{
+		DBParameterGroupName: aws.String(buildParameterGroupName(aws.StringValue(rdsDeleteConfig.DBInstanceIdentifier))),
+	})
+	rdsErr, isAwsErr := err.(awserr.Error)
+	if err != nil && (!isAwsErr || rdsErr.Code() != rds.ErrCodeDBParameterGroupNotFoundFault) {
+		msg := "failed to delete rds parameter group"
+		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+	}
+
 	// standaloneNetworkExists if no bundled resources are found in the cluster vpc
 	if standaloneNetworkExists && isLastResource {
 		logger.Info("found the last instance of types postgres and redis so deleting the standalone network")
-		networkPeering, err := networkManager.GetClusterNetworkPeering(ctx)
+		// when the _network strategy references an existing vpc, only the resources the operator
+		// created around it are removed, the vpc and subnets themselves are left untouched
+		existingNetworkConfig, err := getExistingNetworkConfig(ctx, p.ConfigManager, pg.Spec.Tier, logger)
 		if err != nil {
-			msg := "failed to get cluster network peering"
+			msg := "failed to get existing network config from _network strategy"
 			return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
 		}
 
-		if err = networkManager.DeleteNetworkConnection(ctx, networkPeering); err != nil {
-			msg := "failed to delete network connection"
-			return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
-		}
+		if existingNetworkConfig != nil {
+			if err := networkManager.DeleteExistingNetwork(ctx, aws.StringValue(existingNetworkConfig.VpcId)); err != nil {
+				msg := "failed to delete network resources for existing vpc"
+				return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+			}
+		} else {
+			connConfig, err := getNetworkConnectionConfig(ctx, p.ConfigManager, pg.Spec.Tier, logger)
+			if err != nil {
+				msg := "failed to get network connection config from _network strategy"
+				return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+			}
 
-		if err = networkManager.DeleteNetworkPeering(networkPeering); err != nil {
-			msg := "failed to delete cluster network peering"
-			return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
-		}
+			if isTransitGatewayConnection(connConfig) {
+				if err := networkManager.DeleteNetworkTransitGatewayAttachment(ctx, aws.StringValue(connConfig.TransitGatewayId)); err != nil {
+					msg := "failed to delete transit gateway attachment"
+					return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+				}
+			} else {
+				networkPeering, err := networkManager.GetClusterNetworkPeering(ctx)
+				if err != nil {
+					msg := "failed to get cluster network peering"
+					return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+				}
+
+				if err = networkManager.DeleteNetworkConnection(ctx, networkPeering); err != nil {
+					msg := "failed to delete network connection"
+					return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+				}
+
+				if err = networkManager.DeleteNetworkPeering(networkPeering); err != nil {
+					msg := "failed to delete cluster network peering"
+					return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+				}
+			}
 
-		if err = networkManager.DeleteNetwork(ctx); err != nil {
-			msg := "failed to delete aws networking"
-			return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+			if err := networkManager.DeleteNetwork(ctx); err != nil {
+				msg := "failed to delete aws networking"
+				return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+			}
 		}
 	}
 
@@ -561,6 +1546,14 @@ func (p *PostgresProvider) deleteRDSInstance(ctx context.Context, pg *v1alpha1.P
 			return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
 		}
 	}
+	return p.removePostgresObjects(ctx, pg)
+}
+
+// removePostgresObjects removes the operator owned kubernetes objects for the cr, the
+// credential secret, the per cr prometheus rules and the finalizer
+func (p *PostgresProvider) removePostgresObjects(ctx context.Context, pg *v1alpha1.Postgres) (croType.StatusMessage, error) {
+	logger := p.Logger.WithField("action", "removePostgresObjects")
+
 	// delete credential secret
 	logger.Info("deleting rds secret")
 	sec := &v1.Secret{
@@ -569,12 +1562,31 @@ func (p *PostgresProvider) deleteRDSInstance(ctx context.Context, pg *v1alpha1.P
 			Namespace: pg.Namespace,
 		},
 	}
-	err = p.Client.Delete(ctx, sec)
+	err := p.Client.Delete(ctx, sec)
 	if err != nil && !k8serr.IsNotFound(err) {
 		msg := "failed to deleted rds secrets"
 		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
 	}
 
+	// delete the replication credential secret published when the strategy for the tier
+	// enables logical replication
+	replicationSec := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      buildReplicationSecretName(pg),
+			Namespace: pg.Namespace,
+		},
+	}
+	if err := p.Client.Delete(ctx, replicationSec); err != nil && !k8serr.IsNotFound(err) {
+		msg := "failed to delete replication credential secret"
+		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+	}
+
+	// remove the per cr prometheus rules created for the instance
+	if err := resources.DeletePostgresAlerts(ctx, p.Client, pg); err != nil {
+		msg := "failed to delete postgres alerts"
+		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+	}
+
 	resources.RemoveFinalizer(&pg.ObjectMeta, DefaultFinalizer)
 	if err := p.Client.Update(ctx, pg); err != nil {
 		msg := "failed to update instance as part of finalizer reconcile"
@@ -607,6 +1619,13 @@ func (p *PostgresProvider) getRDSConfig(ctx context.Context, r *v1alpha1.Postgre
 		return nil, nil, nil, errorUtil.Wrap(err, "failed to read aws strategy config")
 	}
 
+	// apply any per-cr provider config overrides to the create strategy
+	if r.Spec.ProviderConfig != nil {
+		if err := stratCfg.ApplyProviderConfig(r.Spec.ProviderConfig); err != nil {
+			return nil, nil, nil, errorUtil.Wrap(err, "failed to apply provider config overrides")
+		}
+	}
+
 	defRegion, err := GetRegionFromStrategyOrDefault(ctx, p.Client, stratCfg)
 	if err != nil {
 		return nil, nil, nil, errorUtil.Wrap(err, "failed to get default region")
@@ -618,12 +1637,44 @@ func (p *PostgresProvider) getRDSConfig(ctx context.Context, r *v1alpha1.Postgre
 
 	rdsCreateConfig := &rds.CreateDBInstanceInput{}
 	if err := json.Unmarshal(stratCfg.CreateStrategy, rdsCreateConfig); err != nil {
-		return nil, nil, nil, errorUtil.Wrap(err, "failed to unmarshal aws rds cluster configuration")
+		return nil, nil, nil, resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, "failed to unmarshal aws rds cluster configuration")
+	}
+
+	// apply the tier level backup retention and point in time recovery configuration over the
+	// create strategy
+	if stratCfg.BackupRetentionDays != nil {
+		rdsCreateConfig.BackupRetentionPeriod = stratCfg.BackupRetentionDays
+	}
+	if stratCfg.PointInTimeRecovery != nil && !*stratCfg.PointInTimeRecovery {
+		rdsCreateConfig.BackupRetentionPeriod = aws.Int64(0)
+	}
+
+	// enable performance insights over the create strategy when configured on the tier
+	if stratCfg.PerformanceInsights != nil {
+		rdsCreateConfig.EnablePerformanceInsights = aws.Bool(true)
+		retention := int64(defaultPerformanceInsightsRetention)
+		if stratCfg.PerformanceInsights.RetentionPeriod != nil {
+			retention = *stratCfg.PerformanceInsights.RetentionPeriod
+		}
+		rdsCreateConfig.PerformanceInsightsRetentionPeriod = aws.Int64(retention)
+		if stratCfg.PerformanceInsights.KmsKeyID != "" {
+			rdsCreateConfig.PerformanceInsightsKMSKeyId = aws.String(stratCfg.PerformanceInsights.KmsKeyID)
+		}
+	}
+
+	// map the size on the cr to an instance class, the size takes precedence over the instance
+	// class in the create strategy so resizing does not require editing the strategy config map
+	if r.Spec.Size != "" {
+		instanceClass, err := stratCfg.ResolveSizeClass(r.Spec.Size, defaultPostgresSizeClasses)
+		if err != nil {
+			return nil, nil, nil, resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, "failed to resolve instance class from size")
+		}
+		rdsCreateConfig.DBInstanceClass = aws.String(instanceClass)
 	}
 
 	rdsDeleteConfig := &rds.DeleteDBInstanceInput{}
 	if err := json.Unmarshal(stratCfg.DeleteStrategy, rdsDeleteConfig); err != nil {
-		return nil, nil, nil, errorUtil.Wrap(err, "failed to unmarshal aws rds cluster configuration")
+		return nil, nil, nil, resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, "failed to unmarshal aws rds cluster configuration")
 	}
 	return rdsCreateConfig, rdsDeleteConfig, stratCfg, nil
 }
@@ -681,6 +1732,22 @@ func buildRDSUpdateStrategy(rdsConfig *rds.CreateDBInstanceInput, foundConfig *r
 		mi.MultiAZ = rdsConfig.MultiAZ
 		updateFound = true
 	}
+	if rdsConfig.StorageType != nil || rdsConfig.Iops != nil {
+		if err := validateRDSStorageConfig(rdsConfig); err != nil {
+			return nil, err
+		}
+	}
+	if rdsConfig.StorageType != nil && *rdsConfig.StorageType != aws.StringValue(foundConfig.StorageType) {
+		mi.StorageType = rdsConfig.StorageType
+		// a provisioned iops storage type cannot be applied without its iops value, carry it
+		// on the same modification
+		mi.Iops = rdsConfig.Iops
+		updateFound = true
+	}
+	if rdsConfig.Iops != nil && *rdsConfig.Iops != aws.Int64Value(foundConfig.Iops) {
+		mi.Iops = rdsConfig.Iops
+		updateFound = true
+	}
 	if rdsConfig.PreferredBackupWindow != nil && *rdsConfig.PreferredBackupWindow != *foundConfig.PreferredBackupWindow {
 		mi.PreferredBackupWindow = rdsConfig.PreferredBackupWindow
 		updateFound = true
@@ -699,12 +1766,115 @@ func buildRDSUpdateStrategy(rdsConfig *rds.CreateDBInstanceInput, foundConfig *r
 			updateFound = true
 		}
 	}
+	if rdsConfig.DBParameterGroupName != nil && len(foundConfig.DBParameterGroups) > 0 && *rdsConfig.DBParameterGroupName != *foundConfig.DBParameterGroups[0].DBParameterGroupName {
+		mi.DBParameterGroupName = rdsConfig.DBParameterGroupName
+		updateFound = true
+	}
+	if rdsConfig.EnablePerformanceInsights != nil && *rdsConfig.EnablePerformanceInsights != aws.BoolValue(foundConfig.PerformanceInsightsEnabled) {
+		mi.EnablePerformanceInsights = rdsConfig.EnablePerformanceInsights
+		mi.PerformanceInsightsRetentionPeriod = rdsConfig.PerformanceInsightsRetentionPeriod
+		mi.PerformanceInsightsKMSKeyId = rdsConfig.PerformanceInsightsKMSKeyId
+		updateFound = true
+	}
 	if !updateFound || !verifyPendingModification(mi, foundConfig.PendingModifiedValues) {
 		return nil, nil
 	}
 	return mi, nil
 }
 
+// storage types accepted by rds for postgres engines, provisioned iops types require an iops
+// value in the strategy and iops can only be set on storage types that support provisioning them
+var (
+	rdsStorageTypes                = map[string]bool{"standard": true, "gp2": true, "gp3": true, "io1": true, "io2": true}
+	rdsProvisionedIopsStorageTypes = map[string]bool{"gp3": true, "io1": true, "io2": true}
+)
+
+// validateRDSStorageConfig rejects storage type and iops combinations rds would refuse, so a
+// misconfigured strategy fails the reconcile with a clear message instead of an aws api error
+func validateRDSStorageConfig(rdsConfig *rds.CreateDBInstanceInput) error {
+	storageType := aws.StringValue(rdsConfig.StorageType)
+	if rdsConfig.StorageType != nil && !rdsStorageTypes[storageType] {
+		return errorUtil.New(fmt.Sprintf("unsupported storage type %s, expected one of standard, gp2, gp3, io1, io2", storageType))
+	}
+	if (storageType == "io1" || storageType == "io2") && rdsConfig.Iops == nil {
+		return errorUtil.New(fmt.Sprintf("storage type %s requires iops to be set in the strategy for the tier", storageType))
+	}
+	if rdsConfig.Iops != nil && rdsConfig.StorageType != nil && !rdsProvisionedIopsStorageTypes[storageType] {
+		return errorUtil.New(fmt.Sprintf("iops cannot be provisioned for storage type %s, expected one of gp3, io1, io2", storageType))
+	}
+	return nil
+}
+
+// buildRDSDriftItems builds a list of drifted fields from the proposed modifications to an rds instance,
+// used to expose drift between the expected strategy and the found instance
+func buildRDSDriftItems(mi *rds.ModifyDBInstanceInput, foundConfig *rds.DBInstance) []resources.DriftItem {
+	if mi == nil {
+		return nil
+	}
+	var items []resources.DriftItem
+	if mi.DeletionProtection != nil {
+		items = append(items, resources.DriftItem{Field: "deletionProtection", Expected: strconv.FormatBool(aws.BoolValue(mi.DeletionProtection)), Actual: strconv.FormatBool(aws.BoolValue(foundConfig.DeletionProtection))})
+	}
+	if mi.DBPortNumber != nil {
+		items = append(items, resources.DriftItem{Field: "port", Expected: strconv.FormatInt(aws.Int64Value(mi.DBPortNumber), 10), Actual: strconv.FormatInt(aws.Int64Value(foundConfig.Endpoint.Port), 10)})
+	}
+	if mi.BackupRetentionPeriod != nil {
+		items = append(items, resources.DriftItem{Field: "backupRetentionPeriod", Expected: strconv.FormatInt(aws.Int64Value(mi.BackupRetentionPeriod), 10), Actual: strconv.FormatInt(aws.Int64Value(foundConfig.BackupRetentionPeriod), 10)})
+	}
+	if mi.DBInstanceClass != nil {
+		items = append(items, resources.DriftItem{Field: "dbInstanceClass", Expected: aws.StringValue(mi.DBInstanceClass), Actual: aws.StringValue(foundConfig.DBInstanceClass)})
+	}
+	if mi.PubliclyAccessible != nil {
+		items = append(items, resources.DriftItem{Field: "publiclyAccessible", Expected: strconv.FormatBool(aws.BoolValue(mi.PubliclyAccessible)), Actual: strconv.FormatBool(aws.BoolValue(foundConfig.PubliclyAccessible))})
+	}
+	if mi.MaxAllocatedStorage != nil {
+		items = append(items, resources.DriftItem{Field: "maxAllocatedStorage", Expected: strconv.FormatInt(aws.Int64Value(mi.MaxAllocatedStorage), 10), Actual: strconv.FormatInt(aws.Int64Value(foundConfig.MaxAllocatedStorage), 10)})
+	}
+	if mi.MultiAZ != nil {
+		items = append(items, resources.DriftItem{Field: "multiAZ", Expected: strconv.FormatBool(aws.BoolValue(mi.MultiAZ)), Actual: strconv.FormatBool(aws.BoolValue(foundConfig.MultiAZ))})
+	}
+	if mi.StorageType != nil {
+		items = append(items, resources.DriftItem{Field: "storageType", Expected: aws.StringValue(mi.StorageType), Actual: aws.StringValue(foundConfig.StorageType)})
+	}
+	if mi.Iops != nil {
+		items = append(items, resources.DriftItem{Field: "iops", Expected: strconv.FormatInt(aws.Int64Value(mi.Iops), 10), Actual: strconv.FormatInt(aws.Int64Value(foundConfig.Iops), 10)})
+	}
+	if mi.EngineVersion != nil {
+		items = append(items, resources.DriftItem{Field: "engineVersion", Expected: aws.StringValue(mi.EngineVersion), Actual: aws.StringValue(foundConfig.EngineVersion)})
+	}
+	if mi.PreferredBackupWindow != nil {
+		items = append(items, resources.DriftItem{Field: "preferredBackupWindow", Expected: aws.StringValue(mi.PreferredBackupWindow), Actual: aws.StringValue(foundConfig.PreferredBackupWindow)})
+	}
+	if mi.PreferredMaintenanceWindow != nil {
+		items = append(items, resources.DriftItem{Field: "preferredMaintenanceWindow", Expected: aws.StringValue(mi.PreferredMaintenanceWindow), Actual: aws.StringValue(foundConfig.PreferredMaintenanceWindow)})
+	}
+	if mi.DBParameterGroupName != nil && len(foundConfig.DBParameterGroups) > 0 {
+		items = append(items, resources.DriftItem{Field: "dbParameterGroupName", Expected: aws.StringValue(mi.DBParameterGroupName), Actual: aws.StringValue(foundConfig.DBParameterGroups[0].DBParameterGroupName)})
+	}
+	if mi.EnablePerformanceInsights != nil {
+		items = append(items, resources.DriftItem{Field: "performanceInsights", Expected: strconv.FormatBool(aws.BoolValue(mi.EnablePerformanceInsights)), Actual: strconv.FormatBool(aws.BoolValue(foundConfig.PerformanceInsightsEnabled))})
+	}
+	return items
+}
+
+// expose the number of drifted fields on the postgres instance as a metric
+func (p *PostgresProvider) exposePostgresDriftMetric(ctx context.Context, cr *v1alpha1.Postgres, items []resources.DriftItem) {
+	// build instance name
+	instanceName, err := p.buildInstanceName(ctx, cr)
+	if err != nil {
+		logrus.Errorf("error occurred while building instance name during postgres drift metric: %v", err)
+	}
+
+	// get Cluster Id
+	clusterID, err := resources.GetClusterID(ctx, p.Client)
+	if err != nil {
+		logrus.Errorf("failed to get cluster id while exposing drift metric for %v", instanceName)
+		return
+	}
+
+	resources.SetResourceDriftMetric(buildPostgresGenericMetricLabels(cr, clusterID, instanceName), items)
+}
+
 // returns true if modify input is not pending
 func verifyPendingModification(mi *rds.ModifyDBInstanceInput, pm *rds.PendingModifiedValues) bool {
 	pendingModifications := true
@@ -736,9 +1906,76 @@ func verifyPendingModification(mi *rds.ModifyDBInstanceInput, pm *rds.PendingMod
 			pendingModifications = false
 		}
 	}
+	if mi.StorageType != nil && pm.StorageType != nil {
+		if *mi.StorageType == *pm.StorageType {
+			pendingModifications = false
+		}
+	}
+	if mi.Iops != nil && pm.Iops != nil {
+		if *mi.Iops == *pm.Iops {
+			pendingModifications = false
+		}
+	}
 	return pendingModifications
 }
 
+// buildPostgresPlan reports the instance configuration and network layout a create would use,
+// the values follow the same defaulting rules as buildRDSCreateStrategy so the plan matches
+// what a real reconcile would provision
+func (p *PostgresProvider) buildPostgresPlan(ctx context.Context, pg *v1alpha1.Postgres, rdsCreateConfig *rds.CreateDBInstanceInput, isEnabled bool) croType.StatusMessage {
+	instanceClass := defaultAwsDBInstanceClass
+	if rdsCreateConfig.DBInstanceClass != nil {
+		instanceClass = *rdsCreateConfig.DBInstanceClass
+	}
+	engineVersion := defaultAwsEngineVersion
+	if rdsCreateConfig.EngineVersion != nil {
+		engineVersion = *rdsCreateConfig.EngineVersion
+	}
+	allocatedStorage := int64(defaultAwsAllocatedStorage)
+	if rdsCreateConfig.AllocatedStorage != nil {
+		allocatedStorage = *rdsCreateConfig.AllocatedStorage
+	}
+	multiAZ := defaultAwsMultiAZ
+	if rdsCreateConfig.MultiAZ != nil {
+		multiAZ = *rdsCreateConfig.MultiAZ
+	}
+
+	// the abstract size acts as a cost class for reviewers, explicit instance classes that do
+	// not map back to an abstract size are reported as custom
+	costClass := "custom"
+	for size, class := range defaultPostgresSizeClasses {
+		if class == instanceClass {
+			costClass = size
+			break
+		}
+	}
+
+	networkLayout := "cluster vpc (bundled)"
+	if isEnabled {
+		networkLayout = "standalone vpc"
+		if vpcCidrBlock, err := getNetworkProviderConfig(ctx, p.ConfigManager, pg.Spec.Tier, p.Logger); err == nil {
+			networkLayout = fmt.Sprintf("standalone vpc %s", vpcCidrBlock.String())
+		}
+	}
+
+	return croType.StatusMessage(fmt.Sprintf("dry run: would create rds instance class %s (cost class %s), engine version %s, allocated storage %dGiB, multi az %t, network %s", instanceClass, costClass, engineVersion, allocatedStorage, multiAZ, networkLayout))
+}
+
+// validateRDSMasterPassword enforces the rds master password restrictions, 8 to 128
+// printable ascii characters excluding '/', '"', '@' and space. generated passwords comply by
+// construction, user supplied credential secrets can violate them
+func validateRDSMasterPassword(password string) error {
+	if len(password) < 8 || len(password) > 128 {
+		return errorUtil.Errorf("rds master passwords must be between 8 and 128 characters, got %d", len(password))
+	}
+	for _, char := range password {
+		if char < 33 || char > 126 || strings.ContainsRune("/\"@", char) {
+			return errorUtil.New("rds master passwords cannot contain '/', '\"', '@', spaces or non printable characters")
+		}
+	}
+	return nil
+}
+
 // verify postgres create config
 func (p *PostgresProvider) buildRDSCreateStrategy(ctx context.Context, pg *v1alpha1.Postgres, ec2Svc ec2iface.EC2API, rdsCreateConfig *rds.CreateDBInstanceInput, postgresPassword string) error {
 	if rdsCreateConfig.DeletionProtection == nil {
@@ -777,6 +2014,13 @@ func (p *PostgresProvider) buildRDSCreateStrategy(ctx context.Context, pg *v1alp
 	if rdsCreateConfig.StorageEncrypted == nil {
 		rdsCreateConfig.StorageEncrypted = aws.Bool(defaultStorageEncrypted)
 	}
+	// the storage type and iops are left unset unless configured in the strategy, rds then
+	// applies its own general purpose default
+	if rdsCreateConfig.StorageType != nil || rdsCreateConfig.Iops != nil {
+		if err := validateRDSStorageConfig(rdsCreateConfig); err != nil {
+			return err
+		}
+	}
 	if rdsCreateConfig.EngineVersion != nil {
 		if !resources.Contains(defaultSupportedEngineVersions, *rdsCreateConfig.EngineVersion) {
 			rdsCreateConfig.EngineVersion = aws.String(defaultAwsEngineVersion)
@@ -854,26 +2098,66 @@ func (p *PostgresProvider) buildRDSDeleteConfig(ctx context.Context, pg *v1alpha
 	return nil
 }
 
-func buildDefaultRDSSecret(ps *v1alpha1.Postgres) *v1.Secret {
+func buildDefaultRDSSecret(ps *v1alpha1.Postgres, rdsCreateConfig *rds.CreateDBInstanceInput) *v1.Secret {
 	password, err := resources.GeneratePassword()
 	if err != nil {
 		return nil
 	}
+	// the master username is overridable through the strategy config or the cr provider config,
+	// the credential secret must reflect the value the instance is created with
+	masterUser := defaultAwsPostgresUser
+	if rdsCreateConfig != nil && rdsCreateConfig.MasterUsername != nil {
+		masterUser = *rdsCreateConfig.MasterUsername
+	}
 	return &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      ps.Name + defaultCredSecSuffix,
 			Namespace: ps.Namespace,
 		},
 		StringData: map[string]string{
-			defaultPostgresUserKey:     defaultAwsPostgresUser,
+			defaultPostgresUserKey:     masterUser,
 			defaultPostgresPasswordKey: password,
 		},
 		Type: v1.SecretTypeOpaque,
 	}
 }
 
+// seedAdoptedCredentials copies the master credentials of an adopted rds instance from the
+// secret referenced by the adopt credentials annotation into the operator credential secret
+func (p *PostgresProvider) seedAdoptedCredentials(ctx context.Context, pg *v1alpha1.Postgres) error {
+	sourceName := pg.ObjectMeta.Annotations[AdoptCredentialsSecretAnnotation]
+	if sourceName == "" {
+		return errorUtil.Errorf("adopting an rds instance requires the %s annotation to reference a secret containing the master credentials", AdoptCredentialsSecretAnnotation)
+	}
+	source := &v1.Secret{}
+	if err := p.Client.Get(ctx, types.NamespacedName{Name: sourceName, Namespace: pg.Namespace}, source); err != nil {
+		return errorUtil.Wrapf(err, "failed to retrieve adopt credentials secret %s", sourceName)
+	}
+	if len(source.Data[defaultPostgresUserKey]) == 0 || len(source.Data[defaultPostgresPasswordKey]) == 0 {
+		return errorUtil.Errorf("adopt credentials secret %s must contain %s and %s keys", sourceName, defaultPostgresUserKey, defaultPostgresPasswordKey)
+	}
+	sec := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pg.Name + defaultCredSecSuffix,
+			Namespace: pg.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, p.Client, sec, func() error {
+		if sec.Data == nil {
+			sec.Data = map[string][]byte{}
+		}
+		sec.Data[defaultPostgresUserKey] = source.Data[defaultPostgresUserKey]
+		sec.Data[defaultPostgresPasswordKey] = source.Data[defaultPostgresPasswordKey]
+		return nil
+	})
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to reconcile credential secret for adopted rds instance")
+	}
+	return nil
+}
+
 // ensures a subnet group is in place to configure the resource to be in the same vpc as the cluster
-func (p *PostgresProvider) configureRDSVpc(ctx context.Context, rdsSvc rdsiface.RDSAPI, ec2Svc ec2iface.EC2API) error {
+func (p *PostgresProvider) configureRDSVpc(ctx context.Context, cr *v1alpha1.Postgres, rdsSvc rdsiface.RDSAPI, ec2Svc ec2iface.EC2API) error {
 	logger := p.Logger.WithField("action", "configureRDSVpc")
 	logger.Info("ensuring vpc is as expected for resource")
 	// get subnet group id
@@ -882,6 +2166,14 @@ func (p *PostgresProvider) configureRDSVpc(ctx context.Context, rdsSvc rdsiface.
 		return errorUtil.Wrap(err, "error building subnet group name")
 	}
 
+	defaultOrganizationTag := resources.GetOrganizationTag()
+
+	// get cluster id
+	clusterID, err := resources.GetClusterID(ctx, p.Client)
+	if err != nil {
+		return errorUtil.Wrap(err, "error getting cluster id")
+	}
+
 	// check if group exists
 	groups, err := rdsSvc.DescribeDBSubnetGroups(&rds.DescribeDBSubnetGroupsInput{})
 	if err != nil {
@@ -896,14 +2188,32 @@ func (p *PostgresProvider) configureRDSVpc(ctx context.Context, rdsSvc rdsiface.
 	}
 	if foundSubnet != nil {
 		logger.Infof("subnet group %s found", *foundSubnet.DBSubnetGroupName)
-		return nil
-	}
-	defaultOrganizationTag := resources.GetOrganizationTag()
-
-	// get cluster id
-	clusterID, err := resources.GetClusterID(ctx, p.Client)
-	if err != nil {
-		return errorUtil.Wrap(err, "error getting cluster id")
+		// refuse to manage a group created for a different cluster in the same account
+		groupTags, err := rdsSvc.ListTagsForResource(&rds.ListTagsForResourceInput{
+			ResourceName: foundSubnet.DBSubnetGroupArn,
+		})
+		if err != nil {
+			return errorUtil.Wrap(err, "error listing db subnet group tags")
+		}
+		owned, err := verifyClusterOwnership(rdsTagstoGeneric(groupTags.TagList), clusterID, fmt.Sprintf("db subnet group %s", aws.StringValue(foundSubnet.DBSubnetGroupName)))
+		if err != nil {
+			return err
+		}
+		if !owned {
+			logger.Infof("tagging db subnet group %s with cluster id %s", aws.StringValue(foundSubnet.DBSubnetGroupName), clusterID)
+			if _, err := rdsSvc.AddTagsToResource(&rds.AddTagsToResourceInput{
+				ResourceName: foundSubnet.DBSubnetGroupArn,
+				Tags: []*rds.Tag{
+					{
+						Key:   aws.String(defaultOrganizationTag + "clusterID"),
+						Value: aws.String(clusterID),
+					},
+				},
+			}); err != nil {
+				return errorUtil.Wrap(err, "unable to tag db subnet group")
+			}
+		}
+		return p.reconcileRDSSubnetGroupMembership(ctx, cr, rdsSvc, ec2Svc, foundSubnet, logger)
 	}
 
 	// get cluster vpc subnets
@@ -934,6 +2244,48 @@ func (p *PostgresProvider) configureRDSVpc(ctx context.Context, rdsSvc rdsiface.
 	return nil
 }
 
+// the cluster can gain availability zones or run subnets out of addresses after the subnet
+// group is first created, GetPrivateSubnetIDS creates any missing private subnets and the
+// group is extended to the current topology so new instances are not placed in a stale layout
+func (p *PostgresProvider) reconcileRDSSubnetGroupMembership(ctx context.Context, cr *v1alpha1.Postgres, rdsSvc rdsiface.RDSAPI, ec2Svc ec2iface.EC2API, foundSubnet *rds.DBSubnetGroup, logger *logrus.Entry) error {
+	subIDs, err := GetPrivateSubnetIDS(ctx, p.Client, ec2Svc, logger)
+	if err != nil {
+		return errorUtil.Wrap(err, "error getting vpc subnets")
+	}
+	var groupSubnetIds []*string
+	for _, sub := range foundSubnet.Subnets {
+		groupSubnetIds = append(groupSubnetIds, sub.SubnetIdentifier)
+	}
+	if subnetIdsMatch(groupSubnetIds, subIDs) {
+		cr.Status.Conditions = croType.SetCondition(cr.Status.Conditions, croType.StatusCondition{
+			Type:    ConditionTypeSubnetGroupStale,
+			Status:  "False",
+			Message: fmt.Sprintf("db subnet group %s matches the cluster subnet topology", aws.StringValue(foundSubnet.DBSubnetGroupName)),
+		})
+		return nil
+	}
+	logger.Infof("extending db subnet group %s to the current cluster subnet topology", aws.StringValue(foundSubnet.DBSubnetGroupName))
+	if _, err := rdsSvc.ModifyDBSubnetGroup(&rds.ModifyDBSubnetGroupInput{
+		DBSubnetGroupName: foundSubnet.DBSubnetGroupName,
+		SubnetIds:         subIDs,
+	}); err != nil {
+		// an incompatible topology, e.g. a subnet in an unsupported availability zone, is
+		// surfaced as a condition on the cr rather than an opaque create failure
+		cr.Status.Conditions = croType.SetCondition(cr.Status.Conditions, croType.StatusCondition{
+			Type:    ConditionTypeSubnetGroupStale,
+			Status:  "True",
+			Message: fmt.Sprintf("db subnet group %s could not be extended to the current subnet topology: %s", aws.StringValue(foundSubnet.DBSubnetGroupName), err),
+		})
+		return errorUtil.Wrap(err, "unable to modify db subnet group")
+	}
+	cr.Status.Conditions = croType.SetCondition(cr.Status.Conditions, croType.StatusCondition{
+		Type:    ConditionTypeSubnetGroupStale,
+		Status:  "False",
+		Message: fmt.Sprintf("db subnet group %s extended to the cluster subnet topology", aws.StringValue(foundSubnet.DBSubnetGroupName)),
+	})
+	return nil
+}
+
 func buildPostgresInfoMetricLabels(cr *v1alpha1.Postgres, instance *rds.DBInstance, clusterID, instanceName string) map[string]string {
 	labels := buildPostgresGenericMetricLabels(cr, clusterID, instanceName)
 	if instance != nil {
@@ -1012,6 +2364,26 @@ func (p *PostgresProvider) exposePostgresMetrics(ctx context.Context, cr *v1alph
 		// convert allocated storage to bytes and expose as a metric
 		resources.SetMetric(resources.DefaultPostgresAllocatedStorageMetricName, genericLabels, float64(*instance.AllocatedStorage*resources.BytesInGibiBytes))
 	}
+
+	// expose the storage autoscaling ceiling alongside the current allocation so dashboards
+	// can alert on how much autoscaling headroom remains
+	if instance != nil && instance.MaxAllocatedStorage != nil {
+		resources.SetMetric(resources.DefaultPostgresMaxAllocatedStorageMetricName, genericLabels, float64(*instance.MaxAllocatedStorage*resources.BytesInGibiBytes))
+	}
+
+	// estimate the monthly on demand cost of the instance for chargeback dashboards, instance
+	// classes with no published rate are skipped rather than reported with a misleading zero
+	if instance != nil && instance.DBInstanceClass != nil {
+		allocatedStorage := int64(0)
+		if instance.AllocatedStorage != nil {
+			allocatedStorage = *instance.AllocatedStorage
+		}
+		if cost, ok := resources.GetPricingTable(ctx, p.Client).EstimateMonthlyCost(*instance.DBInstanceClass, 1, allocatedStorage); ok {
+			costLabels := buildPostgresGenericMetricLabels(cr, clusterID, instanceName)
+			costLabels["instanceClass"] = *instance.DBInstanceClass
+			resources.SetMetric(resources.DefaultResourceEstimatedCostMetricName, costLabels, cost)
+		}
+	}
 }
 
 // set metrics about the postgres instance being deleted
@@ -1125,6 +2497,138 @@ func (p *PostgresProvider) createRDSConnectionMetric(ctx context.Context, cr *v1
 }
 
 // returns the name of the instance from build infra
+// reconcileDBParameterGroup maintains the dedicated db parameter group for the cr when the
+// strategy for the tier declares postgres parameters, parameter drift in the group is
+// reconciled on every pass
+func (p *PostgresProvider) reconcileDBParameterGroup(ctx context.Context, cr *v1alpha1.Postgres, rdsSvc rdsiface.RDSAPI, rdsCfg *rds.CreateDBInstanceInput, stratCfg *StrategyConfig) error {
+	desiredParameters := buildDesiredDBParameters(stratCfg)
+	if len(desiredParameters) == 0 {
+		return nil
+	}
+	logger := p.Logger.WithField("action", "reconcileDBParameterGroup")
+	instanceName, err := p.buildInstanceName(ctx, cr)
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to retrieve instance name for %s", cr.Name)
+	}
+	groupName := buildParameterGroupName(instanceName)
+	engineVersion := defaultAwsEngineVersion
+	if rdsCfg.EngineVersion != nil {
+		engineVersion = *rdsCfg.EngineVersion
+	}
+	_, err = rdsSvc.DescribeDBParameterGroups(&rds.DescribeDBParameterGroupsInput{
+		DBParameterGroupName: aws.String(groupName),
+	})
+	rdsErr, isAwsErr := err.(awserr.Error)
+	if err != nil {
+		if !isAwsErr || rdsErr.Code() != rds.ErrCodeDBParameterGroupNotFoundFault {
+			return errorUtil.Wrapf(err, "failed to describe db parameter group %s", groupName)
+		}
+		family := rdsParameterGroupFamily(engineVersion)
+		logger.Infof("creating db parameter group %s with family %s", groupName, family)
+		if _, err := rdsSvc.CreateDBParameterGroup(&rds.CreateDBParameterGroupInput{
+			DBParameterGroupName:   aws.String(groupName),
+			DBParameterGroupFamily: aws.String(family),
+			Description:            aws.String(fmt.Sprintf("parameter group for postgres cr %s in namespace %s", cr.Name, cr.Namespace)),
+		}); err != nil {
+			return errorUtil.Wrapf(err, "failed to create db parameter group %s", groupName)
+		}
+	}
+	describeParametersOutput, err := rdsSvc.DescribeDBParameters(&rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(groupName),
+	})
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to describe parameters of db parameter group %s", groupName)
+	}
+	parameterUpdates := buildDBParameterUpdates(desiredParameters, describeParametersOutput.Parameters)
+	if len(parameterUpdates) > 0 {
+		logger.Infof("updating %d parameters in db parameter group %s", len(parameterUpdates), groupName)
+		if _, err := rdsSvc.ModifyDBParameterGroup(&rds.ModifyDBParameterGroupInput{
+			DBParameterGroupName: aws.String(groupName),
+			Parameters:           parameterUpdates,
+		}); err != nil {
+			return errorUtil.Wrapf(err, "failed to modify db parameter group %s", groupName)
+		}
+	}
+	rdsCfg.DBParameterGroupName = aws.String(groupName)
+	return nil
+}
+
+// buildDesiredDBParameters merges the parameters implied by strategy level features with the
+// parameters declared on the strategy for the tier, declared parameters take precedence
+func buildDesiredDBParameters(stratCfg *StrategyConfig) map[string]string {
+	desired := map[string]string{}
+	// logical replication is a static parameter, rds applies it at the next reboot
+	if stratCfg.LogicalReplication {
+		desired["rds.logical_replication"] = "1"
+	}
+	for name, value := range stratCfg.Parameters {
+		desired[name] = value
+	}
+	return desired
+}
+
+// buildDBParameterUpdates returns the parameter changes needed to bring a db parameter group
+// in line with the parameters declared on the strategy for the tier
+func buildDBParameterUpdates(desired map[string]string, found []*rds.Parameter) []*rds.Parameter {
+	foundParameters := map[string]*rds.Parameter{}
+	for _, parameter := range found {
+		foundParameters[aws.StringValue(parameter.ParameterName)] = parameter
+	}
+	var updates []*rds.Parameter
+	for name, value := range desired {
+		foundParameter := foundParameters[name]
+		if foundParameter != nil && aws.StringValue(foundParameter.ParameterValue) == value {
+			continue
+		}
+		// static parameters can only be applied at reboot, dynamic parameters take effect
+		// immediately
+		applyMethod := "pending-reboot"
+		if foundParameter != nil && aws.StringValue(foundParameter.ApplyType) == "dynamic" {
+			applyMethod = "immediate"
+		}
+		updates = append(updates, &rds.Parameter{
+			ParameterName:  aws.String(name),
+			ParameterValue: aws.String(value),
+			ApplyMethod:    aws.String(applyMethod),
+		})
+	}
+	// map iteration order is random, sort so the applied updates are deterministic
+	sort.Slice(updates, func(i, j int) bool {
+		return aws.StringValue(updates[i].ParameterName) < aws.StringValue(updates[j].ParameterName)
+	})
+	return updates
+}
+
+// rdsParameterGroupFamily maps a postgres engine version to its parameter group family
+func rdsParameterGroupFamily(engineVersion string) string {
+	parts := strings.Split(engineVersion, ".")
+	// from postgres 10 the family only carries the major version
+	if major, err := strconv.Atoi(parts[0]); err == nil && major >= 10 {
+		return fmt.Sprintf("postgres%d", major)
+	}
+	if len(parts) >= 2 {
+		return fmt.Sprintf("postgres%s.%s", parts[0], parts[1])
+	}
+	return fmt.Sprintf("postgres%s", engineVersion)
+}
+
+// buildPendingRebootCondition builds the pending reboot status condition of an instance,
+// parameter group changes to static parameters stay pending until the next reboot
+func buildPendingRebootCondition(instance *rds.DBInstance) croType.StatusCondition {
+	condition := croType.StatusCondition{
+		Type:   postgresPendingRebootConditionType,
+		Status: "False",
+	}
+	for _, parameterGroupStatus := range instance.DBParameterGroups {
+		if aws.StringValue(parameterGroupStatus.ParameterApplyStatus) == "pending-reboot" {
+			condition.Status = "True"
+			condition.Message = fmt.Sprintf("parameter group %s has changes pending a reboot, they are applied in the next maintenance window or manual reboot", aws.StringValue(parameterGroupStatus.DBParameterGroupName))
+			break
+		}
+	}
+	return condition
+}
+
 func (p *PostgresProvider) buildInstanceName(ctx context.Context, pg *v1alpha1.Postgres) (string, error) {
 	instanceName, err := BuildInfraNameFromObject(ctx, p.Client, pg.ObjectMeta, DefaultAwsIdentifierLength)
 	if err != nil {
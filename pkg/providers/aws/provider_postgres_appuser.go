@@ -0,0 +1,129 @@
+package aws
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+
+	errorUtil "github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	defaultAppUserPrefix      = "app_"
+	defaultAppUserKey         = "appUser"
+	defaultAppUserPasswordKey = "appPassword"
+)
+
+// reconcileApplicationUser connects to the provisioned postgres with the admin credentials and
+// ensures a scoped application user and database exist with limited grants, the application
+// credentials are returned so they can be published to applications while the admin credentials
+// remain only in the operator credential secret
+func (p *PostgresProvider) reconcileApplicationUser(ctx context.Context, cr *v1alpha1.Postgres, adminDetails *providers.PostgresDeploymentDetails) (*providers.PostgresDeploymentDetails, error) {
+	logger := p.Logger.WithField("action", "reconcileApplicationUser")
+	appUser := buildApplicationUserName(cr)
+	logger.Infof("reconciling application user %s for postgres %s", appUser, cr.Name)
+
+	// get or create the application user password, stored in the operator credential secret
+	appPassword, err := p.reconcileApplicationUserPassword(ctx, cr, appUser)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to reconcile application user password")
+	}
+
+	// connect to the admin database with the admin credentials
+	db, err := sql.Open("postgres", fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=require", adminDetails.Host, adminDetails.Port, adminDetails.Username, adminDetails.Password, adminDetails.Database))
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to open connection to postgres instance")
+	}
+	defer db.Close()
+
+	// ensure the application user exists with the expected password and no elevated attributes
+	var userExists bool
+	if err := db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pg_roles WHERE rolname = $1)", appUser).Scan(&userExists); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to check for existing application user")
+	}
+	if !userExists {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE ROLE %s LOGIN NOSUPERUSER NOCREATEDB NOCREATEROLE PASSWORD %s", pq.QuoteIdentifier(appUser), pq.QuoteLiteral(appPassword))); err != nil {
+			return nil, errorUtil.Wrap(err, "failed to create application user")
+		}
+	} else {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("ALTER ROLE %s LOGIN NOSUPERUSER NOCREATEDB NOCREATEROLE PASSWORD %s", pq.QuoteIdentifier(appUser), pq.QuoteLiteral(appPassword))); err != nil {
+			return nil, errorUtil.Wrap(err, "failed to update application user")
+		}
+	}
+
+	// ensure the application database exists and is owned by the application user
+	var databaseExists bool
+	if err := db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pg_database WHERE datname = $1)", appUser).Scan(&databaseExists); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to check for existing application database")
+	}
+	if !databaseExists {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE %s OWNER %s", pq.QuoteIdentifier(appUser), pq.QuoteIdentifier(appUser))); err != nil {
+			return nil, errorUtil.Wrap(err, "failed to create application database")
+		}
+	}
+
+	// limit the grants on the application database to the application user
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("REVOKE ALL ON DATABASE %s FROM PUBLIC", pq.QuoteIdentifier(appUser))); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to revoke public grants on application database")
+	}
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("GRANT CONNECT, TEMPORARY ON DATABASE %s TO %s", pq.QuoteIdentifier(appUser), pq.QuoteIdentifier(appUser))); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to grant application user access to application database")
+	}
+
+	return &providers.PostgresDeploymentDetails{
+		Username: appUser,
+		Password: appPassword,
+		Host:     adminDetails.Host,
+		Database: appUser,
+		Port:     adminDetails.Port,
+	}, nil
+}
+
+// reconcileApplicationUserPassword returns the application user password from the operator
+// credential secret, generating and persisting one if it does not already exist
+func (p *PostgresProvider) reconcileApplicationUserPassword(ctx context.Context, cr *v1alpha1.Postgres, appUser string) (string, error) {
+	credSec := &v1.Secret{}
+	if err := p.Client.Get(ctx, types.NamespacedName{Name: cr.Name + defaultCredSecSuffix, Namespace: cr.Namespace}, credSec); err != nil {
+		return "", errorUtil.Wrap(err, "failed to retrieve rds credential secret")
+	}
+	appPassword := string(credSec.Data[defaultAppUserPasswordKey])
+	if appPassword != "" {
+		return appPassword, nil
+	}
+	appPassword, err := resources.GeneratePassword()
+	if err != nil {
+		return "", errorUtil.Wrap(err, "failed to generate application user password")
+	}
+	sec := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + defaultCredSecSuffix,
+			Namespace: cr.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, p.Client, sec, func() error {
+		if sec.Data == nil {
+			sec.Data = map[string][]byte{}
+		}
+		sec.Data[defaultAppUserKey] = []byte(appUser)
+		sec.Data[defaultAppUserPasswordKey] = []byte(appPassword)
+		return nil
+	}); err != nil {
+		return "", errorUtil.Wrap(err, "failed to update credential secret with application user credentials")
+	}
+	return appPassword, nil
+}
+
+// buildApplicationUserName builds a valid postgres role name from the cr name
+func buildApplicationUserName(cr *v1alpha1.Postgres) string {
+	return defaultAppUserPrefix + buildSQLIdentifier(cr.Name)
+}
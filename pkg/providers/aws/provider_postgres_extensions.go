@@ -0,0 +1,72 @@
+package aws
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/lib/pq"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+
+	errorUtil "github.com/pkg/errors"
+)
+
+// reconcilePostgresExtensions connects to the provisioned postgres with the admin
+// credentials and ensures each extension requested on the cr exists in the served database,
+// requests missing from the engine's allowed extension list are skipped and surfaced
+// through the UnsupportedExtensions status condition
+func (p *PostgresProvider) reconcilePostgresExtensions(ctx context.Context, cr *v1alpha1.Postgres, adminDetails *providers.PostgresDeploymentDetails, databaseName string) error {
+	if len(cr.Spec.Extensions) == 0 {
+		return nil
+	}
+	logger := p.Logger.WithField("action", "reconcilePostgresExtensions")
+	logger.Infof("reconciling %d extensions for postgres %s", len(cr.Spec.Extensions), cr.Name)
+
+	// connect to the served database with the admin credentials, extensions are database
+	// scoped so they are created where applications will use them
+	db, err := sql.Open("postgres", fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=require", adminDetails.Host, adminDetails.Port, adminDetails.Username, adminDetails.Password, databaseName))
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to open connection to postgres instance")
+	}
+	defer db.Close()
+
+	// the engine reports the extensions it allows, on rds this is the supported extension
+	// list of the engine version
+	rows, err := db.QueryContext(ctx, "SELECT name FROM pg_available_extensions")
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to list available extensions")
+	}
+	defer rows.Close()
+	available := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return errorUtil.Wrap(err, "failed to scan available extension")
+		}
+		available[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return errorUtil.Wrap(err, "failed to list available extensions")
+	}
+
+	var unsupported []string
+	for _, extension := range cr.Spec.Extensions {
+		if !available[extension] {
+			logger.Infof("extension %s is not supported by the engine, skipping", extension)
+			unsupported = append(unsupported, extension)
+			continue
+		}
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE EXTENSION IF NOT EXISTS %s", pq.QuoteIdentifier(extension))); err != nil {
+			return errorUtil.Wrapf(err, "failed to create extension %s", extension)
+		}
+	}
+	// sort so the condition message is deterministic across reconciles
+	sort.Strings(unsupported)
+	cr.Status.Conditions = croType.SetCondition(cr.Status.Conditions, resources.BuildUnsupportedExtensionsCondition(unsupported))
+	return nil
+}
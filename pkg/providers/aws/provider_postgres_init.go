@@ -0,0 +1,92 @@
+package aws
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/annotations"
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+
+	errorUtil "github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const defaultInitSQLKey = "init.sql"
+
+// reconcileInitSQL applies the bootstrap sql referenced on the cr to the served database
+// exactly once after first provisioning, the checksum of the applied sql is recorded in an
+// annotation so the script is never re-run, changed sql after application is logged and
+// ignored rather than applied to a database that may hold data
+func (p *PostgresProvider) reconcileInitSQL(ctx context.Context, cr *v1alpha1.Postgres, adminDetails *providers.PostgresDeploymentDetails, databaseName string) error {
+	if cr.Spec.InitSQLRef == nil {
+		return nil
+	}
+	logger := p.Logger.WithField("action", "reconcileInitSQL")
+
+	initSQL, err := p.getInitSQL(ctx, cr)
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to read init sql")
+	}
+	checksum := fmt.Sprintf("%x", sha256.Sum256([]byte(initSQL)))
+
+	if annotations.Has(cr, InitSQLChecksumAnnotation) {
+		appliedChecksum := cr.GetAnnotations()[InitSQLChecksumAnnotation]
+		if appliedChecksum != checksum {
+			logger.Warnf("init sql for postgres %s changed after it was applied, the new sql is not re-run", cr.Name)
+		}
+		return nil
+	}
+
+	logger.Infof("applying init sql to postgres %s", cr.Name)
+	db, err := sql.Open("postgres", fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=require", adminDetails.Host, adminDetails.Port, adminDetails.Username, adminDetails.Password, databaseName))
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to open connection to postgres instance")
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, initSQL); err != nil {
+		return errorUtil.Wrap(err, "failed to apply init sql")
+	}
+
+	// record the applied checksum so the script is not re-run on later reconciles
+	annotations.Add(cr, InitSQLChecksumAnnotation, checksum)
+	if err := p.Client.Update(ctx, cr); err != nil {
+		return errorUtil.Wrap(err, "failed to record init sql checksum")
+	}
+	return nil
+}
+
+// getInitSQL reads the bootstrap sql from the config map or secret referenced on the cr
+func (p *PostgresProvider) getInitSQL(ctx context.Context, cr *v1alpha1.Postgres) (string, error) {
+	ref := cr.Spec.InitSQLRef
+	if (ref.ConfigMap == "") == (ref.Secret == "") {
+		return "", errorUtil.New("exactly one of configMap or secret must be set on initSQLRef")
+	}
+	key := ref.Key
+	if key == "" {
+		key = defaultInitSQLKey
+	}
+	if ref.ConfigMap != "" {
+		cm := &v1.ConfigMap{}
+		if err := p.Client.Get(ctx, types.NamespacedName{Name: ref.ConfigMap, Namespace: cr.Namespace}, cm); err != nil {
+			return "", errorUtil.Wrapf(err, "failed to get init sql config map %s", ref.ConfigMap)
+		}
+		initSQL, ok := cm.Data[key]
+		if !ok {
+			return "", errorUtil.New(fmt.Sprintf("key %s not found in init sql config map %s", key, ref.ConfigMap))
+		}
+		return initSQL, nil
+	}
+	sec := &v1.Secret{}
+	if err := p.Client.Get(ctx, types.NamespacedName{Name: ref.Secret, Namespace: cr.Namespace}, sec); err != nil {
+		return "", errorUtil.Wrapf(err, "failed to get init sql secret %s", ref.Secret)
+	}
+	initSQL, ok := sec.Data[key]
+	if !ok {
+		return "", errorUtil.New(fmt.Sprintf("key %s not found in init sql secret %s", key, ref.Secret))
+	}
+	return string(initSQL), nil
+}
@@ -76,7 +76,7 @@ func (p PostgresMetricsProvider) ScrapePostgresMetrics(ctx context.Context, post
 	}
 
 	// create a session from postgres strategy (region) and reconciled aws keys
-	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds.AccessKeyID, providerCreds.SecretAccessKey, postgresStrategyConfig)
+	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds, postgresStrategyConfig)
 	if err != nil {
 		return nil, errorUtil.Wrap(err, "failed to create aws session to scrape rds cloud watch metrics")
 	}
@@ -0,0 +1,139 @@
+package aws
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/lib/pq"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+
+	errorUtil "github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	defaultReplicationUserPrefix      = "repl_"
+	defaultReplicationUserKey         = "replicationUser"
+	defaultReplicationUserPasswordKey = "replicationPassword"
+	defaultReplicationSecSuffix       = "-replication"
+)
+
+// reconcileReplicationUser connects to the provisioned postgres with the admin credentials
+// and ensures a role with the replication attribute exists, publishing its credentials in a
+// secondary secret next to the connection secret so change data capture tooling can attach
+// to the database, publications and table level grants are left to the cdc tooling
+func (p *PostgresProvider) reconcileReplicationUser(ctx context.Context, cr *v1alpha1.Postgres, adminDetails *providers.PostgresDeploymentDetails) error {
+	logger := p.Logger.WithField("action", "reconcileReplicationUser")
+	replicationUser := buildReplicationUserName(cr)
+	logger.Infof("reconciling replication user %s for postgres %s", replicationUser, cr.Name)
+
+	// get or create the replication user password, stored in the operator credential secret
+	replicationPassword, err := p.reconcileReplicationUserPassword(ctx, cr, replicationUser)
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to reconcile replication user password")
+	}
+
+	// connect to the admin database with the admin credentials
+	db, err := sql.Open("postgres", fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=require", adminDetails.Host, adminDetails.Port, adminDetails.Username, adminDetails.Password, adminDetails.Database))
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to open connection to postgres instance")
+	}
+	defer db.Close()
+
+	// ensure the replication user exists with the expected password and no elevated
+	// attributes beyond replication, on rds the role additionally needs the rds_replication
+	// grant to consume logical replication slots
+	var userExists bool
+	if err := db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pg_roles WHERE rolname = $1)", replicationUser).Scan(&userExists); err != nil {
+		return errorUtil.Wrap(err, "failed to check for existing replication user")
+	}
+	if !userExists {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE ROLE %s REPLICATION LOGIN NOSUPERUSER NOCREATEDB NOCREATEROLE PASSWORD %s", pq.QuoteIdentifier(replicationUser), pq.QuoteLiteral(replicationPassword))); err != nil {
+			return errorUtil.Wrap(err, "failed to create replication user")
+		}
+	} else {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("ALTER ROLE %s REPLICATION LOGIN NOSUPERUSER NOCREATEDB NOCREATEROLE PASSWORD %s", pq.QuoteIdentifier(replicationUser), pq.QuoteLiteral(replicationPassword))); err != nil {
+			return errorUtil.Wrap(err, "failed to update replication user")
+		}
+	}
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("GRANT rds_replication TO %s", pq.QuoteIdentifier(replicationUser))); err != nil {
+		return errorUtil.Wrap(err, "failed to grant rds_replication to replication user")
+	}
+
+	// publish the replication credentials in a secondary secret next to the connection secret
+	sec := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      buildReplicationSecretName(cr),
+			Namespace: cr.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, p.Client, sec, func() error {
+		sec.Data = map[string][]byte{
+			"username": []byte(replicationUser),
+			"password": []byte(replicationPassword),
+			"host":     []byte(adminDetails.Host),
+			"database": []byte(adminDetails.Database),
+			"port":     []byte(strconv.Itoa(adminDetails.Port)),
+		}
+		return nil
+	}); err != nil {
+		return errorUtil.Wrap(err, "failed to reconcile replication credential secret")
+	}
+	return nil
+}
+
+// reconcileReplicationUserPassword returns the replication user password from the operator
+// credential secret, generating and persisting one if it does not already exist
+func (p *PostgresProvider) reconcileReplicationUserPassword(ctx context.Context, cr *v1alpha1.Postgres, replicationUser string) (string, error) {
+	credSec := &v1.Secret{}
+	if err := p.Client.Get(ctx, types.NamespacedName{Name: cr.Name + defaultCredSecSuffix, Namespace: cr.Namespace}, credSec); err != nil {
+		return "", errorUtil.Wrap(err, "failed to retrieve rds credential secret")
+	}
+	replicationPassword := string(credSec.Data[defaultReplicationUserPasswordKey])
+	if replicationPassword != "" {
+		return replicationPassword, nil
+	}
+	replicationPassword, err := resources.GeneratePassword()
+	if err != nil {
+		return "", errorUtil.Wrap(err, "failed to generate replication user password")
+	}
+	sec := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + defaultCredSecSuffix,
+			Namespace: cr.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, p.Client, sec, func() error {
+		if sec.Data == nil {
+			sec.Data = map[string][]byte{}
+		}
+		sec.Data[defaultReplicationUserKey] = []byte(replicationUser)
+		sec.Data[defaultReplicationUserPasswordKey] = []byte(replicationPassword)
+		return nil
+	}); err != nil {
+		return "", errorUtil.Wrap(err, "failed to update credential secret with replication user credentials")
+	}
+	return replicationPassword, nil
+}
+
+// buildReplicationUserName builds a valid postgres role name from the cr name
+func buildReplicationUserName(cr *v1alpha1.Postgres) string {
+	return defaultReplicationUserPrefix + buildSQLIdentifier(cr.Name)
+}
+
+// buildReplicationSecretName builds the name of the secondary secret holding the replication
+// credentials, derived from the connection secret named on the cr
+func buildReplicationSecretName(cr *v1alpha1.Postgres) string {
+	if cr.Spec.SecretRef != nil && cr.Spec.SecretRef.Name != "" {
+		return cr.Spec.SecretRef.Name + defaultReplicationSecSuffix
+	}
+	return cr.Name + defaultReplicationSecSuffix
+}
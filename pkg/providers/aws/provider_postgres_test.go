@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
 	"reflect"
 	"sync"
 	"testing"
@@ -22,6 +23,7 @@ import (
 	croApis "github.com/integr8ly/cloud-resource-operator/pkg/apis"
 	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
 	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
 	cloudCredentialApis "github.com/openshift/cloud-credential-operator/pkg/apis"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
@@ -55,10 +57,14 @@ type mockRdsClient struct {
 	subnetGroups  []*rds.DBSubnetGroup
 	// new approach for manually defined mocks
 	// to allow for simple overrides in test table declarations
-	modifyDBSubnetGroupFn    func(*rds.ModifyDBSubnetGroupInput) (*rds.ModifyDBSubnetGroupOutput, error)
-	listTagsForResourceFn    func(*rds.ListTagsForResourceInput) (*rds.ListTagsForResourceOutput, error)
-	removeTagsFromResourceFn func(*rds.RemoveTagsFromResourceInput) (*rds.RemoveTagsFromResourceOutput, error)
-	deleteDBSubnetGroupFn    func(*rds.DeleteDBSubnetGroupInput) (*rds.DeleteDBSubnetGroupOutput, error)
+	modifyDBSubnetGroupFn               func(*rds.ModifyDBSubnetGroupInput) (*rds.ModifyDBSubnetGroupOutput, error)
+	listTagsForResourceFn               func(*rds.ListTagsForResourceInput) (*rds.ListTagsForResourceOutput, error)
+	removeTagsFromResourceFn            func(*rds.RemoveTagsFromResourceInput) (*rds.RemoveTagsFromResourceOutput, error)
+	deleteDBSubnetGroupFn               func(*rds.DeleteDBSubnetGroupInput) (*rds.DeleteDBSubnetGroupOutput, error)
+	describePendingMaintenanceActionsFn func(*rds.DescribePendingMaintenanceActionsInput) (*rds.DescribePendingMaintenanceActionsOutput, error)
+	applyPendingMaintenanceActionFn     func(*rds.ApplyPendingMaintenanceActionInput) (*rds.ApplyPendingMaintenanceActionOutput, error)
+	stopDBInstanceFn                    func(*rds.StopDBInstanceInput) (*rds.StopDBInstanceOutput, error)
+	startDBInstanceFn                   func(*rds.StartDBInstanceInput) (*rds.StartDBInstanceOutput, error)
 }
 
 type mockEc2Client struct {
@@ -171,6 +177,10 @@ func (m *mockRdsClient) DeleteDBInstance(*rds.DeleteDBInstanceInput) (*rds.Delet
 	return &rds.DeleteDBInstanceOutput{}, nil
 }
 
+func (m *mockRdsClient) DeleteDBParameterGroup(*rds.DeleteDBParameterGroupInput) (*rds.DeleteDBParameterGroupOutput, error) {
+	return &rds.DeleteDBParameterGroupOutput{}, nil
+}
+
 func (m *mockRdsClient) AddTagsToResource(input *rds.AddTagsToResourceInput) (*rds.AddTagsToResourceOutput, error) {
 	return &rds.AddTagsToResourceOutput{}, nil
 }
@@ -179,10 +189,38 @@ func (m *mockRdsClient) DescribeDBSnapshots(input *rds.DescribeDBSnapshotsInput)
 	return &rds.DescribeDBSnapshotsOutput{}, nil
 }
 
-func (m *mockRdsClient) DescribePendingMaintenanceActions(*rds.DescribePendingMaintenanceActionsInput) (*rds.DescribePendingMaintenanceActionsOutput, error) {
+func (m *mockRdsClient) DescribeDBInstanceAutomatedBackups(*rds.DescribeDBInstanceAutomatedBackupsInput) (*rds.DescribeDBInstanceAutomatedBackupsOutput, error) {
+	return &rds.DescribeDBInstanceAutomatedBackupsOutput{}, nil
+}
+
+func (m *mockRdsClient) DescribePendingMaintenanceActions(input *rds.DescribePendingMaintenanceActionsInput) (*rds.DescribePendingMaintenanceActionsOutput, error) {
+	if m.describePendingMaintenanceActionsFn != nil {
+		return m.describePendingMaintenanceActionsFn(input)
+	}
 	return &rds.DescribePendingMaintenanceActionsOutput{}, nil
 }
 
+func (m *mockRdsClient) ApplyPendingMaintenanceAction(input *rds.ApplyPendingMaintenanceActionInput) (*rds.ApplyPendingMaintenanceActionOutput, error) {
+	if m.applyPendingMaintenanceActionFn == nil {
+		panic("applyPendingMaintenanceActionFn: method is nil but rdsClient.ApplyPendingMaintenanceAction was just called")
+	}
+	return m.applyPendingMaintenanceActionFn(input)
+}
+
+func (m *mockRdsClient) StopDBInstance(input *rds.StopDBInstanceInput) (*rds.StopDBInstanceOutput, error) {
+	if m.stopDBInstanceFn == nil {
+		panic("stopDBInstanceFn: method is nil but rdsClient.StopDBInstance was just called")
+	}
+	return m.stopDBInstanceFn(input)
+}
+
+func (m *mockRdsClient) StartDBInstance(input *rds.StartDBInstanceInput) (*rds.StartDBInstanceOutput, error) {
+	if m.startDBInstanceFn == nil {
+		panic("startDBInstanceFn: method is nil but rdsClient.StartDBInstance was just called")
+	}
+	return m.startDBInstanceFn(input)
+}
+
 func (m *mockRdsClient) DescribeDBSubnetGroups(*rds.DescribeDBSubnetGroupsInput) (*rds.DescribeDBSubnetGroupsOutput, error) {
 	return &rds.DescribeDBSubnetGroupsOutput{
 		DBSubnetGroups: m.subnetGroups,
@@ -345,6 +383,10 @@ func (m *mockEc2Client) AuthorizeSecurityGroupIngress(*ec2.AuthorizeSecurityGrou
 	return &ec2.AuthorizeSecurityGroupIngressOutput{}, nil
 }
 
+func (m *mockEc2Client) RevokeSecurityGroupIngress(*ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	return &ec2.RevokeSecurityGroupIngressOutput{}, nil
+}
+
 func (m *mockEc2Client) DescribeAvailabilityZones(*ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
 	return &ec2.DescribeAvailabilityZonesOutput{
 		AvailabilityZones: m.azs,
@@ -360,6 +402,9 @@ func (m *mockEc2Client) CreateVpcPeeringConnection(input *ec2.CreateVpcPeeringCo
 }
 
 func (m *mockEc2Client) CreateTags(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	if m.createTagsFn == nil {
+		return &ec2.CreateTagsOutput{}, nil
+	}
 	return m.createTagsFn(input)
 }
 
@@ -435,7 +480,7 @@ func builtTestCredSecret() *v1.Secret {
 		},
 		Data: map[string][]byte{
 			"user":     []byte("postgres"),
-			"password": []byte("test"),
+			"password": []byte("testPass1234"),
 		},
 	}
 }
@@ -730,7 +775,7 @@ func TestAWSPostgresProvider_createPostgresInstance(t *testing.T) {
 			},
 			want: &providers.PostgresInstance{DeploymentDetails: &providers.PostgresDeploymentDetails{
 				Username: defaultAwsPostgresUser,
-				Password: "test",
+				Password: "testPass1234",
 				Host:     "blob",
 				Database: defaultAwsEngine,
 				Port:     defaultAwsPostgresPort,
@@ -860,7 +905,7 @@ func TestAWSPostgresProvider_createPostgresInstance(t *testing.T) {
 			},
 			want: &providers.PostgresInstance{DeploymentDetails: &providers.PostgresDeploymentDetails{
 				Username: defaultAwsPostgresUser,
-				Password: "test",
+				Password: "testPass1234",
 				Host:     "blob",
 				Database: defaultAwsEngine,
 				Port:     defaultAwsPostgresPort,
@@ -1011,7 +1056,13 @@ func TestAWSPostgresProvider_deletePostgresInstance(t *testing.T) {
 				Client:            fake.NewFakeClientWithScheme(scheme, buildTestPostgresCR(), buildTestInfra(), buildTestPostgresqlPrometheusRule()),
 				Logger:            testLogger,
 				CredentialManager: &CredentialManagerMock{},
-				ConfigManager:     &ConfigManagerMock{},
+				ConfigManager: buildTestConfigManager(func(m *ConfigManagerMock) {
+					m.ReadStorageStrategyFunc = func(ctx context.Context, rt providers.ResourceType, tier string) (*StrategyConfig, error) {
+						return &StrategyConfig{
+							CreateStrategy: json.RawMessage("{}"),
+						}, nil
+					}
+				}),
 			},
 			want:    croType.StatusMessage(""),
 			wantErr: false,
@@ -1399,6 +1450,86 @@ func Test_buildRDSUpdateStrategy(t *testing.T) {
 			want:    nil,
 			wantErr: "invalid postgres version: failed to parse current version: Malformed version: broken version num",
 		},
+		{
+			name: "test modification required when storage type differs",
+			args: args{
+				rdsConfig: &rds.CreateDBInstanceInput{
+					DeletionProtection:         aws.Bool(true),
+					BackupRetentionPeriod:      aws.Int64(1),
+					DBInstanceClass:            aws.String("test"),
+					PubliclyAccessible:         aws.Bool(true),
+					AllocatedStorage:           aws.Int64(1),
+					MaxAllocatedStorage:        aws.Int64(1),
+					EngineVersion:              aws.String("10.1"),
+					MultiAZ:                    aws.Bool(true),
+					PreferredBackupWindow:      aws.String("test"),
+					PreferredMaintenanceWindow: aws.String("test"),
+					Port:                       aws.Int64(1),
+					StorageType:                aws.String("io1"),
+					Iops:                       aws.Int64(1000),
+				},
+				foundConfig: &rds.DBInstance{
+					DeletionProtection:         aws.Bool(true),
+					BackupRetentionPeriod:      aws.Int64(1),
+					DBInstanceClass:            aws.String("test"),
+					PubliclyAccessible:         aws.Bool(true),
+					AllocatedStorage:           aws.Int64(1),
+					MaxAllocatedStorage:        aws.Int64(1),
+					EngineVersion:              aws.String("10.1"),
+					MultiAZ:                    aws.Bool(true),
+					PreferredBackupWindow:      aws.String("test"),
+					PreferredMaintenanceWindow: aws.String("test"),
+					Endpoint: &rds.Endpoint{
+						Port: aws.Int64(1),
+					},
+					StorageType:          aws.String("gp2"),
+					DBInstanceIdentifier: aws.String("test"),
+				},
+			},
+			want: &rds.ModifyDBInstanceInput{
+				StorageType:          aws.String("io1"),
+				Iops:                 aws.Int64(1000),
+				DBInstanceIdentifier: aws.String("test"),
+			},
+		},
+		{
+			name: "test error when provisioned iops storage type has no iops",
+			args: args{
+				rdsConfig: &rds.CreateDBInstanceInput{
+					DeletionProtection:         aws.Bool(true),
+					BackupRetentionPeriod:      aws.Int64(1),
+					DBInstanceClass:            aws.String("test"),
+					PubliclyAccessible:         aws.Bool(true),
+					AllocatedStorage:           aws.Int64(1),
+					MaxAllocatedStorage:        aws.Int64(1),
+					EngineVersion:              aws.String("10.1"),
+					MultiAZ:                    aws.Bool(true),
+					PreferredBackupWindow:      aws.String("test"),
+					PreferredMaintenanceWindow: aws.String("test"),
+					Port:                       aws.Int64(1),
+					StorageType:                aws.String("io1"),
+				},
+				foundConfig: &rds.DBInstance{
+					DeletionProtection:         aws.Bool(true),
+					BackupRetentionPeriod:      aws.Int64(1),
+					DBInstanceClass:            aws.String("test"),
+					PubliclyAccessible:         aws.Bool(true),
+					AllocatedStorage:           aws.Int64(1),
+					MaxAllocatedStorage:        aws.Int64(1),
+					EngineVersion:              aws.String("10.1"),
+					MultiAZ:                    aws.Bool(true),
+					PreferredBackupWindow:      aws.String("test"),
+					PreferredMaintenanceWindow: aws.String("test"),
+					Endpoint: &rds.Endpoint{
+						Port: aws.Int64(1),
+					},
+					StorageType:          aws.String("gp2"),
+					DBInstanceIdentifier: aws.String("test"),
+				},
+			},
+			want:    nil,
+			wantErr: "storage type io1 requires iops to be set in the strategy for the tier",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1418,3 +1549,478 @@ func Test_buildRDSUpdateStrategy(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildDBParameterUpdates(t *testing.T) {
+	cases := []struct {
+		name            string
+		desired         map[string]string
+		found           []*rds.Parameter
+		expectedUpdates []*rds.Parameter
+	}{
+		{
+			name: "test parameters already in sync produce no updates",
+			desired: map[string]string{
+				"log_min_duration_statement": "1000",
+			},
+			found: []*rds.Parameter{
+				{
+					ParameterName:  aws.String("log_min_duration_statement"),
+					ParameterValue: aws.String("1000"),
+					ApplyType:      aws.String("dynamic"),
+				},
+			},
+			expectedUpdates: nil,
+		},
+		{
+			name: "test dynamic parameters apply immediately and static parameters pend a reboot",
+			desired: map[string]string{
+				"log_min_duration_statement": "1000",
+				"max_connections":            "500",
+			},
+			found: []*rds.Parameter{
+				{
+					ParameterName:  aws.String("log_min_duration_statement"),
+					ParameterValue: aws.String("-1"),
+					ApplyType:      aws.String("dynamic"),
+				},
+				{
+					ParameterName:  aws.String("max_connections"),
+					ParameterValue: aws.String("100"),
+					ApplyType:      aws.String("static"),
+				},
+			},
+			expectedUpdates: []*rds.Parameter{
+				{
+					ParameterName:  aws.String("log_min_duration_statement"),
+					ParameterValue: aws.String("1000"),
+					ApplyMethod:    aws.String("immediate"),
+				},
+				{
+					ParameterName:  aws.String("max_connections"),
+					ParameterValue: aws.String("500"),
+					ApplyMethod:    aws.String("pending-reboot"),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			updates := buildDBParameterUpdates(tc.desired, tc.found)
+			if len(updates) != len(tc.expectedUpdates) {
+				t.Fatalf("unexpected number of updates, expected %d but got %d", len(tc.expectedUpdates), len(updates))
+			}
+			for i, update := range updates {
+				if aws.StringValue(update.ParameterName) != aws.StringValue(tc.expectedUpdates[i].ParameterName) || aws.StringValue(update.ParameterValue) != aws.StringValue(tc.expectedUpdates[i].ParameterValue) || aws.StringValue(update.ApplyMethod) != aws.StringValue(tc.expectedUpdates[i].ApplyMethod) {
+					t.Fatalf("unexpected update, expected %v but got %v", tc.expectedUpdates[i], update)
+				}
+			}
+		})
+	}
+}
+
+func TestRdsParameterGroupFamily(t *testing.T) {
+	cases := []struct {
+		name           string
+		engineVersion  string
+		expectedFamily string
+	}{
+		{
+			name:           "test postgres 10 uses major version family",
+			engineVersion:  "10.6",
+			expectedFamily: "postgres10",
+		},
+		{
+			name:           "test postgres 9 uses major minor family",
+			engineVersion:  "9.6.11",
+			expectedFamily: "postgres9.6",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := rdsParameterGroupFamily(tc.engineVersion); got != tc.expectedFamily {
+				t.Fatalf("unexpected parameter group family, expected %s but got %s", tc.expectedFamily, got)
+			}
+		})
+	}
+}
+
+func TestAWSPostgresProvider_reconcilePendingMaintenance(t *testing.T) {
+	buildPendingOutput := func(optInStatus string) *rds.DescribePendingMaintenanceActionsOutput {
+		return &rds.DescribePendingMaintenanceActionsOutput{
+			PendingMaintenanceActions: []*rds.ResourcePendingMaintenanceActions{
+				{
+					ResourceIdentifier: aws.String("arn-test"),
+					PendingMaintenanceActionDetails: []*rds.PendingMaintenanceAction{
+						{
+							Action:      aws.String("system-update"),
+							Description: aws.String("New Operating System update is available"),
+							OptInStatus: aws.String(optInStatus),
+						},
+					},
+				},
+			},
+		}
+	}
+	type args struct {
+		cr     *v1alpha1.Postgres
+		rdsSvc *mockRdsClient
+	}
+	tests := []struct {
+		name                string
+		args                args
+		wantErr             bool
+		wantConditionStatus string
+		wantApplied         int
+	}{
+		{
+			name: "test no pending maintenance reports a false condition",
+			args: args{
+				cr:     buildTestPostgresCR(),
+				rdsSvc: buildMockRdsClient(nil),
+			},
+			wantConditionStatus: "False",
+		},
+		{
+			name: "test pending maintenance is surfaced without the approval annotation",
+			args: args{
+				cr: buildTestPostgresCR(),
+				rdsSvc: buildMockRdsClient(func(rdsClient *mockRdsClient) {
+					rdsClient.describePendingMaintenanceActionsFn = func(input *rds.DescribePendingMaintenanceActionsInput) (*rds.DescribePendingMaintenanceActionsOutput, error) {
+						return buildPendingOutput(""), nil
+					}
+				}),
+			},
+			wantConditionStatus: "True",
+		},
+		{
+			name: "test the approval annotation applies pending maintenance immediately",
+			args: args{
+				cr: func() *v1alpha1.Postgres {
+					cr := buildTestPostgresCR()
+					cr.ObjectMeta.Annotations = map[string]string{
+						ApplyMaintenanceImmediatelyAnnotation: "true",
+					}
+					return cr
+				}(),
+				rdsSvc: buildMockRdsClient(func(rdsClient *mockRdsClient) {
+					rdsClient.describePendingMaintenanceActionsFn = func(input *rds.DescribePendingMaintenanceActionsInput) (*rds.DescribePendingMaintenanceActionsOutput, error) {
+						return buildPendingOutput(""), nil
+					}
+				}),
+			},
+			wantConditionStatus: "True",
+			wantApplied:         1,
+		},
+		{
+			name: "test an action already opted in is not opted in again",
+			args: args{
+				cr: func() *v1alpha1.Postgres {
+					cr := buildTestPostgresCR()
+					cr.ObjectMeta.Annotations = map[string]string{
+						ApplyMaintenanceImmediatelyAnnotation: "true",
+					}
+					return cr
+				}(),
+				rdsSvc: buildMockRdsClient(func(rdsClient *mockRdsClient) {
+					rdsClient.describePendingMaintenanceActionsFn = func(input *rds.DescribePendingMaintenanceActionsInput) (*rds.DescribePendingMaintenanceActionsOutput, error) {
+						return buildPendingOutput("immediate"), nil
+					}
+				}),
+			},
+			wantConditionStatus: "True",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied := 0
+			tt.args.rdsSvc.applyPendingMaintenanceActionFn = func(input *rds.ApplyPendingMaintenanceActionInput) (*rds.ApplyPendingMaintenanceActionOutput, error) {
+				applied++
+				if aws.StringValue(input.OptInType) != "immediate" {
+					t.Errorf("ApplyPendingMaintenanceAction() opt in type = %s, want immediate", aws.StringValue(input.OptInType))
+				}
+				return &rds.ApplyPendingMaintenanceActionOutput{}, nil
+			}
+			p := &PostgresProvider{
+				Logger: testLogger,
+			}
+			err := p.reconcilePendingMaintenance(context.TODO(), tt.args.cr, tt.args.rdsSvc, &rds.DBInstance{
+				DBInstanceIdentifier: aws.String("test-id"),
+				DBInstanceArn:        aws.String("arn-test"),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("reconcilePendingMaintenance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if applied != tt.wantApplied {
+				t.Errorf("reconcilePendingMaintenance() applied %d maintenance actions, want %d", applied, tt.wantApplied)
+			}
+			found := false
+			for _, condition := range tt.args.cr.Status.Conditions {
+				if condition.Type != resources.ConditionTypePendingMaintenance {
+					continue
+				}
+				found = true
+				if condition.Status != tt.wantConditionStatus {
+					t.Errorf("pending maintenance condition status = %s, want %s", condition.Status, tt.wantConditionStatus)
+				}
+			}
+			if !found {
+				t.Errorf("pending maintenance condition not found on cr")
+			}
+		})
+	}
+}
+
+func TestAWSPostgresProvider_reconcileScheduledState(t *testing.T) {
+	// a stop expression that fires every minute with a start expression that never fires
+	// (february 31st) keeps the desired state stopped regardless of when the test runs,
+	// the inverse keeps the desired state running
+	alwaysStopped := &croType.ScheduleSpec{
+		Start: "0 0 31 2 *",
+		Stop:  "* * * * *",
+	}
+	alwaysRunning := &croType.ScheduleSpec{
+		Start: "* * * * *",
+		Stop:  "0 0 31 2 *",
+	}
+	buildScheduledCR := func(schedule *croType.ScheduleSpec, annotations map[string]string) *v1alpha1.Postgres {
+		cr := buildTestPostgresCR()
+		cr.Spec.Schedule = schedule
+		cr.ObjectMeta.Annotations = annotations
+		return cr
+	}
+	type args struct {
+		cr             *v1alpha1.Postgres
+		instanceStatus string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantHandled bool
+		wantStops   int
+		wantStarts  int
+	}{
+		{
+			name: "test no schedule on the cr is a noop",
+			args: args{
+				cr:             buildTestPostgresCR(),
+				instanceStatus: "available",
+			},
+		},
+		{
+			name: "test an available instance is stopped outside the working window",
+			args: args{
+				cr:             buildScheduledCR(alwaysStopped, nil),
+				instanceStatus: "available",
+			},
+			wantHandled: true,
+			wantStops:   1,
+		},
+		{
+			name: "test a stopped instance outside the working window reports scheduled state",
+			args: args{
+				cr:             buildScheduledCR(alwaysStopped, nil),
+				instanceStatus: "stopped",
+			},
+			wantHandled: true,
+		},
+		{
+			name: "test a stopped instance is started inside the working window",
+			args: args{
+				cr:             buildScheduledCR(alwaysRunning, nil),
+				instanceStatus: "stopped",
+			},
+			wantHandled: true,
+			wantStarts:  1,
+		},
+		{
+			name: "test the override annotation keeps a stopped instance running",
+			args: args{
+				cr: buildScheduledCR(alwaysStopped, map[string]string{
+					resources.ScheduleOverrideAnnotation: "true",
+				}),
+				instanceStatus: "stopped",
+			},
+			wantHandled: true,
+			wantStarts:  1,
+		},
+		{
+			name: "test an available instance inside the working window is a noop",
+			args: args{
+				cr:             buildScheduledCR(alwaysRunning, nil),
+				instanceStatus: "available",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stops, starts := 0, 0
+			rdsSvc := buildMockRdsClient(func(rdsClient *mockRdsClient) {
+				rdsClient.stopDBInstanceFn = func(input *rds.StopDBInstanceInput) (*rds.StopDBInstanceOutput, error) {
+					stops++
+					return &rds.StopDBInstanceOutput{}, nil
+				}
+				rdsClient.startDBInstanceFn = func(input *rds.StartDBInstanceInput) (*rds.StartDBInstanceOutput, error) {
+					starts++
+					return &rds.StartDBInstanceOutput{}, nil
+				}
+			})
+			p := &PostgresProvider{
+				Logger: testLogger,
+			}
+			handled, _, err := p.reconcileScheduledState(tt.args.cr, rdsSvc, &rds.DBInstance{
+				DBInstanceIdentifier: aws.String("test-id"),
+				DBInstanceStatus:     aws.String(tt.args.instanceStatus),
+			})
+			if err != nil {
+				t.Fatalf("reconcileScheduledState() unexpected error %v", err)
+			}
+			if handled != tt.wantHandled {
+				t.Errorf("reconcileScheduledState() handled = %v, want %v", handled, tt.wantHandled)
+			}
+			if stops != tt.wantStops {
+				t.Errorf("reconcileScheduledState() stopped the instance %d times, want %d", stops, tt.wantStops)
+			}
+			if starts != tt.wantStarts {
+				t.Errorf("reconcileScheduledState() started the instance %d times, want %d", starts, tt.wantStarts)
+			}
+		})
+	}
+}
+
+func Test_buildDesiredDBParameters(t *testing.T) {
+	tests := []struct {
+		name     string
+		stratCfg *StrategyConfig
+		want     map[string]string
+	}{
+		{
+			name:     "test no parameters without strategy features",
+			stratCfg: &StrategyConfig{},
+			want:     map[string]string{},
+		},
+		{
+			name: "test logical replication implies the rds parameter",
+			stratCfg: &StrategyConfig{
+				LogicalReplication: true,
+			},
+			want: map[string]string{
+				"rds.logical_replication": "1",
+			},
+		},
+		{
+			name: "test declared parameters take precedence",
+			stratCfg: &StrategyConfig{
+				LogicalReplication: true,
+				Parameters: map[string]string{
+					"rds.logical_replication": "0",
+					"max_connections":         "200",
+				},
+			},
+			want: map[string]string{
+				"rds.logical_replication": "0",
+				"max_connections":         "200",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildDesiredDBParameters(tt.stratCfg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildDesiredDBParameters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getInitSQL(t *testing.T) {
+	scheme, err := buildTestSchemePostgresql()
+	if err != nil {
+		t.Fatal("failed to build scheme", err)
+	}
+	initConfigMap := &corev1.ConfigMap{
+		ObjectMeta: controllerruntime.ObjectMeta{
+			Name:      "test-init-sql",
+			Namespace: "test",
+		},
+		Data: map[string]string{
+			"init.sql":   "CREATE SCHEMA app;",
+			"custom.sql": "CREATE SCHEMA custom;",
+		},
+	}
+	initSecret := &corev1.Secret{
+		ObjectMeta: controllerruntime.ObjectMeta{
+			Name:      "test-init-sql-sec",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			"init.sql": []byte("CREATE ROLE reporting;"),
+		},
+	}
+	tests := []struct {
+		name       string
+		initSQLRef *croType.InitSQLRefSpec
+		want       string
+		wantErr    bool
+	}{
+		{
+			name: "test sql read from config map with default key",
+			initSQLRef: &croType.InitSQLRefSpec{
+				ConfigMap: "test-init-sql",
+			},
+			want: "CREATE SCHEMA app;",
+		},
+		{
+			name: "test sql read from config map with explicit key",
+			initSQLRef: &croType.InitSQLRefSpec{
+				ConfigMap: "test-init-sql",
+				Key:       "custom.sql",
+			},
+			want: "CREATE SCHEMA custom;",
+		},
+		{
+			name: "test sql read from secret",
+			initSQLRef: &croType.InitSQLRefSpec{
+				Secret: "test-init-sql-sec",
+			},
+			want: "CREATE ROLE reporting;",
+		},
+		{
+			name: "test config map and secret together are rejected",
+			initSQLRef: &croType.InitSQLRefSpec{
+				ConfigMap: "test-init-sql",
+				Secret:    "test-init-sql-sec",
+			},
+			wantErr: true,
+		},
+		{
+			name:       "test neither config map nor secret is rejected",
+			initSQLRef: &croType.InitSQLRefSpec{},
+			wantErr:    true,
+		},
+		{
+			name: "test missing key is rejected",
+			initSQLRef: &croType.InitSQLRefSpec{
+				ConfigMap: "test-init-sql",
+				Key:       "missing.sql",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PostgresProvider{
+				Client: fake.NewFakeClientWithScheme(scheme, initConfigMap.DeepCopy(), initSecret.DeepCopy()),
+				Logger: testLogger,
+			}
+			cr := buildTestPostgresCR()
+			cr.Spec.InitSQLRef = tt.initSQLRef
+			got, err := p.getInitSQL(context.TODO(), cr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getInitSQL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getInitSQL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
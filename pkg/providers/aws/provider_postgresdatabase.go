@@ -0,0 +1,148 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+
+	errorUtil "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	postgresDatabaseProviderName = "aws-rds-database"
+)
+
+var _ providers.PostgresDatabaseProvider = (*PostgresDatabaseProvider)(nil)
+
+// PostgresDatabaseProvider declaratively manages additional databases on a provisioned rds
+// instance, so multiple applications can share one instance safely
+type PostgresDatabaseProvider struct {
+	Client client.Client
+	Logger *logrus.Entry
+}
+
+func NewAWSPostgresDatabaseProvider(client client.Client, logger *logrus.Entry) *PostgresDatabaseProvider {
+	return &PostgresDatabaseProvider{
+		Client: client,
+		Logger: logger.WithFields(logrus.Fields{"provider": postgresDatabaseProviderName}),
+	}
+}
+
+func (p *PostgresDatabaseProvider) GetName() string {
+	return postgresDatabaseProviderName
+}
+
+func (p *PostgresDatabaseProvider) SupportsStrategy(d string) bool {
+	return d == providers.AWSDeploymentStrategy
+}
+
+func (p *PostgresDatabaseProvider) GetReconcileTime(d *v1alpha1.PostgresDatabase) time.Duration {
+	if d.Status.Phase == croType.PhaseFailed {
+		return resources.GetErrorBackoffTime(d.Status.FailureCount)
+	}
+	if d.Status.Phase != croType.PhaseComplete {
+		return time.Second * 60
+	}
+	return resources.GetHealthyReconcileTimeOrDefault(defaultReconcileTime)
+}
+
+// ReconcilePostgresDatabase ensures a database exists on the rds instance referenced by the cr,
+// owned by the referenced PostgresUser when one is set
+func (p *PostgresDatabaseProvider) ReconcilePostgresDatabase(ctx context.Context, d *v1alpha1.PostgresDatabase, pg *v1alpha1.Postgres) (croType.StatusMessage, error) {
+	logger := p.Logger.WithField("action", "ReconcilePostgresDatabase")
+	logger.Infof("reconciling postgres database %s on postgres %s", d.Name, pg.Name)
+
+	// handle provider-specific finalizer
+	if err := resources.CreateFinalizer(ctx, p.Client, d, DefaultFinalizer); err != nil {
+		return "failed to set finalizer", err
+	}
+
+	// connect to the admin database with the admin credentials
+	db, err := buildAdminPostgresConnection(ctx, p.Client, pg)
+	if err != nil {
+		errMsg := "failed to connect to postgres instance"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	defer db.Close()
+
+	// when an owner is set it must exist before the database can be owned by it
+	databaseName := buildSQLIdentifier(d.Name)
+	ownerName := ""
+	if d.Spec.Owner != "" {
+		ownerName = buildSQLIdentifier(d.Spec.Owner)
+		var ownerExists bool
+		if err := db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pg_roles WHERE rolname = $1)", ownerName).Scan(&ownerExists); err != nil {
+			errMsg := "failed to check for existing owner"
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+		if !ownerExists {
+			errMsg := fmt.Sprintf("owner %s referenced by database %s does not exist", d.Spec.Owner, d.Name)
+			return croType.StatusMessage(errMsg), errorUtil.New(errMsg)
+		}
+	}
+
+	// ensure the database exists with the expected owner
+	var databaseExists bool
+	if err := db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pg_database WHERE datname = $1)", databaseName).Scan(&databaseExists); err != nil {
+		errMsg := "failed to check for existing database"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	if !databaseExists {
+		createDatabase := fmt.Sprintf("CREATE DATABASE %s", pq.QuoteIdentifier(databaseName))
+		if ownerName != "" {
+			createDatabase = fmt.Sprintf("%s OWNER %s", createDatabase, pq.QuoteIdentifier(ownerName))
+		}
+		if _, err := db.ExecContext(ctx, createDatabase); err != nil {
+			errMsg := "failed to create database"
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+	} else if ownerName != "" {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("ALTER DATABASE %s OWNER TO %s", pq.QuoteIdentifier(databaseName), pq.QuoteIdentifier(ownerName))); err != nil {
+			errMsg := "failed to update database owner"
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+	}
+
+	// limit database access to roles that have been granted it explicitly
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("REVOKE ALL ON DATABASE %s FROM PUBLIC", pq.QuoteIdentifier(databaseName))); err != nil {
+		errMsg := "failed to revoke public grants on database"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	return "reconcile complete", nil
+}
+
+// DeletePostgresDatabase drops the database from the rds instance
+func (p *PostgresDatabaseProvider) DeletePostgresDatabase(ctx context.Context, d *v1alpha1.PostgresDatabase, pg *v1alpha1.Postgres) (croType.StatusMessage, error) {
+	logger := p.Logger.WithField("action", "DeletePostgresDatabase")
+	logger.Infof("deleting postgres database %s on postgres %s", d.Name, pg.Name)
+
+	// connect to the admin database with the admin credentials
+	db, err := buildAdminPostgresConnection(ctx, p.Client, pg)
+	if err != nil {
+		errMsg := "failed to connect to postgres instance"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("DROP DATABASE IF EXISTS %s", pq.QuoteIdentifier(buildSQLIdentifier(d.Name)))); err != nil {
+		errMsg := "failed to drop database"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	resources.RemoveFinalizer(&d.ObjectMeta, DefaultFinalizer)
+	if err := p.Client.Update(ctx, d); err != nil {
+		errMsg := "failed to update instance as part of finalizer reconcile"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	return croType.StatusEmpty, nil
+}
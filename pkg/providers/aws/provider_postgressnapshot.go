@@ -234,5 +234,5 @@ func (p *PostgresSnapshotProvider) createSessionForResource(ctx context.Context,
 		return nil, err
 	}
 
-	return CreateSessionFromStrategy(ctx, p.client, providerCreds.AccessKeyID, providerCreds.SecretAccessKey, stratCfg)
+	return CreateSessionFromStrategy(ctx, p.client, providerCreds, stratCfg)
 }
@@ -0,0 +1,290 @@
+package aws
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+
+	errorUtil "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	postgresUserProviderName = "aws-rds-user"
+)
+
+// defaultPostgresUserPrivileges the privileges granted on a database when none are set on the cr
+var defaultPostgresUserPrivileges = []string{"CONNECT", "TEMPORARY"}
+
+// supportedPostgresUserPrivileges the database level privileges that can be granted through the cr
+var supportedPostgresUserPrivileges = []string{"ALL", "CONNECT", "CREATE", "TEMP", "TEMPORARY"}
+
+var _ providers.PostgresUserProvider = (*PostgresUserProvider)(nil)
+
+// PostgresUserProvider declaratively manages additional users on a provisioned rds instance, so
+// multiple applications can share one instance without sharing credentials
+type PostgresUserProvider struct {
+	Client client.Client
+	Logger *logrus.Entry
+}
+
+func NewAWSPostgresUserProvider(client client.Client, logger *logrus.Entry) *PostgresUserProvider {
+	return &PostgresUserProvider{
+		Client: client,
+		Logger: logger.WithFields(logrus.Fields{"provider": postgresUserProviderName}),
+	}
+}
+
+func (p *PostgresUserProvider) GetName() string {
+	return postgresUserProviderName
+}
+
+func (p *PostgresUserProvider) SupportsStrategy(d string) bool {
+	return d == providers.AWSDeploymentStrategy
+}
+
+func (p *PostgresUserProvider) GetReconcileTime(u *v1alpha1.PostgresUser) time.Duration {
+	if u.Status.Phase == croType.PhaseFailed {
+		return resources.GetErrorBackoffTime(u.Status.FailureCount)
+	}
+	if u.Status.Phase != croType.PhaseComplete {
+		return time.Second * 60
+	}
+	return resources.GetHealthyReconcileTimeOrDefault(defaultReconcileTime)
+}
+
+// ReconcilePostgresUser ensures a role exists on the rds instance referenced by the cr with the
+// requested database grants and publishes the role credentials to the cr secret ref
+func (p *PostgresUserProvider) ReconcilePostgresUser(ctx context.Context, u *v1alpha1.PostgresUser, pg *v1alpha1.Postgres) (croType.StatusMessage, error) {
+	logger := p.Logger.WithField("action", "ReconcilePostgresUser")
+	logger.Infof("reconciling postgres user %s on postgres %s", u.Name, pg.Name)
+
+	// handle provider-specific finalizer
+	if err := resources.CreateFinalizer(ctx, p.Client, u, DefaultFinalizer); err != nil {
+		return "failed to set finalizer", err
+	}
+
+	// validate the requested privileges before they are used to build grant statements
+	privileges := u.Spec.Privileges
+	if len(privileges) == 0 {
+		privileges = defaultPostgresUserPrivileges
+	}
+	for _, privilege := range privileges {
+		if !resources.Contains(supportedPostgresUserPrivileges, strings.ToUpper(privilege)) {
+			errMsg := fmt.Sprintf("unsupported database privilege %s", privilege)
+			return croType.StatusMessage(errMsg), errorUtil.New(errMsg)
+		}
+	}
+
+	// get or create the user password and publish the credentials to the cr secret ref
+	userName := buildSQLIdentifier(u.Name)
+	password, err := p.reconcileUserCredentialSecret(ctx, u, pg, userName)
+	if err != nil {
+		errMsg := "failed to reconcile user credential secret"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// connect to the admin database with the admin credentials
+	db, err := buildAdminPostgresConnection(ctx, p.Client, pg)
+	if err != nil {
+		errMsg := "failed to connect to postgres instance"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	defer db.Close()
+
+	// ensure the role exists with the expected password and no elevated attributes
+	var userExists bool
+	if err := db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pg_roles WHERE rolname = $1)", userName).Scan(&userExists); err != nil {
+		errMsg := "failed to check for existing user"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	if !userExists {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE ROLE %s LOGIN NOSUPERUSER NOCREATEDB NOCREATEROLE PASSWORD %s", pq.QuoteIdentifier(userName), pq.QuoteLiteral(password))); err != nil {
+			errMsg := "failed to create user"
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+	} else {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("ALTER ROLE %s LOGIN NOSUPERUSER NOCREATEDB NOCREATEROLE PASSWORD %s", pq.QuoteIdentifier(userName), pq.QuoteLiteral(password))); err != nil {
+			errMsg := "failed to update user"
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+	}
+
+	// grant the requested privileges on each referenced database
+	for _, database := range u.Spec.Databases {
+		databaseName := buildSQLIdentifier(database)
+		var databaseExists bool
+		if err := db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pg_database WHERE datname = $1)", databaseName).Scan(&databaseExists); err != nil {
+			errMsg := "failed to check for existing database"
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+		if !databaseExists {
+			errMsg := fmt.Sprintf("database %s referenced by user %s does not exist", database, u.Name)
+			return croType.StatusMessage(errMsg), errorUtil.New(errMsg)
+		}
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("GRANT %s ON DATABASE %s TO %s", strings.Join(privileges, ", "), pq.QuoteIdentifier(databaseName), pq.QuoteIdentifier(userName))); err != nil {
+			errMsg := fmt.Sprintf("failed to grant privileges on database %s", database)
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+	}
+
+	return "reconcile complete", nil
+}
+
+// DeletePostgresUser removes the role from the rds instance and deletes the credential secret
+func (p *PostgresUserProvider) DeletePostgresUser(ctx context.Context, u *v1alpha1.PostgresUser, pg *v1alpha1.Postgres) (croType.StatusMessage, error) {
+	logger := p.Logger.WithField("action", "DeletePostgresUser")
+	logger.Infof("deleting postgres user %s on postgres %s", u.Name, pg.Name)
+
+	// connect to the admin database with the admin credentials
+	db, err := buildAdminPostgresConnection(ctx, p.Client, pg)
+	if err != nil {
+		errMsg := "failed to connect to postgres instance"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	defer db.Close()
+
+	// revoke any remaining database grants before the role is dropped
+	userName := buildSQLIdentifier(u.Name)
+	for _, database := range u.Spec.Databases {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("REVOKE ALL ON DATABASE %s FROM %s", pq.QuoteIdentifier(buildSQLIdentifier(database)), pq.QuoteIdentifier(userName))); err != nil {
+			errMsg := fmt.Sprintf("failed to revoke privileges on database %s", database)
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+	}
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("DROP ROLE IF EXISTS %s", pq.QuoteIdentifier(userName))); err != nil {
+		errMsg := "failed to drop user"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// delete the credential secret
+	if u.Spec.SecretRef != nil {
+		sec := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      u.Spec.SecretRef.Name,
+				Namespace: buildSecretNamespace(u.Spec.SecretRef, u.Namespace),
+			},
+		}
+		if err := p.Client.Delete(ctx, sec); err != nil && !k8serr.IsNotFound(err) {
+			errMsg := "failed to delete user credential secret"
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+	}
+
+	resources.RemoveFinalizer(&u.ObjectMeta, DefaultFinalizer)
+	if err := p.Client.Update(ctx, u); err != nil {
+		errMsg := "failed to update instance as part of finalizer reconcile"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	return croType.StatusEmpty, nil
+}
+
+// reconcileUserCredentialSecret publishes the user credentials and connection details to the cr
+// secret ref, generating a password if one has not already been persisted
+func (p *PostgresUserProvider) reconcileUserCredentialSecret(ctx context.Context, u *v1alpha1.PostgresUser, pg *v1alpha1.Postgres, userName string) (string, error) {
+	if u.Spec.SecretRef == nil {
+		return "", errorUtil.New("secret ref is not set on the cr")
+	}
+	host, port, err := getPostgresConnectionDetails(ctx, p.Client, pg)
+	if err != nil {
+		return "", errorUtil.Wrap(err, "failed to get postgres connection details")
+	}
+	sec := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      u.Spec.SecretRef.Name,
+			Namespace: buildSecretNamespace(u.Spec.SecretRef, u.Namespace),
+		},
+	}
+	var password string
+	if _, err := controllerutil.CreateOrUpdate(ctx, p.Client, sec, func() error {
+		if sec.Data == nil {
+			sec.Data = map[string][]byte{}
+		}
+		password = string(sec.Data[defaultPostgresPasswordKey])
+		if password == "" {
+			generatedPassword, err := resources.GeneratePassword()
+			if err != nil {
+				return errorUtil.Wrap(err, "failed to generate password")
+			}
+			password = generatedPassword
+		}
+		sec.Data[defaultPostgresUserKey] = []byte(userName)
+		sec.Data[defaultPostgresPasswordKey] = []byte(password)
+		sec.Data["host"] = []byte(host)
+		sec.Data["port"] = []byte(port)
+		return nil
+	}); err != nil {
+		return "", errorUtil.Wrap(err, "failed to reconcile user credential secret")
+	}
+	return password, nil
+}
+
+// buildAdminPostgresConnection opens a connection to the admin database of a provisioned postgres
+// using the admin credentials from the operator credential secret, the caller owns the connection
+func buildAdminPostgresConnection(ctx context.Context, c client.Client, pg *v1alpha1.Postgres) (*sql.DB, error) {
+	// admin credentials are kept in the operator credential secret
+	credSec := &v1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: pg.Name + defaultCredSecSuffix, Namespace: pg.Namespace}, credSec); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to retrieve rds credential secret")
+	}
+	adminUser := string(credSec.Data[defaultPostgresUserKey])
+	adminPassword := string(credSec.Data[defaultPostgresPasswordKey])
+	if adminUser == "" || adminPassword == "" {
+		return nil, errorUtil.New("admin credentials not found in rds credential secret")
+	}
+	host, port, err := getPostgresConnectionDetails(ctx, c, pg)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to get postgres connection details")
+	}
+	db, err := sql.Open("postgres", fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=require", host, port, adminUser, adminPassword, defaultAwsPostgresDatabase))
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to open connection to postgres instance")
+	}
+	return db, nil
+}
+
+// getPostgresConnectionDetails returns the host and port of a provisioned postgres from the
+// connection secret published on the postgres cr
+func getPostgresConnectionDetails(ctx context.Context, c client.Client, pg *v1alpha1.Postgres) (string, string, error) {
+	if pg.Status.SecretRef == nil {
+		return "", "", errorUtil.New("connection secret is not yet available on the postgres cr")
+	}
+	sec := &v1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: pg.Status.SecretRef.Name, Namespace: buildSecretNamespace(pg.Status.SecretRef, pg.Namespace)}, sec); err != nil {
+		return "", "", errorUtil.Wrap(err, "failed to retrieve postgres connection secret")
+	}
+	host := string(sec.Data["host"])
+	port := string(sec.Data["port"])
+	if host == "" || port == "" {
+		return "", "", errorUtil.New("host and port not found in postgres connection secret")
+	}
+	return host, port, nil
+}
+
+// buildSecretNamespace returns the namespace from a secret ref, defaulting to the cr namespace
+func buildSecretNamespace(sr *croType.SecretRef, ns string) string {
+	if sr.Namespace != "" {
+		return sr.Namespace
+	}
+	return ns
+}
+
+// buildSQLIdentifier builds a valid postgres identifier from a cr name
+func buildSQLIdentifier(name string) string {
+	return strings.Replace(name, "-", "_", -1)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -28,6 +29,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/elasticache"
 	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
 	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
@@ -50,6 +52,23 @@ const (
 	defaultInTransitEncryption = false
 )
 
+// defaultRedisSizeClasses maps the abstract sizes that can be set on a cr to elasticache cache
+// node types, used when the strategy config for the tier does not define its own mapping
+var defaultRedisSizeClasses = map[string]string{
+	"small":  "cache.t3.micro",
+	"medium": "cache.m5.large",
+	"large":  "cache.m5.xlarge",
+}
+
+// defaultTierMaxMemoryPolicies maps cr tiers to the redis maxmemory-policy applied through
+// the dedicated cache parameter group, production refuses writes under memory pressure so
+// data is not silently evicted while development evicts cache entries, the parameters block
+// of the strategy for the tier overrides the policy
+var defaultTierMaxMemoryPolicies = map[string]string{
+	"production":  "noeviction",
+	"development": "allkeys-lru",
+}
+
 var healthyAWSReplicationGroupStatuses = []string{
 	"creating",
 	"available",
@@ -89,14 +108,20 @@ func (p *RedisProvider) SupportsStrategy(d string) bool {
 }
 
 func (p *RedisProvider) GetReconcileTime(r *v1alpha1.Redis) time.Duration {
+	if r.Status.Phase == croType.PhaseFailed {
+		return resources.GetErrorBackoffTime(r.Status.FailureCount)
+	}
 	if r.Status.Phase != croType.PhaseComplete {
 		return time.Second * 60
 	}
-	return resources.GetForcedReconcileTimeOrDefault(defaultReconcileTime)
+	return resources.GetHealthyReconcileTimeOrDefault(defaultReconcileTime)
 }
 
 // CreateRedis Create an Elasticache Replication Group from strategy config
 func (p *RedisProvider) CreateRedis(ctx context.Context, r *v1alpha1.Redis) (*providers.RedisCluster, croType.StatusMessage, error) {
+	ctx, span := resources.StartSpan(ctx, "aws.CreateRedis")
+	defer span.End()
+	ctx = resources.WithAuditResource(ctx, "Redis", r.Namespace, r.Name)
 	logger := p.Logger.WithField("action", "CreateRedis")
 	logger.Infof("reconciling redes %s", r.Name)
 	// handle provider-specific finalizer
@@ -115,11 +140,11 @@ func (p *RedisProvider) CreateRedis(ctx context.Context, r *v1alpha1.Redis) (*pr
 	providerCreds, err := p.CredentialManager.ReconcileProviderCredentials(ctx, r.Namespace)
 	if err != nil {
 		msg := "failed to reconcile elasticache credentials"
-		return nil, croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+		return nil, croType.StatusMessage(msg), resources.WrapWithClass(err, resources.ErrorClassAuth, msg)
 	}
 
 	// setup aws elasticache cluster sdk session
-	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds.AccessKeyID, providerCreds.SecretAccessKey, stratCfg)
+	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds, stratCfg)
 	if err != nil {
 		errMsg := "failed to create aws session to create elasticache replication group"
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
@@ -133,6 +158,11 @@ func (p *RedisProvider) CreateRedis(ctx context.Context, r *v1alpha1.Redis) (*pr
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
 
+	// dry run crs report what would be created without calling any mutating cloud apis
+	if r.Spec.DryRun {
+		return nil, p.buildRedisPlan(ctx, r, elasticacheCreateConfig, isEnabled), nil
+	}
+
 	//networkManager isEnabled checks for the presence of valid RHMI subnets in the cluster vpc
 	//when rhmi subnets are present in a cluster vpc it indicates that the vpc configuration
 	//was created in a cluster with a cluster version <= 4.4.5
@@ -140,39 +170,81 @@ func (p *RedisProvider) CreateRedis(ctx context.Context, r *v1alpha1.Redis) (*pr
 	//when rhmi subnets are absent in a cluster vpc it indicates that the vpc configuration has not been created
 	//and a new vpc is created for all resources to be deployed in and peered with the cluster vpc
 	if isEnabled {
-		// get cidr block from _network strat map, based on tier from redis cr
-		vpcCidrBlock, err := getNetworkProviderConfig(ctx, p.ConfigManager, r.Spec.Tier, logger)
+		// surface the network work on the cr so time spent peering is distinguishable from
+		// waiting on the cloud resource
+		r.Status.SetStage(croType.StageModifyingNetwork)
+		// when the _network strategy references an existing vpc and subnets the network is validated
+		// and reused instead of being created by the operator
+		existingNetworkConfig, err := getExistingNetworkConfig(ctx, p.ConfigManager, r.Spec.Tier, logger)
 		if err != nil {
-			errMsg := "failed to get _network strategy config"
+			errMsg := "failed to get existing network config from _network strategy"
 			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 		}
-		logger.Debug("standalone network provider enabled, reconciling standalone vpc")
 
-		// create the standalone vpc, subnets and subnet groups
-		standaloneNetwork, err := networkManager.CreateNetwork(ctx, vpcCidrBlock)
-		if err != nil {
-			errMsg := "failed to create resource network"
-			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		var standaloneNetwork *Network
+		if existingNetworkConfig != nil {
+			logger.Debug("existing network referenced in _network strategy, validating and reusing it")
+			standaloneNetwork, err = networkManager.ReconcileExistingNetwork(ctx, aws.StringValue(existingNetworkConfig.VpcId), aws.StringValueSlice(existingNetworkConfig.SubnetIds))
+			if err != nil {
+				errMsg := "failed to reconcile existing resource network"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+		} else {
+			// get cidr block from _network strat map, based on tier from redis cr
+			vpcCidrBlock, err := getNetworkProviderConfig(ctx, p.ConfigManager, r.Spec.Tier, logger)
+			if err != nil {
+				errMsg := "failed to get _network strategy config"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+			logger.Debug("standalone network provider enabled, reconciling standalone vpc")
+
+			// create the standalone vpc, subnets and subnet groups
+			standaloneNetwork, err = networkManager.CreateNetwork(ctx, vpcCidrBlock)
+			if err != nil {
+				errMsg := "failed to create resource network"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
 		}
-		// we've created the standalone vpc, now we peer it to the cluster vpc
-		logger.Infof("creating network peering")
-		networkPeering, err := networkManager.CreateNetworkPeering(ctx, standaloneNetwork)
+		// the connection type between the standalone vpc and the cluster vpc is selectable through the
+		// _network strategy, defaulting to a vpc peering connection
+		connConfig, err := getNetworkConnectionConfig(ctx, p.ConfigManager, r.Spec.Tier, logger)
 		if err != nil {
-			errMsg := "failed to peer standalone network"
+			errMsg := "failed to get network connection config from _network strategy"
 			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 		}
-		logger.Infof("created network peering %s", aws.StringValue(networkPeering.PeeringConnection.VpcPeeringConnectionId))
 
-		// we have created the peering connection we must now create the security groups and update the route tables
-		securityGroup, err := networkManager.CreateNetworkConnection(ctx, standaloneNetwork)
-		if err != nil {
-			errMsg := "failed to create standalone network"
-			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		if isTransitGatewayConnection(connConfig) {
+			// organisations that disallow vpc peering can attach the standalone vpc to an existing
+			// transit gateway instead, the attachment provides the same connectivity as the peering connection
+			logger.Infof("creating transit gateway attachment")
+			networkConnection, err := networkManager.CreateNetworkTransitGatewayAttachment(ctx, standaloneNetwork, aws.StringValue(connConfig.TransitGatewayId))
+			if err != nil {
+				errMsg := "failed to attach standalone network to transit gateway"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+			logger.Infof("created security group %s", aws.StringValue(networkConnection.StandaloneSecurityGroup.GroupName))
+		} else {
+			// we've created the standalone vpc, now we peer it to the cluster vpc
+			logger.Infof("creating network peering")
+			networkPeering, err := networkManager.CreateNetworkPeering(ctx, standaloneNetwork)
+			if err != nil {
+				errMsg := "failed to peer standalone network"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+			logger.Infof("created network peering %s", aws.StringValue(networkPeering.PeeringConnection.VpcPeeringConnectionId))
+
+			// we have created the peering connection we must now create the security groups and update the route tables
+			securityGroup, err := networkManager.CreateNetworkConnection(ctx, standaloneNetwork)
+			if err != nil {
+				errMsg := "failed to create standalone network"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+			logger.Infof("created security group %s", aws.StringValue(securityGroup.StandaloneSecurityGroup.GroupName))
 		}
-		logger.Infof("created security group %s", aws.StringValue(securityGroup.StandaloneSecurityGroup.GroupName))
 	}
 
 	// create the aws elasticache cluster
+	r.Status.SetStage(croType.StageProvisioning)
 	return p.createElasticacheCluster(ctx, r, elasticache.New(sess), sts.New(sess), ec2.New(sess), elasticacheCreateConfig, stratCfg, isEnabled)
 }
 
@@ -194,7 +266,7 @@ func (p *RedisProvider) createElasticacheCluster(ctx context.Context, r *v1alpha
 	// standaloneNetworkExists if no bundled subnets (created by this operator) are found in the cluster vpc
 	if !standaloneNetworkExists {
 		// setup networking in cluster vpc
-		if err := p.configureElasticacheVpc(ctx, cacheSvc, ec2Svc); err != nil {
+		if err := p.configureElasticacheVpc(ctx, r, cacheSvc, ec2Svc); err != nil {
 			errMsg := "error setting up resource vpc"
 			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 		}
@@ -212,6 +284,13 @@ func (p *RedisProvider) createElasticacheCluster(ctx context.Context, r *v1alpha
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
 
+	// maintain the dedicated cache parameter group when the strategy for the tier declares
+	// redis parameters, the group is threaded through the create and update strategies
+	if err := p.reconcileCacheParameterGroup(r, cacheSvc, elasticacheConfig, stratCfg); err != nil {
+		errMsg := "failed to reconcile elasticache parameter group"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
 	// check if the cluster has already been created
 	var foundCache *elasticache.ReplicationGroup
 	for _, c := range rgs {
@@ -230,18 +309,58 @@ func (p *RedisProvider) createElasticacheCluster(ctx context.Context, r *v1alpha
 	// expose a connection metric
 	defer p.createElasticacheConnectionMetric(ctx, r, foundCache)
 
+	// reflect recent failovers, node replacements and pending maintenance on the cr
+	defer p.surfaceReplicationGroupDisruptions(ctx, r, cacheSvc, foundCache)
+
 	// create elasticache cluster if it doesn't exist
 	if foundCache == nil {
+		// an adopted replication group must already exist, never create one under the adopted name
+		if annotations.Has(r, AdoptResourceIDAnnotation) {
+			errMsg := fmt.Sprintf("no elasticache replication group named %s found to adopt", *elasticacheConfig.ReplicationGroupId)
+			return nil, croType.StatusMessage(errMsg), errorUtil.New(errMsg)
+		}
+
 		if annotations.Has(r, ResourceIdentifierAnnotation) {
 			errMsg := fmt.Sprintf("Redis CR %s in %s namespace has %s annotation with value %s, but no corresponding Elasticache cluster was found",
 				r.Name, r.Namespace, ResourceIdentifierAnnotation, r.ObjectMeta.Annotations[ResourceIdentifierAnnotation])
 			return nil, croType.StatusMessage(errMsg), fmt.Errorf(errMsg)
 		}
 
+		// guard against provisioning a duplicate, a replication group already carrying the
+		// cro identity tags of the cr under a different identifier is never recreated under
+		// the expected name
+		taggedGroup, err := findReplicationGroupWithCROTags(ctx, p.Client, cacheSvc, stsSvc, stratCfg.Region, r.Spec.Type, r.ObjectMeta, rgs)
+		if err != nil {
+			errMsg := "failed to check existing elasticache replication groups for cro identity tags"
+			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+		if taggedGroup != nil {
+			errMsg := fmt.Sprintf("elasticache replication group %s already carries the tags for redis cr %s, refusing to create a duplicate under identifier %s, use the %s annotation to manage the existing replication group", aws.StringValue(taggedGroup.ReplicationGroupId), r.Name, *elasticacheConfig.ReplicationGroupId, AdoptResourceIDAnnotation)
+			return nil, croType.StatusMessage(errMsg), errorUtil.New(errMsg)
+		}
+
+		// cap the number of simultaneous expensive elasticache operations so a bulk rollout
+		// of crs does not trip cloud api throttles, the cr requeues until a slot is free
+		if !resources.AcquireProvisioningSlot("elasticache", fmt.Sprintf("%s/%s", r.Namespace, r.Name)) {
+			msg := fmt.Sprintf("waiting for an elasticache provisioning slot, %d operations are already in flight", resources.ProvisioningConcurrency("elasticache"))
+			logger.Info(msg)
+			return nil, croType.StatusMessage(msg), nil
+		}
+
+		// the operation is checkpointed on the cr before it is issued so a restarted operator
+		// resumes polling the replication group instead of losing track of it
+		if err := resources.SetInFlightOperation(ctx, p.Client, r, "elasticache:CreateReplicationGroup", *elasticacheConfig.ReplicationGroupId); err != nil {
+			return nil, croType.StatusMessage("failed to checkpoint elasticache create"), err
+		}
 		logrus.Info("creating elasticache cluster")
 		if _, err := cacheSvc.CreateReplicationGroup(elasticacheConfig); err != nil {
-			errMsg := fmt.Sprintf("error creating elasticache cluster %s", err)
-			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			// the create is idempotent by identifier, an already exists failure means a create
+			// issued by a racing reconcile or a previous pod is still settling
+			if !isAlreadyExistsError(err) {
+				errMsg := fmt.Sprintf("error creating elasticache cluster %s", err)
+				return nil, croType.StatusMessage(errMsg), wrapAWSError(err, errMsg)
+			}
+			logger.Infof("elasticache replication group %s already exists, create was issued by a previous reconcile", *elasticacheConfig.ReplicationGroupId)
 		}
 
 		annotations.Add(r, ResourceIdentifierAnnotation, *elasticacheConfig.ReplicationGroupId)
@@ -253,11 +372,20 @@ func (p *RedisProvider) createElasticacheCluster(ctx context.Context, r *v1alpha
 
 	// check elasticache phase
 	if *foundCache.Status != "available" {
+		if operation, ok := resources.InFlightOperationFrom(r); ok {
+			logger.Infof("resuming in-flight %s operation for %s started at %s", operation.Operation, operation.Resource, operation.StartedAt.Format(time.RFC3339))
+		}
 		logger.Infof("found instance %s current status %s", *foundCache.ReplicationGroupId, *foundCache.Status)
 		return nil, croType.StatusMessage(fmt.Sprintf("createReplicationGroup() in progress, current aws elasticache status is %s", *foundCache.Status)), nil
 	}
 	logger.Infof("found existing elasticache cluster %s", *foundCache.ReplicationGroupId)
 
+	// maintain the per cr prometheus rules, thresholds come from the strategy config for the tier
+	if err := resources.ReconcileRedisAlerts(ctx, p.Client, r, stratCfg.AlertThresholds); err != nil {
+		errMsg := "failed to reconcile redis alerts"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
 	cacheClustersOutput, err := cacheSvc.DescribeCacheClusters(&elasticache.DescribeCacheClustersInput{})
 	if err != nil {
 		errMsg := "failed to describe clusters"
@@ -270,24 +398,112 @@ func (p *RedisProvider) createElasticacheCluster(ctx context.Context, r *v1alpha
 		}
 	}
 
+	// validate an adopted replication group is compatible before the operator takes over
+	// management, the resource identifier annotation marks the takeover as complete
+	if annotations.Has(r, AdoptResourceIDAnnotation) {
+		for _, c := range replicationGroupClusters {
+			if aws.StringValue(c.Engine) != "redis" {
+				errMsg := fmt.Sprintf("cannot adopt replication group %s, engine %s does not match expected engine redis", *foundCache.ReplicationGroupId, aws.StringValue(c.Engine))
+				return nil, croType.StatusMessage(errMsg), errorUtil.New(errMsg)
+			}
+		}
+		if !annotations.Has(r, ResourceIdentifierAnnotation) {
+			logger.Infof("adopting existing elasticache replication group %s", *foundCache.ReplicationGroupId)
+			annotations.Add(r, ResourceIdentifierAnnotation, *foundCache.ReplicationGroupId)
+			if err := p.Client.Update(ctx, r); err != nil {
+				return nil, croType.StatusMessage("failed to add annotation"), err
+			}
+		}
+	}
+
 	// check if any modifications are required to bring the elasticache instance up to date with the strategy map.
 	modifyInput, err := buildElasticacheUpdateStrategy(ec2Svc, elasticacheConfig, foundCache, replicationGroupClusters, logger)
 	if err != nil {
 		errMsg := "failed to build elasticache modify strategy"
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
+	// expose any drift between the expected strategy and the found replication group as a status
+	// condition and metric, the found replication group is only reverted when auto heal is enabled on the cr
+	driftItems := buildElasticacheDriftItems(modifyInput, foundCache, replicationGroupClusters)
+	p.exposeRedisDriftMetric(ctx, r, driftItems)
+	r.Status.Conditions = croType.SetCondition(r.Status.Conditions, resources.BuildDriftCondition(driftItems))
+
 	if modifyInput == nil {
 		logger.Infof("elasticache replication group %s is as expected", *foundCache.ReplicationGroupId)
 	}
 
 	// modifications are required to bring the elasticache instance up to date with the strategy map, perform updates.
+	modificationIssued := false
 	if modifyInput != nil {
-		logger.Infof("%s differs from expected strategy, applying pending modifications :\n%s", *foundCache.ReplicationGroupId, modifyInput)
-		if _, err := cacheSvc.ModifyReplicationGroup(modifyInput); err != nil {
-			errMsg := "failed to modify elasticache cluster"
-			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		applyModification := r.Spec.AutoHeal
+		// a cache node type change requested through the size field on the cr is an explicit
+		// request to scale rather than drift, so it is applied even when auto heal is disabled
+		if !applyModification && r.Spec.Size != "" && modifyInput.CacheNodeType != nil {
+			modifyInput = &elasticache.ModifyReplicationGroupInput{
+				ReplicationGroupId: modifyInput.ReplicationGroupId,
+				CacheNodeType:      modifyInput.CacheNodeType,
+			}
+			applyModification = true
+		}
+		if !applyModification {
+			logger.Infof("drift detected on elasticache replication group %s, auto heal is disabled on the cr so skipping modification", *foundCache.ReplicationGroupId)
+		} else {
+			// an engine version upgrade is snapshotted first so the pre upgrade state can be
+			// restored, the upgrade itself is applied by aws in the preferred maintenance window
+			if modifyInput.EngineVersion != nil {
+				snapshotReady, msg, err := p.ensurePreUpgradeSnapshot(cacheSvc, foundCache, aws.StringValue(modifyInput.EngineVersion))
+				if err != nil {
+					errMsg := "failed to snapshot elasticache cluster before engine upgrade"
+					return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+				}
+				if !snapshotReady {
+					return nil, msg, nil
+				}
+			}
+			// modifications contend for the same provisioning slots as creates, skip this
+			// pass when the cap is reached and apply the modification on a later reconcile
+			if !resources.AcquireProvisioningSlot("elasticache", fmt.Sprintf("%s/%s", r.Namespace, r.Name)) {
+				msg := fmt.Sprintf("waiting for an elasticache provisioning slot to modify replication group %s, %d operations are already in flight", *foundCache.ReplicationGroupId, resources.ProvisioningConcurrency("elasticache"))
+				logger.Info(msg)
+				return nil, croType.StatusMessage(msg), nil
+			}
+			if err := resources.SetInFlightOperation(ctx, p.Client, r, "elasticache:ModifyReplicationGroup", *foundCache.ReplicationGroupId); err != nil {
+				return nil, croType.StatusMessage("failed to checkpoint elasticache modification"), err
+			}
+			logger.Infof("%s differs from expected strategy, applying pending modifications :\n%s", *foundCache.ReplicationGroupId, modifyInput)
+			if _, err := cacheSvc.ModifyReplicationGroup(modifyInput); err != nil {
+				errMsg := "failed to modify elasticache cluster"
+				return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+			}
+			logger.Infof("set pending modifications to elasticache replication group %s", *foundCache.ReplicationGroupId)
+			modificationIssued = true
+		}
+	}
+
+	// report scaling progress while a cache node type change is pending on the replication group
+	for _, replicationGroupCluster := range replicationGroupClusters {
+		if replicationGroupCluster.PendingModifiedValues != nil && replicationGroupCluster.PendingModifiedValues.CacheNodeType != nil {
+			statusMessage := fmt.Sprintf("scaling in progress, cache node type %s pending for elasticache replication group %s", aws.StringValue(replicationGroupCluster.PendingModifiedValues.CacheNodeType), aws.StringValue(foundCache.ReplicationGroupId))
+			logger.Info(statusMessage)
+			return nil, croType.StatusMessage(statusMessage), nil
+		}
+		// engine upgrades are applied by aws in the preferred maintenance window, report
+		// progress on the cr until the pending version is gone
+		if replicationGroupCluster.PendingModifiedValues != nil && replicationGroupCluster.PendingModifiedValues.EngineVersion != nil {
+			statusMessage := fmt.Sprintf("engine upgrade in progress, version %s will be applied in maintenance window %s for elasticache replication group %s", aws.StringValue(replicationGroupCluster.PendingModifiedValues.EngineVersion), aws.StringValue(replicationGroupCluster.PreferredMaintenanceWindow), aws.StringValue(foundCache.ReplicationGroupId))
+			logger.Info(statusMessage)
+			return nil, croType.StatusMessage(statusMessage), nil
+		}
+	}
+
+	// the replication group is available with no pending work, release the provisioning slot
+	// and remove any checkpointed operation, a modification issued in this reconcile keeps
+	// both until the replication group settles
+	if !modificationIssued {
+		resources.ReleaseProvisioningSlot("elasticache", fmt.Sprintf("%s/%s", r.Namespace, r.Name))
+		if err := resources.ClearInFlightOperation(ctx, p.Client, r); err != nil {
+			return nil, croType.StatusMessage("failed to clear in-flight operation checkpoint"), err
 		}
-		logger.Infof("set pending modifications to elasticache replication group %s", *foundCache.ReplicationGroupId)
 	}
 
 	// add tags to cache nodes
@@ -305,6 +521,13 @@ func (p *RedisProvider) createElasticacheCluster(ctx context.Context, r *v1alpha
 		}
 	}
 
+	// expose the cloud native identifier of the replication group on the cr
+	r.Status.ResourceID = aws.StringValue(foundCache.ReplicationGroupId)
+
+	// expose the start of the next preferred maintenance window on the cr, so consumers
+	// know when pending modifications and engine upgrades will be applied
+	p.setRedisNextMaintenanceWindow(r, replicationGroupClusters)
+
 	primaryEndpoint := foundCache.NodeGroups[0].PrimaryEndpoint
 	rdd := &providers.RedisDeploymentDetails{
 		URI:  *primaryEndpoint.Address,
@@ -315,6 +538,65 @@ func (p *RedisProvider) createElasticacheCluster(ctx context.Context, r *v1alpha
 	return &providers.RedisCluster{DeploymentDetails: rdd}, croType.StatusMessage(fmt.Sprintf("successfully created and tagged, aws elasticache status is %s", *foundCache.Status)), nil
 }
 
+// maintenanceWindowDays maps the day abbreviations used in aws maintenance windows
+var maintenanceWindowDays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// nextMaintenanceWindowStart returns the next time an aws preferred maintenance window of
+// the form ddd:hh24:mi-ddd:hh24:mi (e.g. sun:23:00-mon:01:30) opens after now, aws
+// maintenance windows are expressed in utc
+func nextMaintenanceWindowStart(window string, now time.Time) (time.Time, error) {
+	windowStart := strings.Split(window, "-")[0]
+	parts := strings.Split(windowStart, ":")
+	if len(parts) != 3 {
+		return time.Time{}, errorUtil.New(fmt.Sprintf("unexpected maintenance window format %s", window))
+	}
+	day, ok := maintenanceWindowDays[strings.ToLower(parts[0])]
+	if !ok {
+		return time.Time{}, errorUtil.New(fmt.Sprintf("unexpected day %s in maintenance window %s", parts[0], window))
+	}
+	hour, err := strconv.Atoi(parts[1])
+	if err != nil || hour < 0 || hour > 23 {
+		return time.Time{}, errorUtil.New(fmt.Sprintf("unexpected hour %s in maintenance window %s", parts[1], window))
+	}
+	minute, err := strconv.Atoi(parts[2])
+	if err != nil || minute < 0 || minute > 59 {
+		return time.Time{}, errorUtil.New(fmt.Sprintf("unexpected minute %s in maintenance window %s", parts[2], window))
+	}
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, time.UTC)
+	next = next.AddDate(0, 0, int((day-now.Weekday()+7)%7))
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next, nil
+}
+
+// setRedisNextMaintenanceWindow sets the start of the next preferred maintenance window of
+// the replication group on the cr status
+func (p *RedisProvider) setRedisNextMaintenanceWindow(cr *v1alpha1.Redis, replicationGroupClusters []elasticache.CacheCluster) {
+	for _, replicationGroupCluster := range replicationGroupClusters {
+		if replicationGroupCluster.PreferredMaintenanceWindow == nil {
+			continue
+		}
+		windowStart, err := nextMaintenanceWindowStart(aws.StringValue(replicationGroupCluster.PreferredMaintenanceWindow), time.Now())
+		if err != nil {
+			logrus.Errorf("failed to parse preferred maintenance window while setting next maintenance window for %s: %v", aws.StringValue(replicationGroupCluster.ReplicationGroupId), err)
+			return
+		}
+		nextMaintenanceWindow := metav1.NewTime(windowStart)
+		cr.Status.NextMaintenanceWindow = &nextMaintenanceWindow
+		return
+	}
+}
+
 // TagElasticacheNode Add Tags to AWS Elasticache
 func (p *RedisProvider) TagElasticacheNode(ctx context.Context, cacheSvc elasticacheiface.ElastiCacheAPI, stsSvc stsiface.STSAPI, r *v1alpha1.Redis, cache *elasticache.NodeGroupMember) (types.StatusMessage, error) {
 	logrus.Info("creating or updating tags on elasticache nodes and snapshots")
@@ -347,38 +629,16 @@ func (p *RedisProvider) TagElasticacheNode(ctx context.Context, cacheSvc elastic
 
 	// build cluster arn
 	// need arn in the following format arn:aws:elasticache:us-east-1:1234567890:cluster:my-mem-cluster
-	arn := fmt.Sprintf("arn:aws:elasticache:%s:%s:cluster:%s", region, *id.Account, *cache.CacheClusterId)
+	// the partition differs on govcloud and china clusters
+	arn := fmt.Sprintf("arn:%s:elasticache:%s:%s:cluster:%s", arnPartitionForRegion(region), region, *id.Account, *cache.CacheClusterId)
 
-	// set the tag values that will always be added
-	organizationTag := resources.GetOrganizationTag()
-	clusterID, err := resources.GetClusterID(ctx, p.Client)
+	// build the cross provider tag set applied to the elasticache node and its snapshots
+	resourceTags, err := resources.BuildDefaultResourceTags(ctx, p.Client, r.Spec.Type, r.ObjectMeta)
 	if err != nil {
-		errMsg := "failed to get cluster id"
-		return croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
-	}
-	cacheTags := []*elasticache.Tag{
-		{
-			Key:   aws.String(organizationTag + "clusterID"),
-			Value: aws.String(clusterID),
-		},
-		{
-			Key:   aws.String(organizationTag + "resource-type"),
-			Value: aws.String(r.Spec.Type),
-		},
-		{
-			Key:   aws.String(organizationTag + "resource-name"),
-			Value: aws.String(r.Name),
-		},
-	}
-
-	// check is product name exists on cr
-	if r.ObjectMeta.Labels["productName"] != "" {
-		productTag := &elasticache.Tag{
-			Key:   aws.String(organizationTag + "product-name"),
-			Value: aws.String(r.ObjectMeta.Labels["productName"]),
-		}
-		cacheTags = append(cacheTags, productTag)
+		errMsg := "failed to build resource tags"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
+	cacheTags := resourceTagsToElasticacheTags(resourceTags)
 
 	// add tags
 	_, err = cacheSvc.AddTagsToResource(&elasticache.AddTagsToResourceInput{
@@ -399,7 +659,7 @@ func (p *RedisProvider) TagElasticacheNode(ctx context.Context, cacheSvc elastic
 	snapshotList, _ := cacheSvc.DescribeSnapshots(inputDescribe)
 	if snapshotList.Snapshots != nil {
 		for _, snapshot := range snapshotList.Snapshots {
-			snapshotArn := fmt.Sprintf("arn:aws:elasticache:%s:%s:snapshot:%s", region, *id.Account, *snapshot.SnapshotName)
+			snapshotArn := fmt.Sprintf("arn:%s:elasticache:%s:%s:snapshot:%s", arnPartitionForRegion(region), region, *id.Account, *snapshot.SnapshotName)
 			logrus.Infof("Adding operator tags to snapshot : %s", *snapshot.SnapshotName)
 			snapshotInput := &elasticache.AddTagsToResourceInput{
 				ResourceName: aws.String(snapshotArn),
@@ -419,6 +679,9 @@ func (p *RedisProvider) TagElasticacheNode(ctx context.Context, cacheSvc elastic
 
 //DeleteRedis Delete elasticache replication group
 func (p *RedisProvider) DeleteRedis(ctx context.Context, r *v1alpha1.Redis) (croType.StatusMessage, error) {
+	ctx, span := resources.StartSpan(ctx, "aws.DeleteRedis")
+	defer span.End()
+	ctx = resources.WithAuditResource(ctx, "Redis", r.Namespace, r.Name)
 	// resolve elasticache information for elasticache created by provider
 	logger := p.Logger.WithField("action", "DeleteRedis")
 	logger.Infof("reconciling delete redis %s", r.Name)
@@ -426,6 +689,13 @@ func (p *RedisProvider) DeleteRedis(ctx context.Context, r *v1alpha1.Redis) (cro
 	// expose metrics about the redis being deleted
 	p.setRedisDeletionTimestampMetric(ctx, r)
 
+	// a retain policy leaves the elasticache cluster in place so it can be adopted by
+	// another cluster, only the operator owned kubernetes objects are removed
+	if r.Spec.DeletionPolicy == croType.DeletionPolicyRetain {
+		logger.Infof("deletion policy is Retain, elasticache cluster for %s is left in place", r.Name)
+		return p.removeRedisObjects(ctx, r)
+	}
+
 	elasticacheCreateConfig, elasticacheDeleteConfig, stratCfg, err := p.getElasticacheConfig(ctx, r)
 	if err != nil {
 		errMsg := fmt.Sprintf("failed to retrieve aws elasticache config for instance %s", r.Name)
@@ -440,7 +710,7 @@ func (p *RedisProvider) DeleteRedis(ctx context.Context, r *v1alpha1.Redis) (cro
 	}
 
 	// setup aws elasticache cluster sdk session
-	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds.AccessKeyID, providerCreds.SecretAccessKey, stratCfg)
+	sess, err := CreateSessionFromStrategy(ctx, p.Client, providerCreds, stratCfg)
 	if err != nil {
 		errMsg := "failed to create aws session to delete elasticache replication group"
 		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
@@ -515,27 +785,67 @@ func (p *RedisProvider) deleteElasticacheCluster(ctx context.Context, networkMan
 
 		return "delete detected, deleteReplicationGroup started", nil
 	}
+
+	// remove the dedicated parameter group once the replication group is gone, the group only
+	// exists when the strategy for the tier declares redis parameters
+	_, err = cacheSvc.DeleteCacheParameterGroup(&elasticache.DeleteCacheParameterGroupInput{
+		CacheParameterGroupName: aws.String(buildParameterGroupName(aws.StringValue(elasticacheDeleteConfig.ReplicationGroupId))),
+	})
+	elasticacheErr, isAwsErr := err.(awserr.Error)
+	if err != nil && (!isAwsErr || elasticacheErr.Code() != elasticache.ErrCodeCacheParameterGroupNotFoundFault) {
+		errMsg := "failed to delete elasticache parameter group"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
 	if standaloneNetworkExists && isLastResource {
 		logger.Info("found the last instance of types postgres and redis so deleting the standalone network")
-		networkPeering, err := networkManager.GetClusterNetworkPeering(ctx)
+		// when the _network strategy references an existing vpc, only the resources the operator
+		// created around it are removed, the vpc and subnets themselves are left untouched
+		existingNetworkConfig, err := getExistingNetworkConfig(ctx, p.ConfigManager, r.Spec.Tier, logger)
 		if err != nil {
-			msg := "failed to get cluster network peering"
+			msg := "failed to get existing network config from _network strategy"
 			return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
 		}
 
-		if err = networkManager.DeleteNetworkConnection(ctx, networkPeering); err != nil {
-			msg := "failed to delete network connection"
-			return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
-		}
+		if existingNetworkConfig != nil {
+			if err := networkManager.DeleteExistingNetwork(ctx, aws.StringValue(existingNetworkConfig.VpcId)); err != nil {
+				msg := "failed to delete network resources for existing vpc"
+				return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+			}
+		} else {
+			connConfig, err := getNetworkConnectionConfig(ctx, p.ConfigManager, r.Spec.Tier, logger)
+			if err != nil {
+				msg := "failed to get network connection config from _network strategy"
+				return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+			}
 
-		if err = networkManager.DeleteNetworkPeering(networkPeering); err != nil {
-			msg := "failed to delete cluster network peering"
-			return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
-		}
+			if isTransitGatewayConnection(connConfig) {
+				if err := networkManager.DeleteNetworkTransitGatewayAttachment(ctx, aws.StringValue(connConfig.TransitGatewayId)); err != nil {
+					msg := "failed to delete transit gateway attachment"
+					return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+				}
+			} else {
+				networkPeering, err := networkManager.GetClusterNetworkPeering(ctx)
+				if err != nil {
+					msg := "failed to get cluster network peering"
+					return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+				}
+
+				if err = networkManager.DeleteNetworkConnection(ctx, networkPeering); err != nil {
+					msg := "failed to delete network connection"
+					return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+				}
+
+				if err = networkManager.DeleteNetworkPeering(networkPeering); err != nil {
+					msg := "failed to delete cluster network peering"
+					return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+				}
+			}
 
-		if err = networkManager.DeleteNetwork(ctx); err != nil {
-			msg := "failed to delete aws networking"
-			return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+			if err := networkManager.DeleteNetwork(ctx); err != nil {
+				msg := "failed to delete aws networking"
+				return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+			}
 		}
 	}
 
@@ -549,6 +859,18 @@ func (p *RedisProvider) deleteElasticacheCluster(ctx context.Context, networkMan
 			return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
 		}
 	}
+	return p.removeRedisObjects(ctx, r)
+}
+
+// removeRedisObjects removes the operator owned kubernetes objects for the cr, the per cr
+// prometheus rules and the finalizer
+func (p *RedisProvider) removeRedisObjects(ctx context.Context, r *v1alpha1.Redis) (croType.StatusMessage, error) {
+	// remove the per cr prometheus rules created for the replication group
+	if err := resources.DeleteRedisAlerts(ctx, p.Client, r); err != nil {
+		msg := "failed to delete redis alerts"
+		return croType.StatusMessage(msg), errorUtil.Wrap(err, msg)
+	}
+
 	// remove the finalizer added by the provider
 	resources.RemoveFinalizer(&r.ObjectMeta, DefaultFinalizer)
 	if err := p.Client.Update(ctx, r); err != nil {
@@ -581,6 +903,13 @@ func (p *RedisProvider) getElasticacheConfig(ctx context.Context, r *v1alpha1.Re
 	if err != nil {
 		return nil, nil, nil, errorUtil.Wrap(err, "failed to read aws strategy config")
 	}
+
+	// apply any per-cr provider config overrides to the create strategy
+	if r.Spec.ProviderConfig != nil {
+		if err := stratCfg.ApplyProviderConfig(r.Spec.ProviderConfig); err != nil {
+			return nil, nil, nil, errorUtil.Wrap(err, "failed to apply provider config overrides")
+		}
+	}
 	defRegion, err := GetRegionFromStrategyOrDefault(ctx, p.Client, stratCfg)
 	if err != nil {
 		return nil, nil, nil, errorUtil.Wrap(err, "failed to get default region")
@@ -593,12 +922,22 @@ func (p *RedisProvider) getElasticacheConfig(ctx context.Context, r *v1alpha1.Re
 	// unmarshal the elasticache cluster config
 	elasticacheCreateConfig := &elasticache.CreateReplicationGroupInput{}
 	if err := json.Unmarshal(stratCfg.CreateStrategy, elasticacheCreateConfig); err != nil {
-		return nil, nil, nil, errorUtil.Wrap(err, "failed to unmarshal aws elasticache cluster configuration")
+		return nil, nil, nil, resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, "failed to unmarshal aws elasticache cluster configuration")
+	}
+
+	// map the size on the cr to a cache node type, the size takes precedence over the cache node
+	// type in the create strategy so resizing does not require editing the strategy config map
+	if r.Spec.Size != "" {
+		cacheNodeType, err := stratCfg.ResolveSizeClass(r.Spec.Size, defaultRedisSizeClasses)
+		if err != nil {
+			return nil, nil, nil, resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, "failed to resolve cache node type from size")
+		}
+		elasticacheCreateConfig.CacheNodeType = aws.String(cacheNodeType)
 	}
 
 	elasticacheDeleteConfig := &elasticache.DeleteReplicationGroupInput{}
 	if err := json.Unmarshal(stratCfg.DeleteStrategy, elasticacheDeleteConfig); err != nil {
-		return nil, nil, nil, errorUtil.Wrap(err, "failed to unmarshal aws elasticache cluster configuration")
+		return nil, nil, nil, resources.WrapWithClass(err, resources.ErrorClassInvalidConfig, "failed to unmarshal aws elasticache cluster configuration")
 	}
 	return elasticacheCreateConfig, elasticacheDeleteConfig, stratCfg, nil
 }
@@ -620,6 +959,56 @@ func (p *RedisProvider) isLastResource(ctx context.Context, namespace string) (b
 	return len(postgresList.Items) == 0 && len(redisList.Items) == 1, nil
 }
 
+// buildElasticacheDriftItems builds a list of drifted fields from the proposed modifications to an elasticache
+// replication group, used to expose drift between the expected strategy and the found replication group
+func buildElasticacheDriftItems(mi *elasticache.ModifyReplicationGroupInput, foundConfig *elasticache.ReplicationGroup, replicationGroupClusters []elasticache.CacheCluster) []resources.DriftItem {
+	if mi == nil {
+		return nil
+	}
+	var items []resources.DriftItem
+	if mi.CacheNodeType != nil {
+		items = append(items, resources.DriftItem{Field: "cacheNodeType", Expected: aws.StringValue(mi.CacheNodeType), Actual: aws.StringValue(foundConfig.CacheNodeType)})
+	}
+	if mi.SnapshotRetentionLimit != nil {
+		items = append(items, resources.DriftItem{Field: "snapshotRetentionLimit", Expected: strconv.FormatInt(aws.Int64Value(mi.SnapshotRetentionLimit), 10), Actual: strconv.FormatInt(aws.Int64Value(foundConfig.SnapshotRetentionLimit), 10)})
+	}
+	// engine version, maintenance window and snapshot window can only be retrieved from the underlying cache clusters
+	if len(replicationGroupClusters) > 0 {
+		foundCluster := replicationGroupClusters[0]
+		if mi.EngineVersion != nil {
+			items = append(items, resources.DriftItem{Field: "engineVersion", Expected: aws.StringValue(mi.EngineVersion), Actual: aws.StringValue(foundCluster.EngineVersion)})
+		}
+		if mi.PreferredMaintenanceWindow != nil {
+			items = append(items, resources.DriftItem{Field: "preferredMaintenanceWindow", Expected: aws.StringValue(mi.PreferredMaintenanceWindow), Actual: aws.StringValue(foundCluster.PreferredMaintenanceWindow)})
+		}
+		if mi.SnapshotWindow != nil {
+			items = append(items, resources.DriftItem{Field: "snapshotWindow", Expected: aws.StringValue(mi.SnapshotWindow), Actual: aws.StringValue(foundCluster.SnapshotWindow)})
+		}
+		if mi.CacheParameterGroupName != nil && foundCluster.CacheParameterGroup != nil {
+			items = append(items, resources.DriftItem{Field: "cacheParameterGroupName", Expected: aws.StringValue(mi.CacheParameterGroupName), Actual: aws.StringValue(foundCluster.CacheParameterGroup.CacheParameterGroupName)})
+		}
+	}
+	return items
+}
+
+// expose the number of drifted fields on the redis instance as a metric
+func (p *RedisProvider) exposeRedisDriftMetric(ctx context.Context, cr *v1alpha1.Redis, items []resources.DriftItem) {
+	// build cache name
+	cacheName, err := p.buildCacheName(ctx, cr)
+	if err != nil {
+		logrus.Errorf("error occurred while building cache name during redis drift metric: %v", err)
+	}
+
+	// get Cluster Id
+	clusterID, err := resources.GetClusterID(ctx, p.Client)
+	if err != nil {
+		logrus.Errorf("failed to get cluster id while exposing drift metric for %v", cacheName)
+		return
+	}
+
+	resources.SetResourceDriftMetric(buildRedisGenericMetricLabels(cr, clusterID, cacheName), items)
+}
+
 // buildElasticacheUpdateStrategy compare the current elasticache state to the proposed elasticache state from the
 // strategy map.
 //
@@ -723,6 +1112,12 @@ func buildElasticacheUpdateStrategy(ec2Client ec2iface.EC2API, elasticacheConfig
 			modifyInput.SnapshotWindow = elasticacheConfig.SnapshotWindow
 			updateFound = true
 		}
+
+		// check if the dedicated parameter group requires an update.
+		if elasticacheConfig.CacheParameterGroupName != nil && foundCacheCluster.CacheParameterGroup != nil && *elasticacheConfig.CacheParameterGroupName != *foundCacheCluster.CacheParameterGroup.CacheParameterGroupName {
+			modifyInput.CacheParameterGroupName = elasticacheConfig.CacheParameterGroupName
+			updateFound = true
+		}
 	}
 
 	if updateFound {
@@ -731,6 +1126,229 @@ func buildElasticacheUpdateStrategy(ec2Client ec2iface.EC2API, elasticacheConfig
 	return nil, nil
 }
 
+// reconcileCacheParameterGroup maintains the dedicated cache parameter group for the cr when
+// the strategy for the tier declares redis parameters, parameter drift in the group is
+// reconciled on every pass
+func (p *RedisProvider) reconcileCacheParameterGroup(r *v1alpha1.Redis, cacheSvc elasticacheiface.ElastiCacheAPI, elasticacheConfig *elasticache.CreateReplicationGroupInput, stratCfg *StrategyConfig) error {
+	desiredParameters := buildDesiredCacheParameters(r.Spec.Tier, stratCfg.Parameters)
+	if len(desiredParameters) == 0 {
+		return nil
+	}
+	if err := validateReservedMemoryParameters(desiredParameters); err != nil {
+		return err
+	}
+	logger := p.Logger.WithField("action", "reconcileCacheParameterGroup")
+	groupName := buildParameterGroupName(aws.StringValue(elasticacheConfig.ReplicationGroupId))
+	engineVersion := defaultEngineVersion
+	if elasticacheConfig.EngineVersion != nil {
+		engineVersion = *elasticacheConfig.EngineVersion
+	}
+	_, err := cacheSvc.DescribeCacheParameterGroups(&elasticache.DescribeCacheParameterGroupsInput{
+		CacheParameterGroupName: aws.String(groupName),
+	})
+	cacheErr, isAwsErr := err.(awserr.Error)
+	if err != nil {
+		if !isAwsErr || cacheErr.Code() != elasticache.ErrCodeCacheParameterGroupNotFoundFault {
+			return errorUtil.Wrapf(err, "failed to describe cache parameter group %s", groupName)
+		}
+		family := elasticacheParameterGroupFamily(engineVersion)
+		logger.Infof("creating cache parameter group %s with family %s", groupName, family)
+		if _, err := cacheSvc.CreateCacheParameterGroup(&elasticache.CreateCacheParameterGroupInput{
+			CacheParameterGroupName:   aws.String(groupName),
+			CacheParameterGroupFamily: aws.String(family),
+			Description:               aws.String(fmt.Sprintf("parameter group for redis cr %s in namespace %s", r.Name, r.Namespace)),
+		}); err != nil {
+			return errorUtil.Wrapf(err, "failed to create cache parameter group %s", groupName)
+		}
+	}
+	describeParametersOutput, err := cacheSvc.DescribeCacheParameters(&elasticache.DescribeCacheParametersInput{
+		CacheParameterGroupName: aws.String(groupName),
+	})
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to describe parameters of cache parameter group %s", groupName)
+	}
+	parameterUpdates := buildCacheParameterUpdates(desiredParameters, describeParametersOutput.Parameters)
+	if len(parameterUpdates) > 0 {
+		logger.Infof("updating %d parameters in cache parameter group %s", len(parameterUpdates), groupName)
+		if _, err := cacheSvc.ModifyCacheParameterGroup(&elasticache.ModifyCacheParameterGroupInput{
+			CacheParameterGroupName: aws.String(groupName),
+			ParameterNameValues:     parameterUpdates,
+		}); err != nil {
+			return errorUtil.Wrapf(err, "failed to modify cache parameter group %s", groupName)
+		}
+	}
+	elasticacheConfig.CacheParameterGroupName = aws.String(groupName)
+	return nil
+}
+
+// buildDesiredCacheParameters merges the tier default maxmemory-policy with the parameters
+// declared on the strategy for the tier, strategy parameters take precedence so the policy
+// remains overridable per tier
+func buildDesiredCacheParameters(tier string, strategyParameters map[string]string) map[string]string {
+	desired := map[string]string{}
+	if policy, ok := defaultTierMaxMemoryPolicies[tier]; ok {
+		desired["maxmemory-policy"] = policy
+	}
+	for name, value := range strategyParameters {
+		desired[name] = value
+	}
+	return desired
+}
+
+// buildCacheParameterUpdates returns the parameter changes needed to bring a cache parameter
+// group in line with the parameters declared on the strategy for the tier
+func buildCacheParameterUpdates(desired map[string]string, found []*elasticache.Parameter) []*elasticache.ParameterNameValue {
+	foundValues := map[string]string{}
+	for _, parameter := range found {
+		foundValues[aws.StringValue(parameter.ParameterName)] = aws.StringValue(parameter.ParameterValue)
+	}
+	var updates []*elasticache.ParameterNameValue
+	for name, value := range desired {
+		if foundValues[name] == value {
+			continue
+		}
+		updates = append(updates, &elasticache.ParameterNameValue{
+			ParameterName:  aws.String(name),
+			ParameterValue: aws.String(value),
+		})
+	}
+	// map iteration order is random, sort so the applied updates are deterministic
+	sort.Slice(updates, func(i, j int) bool {
+		return aws.StringValue(updates[i].ParameterName) < aws.StringValue(updates[j].ParameterName)
+	})
+	return updates
+}
+
+// isDataTieringNodeType returns true for cache node types that tier data onto local ssd,
+// currently the r6gd family
+func isDataTieringNodeType(cacheNodeType string) bool {
+	parts := strings.Split(cacheNodeType, ".")
+	return len(parts) >= 2 && strings.HasSuffix(parts[1], "gd")
+}
+
+// engineSupportsDataTiering returns true when the engine version can run on a data tiering
+// cache node type, aws requires redis 6.2 or later
+func engineSupportsDataTiering(engineVersion string) bool {
+	parts := strings.Split(engineVersion, ".")
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	if major != 6 {
+		return major > 6
+	}
+	if len(parts) < 2 {
+		return false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	return err == nil && minor >= 2
+}
+
+// validateReservedMemoryParameters checks the reserved memory settings declared on the
+// strategy for the tier, aws rejects groups that set both the absolute and the percentage
+// based parameter and the percentage must be between 0 and 100
+func validateReservedMemoryParameters(parameters map[string]string) error {
+	percent, percentSet := parameters["reserved-memory-percent"]
+	_, absoluteSet := parameters["reserved-memory"]
+	if percentSet && absoluteSet {
+		return errorUtil.New("reserved-memory and reserved-memory-percent cannot both be set, use reserved-memory-percent")
+	}
+	if percentSet {
+		value, err := strconv.Atoi(percent)
+		if err != nil || value < 0 || value > 100 {
+			return errorUtil.New(fmt.Sprintf("reserved-memory-percent must be a percentage between 0 and 100, got %s", percent))
+		}
+	}
+	return nil
+}
+
+// elasticacheParameterGroupFamily maps a redis engine version to its parameter group family
+func elasticacheParameterGroupFamily(engineVersion string) string {
+	parts := strings.Split(engineVersion, ".")
+	// redis 6 and later use a single family per major version
+	if major, err := strconv.Atoi(parts[0]); err == nil && major >= 6 {
+		return fmt.Sprintf("redis%d.x", major)
+	}
+	if len(parts) >= 2 {
+		return fmt.Sprintf("redis%s.%s", parts[0], parts[1])
+	}
+	return fmt.Sprintf("redis%s", engineVersion)
+}
+
+// buildParameterGroupName returns the name of the dedicated parameter group of an instance
+func buildParameterGroupName(instanceName string) string {
+	return fmt.Sprintf("%s-params", instanceName)
+}
+
+// ensurePreUpgradeSnapshot takes a snapshot of the replication group before an engine version
+// upgrade is applied, returning true once the snapshot is available
+func (p *RedisProvider) ensurePreUpgradeSnapshot(cacheSvc elasticacheiface.ElastiCacheAPI, foundCache *elasticache.ReplicationGroup, engineVersion string) (bool, croType.StatusMessage, error) {
+	logger := p.Logger.WithField("action", "ensurePreUpgradeSnapshot")
+	snapshotName := fmt.Sprintf("%s-pre-upgrade-%s", aws.StringValue(foundCache.ReplicationGroupId), strings.ReplaceAll(engineVersion, ".", "-"))
+	describeOutput, err := cacheSvc.DescribeSnapshots(&elasticache.DescribeSnapshotsInput{
+		SnapshotName: aws.String(snapshotName),
+	})
+	cacheErr, isAwsErr := err.(awserr.Error)
+	if err != nil && (!isAwsErr || cacheErr.Code() != elasticache.ErrCodeSnapshotNotFoundFault) {
+		return false, "", errorUtil.Wrapf(err, "failed to describe pre upgrade snapshot %s", snapshotName)
+	}
+	if err == nil && len(describeOutput.Snapshots) > 0 {
+		snapshot := describeOutput.Snapshots[0]
+		if aws.StringValue(snapshot.SnapshotStatus) != "available" {
+			statusMessage := fmt.Sprintf("engine upgrade detected, pre upgrade snapshot %s in progress, current status is %s", snapshotName, aws.StringValue(snapshot.SnapshotStatus))
+			logger.Info(statusMessage)
+			return false, croType.StatusMessage(statusMessage), nil
+		}
+		return true, "", nil
+	}
+	logger.Infof("creating pre upgrade snapshot %s for engine version %s", snapshotName, engineVersion)
+	if _, err := cacheSvc.CreateSnapshot(&elasticache.CreateSnapshotInput{
+		ReplicationGroupId: foundCache.ReplicationGroupId,
+		SnapshotName:       aws.String(snapshotName),
+	}); err != nil {
+		return false, "", errorUtil.Wrapf(err, "failed to create pre upgrade snapshot %s", snapshotName)
+	}
+	return false, croType.StatusMessage(fmt.Sprintf("engine upgrade detected, pre upgrade snapshot %s started", snapshotName)), nil
+}
+
+// buildRedisPlan reports the cache configuration and network layout a create would use, the
+// values follow the same defaulting rules as buildElasticacheCreateStrategy so the plan
+// matches what a real reconcile would provision
+func (p *RedisProvider) buildRedisPlan(ctx context.Context, r *v1alpha1.Redis, elasticacheCreateConfig *elasticache.CreateReplicationGroupInput, isEnabled bool) croType.StatusMessage {
+	cacheNodeType := defaultCacheNodeType
+	if elasticacheCreateConfig.CacheNodeType != nil {
+		cacheNodeType = *elasticacheCreateConfig.CacheNodeType
+	}
+	engineVersion := defaultEngineVersion
+	if elasticacheCreateConfig.EngineVersion != nil {
+		engineVersion = *elasticacheCreateConfig.EngineVersion
+	}
+	numCacheClusters := int64(defaultNumCacheClusters)
+	if elasticacheCreateConfig.NumCacheClusters != nil {
+		numCacheClusters = *elasticacheCreateConfig.NumCacheClusters
+	}
+
+	// the abstract size acts as a cost class for reviewers, explicit cache node types that do
+	// not map back to an abstract size are reported as custom
+	costClass := "custom"
+	for size, class := range defaultRedisSizeClasses {
+		if class == cacheNodeType {
+			costClass = size
+			break
+		}
+	}
+
+	networkLayout := "cluster vpc (bundled)"
+	if isEnabled {
+		networkLayout = "standalone vpc"
+		if vpcCidrBlock, err := getNetworkProviderConfig(ctx, p.ConfigManager, r.Spec.Tier, p.Logger); err == nil {
+			networkLayout = fmt.Sprintf("standalone vpc %s", vpcCidrBlock.String())
+		}
+	}
+
+	return croType.StatusMessage(fmt.Sprintf("dry run: would create elasticache node type %s (cost class %s), engine version %s, %d cache clusters, network %s", cacheNodeType, costClass, engineVersion, numCacheClusters, networkLayout))
+}
+
 // verifyRedisConfig checks elasticache config, if none exist sets values to default
 func (p *RedisProvider) buildElasticacheCreateStrategy(ctx context.Context, r *v1alpha1.Redis, ec2Svc ec2iface.EC2API, elasticacheConfig *elasticache.CreateReplicationGroupInput) error {
 
@@ -758,6 +1376,11 @@ func (p *RedisProvider) buildElasticacheCreateStrategy(ctx context.Context, r *v
 	if elasticacheConfig.TransitEncryptionEnabled == nil {
 		elasticacheConfig.TransitEncryptionEnabled = aws.Bool(defaultInTransitEncryption)
 	}
+	// data tiering node types keep part of the keyspace on local ssd, aws only supports them
+	// on redis 6.2 and later so the combination is rejected before the create is issued
+	if isDataTieringNodeType(aws.StringValue(elasticacheConfig.CacheNodeType)) && !engineSupportsDataTiering(aws.StringValue(elasticacheConfig.EngineVersion)) {
+		return errorUtil.New(fmt.Sprintf("data tiering cache node type %s requires redis engine version 6.2 or later, version %s is configured", aws.StringValue(elasticacheConfig.CacheNodeType), aws.StringValue(elasticacheConfig.EngineVersion)))
+	}
 	cacheName, err := BuildInfraNameFromObject(ctx, p.Client, r.ObjectMeta, DefaultAwsIdentifierLength)
 	if err != nil {
 		return errorUtil.Wrapf(err, "failed to retrieve elasticache config")
@@ -820,7 +1443,7 @@ func (p *RedisProvider) buildElasticacheDeleteConfig(ctx context.Context, r v1al
 }
 
 // ensures a subnet group is in place to configure the resource, so that it is in the same vpc as the cluster
-func (p *RedisProvider) configureElasticacheVpc(ctx context.Context, cacheSvc elasticacheiface.ElastiCacheAPI, ec2Svc ec2iface.EC2API) error {
+func (p *RedisProvider) configureElasticacheVpc(ctx context.Context, r *v1alpha1.Redis, cacheSvc elasticacheiface.ElastiCacheAPI, ec2Svc ec2iface.EC2API) error {
 	logrus.Info("configuring cluster vpc for redis resource")
 	// get subnet group id
 	sgName, err := BuildInfraName(ctx, p.Client, defaultSubnetPostfix, DefaultAwsIdentifierLength)
@@ -842,7 +1465,10 @@ func (p *RedisProvider) configureElasticacheVpc(ctx context.Context, cacheSvc el
 	}
 	if foundSubnet != nil {
 		logrus.Infof("%s resource subnet group found", *foundSubnet.CacheSubnetGroupName)
-		return nil
+		// cache subnet groups cannot be tagged with this sdk version, so unlike db subnet
+		// groups ownership cannot be verified here, the cluster id prefix on the group
+		// name is the only isolation between clusters sharing an account
+		return p.reconcileCacheSubnetGroupMembership(ctx, r, cacheSvc, ec2Svc, foundSubnet)
 	}
 
 	// get cluster vpc subnets
@@ -866,6 +1492,48 @@ func (p *RedisProvider) configureElasticacheVpc(ctx context.Context, cacheSvc el
 	return nil
 }
 
+// the cluster can gain availability zones or run subnets out of addresses after the subnet
+// group is first created, GetPrivateSubnetIDS creates any missing private subnets and the
+// group is extended to the current topology so new clusters are not placed in a stale layout
+func (p *RedisProvider) reconcileCacheSubnetGroupMembership(ctx context.Context, r *v1alpha1.Redis, cacheSvc elasticacheiface.ElastiCacheAPI, ec2Svc ec2iface.EC2API, foundSubnet *elasticache.CacheSubnetGroup) error {
+	subIDs, err := GetPrivateSubnetIDS(ctx, p.Client, ec2Svc, p.Logger)
+	if err != nil {
+		return errorUtil.Wrap(err, "error getting vpc subnets")
+	}
+	var groupSubnetIds []*string
+	for _, sub := range foundSubnet.Subnets {
+		groupSubnetIds = append(groupSubnetIds, sub.SubnetIdentifier)
+	}
+	if subnetIdsMatch(groupSubnetIds, subIDs) {
+		r.Status.Conditions = croType.SetCondition(r.Status.Conditions, croType.StatusCondition{
+			Type:    ConditionTypeSubnetGroupStale,
+			Status:  "False",
+			Message: fmt.Sprintf("cache subnet group %s matches the cluster subnet topology", aws.StringValue(foundSubnet.CacheSubnetGroupName)),
+		})
+		return nil
+	}
+	logrus.Infof("extending cache subnet group %s to the current cluster subnet topology", aws.StringValue(foundSubnet.CacheSubnetGroupName))
+	if _, err := cacheSvc.ModifyCacheSubnetGroup(&elasticache.ModifyCacheSubnetGroupInput{
+		CacheSubnetGroupName: foundSubnet.CacheSubnetGroupName,
+		SubnetIds:            subIDs,
+	}); err != nil {
+		// an incompatible topology, e.g. a subnet in an unsupported availability zone, is
+		// surfaced as a condition on the cr rather than an opaque create failure
+		r.Status.Conditions = croType.SetCondition(r.Status.Conditions, croType.StatusCondition{
+			Type:    ConditionTypeSubnetGroupStale,
+			Status:  "True",
+			Message: fmt.Sprintf("cache subnet group %s could not be extended to the current subnet topology: %s", aws.StringValue(foundSubnet.CacheSubnetGroupName), err),
+		})
+		return errorUtil.Wrap(err, "unable to modify cache subnet group")
+	}
+	r.Status.Conditions = croType.SetCondition(r.Status.Conditions, croType.StatusCondition{
+		Type:    ConditionTypeSubnetGroupStale,
+		Status:  "False",
+		Message: fmt.Sprintf("cache subnet group %s extended to the cluster subnet topology", aws.StringValue(foundSubnet.CacheSubnetGroupName)),
+	})
+	return nil
+}
+
 // returns generic labels to be added to every metric
 func buildRedisGenericMetricLabels(r *v1alpha1.Redis, clusterID, cacheName string) map[string]string {
 	labels := map[string]string{}
@@ -939,6 +1607,17 @@ func (p *RedisProvider) exposeRedisMetrics(ctx context.Context, cr *v1alpha1.Red
 	} else {
 		resources.SetMetric(resources.DefaultRedisAvailMetricName, genericLabels, 1)
 	}
+
+	// estimate the monthly on demand cost of the replication group for chargeback dashboards,
+	// cache node types with no published rate are skipped rather than reported with a
+	// misleading zero
+	if instance != nil && instance.CacheNodeType != nil {
+		if cost, ok := resources.GetPricingTable(ctx, p.Client).EstimateMonthlyCost(*instance.CacheNodeType, int64(len(instance.MemberClusters)), 0); ok {
+			costLabels := buildRedisGenericMetricLabels(cr, clusterID, cacheName)
+			costLabels["instanceClass"] = *instance.CacheNodeType
+			resources.SetMetric(resources.DefaultResourceEstimatedCostMetricName, costLabels, cost)
+		}
+	}
 }
 
 // set metrics about the redis instance being deleted
@@ -1010,6 +1689,132 @@ func (p *RedisProvider) setRedisServiceMaintenanceMetric(ctx context.Context, ca
 	}
 }
 
+// how far back the elasticache event stream is scanned on each reconcile, wide enough that a
+// failover between reconciles of a healthy resource is not missed
+const elasticacheEventLookbackMinutes = 60
+
+// surfaceReplicationGroupDisruptions reflects cloud side disruptions of the replication group
+// on the cr, recent primary failovers and node replacements are read from the elasticache
+// event stream and pending maintenance from the update actions of the replication group. The
+// conditions are bridged to kubernetes events by the shared reconciler, failures here are
+// logged and never block the reconcile
+func (p *RedisProvider) surfaceReplicationGroupDisruptions(ctx context.Context, r *v1alpha1.Redis, cacheSvc elasticacheiface.ElastiCacheAPI, replicationGroup *elasticache.ReplicationGroup) {
+	if replicationGroup == nil {
+		return
+	}
+	logger := p.Logger.WithField("action", "surfaceReplicationGroupDisruptions")
+	events, err := getReplicationGroupEvents(cacheSvc, replicationGroup)
+	if err != nil {
+		logger.Errorf("failed to describe elasticache events for %s : %v", aws.StringValue(replicationGroup.ReplicationGroupId), err)
+		return
+	}
+	r.Status.Conditions = croType.SetCondition(r.Status.Conditions, resources.BuildDisruptionCondition(
+		resources.ConditionTypePrimaryFailover,
+		latestEventMessage(events, isElasticacheFailoverEvent),
+		"no recent primary failover on the elasticache replication group",
+	))
+	r.Status.Conditions = croType.SetCondition(r.Status.Conditions, resources.BuildDisruptionCondition(
+		resources.ConditionTypeNodeReplacement,
+		latestEventMessage(events, isElasticacheNodeReplacementEvent),
+		"no recent node replacement on the elasticache replication group",
+	))
+	pendingMaintenance, err := getPendingMaintenanceMessage(cacheSvc, replicationGroup)
+	if err != nil {
+		logger.Errorf("failed to describe elasticache update actions for %s : %v", aws.StringValue(replicationGroup.ReplicationGroupId), err)
+		return
+	}
+	r.Status.Conditions = croType.SetCondition(r.Status.Conditions, resources.BuildDisruptionCondition(
+		resources.ConditionTypePendingMaintenance,
+		pendingMaintenance,
+		"no maintenance actions pending on the elasticache replication group",
+	))
+}
+
+// getReplicationGroupEvents returns the recent events of the replication group and its member
+// cache clusters, elasticache reports failovers on the replication group and node level
+// activity on the member clusters
+func getReplicationGroupEvents(cacheSvc elasticacheiface.ElastiCacheAPI, replicationGroup *elasticache.ReplicationGroup) ([]*elasticache.Event, error) {
+	output, err := cacheSvc.DescribeEvents(&elasticache.DescribeEventsInput{
+		SourceType:       aws.String(elasticache.SourceTypeReplicationGroup),
+		SourceIdentifier: replicationGroup.ReplicationGroupId,
+		Duration:         aws.Int64(elasticacheEventLookbackMinutes),
+	})
+	if err != nil {
+		return nil, errorUtil.Wrapf(err, "failed to describe events for replication group %s", aws.StringValue(replicationGroup.ReplicationGroupId))
+	}
+	events := output.Events
+	for _, memberCluster := range replicationGroup.MemberClusters {
+		memberOutput, err := cacheSvc.DescribeEvents(&elasticache.DescribeEventsInput{
+			SourceType:       aws.String(elasticache.SourceTypeCacheCluster),
+			SourceIdentifier: memberCluster,
+			Duration:         aws.Int64(elasticacheEventLookbackMinutes),
+		})
+		if err != nil {
+			return nil, errorUtil.Wrapf(err, "failed to describe events for cache cluster %s", aws.StringValue(memberCluster))
+		}
+		events = append(events, memberOutput.Events...)
+	}
+	return events, nil
+}
+
+// latestEventMessage returns the message of the most recent event matching the classifier
+// together with its source and time, an empty string when no event matches
+func latestEventMessage(events []*elasticache.Event, matches func(string) bool) string {
+	var latest *elasticache.Event
+	for _, event := range events {
+		if !matches(strings.ToLower(aws.StringValue(event.Message))) {
+			continue
+		}
+		if latest == nil || aws.TimeValue(event.Date).After(aws.TimeValue(latest.Date)) {
+			latest = event
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s at %s)", aws.StringValue(latest.Message), aws.StringValue(latest.SourceIdentifier), aws.TimeValue(latest.Date).Format(time.RFC3339))
+}
+
+// isElasticacheFailoverEvent matches the event messages elasticache emits when the primary of
+// a node group fails over to a replica
+func isElasticacheFailoverEvent(message string) bool {
+	return strings.Contains(message, "failover")
+}
+
+// isElasticacheNodeReplacementEvent matches the event messages elasticache emits while cache
+// nodes are recovered or replaced
+func isElasticacheNodeReplacementEvent(message string) bool {
+	return strings.Contains(message, "recover") || strings.Contains(message, "replac")
+}
+
+// getPendingMaintenanceMessage summarises the update actions not yet applied to the
+// replication group, an empty string when nothing is pending
+func getPendingMaintenanceMessage(cacheSvc elasticacheiface.ElastiCacheAPI, replicationGroup *elasticache.ReplicationGroup) (string, error) {
+	output, err := cacheSvc.DescribeUpdateActions(&elasticache.DescribeUpdateActionsInput{
+		ReplicationGroupIds: []*string{replicationGroup.ReplicationGroupId},
+		UpdateActionStatus: aws.StringSlice([]string{
+			elasticache.UpdateActionStatusNotApplied,
+			elasticache.UpdateActionStatusWaitingToStart,
+			elasticache.UpdateActionStatusInProgress,
+		}),
+	})
+	if err != nil {
+		return "", errorUtil.Wrapf(err, "failed to describe update actions for replication group %s", aws.StringValue(replicationGroup.ReplicationGroupId))
+	}
+	if len(output.UpdateActions) == 0 {
+		return "", nil
+	}
+	pending := make([]string, 0, len(output.UpdateActions))
+	for _, updateAction := range output.UpdateActions {
+		item := fmt.Sprintf("%s (%s)", aws.StringValue(updateAction.ServiceUpdateName), aws.StringValue(updateAction.UpdateActionStatus))
+		if updateAction.ServiceUpdateRecommendedApplyByDate != nil {
+			item = fmt.Sprintf("%s (%s, apply by %s)", aws.StringValue(updateAction.ServiceUpdateName), aws.StringValue(updateAction.UpdateActionStatus), updateAction.ServiceUpdateRecommendedApplyByDate.Format(time.RFC3339))
+		}
+		pending = append(pending, item)
+	}
+	return fmt.Sprintf("maintenance actions pending on elasticache replication group %s: %s", aws.StringValue(replicationGroup.ReplicationGroupId), strings.Join(pending, ", ")), nil
+}
+
 func (p *RedisProvider) createElasticacheConnectionMetric(ctx context.Context, cr *v1alpha1.Redis, cache *elasticache.ReplicationGroup) {
 	// build cache name
 	cacheName, err := p.buildCacheName(ctx, cr)
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"reflect"
 	"time"
@@ -15,6 +16,7 @@ import (
 	croApis "github.com/integr8ly/cloud-resource-operator/pkg/apis"
 	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
 	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -58,6 +60,8 @@ type mockElasticacheClient struct {
 	describeCacheClustersFn     func(*elasticache.DescribeCacheClustersInput) (*elasticache.DescribeCacheClustersOutput, error)
 	describeReplicationGroupsFn func(*elasticache.DescribeReplicationGroupsInput) (*elasticache.DescribeReplicationGroupsOutput, error)
 	describeSnapshotsFn         func(*elasticache.DescribeSnapshotsInput) (*elasticache.DescribeSnapshotsOutput, error)
+	describeEventsFn            func(*elasticache.DescribeEventsInput) (*elasticache.DescribeEventsOutput, error)
+	describeUpdateActionsFn     func(*elasticache.DescribeUpdateActionsInput) (*elasticache.DescribeUpdateActionsOutput, error)
 	createSnapshotFn            func(*elasticache.CreateSnapshotInput) (*elasticache.CreateSnapshotOutput, error)
 	deleteSnapshotFn            func(*elasticache.DeleteSnapshotInput) (*elasticache.DeleteSnapshotOutput, error)
 
@@ -82,6 +86,12 @@ func buildMockElasticacheClient(modifyFn func(*mockElasticacheClient)) *mockElas
 		describeCacheSubnetGroupsFn: func(input *elasticache.DescribeCacheSubnetGroupsInput) (*elasticache.DescribeCacheSubnetGroupsOutput, error) {
 			return &elasticache.DescribeCacheSubnetGroupsOutput{}, nil
 		},
+		describeEventsFn: func(input *elasticache.DescribeEventsInput) (*elasticache.DescribeEventsOutput, error) {
+			return &elasticache.DescribeEventsOutput{}, nil
+		},
+		describeUpdateActionsFn: func(input *elasticache.DescribeUpdateActionsInput) (*elasticache.DescribeUpdateActionsOutput, error) {
+			return &elasticache.DescribeUpdateActionsOutput{}, nil
+		},
 	}
 	if modifyFn != nil {
 		modifyFn(mock)
@@ -136,6 +146,11 @@ func (m *mockElasticacheClient) CreateReplicationGroup(*elasticache.CreateReplic
 	return &elasticache.CreateReplicationGroupOutput{}, nil
 }
 
+// mock elasticache DeleteCacheParameterGroup output
+func (m *mockElasticacheClient) DeleteCacheParameterGroup(*elasticache.DeleteCacheParameterGroupInput) (*elasticache.DeleteCacheParameterGroupOutput, error) {
+	return &elasticache.DeleteCacheParameterGroupOutput{}, nil
+}
+
 // mock elasticache DeleteReplicationGroup output
 func (m *mockElasticacheClient) DeleteReplicationGroup(*elasticache.DeleteReplicationGroupInput) (*elasticache.DeleteReplicationGroupOutput, error) {
 	return &elasticache.DeleteReplicationGroupOutput{}, nil
@@ -202,6 +217,20 @@ func (m *mockElasticacheClient) DescribeServiceUpdates(*elasticache.DescribeServ
 	return &elasticache.DescribeServiceUpdatesOutput{}, nil
 }
 
+func (m *mockElasticacheClient) DescribeEvents(input *elasticache.DescribeEventsInput) (*elasticache.DescribeEventsOutput, error) {
+	if m.describeEventsFn == nil {
+		panic("describeEventsFn: method is nil but elasticacheClient.DescribeEvents was just called")
+	}
+	return m.describeEventsFn(input)
+}
+
+func (m *mockElasticacheClient) DescribeUpdateActions(input *elasticache.DescribeUpdateActionsInput) (*elasticache.DescribeUpdateActionsOutput, error) {
+	if m.describeUpdateActionsFn == nil {
+		panic("describeUpdateActionsFn: method is nil but elasticacheClient.DescribeUpdateActions was just called")
+	}
+	return m.describeUpdateActionsFn(input)
+}
+
 func (m *mockElasticacheClient) DescribeCacheSubnetGroups(input *elasticache.DescribeCacheSubnetGroupsInput) (*elasticache.DescribeCacheSubnetGroupsOutput, error) {
 	return m.describeCacheSubnetGroupsFn(input)
 }
@@ -732,7 +761,13 @@ func TestAWSRedisProvider_deleteRedisCluster(t *testing.T) {
 				Client:            fake.NewFakeClientWithScheme(scheme, buildTestRedisCR(), builtTestCredSecret(), buildTestInfra(), buildTestPrometheusRule()),
 				Logger:            testLogger,
 				CredentialManager: &CredentialManagerMock{},
-				ConfigManager:     &ConfigManagerMock{},
+				ConfigManager: buildTestConfigManager(func(m *ConfigManagerMock) {
+					m.ReadStorageStrategyFunc = func(ctx context.Context, rt providers.ResourceType, tier string) (*StrategyConfig, error) {
+						return &StrategyConfig{
+							CreateStrategy: json.RawMessage("{}"),
+						}, nil
+					}
+				}),
 				CacheSvc: buildMockElasticacheClient(func(elasticacheClient *mockElasticacheClient) {
 					elasticacheClient.describeReplicationGroupsFn = func(*elasticache.DescribeReplicationGroupsInput) (*elasticache.DescribeReplicationGroupsOutput, error) {
 						return &elasticache.DescribeReplicationGroupsOutput{}, nil
@@ -1153,3 +1188,460 @@ func Test_buildElasticacheUpdateStrategy(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildCacheParameterUpdates(t *testing.T) {
+	cases := []struct {
+		name            string
+		desired         map[string]string
+		found           []*elasticache.Parameter
+		expectedUpdates []*elasticache.ParameterNameValue
+	}{
+		{
+			name: "test drifted and missing parameters are updated",
+			desired: map[string]string{
+				"maxmemory-policy": "volatile-lru",
+				"timeout":          "300",
+			},
+			found: []*elasticache.Parameter{
+				{
+					ParameterName:  aws.String("maxmemory-policy"),
+					ParameterValue: aws.String("noeviction"),
+				},
+			},
+			expectedUpdates: []*elasticache.ParameterNameValue{
+				{
+					ParameterName:  aws.String("maxmemory-policy"),
+					ParameterValue: aws.String("volatile-lru"),
+				},
+				{
+					ParameterName:  aws.String("timeout"),
+					ParameterValue: aws.String("300"),
+				},
+			},
+		},
+		{
+			name: "test matching parameters require no updates",
+			desired: map[string]string{
+				"maxmemory-policy": "noeviction",
+			},
+			found: []*elasticache.Parameter{
+				{
+					ParameterName:  aws.String("maxmemory-policy"),
+					ParameterValue: aws.String("noeviction"),
+				},
+			},
+			expectedUpdates: nil,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			updates := buildCacheParameterUpdates(tc.desired, tc.found)
+			if len(updates) != len(tc.expectedUpdates) {
+				t.Fatalf("unexpected number of updates, expected %d but got %d", len(tc.expectedUpdates), len(updates))
+			}
+			for i, update := range updates {
+				if aws.StringValue(update.ParameterName) != aws.StringValue(tc.expectedUpdates[i].ParameterName) || aws.StringValue(update.ParameterValue) != aws.StringValue(tc.expectedUpdates[i].ParameterValue) {
+					t.Fatalf("unexpected update, expected %v but got %v", tc.expectedUpdates[i], update)
+				}
+			}
+		})
+	}
+}
+
+func TestElasticacheParameterGroupFamily(t *testing.T) {
+	cases := []struct {
+		name           string
+		engineVersion  string
+		expectedFamily string
+	}{
+		{
+			name:           "test redis 5 uses major minor family",
+			engineVersion:  "5.0.6",
+			expectedFamily: "redis5.0",
+		},
+		{
+			name:           "test redis 6 uses major version family",
+			engineVersion:  "6.0.5",
+			expectedFamily: "redis6.x",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := elasticacheParameterGroupFamily(tc.engineVersion); got != tc.expectedFamily {
+				t.Fatalf("unexpected parameter group family, expected %s but got %s", tc.expectedFamily, got)
+			}
+		})
+	}
+}
+
+func TestRedisProvider_surfaceReplicationGroupDisruptions(t *testing.T) {
+	type args struct {
+		cacheSvc *mockElasticacheClient
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantConditions map[string]string
+	}{
+		{
+			name: "test a quiet replication group reports all disruption conditions false",
+			args: args{
+				cacheSvc: buildMockElasticacheClient(nil),
+			},
+			wantConditions: map[string]string{
+				resources.ConditionTypePrimaryFailover:    "False",
+				resources.ConditionTypeNodeReplacement:    "False",
+				resources.ConditionTypePendingMaintenance: "False",
+			},
+		},
+		{
+			name: "test a failover event sets the primary failover condition",
+			args: args{
+				cacheSvc: buildMockElasticacheClient(func(elasticacheClient *mockElasticacheClient) {
+					elasticacheClient.describeEventsFn = func(input *elasticache.DescribeEventsInput) (*elasticache.DescribeEventsOutput, error) {
+						return &elasticache.DescribeEventsOutput{
+							Events: []*elasticache.Event{
+								{
+									SourceIdentifier: aws.String("test-id"),
+									Message:          aws.String("Failover to replica node test-id-002 completed"),
+									Date:             aws.Time(time.Now()),
+								},
+							},
+						}, nil
+					}
+				}),
+			},
+			wantConditions: map[string]string{
+				resources.ConditionTypePrimaryFailover:    "True",
+				resources.ConditionTypeNodeReplacement:    "False",
+				resources.ConditionTypePendingMaintenance: "False",
+			},
+		},
+		{
+			name: "test a recovery event sets the node replacement condition",
+			args: args{
+				cacheSvc: buildMockElasticacheClient(func(elasticacheClient *mockElasticacheClient) {
+					elasticacheClient.describeEventsFn = func(input *elasticache.DescribeEventsInput) (*elasticache.DescribeEventsOutput, error) {
+						return &elasticache.DescribeEventsOutput{
+							Events: []*elasticache.Event{
+								{
+									SourceIdentifier: aws.String("test-id-001"),
+									Message:          aws.String("Recovering cache nodes 0001"),
+									Date:             aws.Time(time.Now()),
+								},
+							},
+						}, nil
+					}
+				}),
+			},
+			wantConditions: map[string]string{
+				resources.ConditionTypePrimaryFailover:    "False",
+				resources.ConditionTypeNodeReplacement:    "True",
+				resources.ConditionTypePendingMaintenance: "False",
+			},
+		},
+		{
+			name: "test a pending update action sets the pending maintenance condition",
+			args: args{
+				cacheSvc: buildMockElasticacheClient(func(elasticacheClient *mockElasticacheClient) {
+					elasticacheClient.describeUpdateActionsFn = func(input *elasticache.DescribeUpdateActionsInput) (*elasticache.DescribeUpdateActionsOutput, error) {
+						return &elasticache.DescribeUpdateActionsOutput{
+							UpdateActions: []*elasticache.UpdateAction{
+								{
+									ServiceUpdateName:  aws.String("elasticache-security-update"),
+									UpdateActionStatus: aws.String(elasticache.UpdateActionStatusNotApplied),
+								},
+							},
+						}, nil
+					}
+				}),
+			},
+			wantConditions: map[string]string{
+				resources.ConditionTypePrimaryFailover:    "False",
+				resources.ConditionTypeNodeReplacement:    "False",
+				resources.ConditionTypePendingMaintenance: "True",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &RedisProvider{
+				Logger: testLogger,
+			}
+			r := buildTestRedisCR()
+			p.surfaceReplicationGroupDisruptions(context.TODO(), r, tt.args.cacheSvc, &elasticache.ReplicationGroup{
+				ReplicationGroupId: aws.String("test-id"),
+				MemberClusters:     []*string{aws.String("test-id-001")},
+			})
+			for conditionType, wantStatus := range tt.wantConditions {
+				found := false
+				for _, condition := range r.Status.Conditions {
+					if condition.Type != conditionType {
+						continue
+					}
+					found = true
+					if condition.Status != wantStatus {
+						t.Errorf("condition %s status = %s, want %s", conditionType, condition.Status, wantStatus)
+					}
+				}
+				if !found {
+					t.Errorf("condition %s not found on cr", conditionType)
+				}
+			}
+		})
+	}
+}
+
+func Test_nextMaintenanceWindowStart(t *testing.T) {
+	// wednesday 12:00 utc
+	now := time.Date(2021, time.March, 3, 12, 0, 0, 0, time.UTC)
+	type args struct {
+		window string
+		now    time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "test window later in the week resolves to this week",
+			args: args{
+				window: "fri:22:30-fri:23:30",
+				now:    now,
+			},
+			want: time.Date(2021, time.March, 5, 22, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "test window earlier in the week resolves to next week",
+			args: args{
+				window: "mon:01:00-mon:03:00",
+				now:    now,
+			},
+			want: time.Date(2021, time.March, 8, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "test window later the same day resolves to today",
+			args: args{
+				window: "wed:23:00-thu:01:30",
+				now:    now,
+			},
+			want: time.Date(2021, time.March, 3, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "test window already open resolves to next week",
+			args: args{
+				window: "wed:12:00-wed:13:00",
+				now:    now,
+			},
+			want: time.Date(2021, time.March, 10, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "test malformed window returns an error",
+			args: args{
+				window: "every tuesday",
+				now:    now,
+			},
+			wantErr: true,
+		},
+		{
+			name: "test unknown day returns an error",
+			args: args{
+				window: "xxx:12:00-xxx:13:00",
+				now:    now,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextMaintenanceWindowStart(tt.args.window, tt.args.now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("nextMaintenanceWindowStart() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("nextMaintenanceWindowStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_dataTieringValidation(t *testing.T) {
+	type args struct {
+		cacheNodeType string
+		engineVersion string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "test r6gd node type with redis 6.2 is accepted",
+			args: args{
+				cacheNodeType: "cache.r6gd.xlarge",
+				engineVersion: "6.2",
+			},
+		},
+		{
+			name: "test r6gd node type with redis 7 is accepted",
+			args: args{
+				cacheNodeType: "cache.r6gd.2xlarge",
+				engineVersion: "7.0",
+			},
+		},
+		{
+			name: "test r6gd node type with redis 5 is rejected",
+			args: args{
+				cacheNodeType: "cache.r6gd.xlarge",
+				engineVersion: "5.0.6",
+			},
+			wantErr: true,
+		},
+		{
+			name: "test r6gd node type with redis 6.0 is rejected",
+			args: args{
+				cacheNodeType: "cache.r6gd.xlarge",
+				engineVersion: "6.0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "test non data tiering node type with redis 5 is accepted",
+			args: args{
+				cacheNodeType: "cache.m5.large",
+				engineVersion: "5.0.6",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotErr := isDataTieringNodeType(tt.args.cacheNodeType) && !engineSupportsDataTiering(tt.args.engineVersion)
+			if gotErr != tt.wantErr {
+				t.Errorf("data tiering validation for %s on %s = %v, wantErr %v", tt.args.cacheNodeType, tt.args.engineVersion, gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateReservedMemoryParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters map[string]string
+		wantErr    bool
+	}{
+		{
+			name: "test valid reserved memory percent is accepted",
+			parameters: map[string]string{
+				"reserved-memory-percent": "25",
+			},
+		},
+		{
+			name: "test reserved memory percent above 100 is rejected",
+			parameters: map[string]string{
+				"reserved-memory-percent": "125",
+			},
+			wantErr: true,
+		},
+		{
+			name: "test non numeric reserved memory percent is rejected",
+			parameters: map[string]string{
+				"reserved-memory-percent": "a quarter",
+			},
+			wantErr: true,
+		},
+		{
+			name: "test absolute and percentage reserved memory together are rejected",
+			parameters: map[string]string{
+				"reserved-memory":         "1073741824",
+				"reserved-memory-percent": "25",
+			},
+			wantErr: true,
+		},
+		{
+			name: "test unrelated parameters are accepted",
+			parameters: map[string]string{
+				"maxmemory-policy": "allkeys-lru",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateReservedMemoryParameters(tt.parameters); (err != nil) != tt.wantErr {
+				t.Errorf("validateReservedMemoryParameters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_buildDesiredCacheParameters(t *testing.T) {
+	type args struct {
+		tier               string
+		strategyParameters map[string]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]string
+	}{
+		{
+			name: "test production tier defaults to noeviction",
+			args: args{
+				tier: "production",
+			},
+			want: map[string]string{
+				"maxmemory-policy": "noeviction",
+			},
+		},
+		{
+			name: "test development tier defaults to allkeys-lru",
+			args: args{
+				tier: "development",
+			},
+			want: map[string]string{
+				"maxmemory-policy": "allkeys-lru",
+			},
+		},
+		{
+			name: "test strategy parameters override the tier default",
+			args: args{
+				tier: "production",
+				strategyParameters: map[string]string{
+					"maxmemory-policy": "volatile-lru",
+				},
+			},
+			want: map[string]string{
+				"maxmemory-policy": "volatile-lru",
+			},
+		},
+		{
+			name: "test strategy parameters are merged with the tier default",
+			args: args{
+				tier: "production",
+				strategyParameters: map[string]string{
+					"reserved-memory-percent": "25",
+				},
+			},
+			want: map[string]string{
+				"maxmemory-policy":        "noeviction",
+				"reserved-memory-percent": "25",
+			},
+		},
+		{
+			name: "test unknown tier without strategy parameters yields no parameters",
+			args: args{
+				tier: "",
+			},
+			want: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildDesiredCacheParameters(tt.args.tier, tt.args.strategyParameters); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildDesiredCacheParameters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
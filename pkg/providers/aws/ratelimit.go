@@ -0,0 +1,89 @@
+package aws
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// envars overriding the per service token bucket shared by all aws sessions
+	EnvAWSRateLimitQPS   = "AWS_API_RATE_LIMIT_QPS"
+	EnvAWSRateLimitBurst = "AWS_API_RATE_LIMIT_BURST"
+
+	defaultRateLimitQPS   = float32(5)
+	defaultRateLimitBurst = 10
+)
+
+// serviceRateLimiter a token bucket for a single aws service with a running total of calls
+// that had to wait for capacity
+type serviceRateLimiter struct {
+	limiter        flowcontrol.RateLimiter
+	throttledCalls float64
+}
+
+var (
+	rateLimiters     = map[string]*serviceRateLimiter{}
+	rateLimitersLock sync.Mutex
+)
+
+// getServiceRateLimiter returns the shared token bucket for an aws service, creating it on
+// first use so every session throttles against the same request budget
+func getServiceRateLimiter(service string) *serviceRateLimiter {
+	rateLimitersLock.Lock()
+	defer rateLimitersLock.Unlock()
+	if rateLimiters[service] == nil {
+		rateLimiters[service] = &serviceRateLimiter{
+			limiter: flowcontrol.NewTokenBucketRateLimiter(getRateLimitQPS(), getRateLimitBurst()),
+		}
+	}
+	return rateLimiters[service]
+}
+
+// addRateLimitHandler installs a send handler on the session that blocks until the token
+// bucket for the target service has capacity, throttled calls are exposed as a metric
+func addRateLimitHandler(sess *session.Session) {
+	sess.Handlers.Send.PushFront(func(r *request.Request) {
+		srl := getServiceRateLimiter(r.ClientInfo.ServiceName)
+		if srl.limiter.TryAccept() {
+			return
+		}
+		rateLimitersLock.Lock()
+		srl.throttledCalls++
+		throttledCalls := srl.throttledCalls
+		rateLimitersLock.Unlock()
+		resources.SetMetric(resources.DefaultCloudAPIThrottledCallsMetricName, map[string]string{
+			"provider": "aws",
+			"service":  r.ClientInfo.ServiceName,
+		}, throttledCalls)
+		srl.limiter.Accept()
+	})
+}
+
+func getRateLimitQPS() float32 {
+	rawQPS, exist := resources.LookupConfig(EnvAWSRateLimitQPS)
+	if exist {
+		qps, err := strconv.ParseFloat(rawQPS, 32)
+		if err != nil || qps <= 0 {
+			return defaultRateLimitQPS
+		}
+		return float32(qps)
+	}
+	return defaultRateLimitQPS
+}
+
+func getRateLimitBurst() int {
+	rawBurst, exist := resources.LookupConfig(EnvAWSRateLimitBurst)
+	if exist {
+		burst, err := strconv.Atoi(rawBurst)
+		if err != nil || burst < 1 {
+			return defaultRateLimitBurst
+		}
+		return burst
+	}
+	return defaultRateLimitBurst
+}
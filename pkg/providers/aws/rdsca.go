@@ -0,0 +1,98 @@
+package aws
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	errorUtil "github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// the regional certificate bundles published by the rds truststore, the bundle for a region
+	// contains the current and any incoming certificate authorities so a fetched bundle stays
+	// valid across an aws ca rotation
+	rdsCABundleURLFormat = "https://truststore.pki.rds.amazonaws.com/%s/%s-bundle.pem"
+	// how long a fetched bundle is served from the cache before it is fetched again, refetching
+	// picks up rotated certificate authorities without an operator restart
+	rdsCABundleRefreshInterval = time.Hour * 24
+)
+
+type rdsCABundleEntry struct {
+	bundle    []byte
+	fetchedAt time.Time
+}
+
+// rdsCABundleCache caches the regional rds ca bundles so the truststore is not fetched on
+// every reconcile, a stale entry is served when a refresh fails so a truststore outage does not
+// strip the bundle from credential secrets
+type rdsCABundleCache struct {
+	mutex   sync.Mutex
+	bundles map[string]rdsCABundleEntry
+	// fetchBundle fetches the bundle for a region, overridable in tests
+	fetchBundle func(ctx context.Context, c client.Client, region string) ([]byte, error)
+}
+
+var rdsCABundles = &rdsCABundleCache{
+	bundles:     map[string]rdsCABundleEntry{},
+	fetchBundle: fetchRDSCABundle,
+}
+
+// get returns the ca bundle for the given region, fetching it from the rds truststore when the
+// cached copy is missing or older than the refresh interval
+func (cache *rdsCABundleCache) get(ctx context.Context, c client.Client, region string) ([]byte, error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	entry, ok := cache.bundles[region]
+	if ok && time.Since(entry.fetchedAt) < rdsCABundleRefreshInterval {
+		return entry.bundle, nil
+	}
+	bundle, err := cache.fetchBundle(ctx, c, region)
+	if err != nil {
+		if ok {
+			return entry.bundle, nil
+		}
+		return nil, err
+	}
+	cache.bundles[region] = rdsCABundleEntry{
+		bundle:    bundle,
+		fetchedAt: time.Now(),
+	}
+	return bundle, nil
+}
+
+// fetchRDSCABundle fetches the ca bundle for a region from the rds truststore, the request
+// goes through the cluster proxy when one is configured
+func fetchRDSCABundle(ctx context.Context, c client.Client, region string) ([]byte, error) {
+	httpClient, err := resources.BuildProxiedHTTPClient(ctx, c)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to build http client for rds ca bundle fetch")
+	}
+	bundleURL := fmt.Sprintf(rdsCABundleURLFormat, region, region)
+	req, err := http.NewRequest(http.MethodGet, bundleURL, nil)
+	if err != nil {
+		return nil, errorUtil.Wrapf(err, "failed to build request for rds ca bundle %s", bundleURL)
+	}
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errorUtil.Wrapf(err, "failed to fetch rds ca bundle %s", bundleURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorUtil.New(fmt.Sprintf("failed to fetch rds ca bundle %s, status %s", bundleURL, resp.Status))
+	}
+	bundle, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errorUtil.Wrapf(err, "failed to read rds ca bundle %s", bundleURL)
+	}
+	if block, _ := pem.Decode(bundle); block == nil {
+		return nil, errorUtil.New(fmt.Sprintf("rds ca bundle %s does not contain pem encoded certificates", bundleURL))
+	}
+	return bundle, nil
+}
@@ -0,0 +1,95 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	errorUtil "github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRDSCABundleCacheGet(t *testing.T) {
+	type fields struct {
+		bundles     map[string]rdsCABundleEntry
+		fetchBundle func(ctx context.Context, c client.Client, region string) ([]byte, error)
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "test a missing bundle is fetched",
+			fields: fields{
+				bundles: map[string]rdsCABundleEntry{},
+				fetchBundle: func(ctx context.Context, c client.Client, region string) ([]byte, error) {
+					return []byte("fetched bundle"), nil
+				},
+			},
+			want: "fetched bundle",
+		},
+		{
+			name: "test a fresh bundle is served from the cache",
+			fields: fields{
+				bundles: map[string]rdsCABundleEntry{
+					"us-east-1": {bundle: []byte("cached bundle"), fetchedAt: time.Now()},
+				},
+				fetchBundle: func(ctx context.Context, c client.Client, region string) ([]byte, error) {
+					return nil, errorUtil.New("fetch should not be called for a fresh bundle")
+				},
+			},
+			want: "cached bundle",
+		},
+		{
+			name: "test an expired bundle is fetched again",
+			fields: fields{
+				bundles: map[string]rdsCABundleEntry{
+					"us-east-1": {bundle: []byte("cached bundle"), fetchedAt: time.Now().Add(-rdsCABundleRefreshInterval * 2)},
+				},
+				fetchBundle: func(ctx context.Context, c client.Client, region string) ([]byte, error) {
+					return []byte("rotated bundle"), nil
+				},
+			},
+			want: "rotated bundle",
+		},
+		{
+			name: "test a stale bundle is served when the refresh fails",
+			fields: fields{
+				bundles: map[string]rdsCABundleEntry{
+					"us-east-1": {bundle: []byte("cached bundle"), fetchedAt: time.Now().Add(-rdsCABundleRefreshInterval * 2)},
+				},
+				fetchBundle: func(ctx context.Context, c client.Client, region string) ([]byte, error) {
+					return nil, errorUtil.New("truststore unavailable")
+				},
+			},
+			want: "cached bundle",
+		},
+		{
+			name: "test a fetch failure with no cached bundle returns the error",
+			fields: fields{
+				bundles: map[string]rdsCABundleEntry{},
+				fetchBundle: func(ctx context.Context, c client.Client, region string) ([]byte, error) {
+					return nil, errorUtil.New("truststore unavailable")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := &rdsCABundleCache{
+				bundles:     tt.fields.bundles,
+				fetchBundle: tt.fields.fetchBundle,
+			}
+			got, err := cache.get(context.TODO(), nil, "us-east-1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("get() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package aws
+
+// Ingress rule management for the security groups the operator maintains around its database
+// resources. Rules are least privilege, traffic is only accepted from the cluster network cidr
+// on the database ports, and rules outside the expected set, including the broad allow all
+// rule earlier operator versions created, are revoked.
+
+import (
+	"net"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	errorUtil "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// the default elasticache redis port, elasticache has no equivalent of the configurable rds
+// port so the default is the only port replication groups are served on
+const defaultAwsRedisPort = 6379
+
+// databaseIngressPorts the ports accepted through operator managed security groups, ingress
+// is scoped to these rather than allowing all traffic from the cluster network
+var databaseIngressPorts = []int64{defaultAwsPostgresPort, defaultAwsRedisPort}
+
+// DatabaseIngressCidrsConfigKey the operator configuration key holding a comma separated list
+// of additional cidr blocks allowed to reach the database ports, e.g. a vpn range or an
+// analytics vpc, entries removed from the configuration are revoked on the next reconcile
+const DatabaseIngressCidrsConfigKey = "DATABASE_INGRESS_CIDRS"
+
+// extraDatabaseIngressCidrs returns the validated additional ingress cidr blocks whitelisted
+// through the operator configuration
+func extraDatabaseIngressCidrs() ([]string, error) {
+	value, found := resources.LookupConfig(DatabaseIngressCidrsConfigKey)
+	if !found {
+		return nil, nil
+	}
+	var cidrs []string
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(entry); err != nil {
+			return nil, errorUtil.Wrapf(err, "invalid cidr block %s in %s", entry, DatabaseIngressCidrsConfigKey)
+		}
+		cidrs = append(cidrs, entry)
+	}
+	return cidrs, nil
+}
+
+// buildDatabaseIngressPermissions builds the least privilege ingress rules for an operator
+// managed database security group, traffic is only accepted on the database ports from the
+// given cidr block and any additional cidr blocks whitelisted through the operator
+// configuration
+func buildDatabaseIngressPermissions(cidr string) ([]*ec2.IpPermission, error) {
+	extraCidrs, err := extraDatabaseIngressCidrs()
+	if err != nil {
+		return nil, err
+	}
+	var permissions []*ec2.IpPermission
+	for _, cidrBlock := range append([]string{cidr}, extraCidrs...) {
+		for _, port := range databaseIngressPorts {
+			permissions = append(permissions, &ec2.IpPermission{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int64(port),
+				ToPort:     aws.Int64(port),
+				IpRanges: []*ec2.IpRange{
+					{
+						CidrIp: aws.String(cidrBlock),
+					},
+				},
+			})
+		}
+	}
+	return permissions, nil
+}
+
+// ipPermissionMatches compares the fields of an ingress rule the operator manages, found
+// rules carry additional response only fields so a full deep equal cannot be used
+func ipPermissionMatches(found *ec2.IpPermission, expected *ec2.IpPermission) bool {
+	if aws.StringValue(found.IpProtocol) != aws.StringValue(expected.IpProtocol) {
+		return false
+	}
+	if aws.Int64Value(found.FromPort) != aws.Int64Value(expected.FromPort) || aws.Int64Value(found.ToPort) != aws.Int64Value(expected.ToPort) {
+		return false
+	}
+	if len(found.IpRanges) != len(expected.IpRanges) {
+		return false
+	}
+	for i := range found.IpRanges {
+		if aws.StringValue(found.IpRanges[i].CidrIp) != aws.StringValue(expected.IpRanges[i].CidrIp) {
+			return false
+		}
+	}
+	return true
+}
+
+// reconcileSecurityGroupIngress converges the ingress rules of the security group on the
+// expected permissions, missing rules are authorized and drifted rules are revoked
+func reconcileSecurityGroupIngress(ec2Svc ec2iface.EC2API, secGroup *ec2.SecurityGroup, expected []*ec2.IpPermission, logger *logrus.Entry) error {
+	var revoke []*ec2.IpPermission
+	for _, found := range secGroup.IpPermissions {
+		matched := false
+		for _, perm := range expected {
+			if ipPermissionMatches(found, perm) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			revoke = append(revoke, found)
+		}
+	}
+	var authorize []*ec2.IpPermission
+	for _, perm := range expected {
+		matched := false
+		for _, found := range secGroup.IpPermissions {
+			if ipPermissionMatches(found, perm) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			authorize = append(authorize, perm)
+		}
+	}
+	if len(revoke) == 0 && len(authorize) == 0 {
+		logger.Infof("ip permissions are correct for security group %s", aws.StringValue(secGroup.GroupName))
+		return nil
+	}
+	if len(revoke) > 0 {
+		logger.Infof("revoking %d ingress rules outside the expected permissions for security group %s", len(revoke), aws.StringValue(secGroup.GroupName))
+		if _, err := ec2Svc.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       secGroup.GroupId,
+			IpPermissions: revoke,
+		}); err != nil {
+			return errorUtil.Wrap(err, "error revoking security group ingress")
+		}
+	}
+	if len(authorize) > 0 {
+		if _, err := ec2Svc.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{
+			GroupId:       secGroup.GroupId,
+			IpPermissions: authorize,
+		}); err != nil {
+			return errorUtil.Wrap(err, "error authorizing security group ingress")
+		}
+	}
+	logger.Infof("ip permissions have been updated to expected permissions for security group %s", aws.StringValue(secGroup.GroupName))
+	return nil
+}
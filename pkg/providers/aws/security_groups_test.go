@@ -0,0 +1,149 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+)
+
+func TestBuildDatabaseIngressPermissions(t *testing.T) {
+	type args struct {
+		cidr   string
+		config map[string]string
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantPermissions int
+		wantErr         bool
+	}{
+		{
+			name: "test the cluster cidr is scoped to the database ports",
+			args: args{
+				cidr: "10.0.0.0/16",
+			},
+			wantPermissions: 2,
+		},
+		{
+			name: "test whitelisted cidr blocks are included",
+			args: args{
+				cidr: "10.0.0.0/16",
+				config: map[string]string{
+					DatabaseIngressCidrsConfigKey: "192.168.0.0/24, 172.16.0.0/16",
+				},
+			},
+			wantPermissions: 6,
+		},
+		{
+			name: "test an invalid whitelisted cidr block is rejected",
+			args: args{
+				cidr: "10.0.0.0/16",
+				config: map[string]string{
+					DatabaseIngressCidrsConfigKey: "not-a-cidr",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources.SetOperatorConfig(tt.args.config)
+			defer resources.SetOperatorConfig(nil)
+			permissions, err := buildDatabaseIngressPermissions(tt.args.cidr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildDatabaseIngressPermissions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(permissions) != tt.wantPermissions {
+				t.Errorf("buildDatabaseIngressPermissions() returned %d permissions, want %d", len(permissions), tt.wantPermissions)
+			}
+		})
+	}
+}
+
+func TestIpPermissionMatches(t *testing.T) {
+	expected, err := buildDatabaseIngressPermissions("10.0.0.0/16")
+	if err != nil {
+		t.Fatalf("buildDatabaseIngressPermissions() unexpected error %v", err)
+	}
+	type args struct {
+		found *ec2.IpPermission
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "test a matching rule with extra response fields matches",
+			args: args{
+				found: &ec2.IpPermission{
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int64(defaultAwsPostgresPort),
+					ToPort:     aws.Int64(defaultAwsPostgresPort),
+					IpRanges: []*ec2.IpRange{
+						{
+							CidrIp:      aws.String("10.0.0.0/16"),
+							Description: aws.String("test"),
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "test the broad allow all rule does not match",
+			args: args{
+				found: &ec2.IpPermission{
+					IpProtocol: aws.String("-1"),
+					IpRanges: []*ec2.IpRange{
+						{
+							CidrIp: aws.String("10.0.0.0/16"),
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "test a rule for a different cidr does not match",
+			args: args{
+				found: &ec2.IpPermission{
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int64(defaultAwsPostgresPort),
+					ToPort:     aws.Int64(defaultAwsPostgresPort),
+					IpRanges: []*ec2.IpRange{
+						{
+							CidrIp: aws.String("192.168.0.0/16"),
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "test a rule for a different port does not match",
+			args: args{
+				found: &ec2.IpPermission{
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int64(defaultAwsRedisPort),
+					ToPort:     aws.Int64(defaultAwsRedisPort),
+					IpRanges: []*ec2.IpRange{
+						{
+							CidrIp: aws.String("10.0.0.0/16"),
+						},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ipPermissionMatches(tt.args.found, expected[0]); got != tt.want {
+				t.Errorf("ipPermissionMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
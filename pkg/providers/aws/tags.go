@@ -3,7 +3,10 @@ package aws
 import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elasticache"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
 )
 
 const (
@@ -46,6 +49,47 @@ func rdsTagstoGeneric(rdsTags []*rds.Tag) []*tag {
 	return genericTags
 }
 
+func elasticacheTagsToGeneric(elasticacheTags []*elasticache.Tag) []*tag {
+	var genericTags []*tag
+	for _, elasticacheTag := range elasticacheTags {
+		genericTags = append(genericTags, &tag{key: aws.StringValue(elasticacheTag.Key), value: aws.StringValue(elasticacheTag.Value)})
+	}
+	return genericTags
+}
+
+// converters from the generic cross provider tag type to aws service specific tag types
+func resourceTagsToRDSTags(tags []*resources.Tag) []*rds.Tag {
+	var rdsTags []*rds.Tag
+	for _, resourceTag := range tags {
+		rdsTags = append(rdsTags, &rds.Tag{Key: aws.String(resourceTag.Key), Value: aws.String(resourceTag.Value)})
+	}
+	return rdsTags
+}
+
+func resourceTagsToS3Tags(tags []*resources.Tag) []*s3.Tag {
+	var s3Tags []*s3.Tag
+	for _, resourceTag := range tags {
+		s3Tags = append(s3Tags, &s3.Tag{Key: aws.String(resourceTag.Key), Value: aws.String(resourceTag.Value)})
+	}
+	return s3Tags
+}
+
+func resourceTagsToElasticacheTags(tags []*resources.Tag) []*elasticache.Tag {
+	var elasticacheTags []*elasticache.Tag
+	for _, resourceTag := range tags {
+		elasticacheTags = append(elasticacheTags, &elasticache.Tag{Key: aws.String(resourceTag.Key), Value: aws.String(resourceTag.Value)})
+	}
+	return elasticacheTags
+}
+
+func s3TagsToGeneric(s3Tags []*s3.Tag) []*tag {
+	var genericTags []*tag
+	for _, s3Tag := range s3Tags {
+		genericTags = append(genericTags, &tag{key: aws.StringValue(s3Tag.Key), value: aws.StringValue(s3Tag.Value)})
+	}
+	return genericTags
+}
+
 func tagsContains(tags []*tag, key, value string) bool {
 	for _, tag := range tags {
 		if tag.key == key && tag.value == value {
@@ -54,3 +98,12 @@ func tagsContains(tags []*tag, key, value string) bool {
 	}
 	return false
 }
+
+func tagValue(tags []*tag, key string) string {
+	for _, tag := range tags {
+		if tag.key == key {
+			return tag.value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	"go.opencensus.io/trace"
+)
+
+// addTracingHandler installs handlers recording a span per aws api call, spans are parented
+// to the reconcile span when the call carries a request context so slow provisioning and
+// throttling can be traced back to the owning reconcile
+func addTracingHandler(sess *session.Session) {
+	sess.Handlers.Send.PushFront(func(r *request.Request) {
+		ctx, _ := resources.StartSpan(r.Context(), fmt.Sprintf("aws.%s.%s", r.ClientInfo.ServiceName, r.Operation.Name))
+		r.SetContext(ctx)
+	})
+	sess.Handlers.Complete.PushBack(func(r *request.Request) {
+		span := trace.FromContext(r.Context())
+		if span == nil {
+			return
+		}
+		if r.Error != nil {
+			span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: r.Error.Error()})
+		}
+		span.End()
+	})
+}
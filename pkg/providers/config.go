@@ -3,7 +3,9 @@ package providers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
+	configV1 "github.com/integr8ly/cloud-resource-operator/pkg/apis/config/v1"
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 
 	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
@@ -61,7 +63,7 @@ func NewConfigManager(cm string, namespace string, client client.Client) *Config
 
 //GetStrategyMappingForDeploymentType Get high-level information about the strategy used in a deployment type
 func (m *ConfigMapConfigManager) GetStrategyMappingForDeploymentType(ctx context.Context, t string) (*DeploymentStrategyMapping, error) {
-	cm, err := resources.GetConfigMapOrDefault(ctx, m.client, types.NamespacedName{Name: m.providerConfigMapName, Namespace: m.providerConfigMapNamespace}, m.buildDefaultConfigMap())
+	cm, err := resources.GetConfigMapOrDefault(ctx, m.client, types.NamespacedName{Name: m.providerConfigMapName, Namespace: m.providerConfigMapNamespace}, m.buildDefaultConfigMap(ctx))
 	if err != nil {
 		return nil, errorUtil.Wrapf(err, "failed to read provider config from configmap %s in namespace %s", m.providerConfigMapName, m.providerConfigMapNamespace)
 	}
@@ -72,15 +74,36 @@ func (m *ConfigMapConfigManager) GetStrategyMappingForDeploymentType(ctx context
 	return dsm, nil
 }
 
-func (m *ConfigMapConfigManager) buildDefaultConfigMap() *v1.ConfigMap {
+// buildDefaultConfigMap builds the strategy mapping used when no config map exists, the
+// managed strategies follow the platform the cluster runs on so the config map only needs
+// to be hand created when the defaults are not wanted
+func (m *ConfigMapConfigManager) buildDefaultConfigMap(ctx context.Context) *v1.ConfigMap {
+	strategy := m.defaultStrategyForPlatform(ctx)
 	return &v1.ConfigMap{
 		ObjectMeta: controllerruntime.ObjectMeta{
 			Name:      m.providerConfigMapName,
 			Namespace: m.providerConfigMapNamespace,
 		},
 		Data: map[string]string{
-			"managed":  "{\"blobstorage\":\"aws\", \"redis\":\"aws\", \"postgres\":\"aws\"}",
+			"managed":  fmt.Sprintf("{\"blobstorage\":\"%s\", \"redis\":\"%s\", \"postgres\":\"%s\"}", strategy, strategy, strategy),
 			"workshop": "{\"blobstorage\":\"openshift\", \"redis\":\"openshift\", \"postgres\":\"openshift\"}",
 		},
 	}
 }
+
+// defaultStrategyForPlatform detects the platform the cluster runs on through the cluster
+// infrastructure cr, clusters on aws default to aws provisioning while every other platform
+// falls back to in cluster openshift provisioning until a provider for it exists
+func (m *ConfigMapConfigManager) defaultStrategyForPlatform(ctx context.Context) string {
+	infra, err := resources.GetClusterInfrastructure(ctx, m.client)
+	if err != nil || infra.Status.PlatformStatus == nil {
+		// without platform information the safest default is in cluster provisioning
+		return OpenShiftDeploymentStrategy
+	}
+	switch infra.Status.PlatformStatus.Type {
+	case configV1.AWSPlatformType:
+		return AWSDeploymentStrategy
+	default:
+		return OpenShiftDeploymentStrategy
+	}
+}
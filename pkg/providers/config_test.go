@@ -6,6 +6,7 @@ import (
 	"errors"
 	"testing"
 
+	configV1 "github.com/integr8ly/cloud-resource-operator/pkg/apis/config/v1"
 	v1 "k8s.io/api/core/v1"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 
@@ -126,3 +127,51 @@ func TestConfigManager_GetStrategyMappingForDeploymentType(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigManager_DefaultStrategyForPlatform(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := configV1.SchemeBuilder.AddToScheme(scheme); err != nil {
+		t.Fatal("failed to build scheme", err)
+	}
+	buildInfra := func(platformType configV1.PlatformType) *configV1.Infrastructure {
+		return &configV1.Infrastructure{
+			ObjectMeta: controllerruntime.ObjectMeta{
+				Name: "cluster",
+			},
+			Status: configV1.InfrastructureStatus{
+				PlatformStatus: &configV1.PlatformStatus{
+					Type: platformType,
+				},
+			},
+		}
+	}
+	cases := []struct {
+		name     string
+		client   client.Client
+		expected string
+	}{
+		{
+			name:     "test aws platform defaults to the aws strategy",
+			client:   fake.NewFakeClientWithScheme(scheme, buildInfra(configV1.AWSPlatformType)),
+			expected: AWSDeploymentStrategy,
+		},
+		{
+			name:     "test platforms without a provider default to the openshift strategy",
+			client:   fake.NewFakeClientWithScheme(scheme, buildInfra(configV1.GCPPlatformType)),
+			expected: OpenShiftDeploymentStrategy,
+		},
+		{
+			name:     "test missing infrastructure defaults to the openshift strategy",
+			client:   fake.NewFakeClientWithScheme(scheme),
+			expected: OpenShiftDeploymentStrategy,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := NewConfigManager("test", "test", tc.client)
+			if strategy := cm.defaultStrategyForPlatform(context.TODO()); strategy != tc.expected {
+				t.Fatalf("unexpected default strategy, got %s but expected %s", strategy, tc.expected)
+			}
+		})
+	}
+}
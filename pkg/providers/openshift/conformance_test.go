@@ -0,0 +1,69 @@
+package openshift
+
+import (
+	"testing"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers/aws"
+	"github.com/integr8ly/cloud-resource-operator/test/conformance"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// the openshift providers are run against the shared provider contract with ready
+// deployment fixtures, keeping their interface behaviour aligned with the other providers
+func TestOpenShiftProviderConformance(t *testing.T) {
+	scheme, err := buildTestScheme()
+	if err != nil {
+		t.Fatal("failed to build scheme", err)
+	}
+
+	t.Run("postgres", func(t *testing.T) {
+		conformance.RunPostgresProviderConformance(t, func() (providers.PostgresProvider, *v1alpha1.Postgres) {
+			return &PostgresProvider{
+				Client:        fake.NewFakeClientWithScheme(scheme, buildTestPostgresDeploymentReady(), buildTestPostgresCR(), buildTestCredsSecret()),
+				Logger:        testLogger,
+				ConfigManager: buildDefaultConfigManager(),
+				PodCommander:  buildTestPodCommander(),
+			}, buildTestPostgresCR()
+		})
+	})
+
+	t.Run("redis", func(t *testing.T) {
+		conformance.RunRedisProviderConformance(t, func() (providers.RedisProvider, *v1alpha1.Redis) {
+			return &RedisProvider{
+				Client:        fake.NewFakeClientWithScheme(scheme, buildTestDeploymentReady(), buildTestRedisCR()),
+				Logger:        testLogger,
+				ConfigManager: buildDefaultConfigManager(),
+			}, buildTestRedisCR()
+		})
+	})
+
+	t.Run("blobstorage", func(t *testing.T) {
+		conformance.RunBlobStorageProviderConformance(t, func() (providers.BlobStorageProvider, *v1alpha1.BlobStorage) {
+			bs := &v1alpha1.BlobStorage{
+				ObjectMeta: controllerruntime.ObjectMeta{
+					Name:      "test-blobstorage",
+					Namespace: "test-blobstorage",
+				},
+				Spec: v1alpha1.BlobStorageSpec{
+					SecretRef: &types.SecretRef{
+						Name: "test-blobstorage-sec",
+					},
+				},
+			}
+			return BlobStorageProvider{
+				Client:        fake.NewFakeClientWithScheme(scheme, bs.DeepCopy()),
+				Logger:        testLogger,
+				ConfigManager: buildDefaultConfigManager(),
+			}, bs
+		}, []string{
+			aws.DetailsBlobStorageBucketName,
+			aws.DetailsBlobStorageBucketRegion,
+			aws.DetailsBlobStorageCredentialKeyID,
+			aws.DetailsBlobStorageCredentialSecretKey,
+		})
+	})
+}
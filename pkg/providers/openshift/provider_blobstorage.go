@@ -2,13 +2,21 @@ package openshift
 
 import (
 	"context"
-	"k8s.io/apimachinery/pkg/api/errors"
+	"encoding/json"
+	"fmt"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
 	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
 	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
 	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
 	"github.com/integr8ly/cloud-resource-operator/pkg/providers/aws"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	errorUtil "github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -16,8 +24,27 @@ import (
 
 const (
 	varPlaceholder = "REPLACE_ME"
+	// default object bucket claim create options
+	defaultObjectBucketClaimStorageClass = "openshift-storage.noobaa.io"
+	objectBucketClaimBoundPhase          = "Bound"
 )
 
+// BlobStorageStrat to be used to unmarshal strat map
+type BlobStorageStrat struct {
+	_ struct{} `type:"structure"`
+
+	// optional object bucket claim mode, provisions a bucket through the openshift data
+	// foundation / noobaa provisioner instead of copying placeholder credentials
+	ObjectBucketClaim *BlobStorageOBCStrat `json:"objectBucketClaim"`
+}
+
+// BlobStorageOBCStrat to be used to unmarshal the optional object bucket claim block of the
+// strat map
+type BlobStorageOBCStrat struct {
+	Enabled          bool   `json:"enabled"`
+	StorageClassName string `json:"storageClassName"`
+}
+
 type BlobStorageDeploymentDetails struct {
 	data map[string]string
 }
@@ -33,14 +60,16 @@ func (d *BlobStorageDeploymentDetails) Data() map[string][]byte {
 var _ providers.BlobStorageProvider = (*BlobStorageProvider)(nil)
 
 type BlobStorageProvider struct {
-	Client client.Client
-	Logger *logrus.Entry
+	Client        client.Client
+	Logger        *logrus.Entry
+	ConfigManager ConfigManager
 }
 
 func NewBlobStorageProvider(c client.Client, l *logrus.Entry) *BlobStorageProvider {
 	return &BlobStorageProvider{
-		Client: c,
-		Logger: l,
+		Client:        c,
+		Logger:        l,
+		ConfigManager: NewDefaultConfigManager(c),
 	}
 }
 
@@ -57,6 +86,18 @@ func (b BlobStorageProvider) GetReconcileTime(bs *v1alpha1.BlobStorage) time.Dur
 }
 
 func (b BlobStorageProvider) CreateStorage(ctx context.Context, bs *v1alpha1.BlobStorage) (*providers.BlobStorageInstance, croType.StatusMessage, error) {
+	// get blob storage config
+	blobCfg, _, err := b.getBlobStorageConfig(ctx, bs)
+	if err != nil {
+		errMsg := fmt.Sprintf("failed to retrieve openshift blob storage config for instance %s", bs.Name)
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
+	}
+
+	// the object bucket claim mode provisions a real bucket through the odf/noobaa provisioner
+	if blobCfg.ObjectBucketClaim != nil && blobCfg.ObjectBucketClaim.Enabled {
+		return b.createObjectBucketClaimStorage(ctx, bs, blobCfg)
+	}
+
 	// default to an empty s3 set of credentials for now. in the future. this should determine the cloud provider being
 	// used by checking the infrastructure cr.
 	dd := &BlobStorageDeploymentDetails{
@@ -94,6 +135,120 @@ func (b BlobStorageProvider) CreateStorage(ctx context.Context, bs *v1alpha1.Blo
 	}, "reconcile complete", nil
 }
 
+// createObjectBucketClaimStorage reconciles an object bucket claim against the odf/noobaa
+// provisioner and maps the s3-compatible credentials it publishes into the standard blob
+// storage secret format
+func (b BlobStorageProvider) createObjectBucketClaimStorage(ctx context.Context, bs *v1alpha1.BlobStorage, blobCfg *BlobStorageStrat) (*providers.BlobStorageInstance, croType.StatusMessage, error) {
+	// handle provider-specific finalizer
+	if err := resources.CreateFinalizer(ctx, b.Client, bs, DefaultFinalizer); err != nil {
+		errMsg := "failed to set finalizer"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	storageClassName := blobCfg.ObjectBucketClaim.StorageClassName
+	if storageClassName == "" {
+		storageClassName = defaultObjectBucketClaimStorageClass
+	}
+
+	// create the object bucket claim if it does not exist, the provisioner owns it from here
+	// and reports readiness through the status phase
+	foundObc := buildObjectBucketClaim(bs, storageClassName)
+	if err := b.Client.Get(ctx, client.ObjectKey{Name: bs.Name, Namespace: bs.Namespace}, foundObc); err != nil {
+		if !errors.IsNotFound(err) {
+			errMsg := "failed to get object bucket claim"
+			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+		if err := b.Client.Create(ctx, buildObjectBucketClaim(bs, storageClassName)); err != nil {
+			errMsg := "failed to create object bucket claim"
+			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+		return nil, "started object bucket claim provision", nil
+	}
+	phase, _, _ := unstructured.NestedString(foundObc.Object, "status", "phase")
+	if phase != objectBucketClaimBoundPhase {
+		b.Logger.Infof("object bucket claim %s is not yet bound", bs.Name)
+		return nil, "creation in progress", nil
+	}
+
+	// the provisioner publishes connection details through a configmap and secret named after
+	// the claim
+	cm := &v1.ConfigMap{}
+	if err := b.Client.Get(ctx, client.ObjectKey{Name: bs.Name, Namespace: bs.Namespace}, cm); err != nil {
+		errMsg := "failed to get object bucket claim config map"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	sec := &v1.Secret{}
+	if err := b.Client.Get(ctx, client.ObjectKey{Name: bs.Name, Namespace: bs.Namespace}, sec); err != nil {
+		errMsg := "failed to get object bucket claim secret"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	return &providers.BlobStorageInstance{
+		DeploymentDetails: &BlobStorageDeploymentDetails{
+			data: map[string]string{
+				aws.DetailsBlobStorageBucketName:          cm.Data["BUCKET_NAME"],
+				aws.DetailsBlobStorageBucketRegion:        cm.Data["BUCKET_REGION"],
+				aws.DetailsBlobStorageCredentialKeyID:     string(sec.Data["AWS_ACCESS_KEY_ID"]),
+				aws.DetailsBlobStorageCredentialSecretKey: string(sec.Data["AWS_SECRET_ACCESS_KEY"]),
+				"endpoint": fmt.Sprintf("%s:%s", cm.Data["BUCKET_HOST"], cm.Data["BUCKET_PORT"]),
+			},
+		},
+	}, "reconcile complete", nil
+}
+
 func (b BlobStorageProvider) DeleteStorage(ctx context.Context, bs *v1alpha1.BlobStorage) (croType.StatusMessage, error) {
+	// delete the object bucket claim if the tier provisioned one, a missing crd means the
+	// placeholder mode was in use and there is nothing to clean up
+	obc := buildObjectBucketClaim(bs, "")
+	err := b.Client.Delete(ctx, obc)
+	if err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		errMsg := "failed to delete object bucket claim"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// remove the finalizer added by the provider
+	resources.RemoveFinalizer(&bs.ObjectMeta, DefaultFinalizer)
+	if err := b.Client.Update(ctx, bs); err != nil {
+		errMsg := "failed to update instance as part of finalizer reconcile"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
 	return "deletion complete", nil
 }
+
+// getBlobStorageConfig retrieves the blob storage config from the cloud-resources-openshift-strategies configmap
+func (b BlobStorageProvider) getBlobStorageConfig(ctx context.Context, bs *v1alpha1.BlobStorage) (*BlobStorageStrat, *StrategyConfig, error) {
+	stratCfg, err := b.ConfigManager.ReadStorageStrategy(ctx, providers.BlobStorageResourceType, bs.Spec.Tier)
+	if err != nil {
+		return nil, nil, errorUtil.Wrap(err, "failed to read openshift strategy config")
+	}
+
+	// apply any per-cr provider config overrides to the strategy
+	if bs.Spec.ProviderConfig != nil {
+		mergedStrategy, err := resources.MergeRawJSON(stratCfg.RawStrategy, bs.Spec.ProviderConfig)
+		if err != nil {
+			return nil, nil, errorUtil.Wrap(err, "failed to apply provider config overrides")
+		}
+		stratCfg.RawStrategy = mergedStrategy
+	}
+
+	// unmarshal the blob storage config
+	blobCfg := &BlobStorageStrat{}
+	if err := json.Unmarshal(stratCfg.RawStrategy, blobCfg); err != nil {
+		return nil, nil, errorUtil.Wrap(err, "failed to unmarshal openshift blob storage configuration")
+	}
+	return blobCfg, stratCfg, nil
+}
+
+// buildObjectBucketClaim the object bucket claim crd is not a direct dependency of the operator
+// so the claim is built as an unstructured object
+func buildObjectBucketClaim(bs *v1alpha1.BlobStorage, storageClassName string) *unstructured.Unstructured {
+	obc := &unstructured.Unstructured{}
+	obc.SetGroupVersionKind(schema.GroupVersionKind{Group: "objectbucket.io", Version: "v1alpha1", Kind: "ObjectBucketClaim"})
+	obc.SetName(bs.Name)
+	obc.SetNamespace(bs.Namespace)
+	obc.Object["spec"] = map[string]interface{}{
+		"generateBucketName": bs.Name,
+		"storageClassName":   storageClassName,
+	}
+	return obc
+}
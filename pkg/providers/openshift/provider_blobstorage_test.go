@@ -174,8 +174,9 @@ func TestBlobStorageProvider_CreateStorage(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			b := BlobStorageProvider{
-				Client: tt.fields.Client,
-				Logger: tt.fields.Logger,
+				Client:        tt.fields.Client,
+				Logger:        tt.fields.Logger,
+				ConfigManager: buildDefaultConfigManager(),
 			}
 			got, _, err := b.CreateStorage(tt.args.ctx, tt.args.bs)
 			if (err != nil) != tt.wantErr {
@@ -42,6 +42,12 @@ var (
 	defaultPostgresPasswordKey = "password"
 	defaultPostgresDatabaseKey = "database"
 	defaultCredentialsSec      = "postgres-credentials"
+	defaultPostgresHAReplicas  = int32(2)
+	// default pgbouncer parameters used when the strategy opts into connection pooling
+	defaultPoolerImage         = "quay.io/integreatly/pgbouncer:1.12"
+	defaultPoolerReplicas      = int32(2)
+	defaultPoolerMaxClientConn = int32(500)
+	defaultPoolerPoolSize      = int32(20)
 )
 
 // PostgresStrat to be used to unmarshal strat map
@@ -52,6 +58,115 @@ type PostgresStrat struct {
 	PostgresServiceSpec    *v1.ServiceSpec               `json:"serviceSpec"`
 	PostgresPVCSpec        *v1.PersistentVolumeClaimSpec `json:"pvcSpec"`
 	PostgresSecretData     map[string]string             `json:"secretData"`
+	// simple overrides applied to the default deployment and pvc, so common hardening options
+	// do not require a full spec in the strategy
+	PostgresPVCSize          string                   `json:"pvcSize"`
+	PostgresStorageClassName *string                  `json:"storageClassName"`
+	PostgresResources        *v1.ResourceRequirements `json:"resources"`
+	PostgresPodAntiAffinity  *v1.PodAntiAffinity      `json:"podAntiAffinity"`
+	// connection overrides, changing the service port, admin user or database name placed in
+	// the output secret without requiring a full secret or service spec in the strategy
+	PostgresPort     *int   `json:"port"`
+	PostgresUser     string `json:"user"`
+	PostgresDatabase string `json:"database"`
+	// optional ha mode, replaces the single pod deployment with a statefulset running streaming
+	// replication across the configured number of replicas
+	PostgresHighAvailability *PostgresHAStrat `json:"highAvailability"`
+	// optional connection pooling, places a pgbouncer deployment in front of the database and
+	// serves its endpoint in the output secret instead of the database service
+	PostgresConnectionPooling *PostgresPoolingStrat `json:"connectionPooling"`
+}
+
+// PostgresHAStrat to be used to unmarshal the optional ha block of the strat map
+type PostgresHAStrat struct {
+	Enabled  bool   `json:"enabled"`
+	Replicas *int32 `json:"replicas"`
+}
+
+// PostgresPoolingStrat to be used to unmarshal the optional connection pooling block of the
+// strat map
+type PostgresPoolingStrat struct {
+	Enabled              bool   `json:"enabled"`
+	Replicas             *int32 `json:"replicas"`
+	MaxClientConnections *int32 `json:"maxClientConnections"`
+	PoolSize             *int32 `json:"poolSize"`
+}
+
+// poolingEnabled returns true when the strategy opts into connection pooling
+func (s *PostgresStrat) poolingEnabled() bool {
+	return s.PostgresConnectionPooling != nil && s.PostgresConnectionPooling.Enabled
+}
+
+// postgresPort returns the service port from the strategy, falling back to the default. The
+// container itself always listens on the default port, the override changes the client facing
+// service port and the port placed in the output secret
+func (s *PostgresStrat) postgresPort() int {
+	if s.PostgresPort != nil {
+		return *s.PostgresPort
+	}
+	return defaultPostgresPort
+}
+
+// postgresUser returns the admin user from the strategy, falling back to the default. The user
+// is created by the database image on first start from the credentials secret
+func (s *PostgresStrat) postgresUser() string {
+	if s.PostgresUser != "" {
+		return s.PostgresUser
+	}
+	return defaultPostgresUser
+}
+
+// postgresDatabase returns the database name from the strategy, falling back to the cr name.
+// The database is created by the database image on first start from the credentials secret
+func (s *PostgresStrat) postgresDatabase(ps *v1alpha1.Postgres) string {
+	if s.PostgresDatabase != "" {
+		return s.PostgresDatabase
+	}
+	return ps.Name
+}
+
+// ApplyPVCOverrides applies the simple pvc options from the strategy to the provided pvc, the
+// resulting pvc is used to patch any existing pvc when the strategy changes
+func (s *PostgresStrat) ApplyPVCOverrides(pvc *v1.PersistentVolumeClaim) error {
+	if s.PostgresPVCSize != "" {
+		size, err := resource.ParseQuantity(s.PostgresPVCSize)
+		if err != nil {
+			return errorUtil.Wrapf(err, "failed to parse pvc size %s", s.PostgresPVCSize)
+		}
+		pvc.Spec.Resources.Requests[v1.ResourceStorage] = size
+	}
+	if s.PostgresStorageClassName != nil {
+		pvc.Spec.StorageClassName = s.PostgresStorageClassName
+	}
+	return nil
+}
+
+// ApplyDeploymentOverrides applies the simple deployment options from the strategy to the
+// provided deployment, the resulting deployment is used to patch any existing deployment when
+// the strategy changes
+func (s *PostgresStrat) ApplyDeploymentOverrides(d *appsv1.Deployment) {
+	s.applyPodTemplateOverrides(&d.Spec.Template)
+}
+
+// ApplyStatefulSetOverrides applies the simple deployment options from the strategy to the
+// provided statefulset, the resulting statefulset is used to patch any existing statefulset
+// when the strategy changes
+func (s *PostgresStrat) ApplyStatefulSetOverrides(ss *appsv1.StatefulSet) {
+	s.applyPodTemplateOverrides(&ss.Spec.Template)
+}
+
+func (s *PostgresStrat) applyPodTemplateOverrides(t *v1.PodTemplateSpec) {
+	if s.PostgresResources != nil {
+		for i := range t.Spec.Containers {
+			t.Spec.Containers[i].Resources = *s.PostgresResources
+		}
+	}
+	if s.PostgresPodAntiAffinity != nil {
+		if t.Spec.Affinity == nil {
+			t.Spec.Affinity = &v1.Affinity{}
+		}
+		t.Spec.Affinity.PodAntiAffinity = s.PostgresPodAntiAffinity
+	}
 }
 
 var _ providers.PostgresProvider = (*PostgresProvider)(nil)
@@ -81,13 +196,21 @@ func (p *PostgresProvider) SupportsStrategy(d string) bool {
 }
 
 func (p *PostgresProvider) GetReconcileTime(pg *v1alpha1.Postgres) time.Duration {
+	if pg.Status.Phase == croType.PhaseFailed {
+		return resources.GetErrorBackoffTime(pg.Status.FailureCount)
+	}
 	if pg.Status.Phase != croType.PhaseComplete {
 		return time.Second * 10
 	}
-	return resources.GetForcedReconcileTimeOrDefault(defaultReconcileTime)
+	return resources.GetHealthyReconcileTimeOrDefault(defaultReconcileTime)
 }
 
 func (p *PostgresProvider) CreatePostgres(ctx context.Context, ps *v1alpha1.Postgres) (*providers.PostgresInstance, croType.StatusMessage, error) {
+	// dry run crs report what would be created without deploying any workload
+	if ps.Spec.DryRun {
+		return nil, croType.StatusMessage(fmt.Sprintf("dry run: would deploy postgres %s in namespace %s", ps.Name, ps.Namespace)), nil
+	}
+
 	// handle provider-specific finalizer
 	if err := resources.CreateFinalizer(ctx, p.Client, ps, DefaultFinalizer); err != nil {
 		errMsg := "failed to set finalizer"
@@ -101,8 +224,18 @@ func (p *PostgresProvider) CreatePostgres(ctx context.Context, ps *v1alpha1.Post
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
 	}
 
+	// the ha mode replaces the single pod deployment with a statefulset
+	if postgresCfg.PostgresHighAvailability != nil && postgresCfg.PostgresHighAvailability.Enabled {
+		return p.createHAPostgres(ctx, ps, postgresCfg)
+	}
+
 	// deploy pvc
-	if err := p.CreatePVC(ctx, buildDefaultPostgresPVC(ps), postgresCfg); err != nil {
+	defaultPvc := buildDefaultPostgresPVC(ps)
+	if err := postgresCfg.ApplyPVCOverrides(defaultPvc); err != nil {
+		errMsg := fmt.Sprintf("failed to apply pvc overrides from strategy for instance %s", ps.Name)
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	if err := p.CreatePVC(ctx, defaultPvc, postgresCfg); err != nil {
 		errMsg := fmt.Sprintf("failed to create or update postgres PVC for instance %s", ps.Name)
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
@@ -112,17 +245,19 @@ func (p *PostgresProvider) CreatePostgres(ctx context.Context, ps *v1alpha1.Post
 		errMsg := "failed to generate potential postgres password"
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
-	if err := p.CreateSecret(ctx, buildDefaultPostgresSecret(ps, password), postgresCfg); err != nil {
+	if err := p.CreateSecret(ctx, buildDefaultPostgresSecret(ps, password, postgresCfg), postgresCfg); err != nil {
 		errMsg := fmt.Sprintf("failed to create or update postgres secret for instance %s", ps.Name)
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
 	// deploy deployment
-	if err := p.CreateDeployment(ctx, buildDefaultPostgresDeployment(ps), postgresCfg); err != nil {
+	defaultDeployment := buildDefaultPostgresDeployment(ps)
+	postgresCfg.ApplyDeploymentOverrides(defaultDeployment)
+	if err := p.CreateDeployment(ctx, defaultDeployment, postgresCfg); err != nil {
 		errMsg := fmt.Sprintf("failed to create or update postgres deployment for instance %s", ps.Name)
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
 	// deploy service
-	if err := p.CreateService(ctx, buildDefaultPostgresService(ps), postgresCfg); err != nil {
+	if err := p.CreateService(ctx, buildDefaultPostgresService(ps, postgresCfg), postgresCfg); err != nil {
 		errMsg := fmt.Sprintf("failed to create or update postgres service for instance %s", ps.Name)
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
@@ -166,14 +301,126 @@ func (p *PostgresProvider) CreatePostgres(ctx context.Context, ps *v1alpha1.Post
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
 
+	// when the strategy opts into connection pooling clients connect through the pgbouncer
+	// deployment instead of the database service
+	host := fmt.Sprintf("%s.%s.svc.cluster.local", ps.Name, ps.Namespace)
+	if postgresCfg.poolingEnabled() {
+		poolerReady, err := p.reconcilePooler(ctx, ps, postgresCfg)
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to create or update connection pooler for instance %s", ps.Name)
+			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+		if !poolerReady {
+			p.Logger.Info("postgres connection pooler deployment is not ready")
+			return nil, "creation in progress", nil
+		}
+		host = fmt.Sprintf("%s.%s.svc.cluster.local", buildPoolerName(ps), ps.Namespace)
+	}
+
 	p.Logger.Info("found postgres deployment")
 	return &providers.PostgresInstance{
 		DeploymentDetails: &providers.PostgresDeploymentDetails{
 			Username: dbUser,
 			Password: string(sec.Data["password"]),
 			Database: string(sec.Data["database"]),
-			Host:     fmt.Sprintf("%s.%s.svc.cluster.local", ps.Name, ps.Namespace),
-			Port:     defaultPostgresPort,
+			Host:     host,
+			Port:     postgresCfg.postgresPort(),
+		},
+	}, "creation successful", nil
+}
+
+// createHAPostgres reconciles the statefulset based ha topology, pod 0 acts as the primary and
+// the remaining pods stream from it using the replication entrypoints shipped in the rhscl image
+func (p *PostgresProvider) createHAPostgres(ctx context.Context, ps *v1alpha1.Postgres, postgresCfg *PostgresStrat) (*providers.PostgresInstance, croType.StatusMessage, error) {
+	replicas := defaultPostgresHAReplicas
+	if postgresCfg.PostgresHighAvailability.Replicas != nil {
+		replicas = *postgresCfg.PostgresHighAvailability.Replicas
+	}
+
+	// deploy credentials secret
+	password, err := resources.GeneratePassword()
+	if err != nil {
+		errMsg := "failed to generate potential postgres password"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	if err := p.CreateSecret(ctx, buildDefaultPostgresSecret(ps, password, postgresCfg), postgresCfg); err != nil {
+		errMsg := fmt.Sprintf("failed to create or update postgres secret for instance %s", ps.Name)
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// deploy statefulset
+	defaultStatefulSet := buildDefaultPostgresStatefulSet(ps, replicas)
+	if err := postgresCfg.ApplyPVCOverrides(&defaultStatefulSet.Spec.VolumeClaimTemplates[0]); err != nil {
+		errMsg := fmt.Sprintf("failed to apply pvc overrides from strategy for instance %s", ps.Name)
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	postgresCfg.ApplyStatefulSetOverrides(defaultStatefulSet)
+	if err := p.CreateStatefulSet(ctx, defaultStatefulSet); err != nil {
+		errMsg := fmt.Sprintf("failed to create or update postgres statefulset for instance %s", ps.Name)
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// deploy the primary service used for writes and the headless service governing the statefulset
+	if err := p.CreateService(ctx, buildHAPostgresPrimaryService(ps, postgresCfg), postgresCfg); err != nil {
+		errMsg := fmt.Sprintf("failed to create or update postgres primary service for instance %s", ps.Name)
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	if err := p.CreateService(ctx, buildHAPostgresHeadlessService(ps, postgresCfg), postgresCfg); err != nil {
+		errMsg := fmt.Sprintf("failed to create or update postgres replication service for instance %s", ps.Name)
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// check statefulset status
+	ss := &appsv1.StatefulSet{}
+	if err := p.Client.Get(ctx, types.NamespacedName{Name: ps.Name, Namespace: ps.Namespace}, ss); err != nil {
+		errMsg := "failed to get postgres statefulset"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	if ss.Status.ReadyReplicas < replicas {
+		p.Logger.Infof("postgres statefulset has %d of %d ready replicas", ss.Status.ReadyReplicas, replicas)
+		return nil, "creation in progress", nil
+	}
+
+	// get the cred secret
+	sec := &v1.Secret{}
+	credentialsSec := fmt.Sprintf("%s-%s", ps.Name, defaultCredentialsSec)
+	if err := p.Client.Get(ctx, types.NamespacedName{Name: credentialsSec, Namespace: ps.Namespace}, sec); err != nil {
+		errMsg := "failed to get postgres creds"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// statefulset is complete, give user permissions on the primary pod and complete
+	dbUser := string(sec.Data["user"])
+	cmd := "psql -c \"ALTER USER \\\"" + dbUser + "\\\" WITH SUPERUSER;\""
+	if err := p.PodCommander.ExecIntoPodByName(ps.Namespace, fmt.Sprintf("%s-0", ps.Name), cmd); err != nil {
+		errMsg := "failed to reconcile database roles for user"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// when the strategy opts into connection pooling clients connect through the pgbouncer
+	// deployment instead of the primary service
+	host := fmt.Sprintf("%s.%s.svc.cluster.local", ps.Name, ps.Namespace)
+	if postgresCfg.poolingEnabled() {
+		poolerReady, err := p.reconcilePooler(ctx, ps, postgresCfg)
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to create or update connection pooler for instance %s", ps.Name)
+			return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+		if !poolerReady {
+			p.Logger.Info("postgres connection pooler deployment is not ready")
+			return nil, "creation in progress", nil
+		}
+		host = fmt.Sprintf("%s.%s.svc.cluster.local", buildPoolerName(ps), ps.Namespace)
+	}
+
+	p.Logger.Info("found postgres statefulset")
+	return &providers.PostgresInstance{
+		DeploymentDetails: &providers.PostgresDeploymentDetails{
+			Username: dbUser,
+			Password: string(sec.Data["password"]),
+			Database: string(sec.Data["database"]),
+			Host:     host,
+			Port:     postgresCfg.postgresPort(),
 		},
 	}, "creation successful", nil
 }
@@ -235,6 +482,71 @@ func (p *PostgresProvider) DeletePostgres(ctx context.Context, ps *v1alpha1.Post
 		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
 
+	// clean up the pooler deployment and service if the tier ran with connection pooling
+	p.Logger.Info("Deleting postgres connection pooler")
+	poolerDpl := &appsv1.Deployment{
+		ObjectMeta: controllerruntime.ObjectMeta{
+			Name:      buildPoolerName(ps),
+			Namespace: ps.Namespace,
+		},
+	}
+	err = p.Client.Delete(ctx, poolerDpl)
+	if err != nil && !k8serr.IsNotFound(err) {
+		errMsg := "failed to delete postgres connection pooler deployment"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	poolerSvc := &v1.Service{
+		ObjectMeta: controllerruntime.ObjectMeta{
+			Name:      buildPoolerName(ps),
+			Namespace: ps.Namespace,
+		},
+	}
+	err = p.Client.Delete(ctx, poolerSvc)
+	if err != nil && !k8serr.IsNotFound(err) {
+		errMsg := "failed to delete postgres connection pooler service"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// clean up the statefulset and replication service if the tier ran in ha mode
+	p.Logger.Info("Deleting postgres statefulset")
+	ss := &appsv1.StatefulSet{
+		ObjectMeta: controllerruntime.ObjectMeta{
+			Name:      ps.Name,
+			Namespace: ps.Namespace,
+		},
+	}
+	err = p.Client.Delete(ctx, ss)
+	if err != nil && !k8serr.IsNotFound(err) {
+		errMsg := "failed to delete postgres statefulset"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	replSvc := &v1.Service{
+		ObjectMeta: controllerruntime.ObjectMeta{
+			Name:      fmt.Sprintf("%s-replication", ps.Name),
+			Namespace: ps.Namespace,
+		},
+	}
+	err = p.Client.Delete(ctx, replSvc)
+	if err != nil && !k8serr.IsNotFound(err) {
+		errMsg := "failed to delete postgres replication service"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// pvcs created from the statefulset volume claim template are not removed with the
+	// statefulset, they are located through the deployment label carried on the template
+	pvcList := &v1.PersistentVolumeClaimList{}
+	if err := p.Client.List(ctx, pvcList, client.InNamespace(ps.Namespace), client.MatchingLabels{"deployment": ps.Name}); err != nil {
+		errMsg := "failed to list postgres statefulset persistent volume claims"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	for i := range pvcList.Items {
+		err = p.Client.Delete(ctx, &pvcList.Items[i])
+		if err != nil && !k8serr.IsNotFound(err) {
+			errMsg := "failed to delete postgres statefulset persistent volume claim"
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+	}
+
 	// remove the finalizer added by the provider
 	p.Logger.Info("Removing postgres finalizer")
 	resources.RemoveFinalizer(&ps.ObjectMeta, DefaultFinalizer)
@@ -253,6 +565,15 @@ func (p *PostgresProvider) getPostgresConfig(ctx context.Context, ps *v1alpha1.P
 	if err != nil {
 		return nil, nil, errorUtil.Wrap(err, "failed to read openshift strategy config")
 	}
+
+	// apply any per-cr provider config overrides to the strategy
+	if ps.Spec.ProviderConfig != nil {
+		mergedStrategy, err := resources.MergeRawJSON(stratCfg.RawStrategy, ps.Spec.ProviderConfig)
+		if err != nil {
+			return nil, nil, errorUtil.Wrap(err, "failed to apply provider config overrides")
+		}
+		stratCfg.RawStrategy = mergedStrategy
+	}
 	// unmarshal the postgres config
 	postgresCfg := &PostgresStrat{}
 	if err := json.Unmarshal(stratCfg.RawStrategy, postgresCfg); err != nil {
@@ -280,6 +601,21 @@ func (p *PostgresProvider) CreateDeployment(ctx context.Context, d *appsv1.Deplo
 	return nil
 }
 
+func (p *PostgresProvider) CreateStatefulSet(ctx context.Context, ss *appsv1.StatefulSet) error {
+	or, err := immutableCreateOrUpdate(ctx, p.Client, ss, func(existing runtime.Object) error {
+		e := existing.(*appsv1.StatefulSet)
+
+		// service name, selector and volume claim templates are immutable once created
+		e.Spec.Replicas = ss.Spec.Replicas
+		e.Spec.Template = ss.Spec.Template
+		return nil
+	})
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to create or update statefulset %s, action was %s", ss.Name, or)
+	}
+	return nil
+}
+
 func (p *PostgresProvider) CreateService(ctx context.Context, s *v1.Service, postgresCfg *PostgresStrat) error {
 	or, err := immutableCreateOrUpdate(ctx, p.Client, s, func(existing runtime.Object) error {
 		e := existing.(*v1.Service)
@@ -346,6 +682,43 @@ func (p *PostgresProvider) CreatePVC(ctx context.Context, pvc *v1.PersistentVolu
 	return nil
 }
 
+// reconcilePooler maintains the pgbouncer deployment and service fronting the database,
+// returning true once the pooler deployment reports available
+func (p *PostgresProvider) reconcilePooler(ctx context.Context, ps *v1alpha1.Postgres, postgresCfg *PostgresStrat) (bool, error) {
+	dpl := buildPoolerDeployment(ps, postgresCfg)
+	or, err := immutableCreateOrUpdate(ctx, p.Client, dpl, func(existing runtime.Object) error {
+		e := existing.(*appsv1.Deployment)
+		e.Spec = dpl.Spec
+		return nil
+	})
+	if err != nil {
+		return false, errorUtil.Wrapf(err, "failed to create or update deployment %s, action was %s", dpl.Name, or)
+	}
+
+	svc := buildPoolerService(ps, postgresCfg)
+	or, err = immutableCreateOrUpdate(ctx, p.Client, svc, func(existing runtime.Object) error {
+		e := existing.(*v1.Service)
+		clusterIP := e.Spec.ClusterIP
+		e.Spec = svc.Spec
+		e.Spec.ClusterIP = clusterIP
+		return nil
+	})
+	if err != nil {
+		return false, errorUtil.Wrapf(err, "failed to create or update service %s, action was %s", svc.Name, or)
+	}
+
+	found := &appsv1.Deployment{}
+	if err := p.Client.Get(ctx, types.NamespacedName{Name: dpl.Name, Namespace: ps.Namespace}, found); err != nil {
+		return false, errorUtil.Wrap(err, "failed to get connection pooler deployment")
+	}
+	for _, s := range found.Status.Conditions {
+		if s.Type == appsv1.DeploymentAvailable && s.Status == "True" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (p *PostgresProvider) ReconcileDatabaseUserRoles(ctx context.Context, d *appsv1.Deployment, u string) error {
 	cmd := "psql -c \"ALTER USER \\\"" + u + "\\\" WITH SUPERUSER;\""
 	if err := p.PodCommander.ExecIntoPod(d, cmd); err != nil {
@@ -354,7 +727,7 @@ func (p *PostgresProvider) ReconcileDatabaseUserRoles(ctx context.Context, d *ap
 	return nil
 }
 
-func buildDefaultPostgresService(ps *v1alpha1.Postgres) *v1.Service {
+func buildDefaultPostgresService(ps *v1alpha1.Postgres, postgresCfg *PostgresStrat) *v1.Service {
 	return &v1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      ps.Name,
@@ -365,7 +738,7 @@ func buildDefaultPostgresService(ps *v1alpha1.Postgres) *v1.Service {
 				{
 					Name:       "postgresql",
 					Protocol:   v1.ProtocolTCP,
-					Port:       int32(defaultPostgresPort),
+					Port:       int32(postgresCfg.postgresPort()),
 					TargetPort: intstr.FromInt(defaultPostgresPort),
 				},
 			},
@@ -374,6 +747,23 @@ func buildDefaultPostgresService(ps *v1alpha1.Postgres) *v1.Service {
 	}
 }
 
+// buildHAPostgresPrimaryService the service clients connect to for writes, it keeps the same
+// name and dns as the single pod service but routes to the current primary pod only
+func buildHAPostgresPrimaryService(ps *v1alpha1.Postgres, postgresCfg *PostgresStrat) *v1.Service {
+	svc := buildDefaultPostgresService(ps, postgresCfg)
+	svc.Spec.Selector = map[string]string{"statefulset.kubernetes.io/pod-name": fmt.Sprintf("%s-0", ps.Name)}
+	return svc
+}
+
+// buildHAPostgresHeadlessService the headless service governing the statefulset, it gives each
+// pod a stable dns name used by the replicas when streaming from the primary
+func buildHAPostgresHeadlessService(ps *v1alpha1.Postgres, postgresCfg *PostgresStrat) *v1.Service {
+	svc := buildDefaultPostgresService(ps, postgresCfg)
+	svc.Name = fmt.Sprintf("%s-replication", ps.Name)
+	svc.Spec.ClusterIP = v1.ClusterIPNone
+	return svc
+}
+
 func buildDefaultPostgresPVC(ps *v1alpha1.Postgres) *v1.PersistentVolumeClaim {
 	return &v1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
@@ -440,6 +830,69 @@ func buildDefaultPostgresDeployment(ps *v1alpha1.Postgres) *appsv1.Deployment {
 	return depl
 }
 
+func buildDefaultPostgresStatefulSet(ps *v1alpha1.Postgres, replicas int32) *appsv1.StatefulSet {
+	pvcTemplate := buildDefaultPostgresPVC(ps)
+	// the deployment label is carried on the volume claim template so the pvcs created from it
+	// can be located during deletion
+	pvcTemplate.ObjectMeta = metav1.ObjectMeta{
+		Name: ps.Name,
+		Labels: map[string]string{
+			"deployment": ps.Name,
+		},
+	}
+
+	ss := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ps.Name,
+			Namespace: ps.Namespace,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName: fmt.Sprintf("%s-replication", ps.Name),
+			Replicas:    &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"deployment": ps.Name,
+				},
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"deployment": ps.Name,
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: buildHAPostgresPodContainers(ps),
+				},
+			},
+			VolumeClaimTemplates: []v1.PersistentVolumeClaim{*pvcTemplate},
+		},
+	}
+	// required for restricted namespace
+	if strings.HasPrefix(ps.Namespace, NamespacePrefixOpenShift) {
+		userGroupId := int64(26)
+		ss.Spec.Template.Spec.SecurityContext = &v1.PodSecurityContext{
+			FSGroup:            &userGroupId,
+			SupplementalGroups: []int64{userGroupId},
+		}
+	}
+	return ss
+}
+
+// buildHAPostgresPodContainers the default postgres container adjusted for replication, pod 0
+// starts as the primary and the remaining pods bootstrap from it over the primary service
+func buildHAPostgresPodContainers(ps *v1alpha1.Postgres) []v1.Container {
+	credentialsSec := fmt.Sprintf("%s-%s", ps.Name, defaultCredentialsSec)
+
+	containers := buildDefaultPostgresPodContainers(ps)
+	containers[0].Command = []string{"/bin/sh", "-c", "if [ \"${HOSTNAME##*-}\" = \"0\" ]; then run-postgresql-master; else run-postgresql-slave; fi"}
+	containers[0].Env = append(containers[0].Env,
+		envVarFromSecret("POSTGRESQL_MASTER_USER", credentialsSec, defaultPostgresUserKey),
+		envVarFromSecret("POSTGRESQL_MASTER_PASSWORD", credentialsSec, defaultPostgresPasswordKey),
+		v1.EnvVar{Name: "POSTGRESQL_MASTER_SERVICE_NAME", Value: ps.Name},
+	)
+	return containers
+}
+
 func buildDefaultPostgresPodContainers(ps *v1alpha1.Postgres) []v1.Container {
 	credentialsSec := fmt.Sprintf("%s-%s", ps.Name, defaultCredentialsSec)
 
@@ -505,7 +958,101 @@ func buildDefaultPostgresPodContainers(ps *v1alpha1.Postgres) []v1.Container {
 	}
 }
 
-func buildDefaultPostgresSecret(ps *v1alpha1.Postgres, password string) *v1.Secret {
+// buildPoolerName returns the name of the pgbouncer deployment and service fronting the database
+func buildPoolerName(ps *v1alpha1.Postgres) string {
+	return fmt.Sprintf("%s-pooler", ps.Name)
+}
+
+// buildPoolerService the service clients connect to when connection pooling is enabled, it
+// keeps the same port as the database service and routes to the pgbouncer pods
+func buildPoolerService(ps *v1alpha1.Postgres, postgresCfg *PostgresStrat) *v1.Service {
+	svc := buildDefaultPostgresService(ps, postgresCfg)
+	svc.Name = buildPoolerName(ps)
+	svc.Spec.Selector = map[string]string{"deployment": buildPoolerName(ps)}
+	return svc
+}
+
+// buildPoolerDeployment the pgbouncer deployment fronting the database, it authenticates
+// against the database with the credentials secret and runs in transaction pooling mode so
+// idle client connections do not hold database connections open
+func buildPoolerDeployment(ps *v1alpha1.Postgres, postgresCfg *PostgresStrat) *appsv1.Deployment {
+	poolerName := buildPoolerName(ps)
+	credentialsSec := fmt.Sprintf("%s-%s", ps.Name, defaultCredentialsSec)
+
+	poolingCfg := postgresCfg.PostgresConnectionPooling
+	replicas := defaultPoolerReplicas
+	if poolingCfg.Replicas != nil {
+		replicas = *poolingCfg.Replicas
+	}
+	maxClientConn := defaultPoolerMaxClientConn
+	if poolingCfg.MaxClientConnections != nil {
+		maxClientConn = *poolingCfg.MaxClientConnections
+	}
+	poolSize := defaultPoolerPoolSize
+	if poolingCfg.PoolSize != nil {
+		poolSize = *poolingCfg.PoolSize
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      poolerName,
+			Namespace: ps.Namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"deployment": poolerName,
+				},
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"deployment": poolerName,
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  poolerName,
+							Image: defaultPoolerImage,
+							Ports: []v1.ContainerPort{
+								{
+									ContainerPort: int32(defaultPostgresPort),
+									Protocol:      v1.ProtocolTCP,
+								},
+							},
+							Env: []v1.EnvVar{
+								envVarFromSecret("DB_USER", credentialsSec, defaultPostgresUserKey),
+								envVarFromSecret("DB_PASSWORD", credentialsSec, defaultPostgresPasswordKey),
+								envVarFromSecret("DB_NAME", credentialsSec, defaultPostgresDatabaseKey),
+								{Name: "DB_HOST", Value: fmt.Sprintf("%s.%s.svc.cluster.local", ps.Name, ps.Namespace)},
+								{Name: "POOL_MODE", Value: "transaction"},
+								{Name: "MAX_CLIENT_CONN", Value: fmt.Sprintf("%d", maxClientConn)},
+								{Name: "DEFAULT_POOL_SIZE", Value: fmt.Sprintf("%d", poolSize)},
+							},
+							ReadinessProbe: &v1.Probe{
+								Handler: v1.Handler{
+									TCPSocket: &v1.TCPSocketAction{
+										Port: intstr.IntOrString{
+											Type:   intstr.Int,
+											IntVal: int32(defaultPostgresPort),
+										},
+									},
+								},
+								InitialDelaySeconds: 5,
+								PeriodSeconds:       10,
+							},
+							ImagePullPolicy: v1.PullIfNotPresent,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildDefaultPostgresSecret(ps *v1alpha1.Postgres, password string, postgresCfg *PostgresStrat) *v1.Secret {
 	credentialsSec := fmt.Sprintf("%s-%s", ps.Name, defaultCredentialsSec)
 
 	if password == "" {
@@ -517,9 +1064,9 @@ func buildDefaultPostgresSecret(ps *v1alpha1.Postgres, password string) *v1.Secr
 			Namespace: ps.Namespace,
 		},
 		Data: map[string][]byte{
-			"user":     []byte(defaultPostgresUser),
+			"user":     []byte(postgresCfg.postgresUser()),
 			"password": []byte(password),
-			"database": []byte(ps.Name),
+			"database": []byte(postgresCfg.postgresDatabase(ps)),
 		},
 		Type: v1.SecretTypeOpaque,
 	}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -385,6 +386,60 @@ func TestOpenShiftPostgresProvider_overrideDefaults(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "test override connection port",
+			fields: fields{
+				Client:        fake.NewFakeClientWithScheme(scheme, buildTestPostgresCR()),
+				Logger:        testLogger,
+				ConfigManager: buildTestConfigManager(`{"port":5433}`),
+			},
+			args: args{
+				ctx:      context.TODO(),
+				postgres: buildTestPostgresCR(),
+			},
+			want: v1.ServiceSpec{
+				Ports: []v1.ServicePort{
+					{
+						Name:       "postgresql",
+						Protocol:   v1.ProtocolTCP,
+						Port:       int32(5433),
+						TargetPort: intstr.FromInt(defaultPostgresPort),
+					},
+				},
+				Selector: map[string]string{"deployment": testPostgresName},
+			},
+			getTestableSpec: func(ctx context.Context, c client.Client) (interface{}, error) {
+				svc := &v1.Service{}
+				err := c.Get(ctx, types.NamespacedName{Name: testPostgresName, Namespace: testPostgresNamespace}, svc)
+				return svc.Spec, err
+			},
+			wantErr: false,
+		},
+		{
+			name: "test override connection user and database",
+			fields: fields{
+				Client:        fake.NewFakeClientWithScheme(scheme, buildTestPostgresCR()),
+				Logger:        testLogger,
+				ConfigManager: buildTestConfigManager(`{"user":"admin","database":"shop"}`),
+			},
+			args: args{
+				ctx:      context.TODO(),
+				postgres: buildTestPostgresCR(),
+			},
+			want: map[string]string{
+				"user":     "admin",
+				"database": "shop",
+			},
+			getTestableSpec: func(ctx context.Context, c client.Client) (interface{}, error) {
+				sec := &v1.Secret{}
+				err := c.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("%s-%s", testPostgresName, defaultCredentialsSec), Namespace: testPostgresNamespace}, sec)
+				return map[string]string{
+					"user":     string(sec.Data["user"]),
+					"database": string(sec.Data["database"]),
+				}, err
+			},
+			wantErr: false,
+		},
 		{
 			name: "test override service defaults",
 			fields: fields{
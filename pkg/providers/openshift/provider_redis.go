@@ -44,8 +44,14 @@ const (
 	redisContainerName    = "redis"
 	redisPort             = 6379
 	redisContainerCommand = "/opt/rh/rh-redis32/root/usr/bin/redis-server"
+	// default sentinel topology options
+	redisSentinelContainerName = "sentinel"
+	redisSentinelPort          = 26379
+	redisSentinelMasterName    = "mymaster"
 )
 
+var defaultRedisHAReplicas = int32(3)
+
 var _ providers.RedisProvider = (*RedisProvider)(nil)
 
 type RedisProvider struct {
@@ -71,13 +77,21 @@ func (p *RedisProvider) SupportsStrategy(d string) bool {
 }
 
 func (p *RedisProvider) GetReconcileTime(r *v1alpha1.Redis) time.Duration {
+	if r.Status.Phase == croType.PhaseFailed {
+		return resources.GetErrorBackoffTime(r.Status.FailureCount)
+	}
 	if r.Status.Phase != croType.PhaseComplete {
 		return time.Second * 10
 	}
-	return resources.GetForcedReconcileTimeOrDefault(defaultReconcileTime)
+	return resources.GetHealthyReconcileTimeOrDefault(defaultReconcileTime)
 }
 
 func (p *RedisProvider) CreateRedis(ctx context.Context, r *v1alpha1.Redis) (*providers.RedisCluster, croType.StatusMessage, error) {
+	// dry run crs report what would be created without deploying any workload
+	if r.Spec.DryRun {
+		return nil, croType.StatusMessage(fmt.Sprintf("dry run: would deploy redis %s in namespace %s", r.Name, r.Namespace)), nil
+	}
+
 	// handle provider-specific finalizer
 	if err := resources.CreateFinalizer(ctx, p.Client, r, DefaultFinalizer); err != nil {
 		return nil, "failed to set finalizer", err
@@ -90,13 +104,18 @@ func (p *RedisProvider) CreateRedis(ctx context.Context, r *v1alpha1.Redis) (*pr
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrapf(err, errMsg)
 	}
 
+	// the sentinel topology replaces the single pod deployment with a statefulset
+	if redisConfig.RedisHighAvailability != nil && redisConfig.RedisHighAvailability.Enabled {
+		return p.createHARedis(ctx, r, redisConfig)
+	}
+
 	// deploy pvc
 	if err := p.CreatePVC(ctx, buildDefaultRedisPVC(r), redisConfig); err != nil {
 		errMsg := "failed to create or update redis PVC"
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
 	// deploy configmap
-	if err := p.CreateConfigMap(ctx, buildDefaultRedisConfigMap(r), redisConfig); err != nil {
+	if err := p.CreateConfigMap(ctx, buildDefaultRedisConfigMap(r, redisConfig), redisConfig); err != nil {
 		errMsg := "failed to create or update redis config map"
 		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
@@ -134,6 +153,59 @@ func (p *RedisProvider) CreateRedis(ctx context.Context, r *v1alpha1.Redis) (*pr
 	return nil, "creation in progress", nil
 }
 
+// createHARedis reconciles the statefulset based sentinel topology, pod 0 starts as the master
+// and the remaining pods replicate from it, a sentinel sidecar in every pod handles failover
+func (p *RedisProvider) createHARedis(ctx context.Context, r *v1alpha1.Redis, redisConfig *RedisStrat) (*providers.RedisCluster, croType.StatusMessage, error) {
+	replicas := defaultRedisHAReplicas
+	if redisConfig.RedisHighAvailability.Replicas != nil {
+		replicas = *redisConfig.RedisHighAvailability.Replicas
+	}
+
+	// deploy configmap
+	if err := p.CreateConfigMap(ctx, buildDefaultRedisConfigMap(r, redisConfig), redisConfig); err != nil {
+		errMsg := "failed to create or update redis config map"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	// deploy statefulset
+	if err := p.CreateStatefulSet(ctx, buildDefaultRedisStatefulSet(r, replicas)); err != nil {
+		errMsg := "failed to create or update redis statefulset"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	// deploy the master service used for writes, the headless service governing the statefulset
+	// and the sentinel service clients use for discovery
+	if err := p.CreateService(ctx, buildHARedisMasterService(r), redisConfig); err != nil {
+		errMsg := "failed to create or update redis master service"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	if err := p.CreateService(ctx, buildHARedisHeadlessService(r), redisConfig); err != nil {
+		errMsg := "failed to create or update redis replication service"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	if err := p.CreateService(ctx, buildHARedisSentinelService(r), redisConfig); err != nil {
+		errMsg := "failed to create or update redis sentinel service"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+
+	// check statefulset status
+	ss := &appsv1.StatefulSet{}
+	if err := p.Client.Get(ctx, types.NamespacedName{Name: r.Name, Namespace: r.Namespace}, ss); err != nil {
+		errMsg := "failed to get redis statefulset"
+		return nil, croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	if ss.Status.ReadyReplicas < replicas {
+		p.Logger.Infof("redis statefulset has %d of %d ready replicas", ss.Status.ReadyReplicas, replicas)
+		return nil, "creation in progress", nil
+	}
+
+	p.Logger.Info("found redis statefulset")
+	return &providers.RedisCluster{DeploymentDetails: &providers.RedisDeploymentDetails{
+		URI:          fmt.Sprintf("%s.%s.svc.cluster.local", r.Name, r.Namespace),
+		Port:         redisPort,
+		SentinelURI:  fmt.Sprintf("%s-sentinel.%s.svc.cluster.local", r.Name, r.Namespace),
+		SentinelPort: redisSentinelPort,
+	}}, "redis statefulset available", nil
+}
+
 func (p *RedisProvider) DeleteRedis(ctx context.Context, r *v1alpha1.Redis) (croType.StatusMessage, error) {
 	// delete service
 	p.Logger.Info("Deleting redis service")
@@ -191,6 +263,48 @@ func (p *RedisProvider) DeleteRedis(ctx context.Context, r *v1alpha1.Redis) (cro
 		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
 	}
 
+	// clean up the statefulset and its services if the tier ran the sentinel topology
+	p.Logger.Info("Deleting redis statefulset")
+	ss := &appsv1.StatefulSet{
+		ObjectMeta: controllerruntime.ObjectMeta{
+			Name:      r.Name,
+			Namespace: r.Namespace,
+		},
+	}
+	err = p.Client.Delete(ctx, ss)
+	if err != nil && !k8serr.IsNotFound(err) {
+		errMsg := "failed to delete statefulset"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	for _, svcName := range []string{fmt.Sprintf("%s-replication", r.Name), fmt.Sprintf("%s-sentinel", r.Name)} {
+		haSvc := &apiv1.Service{
+			ObjectMeta: controllerruntime.ObjectMeta{
+				Name:      svcName,
+				Namespace: r.Namespace,
+			},
+		}
+		err = p.Client.Delete(ctx, haSvc)
+		if err != nil && !k8serr.IsNotFound(err) {
+			errMsg := fmt.Sprintf("failed to delete service %s", svcName)
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+	}
+
+	// pvcs created from the statefulset volume claim template are not removed with the
+	// statefulset, they are located through the deployment label carried on the template
+	pvcList := &apiv1.PersistentVolumeClaimList{}
+	if err := p.Client.List(ctx, pvcList, client.InNamespace(r.Namespace), client.MatchingLabels{"deployment": r.Name}); err != nil {
+		errMsg := "failed to list redis statefulset persistent volume claims"
+		return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+	}
+	for i := range pvcList.Items {
+		err = p.Client.Delete(ctx, &pvcList.Items[i])
+		if err != nil && !k8serr.IsNotFound(err) {
+			errMsg := "failed to delete redis statefulset persistent volume claim"
+			return croType.StatusMessage(errMsg), errorUtil.Wrap(err, errMsg)
+		}
+	}
+
 	// remove the finalizer added by the provider
 	p.Logger.Info("Removing finalizer")
 	resources.RemoveFinalizer(&r.ObjectMeta, DefaultFinalizer)
@@ -210,6 +324,15 @@ func (p *RedisProvider) getRedisConfig(ctx context.Context, r *v1alpha1.Redis) (
 		return nil, nil, errorUtil.Wrap(err, "failed to read openshift strategy config")
 	}
 
+	// apply any per-cr provider config overrides to the strategy
+	if r.Spec.ProviderConfig != nil {
+		mergedStrategy, err := resources.MergeRawJSON(stratCfg.RawStrategy, r.Spec.ProviderConfig)
+		if err != nil {
+			return nil, nil, errorUtil.Wrap(err, "failed to apply provider config overrides")
+		}
+		stratCfg.RawStrategy = mergedStrategy
+	}
+
 	// unmarshal the redis cluster config
 	redisConfig := &RedisStrat{}
 	if err := json.Unmarshal(stratCfg.RawStrategy, redisConfig); err != nil {
@@ -234,6 +357,21 @@ func (p *RedisProvider) CreateDeployment(ctx context.Context, d *appsv1.Deployme
 	return nil
 }
 
+func (p *RedisProvider) CreateStatefulSet(ctx context.Context, ss *appsv1.StatefulSet) error {
+	or, err := immutableCreateOrUpdate(ctx, p.Client, ss, func(existing runtime.Object) error {
+		e := existing.(*appsv1.StatefulSet)
+
+		// service name, selector and volume claim templates are immutable once created
+		e.Spec.Replicas = ss.Spec.Replicas
+		e.Spec.Template = ss.Spec.Template
+		return nil
+	})
+	if err != nil {
+		return errorUtil.Wrapf(err, "failed to create or update statefulset %s, action was %s", ss.Name, or)
+	}
+	return nil
+}
+
 func (p *RedisProvider) CreateService(ctx context.Context, s *apiv1.Service, redisCfg *RedisStrat) error {
 	or, err := immutableCreateOrUpdate(ctx, p.Client, s, func(existing runtime.Object) error {
 		e := existing.(*apiv1.Service)
@@ -300,6 +438,19 @@ type RedisStrat struct {
 	RedisServiceSpec    *apiv1.ServiceSpec               `json:"serviceSpec"`
 	RedisPVCSpec        *apiv1.PersistentVolumeClaimSpec `json:"pvcSpec"`
 	RedisConfigMapData  map[string]string                `json:"configMapData"`
+	// persistence tuning applied to the generated redis.conf, ignored when a full config is
+	// supplied through configMapData
+	RedisAppendOnly *bool    `json:"appendOnly"`
+	RedisSaveRules  []string `json:"saveRules"`
+	// optional sentinel topology, replaces the single pod deployment with a statefulset running
+	// a replica chain monitored by sentinel
+	RedisHighAvailability *RedisHAStrat `json:"highAvailability"`
+}
+
+// RedisHAStrat to be used to unmarshal the optional sentinel block of the strat map
+type RedisHAStrat struct {
+	Enabled  bool   `json:"enabled"`
+	Replicas *int32 `json:"replicas"`
 }
 
 func buildDefaultRedisDeployment(r *v1alpha1.Redis) *appsv1.Deployment {
@@ -346,6 +497,149 @@ func buildDefaultRedisDeployment(r *v1alpha1.Redis) *appsv1.Deployment {
 	return depl
 }
 
+func buildDefaultRedisStatefulSet(r *v1alpha1.Redis, replicas int32) *appsv1.StatefulSet {
+	pvcTemplate := buildDefaultRedisPVC(r)
+	// the deployment label is carried on the volume claim template so the pvcs created from it
+	// can be located during deletion
+	pvcTemplate.ObjectMeta = metav1.ObjectMeta{
+		Name: r.Name,
+		Labels: map[string]string{
+			"deployment": r.Name,
+		},
+	}
+
+	ss := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.Name,
+			Namespace: r.Namespace,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName: fmt.Sprintf("%s-replication", r.Name),
+			Replicas:    &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"deployment": r.Name,
+				},
+			},
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"deployment": r.Name,
+					},
+				},
+				Spec: apiv1.PodSpec{
+					Volumes:    buildDefaultRedisPodVolumes(r),
+					Containers: buildHARedisPodContainers(r, replicas),
+				},
+			},
+			VolumeClaimTemplates: []apiv1.PersistentVolumeClaim{*pvcTemplate},
+		},
+	}
+	// the volume claim template replaces the standalone pvc volume
+	for i := range ss.Spec.Template.Spec.Volumes {
+		if ss.Spec.Template.Spec.Volumes[i].Name == r.Name {
+			ss.Spec.Template.Spec.Volumes = append(ss.Spec.Template.Spec.Volumes[:i], ss.Spec.Template.Spec.Volumes[i+1:]...)
+			break
+		}
+	}
+	// required for restricted namespace
+	if strings.HasPrefix(r.Namespace, NamespacePrefixOpenShift) {
+		userGroupId := int64(1001)
+		ss.Spec.Template.Spec.SecurityContext = &v1.PodSecurityContext{
+			FSGroup:            &userGroupId,
+			SupplementalGroups: []int64{userGroupId},
+		}
+	}
+	return ss
+}
+
+// buildHARedisPodContainers the default redis container adjusted for replication plus a sentinel
+// sidecar, pod 0 starts as the master and the remaining pods replicate from it over the headless
+// service
+func buildHARedisPodContainers(r *v1alpha1.Redis, replicas int32) []apiv1.Container {
+	masterHost := fmt.Sprintf("%s-0.%s-replication.%s.svc.cluster.local", r.Name, r.Name, r.Namespace)
+	quorum := replicas/2 + 1
+
+	containers := buildDefaultRedisPodContainers(r)
+	containers[0].Command = []string{"/bin/sh", "-c"}
+	containers[0].Args = []string{fmt.Sprintf(
+		"if [ \"${HOSTNAME##*-}\" = \"0\" ]; then exec %[1]s /etc/redis.d/%[2]s --daemonize no; else exec %[1]s /etc/redis.d/%[2]s --daemonize no --slaveof %[3]s %[4]d; fi",
+		redisContainerCommand, redisConfigMapKey, masterHost, redisPort,
+	)}
+
+	// sentinel requires a writable config file, so it is rendered to an emptydir-backed path on
+	// start up rather than mounted from the configmap
+	sentinelConf := strings.Join([]string{
+		fmt.Sprintf("port %d", redisSentinelPort),
+		fmt.Sprintf("sentinel monitor %s %s %d %d", redisSentinelMasterName, masterHost, redisPort, quorum),
+		fmt.Sprintf("sentinel down-after-milliseconds %s 5000", redisSentinelMasterName),
+		fmt.Sprintf("sentinel failover-timeout %s 60000", redisSentinelMasterName),
+		fmt.Sprintf("sentinel parallel-syncs %s 1", redisSentinelMasterName),
+	}, "\\n")
+	sentinel := apiv1.Container{
+		Image:           containers[0].Image,
+		ImagePullPolicy: apiv1.PullIfNotPresent,
+		Name:            redisSentinelContainerName,
+		Command:         []string{"/bin/sh", "-c"},
+		Args: []string{fmt.Sprintf(
+			"printf '%s\\n' > /tmp/sentinel.conf && exec %s /tmp/sentinel.conf --sentinel --daemonize no",
+			sentinelConf, redisContainerCommand,
+		)},
+		Resources: apiv1.ResourceRequirements{
+			Limits: apiv1.ResourceList{
+				apiv1.ResourceCPU:    resource.MustParse("100m"),
+				apiv1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+			Requests: apiv1.ResourceList{
+				apiv1.ResourceCPU:    resource.MustParse("50m"),
+				apiv1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+		},
+		LivenessProbe: &apiv1.Probe{
+			InitialDelaySeconds: 10,
+			PeriodSeconds:       10,
+			Handler: apiv1.Handler{
+				TCPSocket: &apiv1.TCPSocketAction{
+					Port: intstr.FromInt(redisSentinelPort),
+				},
+			},
+		},
+	}
+	return append(containers, sentinel)
+}
+
+// buildHARedisMasterService the service clients connect to for writes, it keeps the same name
+// and dns as the single pod service but routes to the current master pod only
+func buildHARedisMasterService(r *v1alpha1.Redis) *apiv1.Service {
+	svc := buildDefaultRedisService(r)
+	svc.Spec.Selector = map[string]string{"statefulset.kubernetes.io/pod-name": fmt.Sprintf("%s-0", r.Name)}
+	return svc
+}
+
+// buildHARedisHeadlessService the headless service governing the statefulset, it gives each pod
+// a stable dns name used by the replicas and sentinels
+func buildHARedisHeadlessService(r *v1alpha1.Redis) *apiv1.Service {
+	svc := buildDefaultRedisService(r)
+	svc.Name = fmt.Sprintf("%s-replication", r.Name)
+	svc.Spec.ClusterIP = apiv1.ClusterIPNone
+	return svc
+}
+
+// buildHARedisSentinelService the service clients use to discover the current master through
+// sentinel
+func buildHARedisSentinelService(r *v1alpha1.Redis) *apiv1.Service {
+	svc := buildDefaultRedisService(r)
+	svc.Name = fmt.Sprintf("%s-sentinel", r.Name)
+	svc.Spec.Ports = []apiv1.ServicePort{
+		{
+			Port:       redisSentinelPort,
+			TargetPort: intstr.FromInt(redisSentinelPort),
+			Protocol:   apiv1.ProtocolTCP,
+		},
+	}
+	return svc
+}
+
 func buildDefaultRedisPodContainers(r *v1alpha1.Redis) []apiv1.Container {
 	return []apiv1.Container{
 		{
@@ -462,7 +756,7 @@ func buildDefaultRedisService(r *v1alpha1.Redis) *apiv1.Service {
 	}
 }
 
-func buildDefaultRedisConfigMap(r *v1alpha1.Redis) *apiv1.ConfigMap {
+func buildDefaultRedisConfigMap(r *v1alpha1.Redis, redisCfg *RedisStrat) *apiv1.ConfigMap {
 	return &apiv1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      redisConfigMapName,
@@ -473,11 +767,34 @@ func buildDefaultRedisConfigMap(r *v1alpha1.Redis) *apiv1.ConfigMap {
 			APIVersion: "v1",
 		},
 		Data: map[string]string{
-			"redis.conf": getRedisConfData(),
+			"redis.conf": buildRedisConfData(redisCfg),
 		},
 	}
 }
 
+// buildRedisConfData applies the persistence tuning from the strategy to the default redis
+// config, aof is disabled by default and the default rdb save rules can be replaced per tier
+func buildRedisConfData(redisCfg *RedisStrat) string {
+	conf := getRedisConfData()
+	if len(redisCfg.RedisSaveRules) > 0 {
+		var lines []string
+		for _, line := range strings.Split(conf, "\n") {
+			if strings.HasPrefix(line, "save ") {
+				continue
+			}
+			lines = append(lines, line)
+		}
+		conf = strings.Join(lines, "\n")
+		for _, rule := range redisCfg.RedisSaveRules {
+			conf += fmt.Sprintf("save %s\n", rule)
+		}
+	}
+	if redisCfg.RedisAppendOnly != nil && *redisCfg.RedisAppendOnly {
+		conf += "appendonly yes\n"
+	}
+	return conf
+}
+
 func getRedisConfData() string {
 	return `protected-mode no
 port 6379
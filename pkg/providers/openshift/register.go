@@ -0,0 +1,11 @@
+package openshift
+
+import (
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+)
+
+// record the openshift deployment strategy with the provider registry so it can be enabled
+// or disabled as a group through operator configuration
+func init() {
+	providers.RegisterStrategy(providers.OpenShiftDeploymentStrategy)
+}
@@ -0,0 +1,58 @@
+package providers
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+)
+
+// DisabledProvidersConfigKey operator config key (or environment variable) holding a comma
+// separated list of deployment strategies that must not be served, e.g. "openshift" on
+// clusters that only use aws provisioning
+const DisabledProvidersConfigKey = "DISABLED_PROVIDERS"
+
+var (
+	registeredStrategies     []string
+	registeredStrategiesLock sync.Mutex
+)
+
+// RegisterStrategy records a deployment strategy implemented by a provider package, called
+// from provider package init functions so the registry reflects the providers compiled into
+// the operator
+func RegisterStrategy(strategy string) {
+	registeredStrategiesLock.Lock()
+	defer registeredStrategiesLock.Unlock()
+	for _, s := range registeredStrategies {
+		if s == strategy {
+			return
+		}
+	}
+	registeredStrategies = append(registeredStrategies, strategy)
+}
+
+// RegisteredStrategies returns the deployment strategies implemented by the compiled in
+// provider packages
+func RegisteredStrategies() []string {
+	registeredStrategiesLock.Lock()
+	defer registeredStrategiesLock.Unlock()
+	strategies := make([]string, len(registeredStrategies))
+	copy(strategies, registeredStrategies)
+	return strategies
+}
+
+// StrategyEnabled returns false when the deployment strategy has been disabled through
+// operator configuration, controllers skip constructing providers for disabled strategies
+// so no cloud clients exist for providers that can never serve a cr on the cluster
+func StrategyEnabled(strategy string) bool {
+	raw, ok := resources.LookupConfig(DisabledProvidersConfigKey)
+	if !ok || raw == "" {
+		return true
+	}
+	for _, s := range strings.Split(raw, ",") {
+		if strings.TrimSpace(s) == strategy {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+)
+
+func TestStrategyEnabled(t *testing.T) {
+	cases := []struct {
+		name     string
+		disabled string
+		strategy string
+		want     bool
+	}{
+		{
+			name:     "test strategies are enabled when nothing is disabled",
+			disabled: "",
+			strategy: AWSDeploymentStrategy,
+			want:     true,
+		},
+		{
+			name:     "test a disabled strategy is not enabled",
+			disabled: OpenShiftDeploymentStrategy,
+			strategy: OpenShiftDeploymentStrategy,
+			want:     false,
+		},
+		{
+			name:     "test other strategies stay enabled when one is disabled",
+			disabled: OpenShiftDeploymentStrategy,
+			strategy: AWSDeploymentStrategy,
+			want:     true,
+		},
+		{
+			name:     "test whitespace around disabled entries is ignored",
+			disabled: " aws , openshift ",
+			strategy: AWSDeploymentStrategy,
+			want:     false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resources.SetOperatorConfig(map[string]string{DisabledProvidersConfigKey: tc.disabled})
+			defer resources.SetOperatorConfig(nil)
+			if got := StrategyEnabled(tc.strategy); got != tc.want {
+				t.Fatalf("StrategyEnabled(%s) = %v, want %v", tc.strategy, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegisterStrategy(t *testing.T) {
+	RegisterStrategy("test-strategy")
+	RegisterStrategy("test-strategy")
+	found := 0
+	for _, s := range RegisteredStrategies() {
+		if s == "test-strategy" {
+			found++
+		}
+	}
+	if found != 1 {
+		t.Fatalf("expected test-strategy to be registered exactly once, found %d entries", found)
+	}
+}
@@ -2,6 +2,8 @@ package providers
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -72,6 +74,22 @@ type PostgresProvider interface {
 	DeletePostgres(ctx context.Context, ps *v1alpha1.Postgres) (croType.StatusMessage, error)
 }
 
+type PostgresUserProvider interface {
+	GetName() string
+	SupportsStrategy(s string) bool
+	GetReconcileTime(u *v1alpha1.PostgresUser) time.Duration
+	ReconcilePostgresUser(ctx context.Context, u *v1alpha1.PostgresUser, ps *v1alpha1.Postgres) (croType.StatusMessage, error)
+	DeletePostgresUser(ctx context.Context, u *v1alpha1.PostgresUser, ps *v1alpha1.Postgres) (croType.StatusMessage, error)
+}
+
+type PostgresDatabaseProvider interface {
+	GetName() string
+	SupportsStrategy(s string) bool
+	GetReconcileTime(d *v1alpha1.PostgresDatabase) time.Duration
+	ReconcilePostgresDatabase(ctx context.Context, d *v1alpha1.PostgresDatabase, ps *v1alpha1.Postgres) (croType.StatusMessage, error)
+	DeletePostgresDatabase(ctx context.Context, d *v1alpha1.PostgresDatabase, ps *v1alpha1.Postgres) (croType.StatusMessage, error)
+}
+
 type PostgresSnapshotProvider interface {
 	GetName() string
 	SupportsStrategy(s string) bool
@@ -88,18 +106,36 @@ type RedisSnapshotProvider interface {
 	DeleteRedisSnapshot(ctx context.Context, snapshot *v1alpha1.RedisSnapshot, redis *v1alpha1.Redis) (croType.StatusMessage, error)
 }
 
+// NetworkProvider reconciles the standalone network resources (vpc, subnets, peering, route
+// tables and security groups) that postgres and redis instances are deployed into
+type NetworkProvider interface {
+	GetName() string
+	SupportsStrategy(s string) bool
+	GetReconcileTime(n *v1alpha1.Network) time.Duration
+	ReconcileNetwork(ctx context.Context, n *v1alpha1.Network) (croType.StatusMessage, error)
+	DeleteNetwork(ctx context.Context, n *v1alpha1.Network) (croType.StatusMessage, error)
+}
+
 // RedisDeploymentDetails provider specific details about the AWS Redis Cluster created
 type RedisDeploymentDetails struct {
 	URI  string
 	Port int64
+	// sentinel connection details, only set by providers running a sentinel topology
+	SentinelURI  string
+	SentinelPort int64
 }
 
-//Data Redis provider Data function
+// Data Redis provider Data function
 func (r *RedisDeploymentDetails) Data() map[string][]byte {
-	return map[string][]byte{
+	data := map[string][]byte{
 		"uri":  []byte(r.URI),
 		"port": []byte(strconv.FormatInt(r.Port, 10)),
 	}
+	if r.SentinelURI != "" {
+		data["sentinelUri"] = []byte(r.SentinelURI)
+		data["sentinelPort"] = []byte(strconv.FormatInt(r.SentinelPort, 10))
+	}
+	return data
 }
 
 type PostgresDeploymentDetails struct {
@@ -108,16 +144,37 @@ type PostgresDeploymentDetails struct {
 	Host     string
 	Database string
 	Port     int
+	// the ca bundle of the certificate authority signing the server certificate, only set by
+	// providers that expose the bundle
+	CertificateAuthority []byte
 }
 
 func (d *PostgresDeploymentDetails) Data() map[string][]byte {
-	return map[string][]byte{
+	data := map[string][]byte{
 		"username": []byte(d.Username),
 		"password": []byte(d.Password),
 		"host":     []byte(d.Host),
 		"database": []byte(d.Database),
 		"port":     []byte(strconv.Itoa(d.Port)),
 	}
+	if len(d.CertificateAuthority) > 0 {
+		data["ca.crt"] = d.CertificateAuthority
+		data["uri"] = []byte(d.verifyFullURI())
+	}
+	return data
+}
+
+// verifyFullURI a ready made connection string requiring full verification of the server
+// certificate against the published ca bundle
+func (d *PostgresDeploymentDetails) verifyFullURI() string {
+	uri := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(d.Username, d.Password),
+		Host:     fmt.Sprintf("%s:%d", d.Host, d.Port),
+		Path:     "/" + d.Database,
+		RawQuery: "sslmode=verify-full",
+	}
+	return uri.String()
 }
 
 // GenericCloudMetric is a wrapper to represent provider specific metrics generically
@@ -0,0 +1,468 @@
+// Package reconciler implements the reconcile loop shared by the postgres, redis and
+// blobstorage controllers. The controllers repeat the same strategy lookup, provider
+// selection, deletion handling, connection secret and status update plumbing, so it lives
+// here once and each controller supplies the resource specific pieces through the exported
+// fields of Reconciler.
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers/aws"
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+	errorUtil "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ResourceProvider is the subset of provider behaviour the shared reconcile loop needs,
+// each controller adapts its typed provider interface (PostgresProvider, RedisProvider,
+// BlobStorageProvider) onto it
+type ResourceProvider interface {
+	GetName() string
+	SupportsStrategy(s string) bool
+	ReconcileTime(o runtime.Object) time.Duration
+	Create(ctx context.Context, o runtime.Object) (providers.DeploymentDetails, croType.StatusMessage, error)
+	Delete(ctx context.Context, o runtime.Object) (croType.StatusMessage, error)
+}
+
+// Reconciler implements reconcile.Reconciler for a single resource type, the resource
+// specific pieces are provided by the owning controller
+type Reconciler struct {
+	Client           client.Client
+	Logger           *logrus.Entry
+	EventRecorder    record.EventRecorder
+	ResourceProvider *resources.ReconcileResourceProvider
+	// ResourceType the cro resource type served, used in metrics and spans
+	ResourceType providers.ResourceType
+	// Kind the cr kind served, used in the log correlation fields
+	Kind string
+	// FriendlyName the human readable resource name used in events and log lines
+	FriendlyName string
+	// NewInstance returns an empty cr of the served kind for the reconcile to hydrate
+	NewInstance func() runtime.Object
+	// StrategyForMapping selects the strategy for the served kind from the deployment type
+	// mapping of the cloud-resource-config config map
+	StrategyForMapping func(m *providers.DeploymentStrategyMapping) string
+	// Providers the candidate providers, the first supporting the strategy serves the cr
+	Providers []ResourceProvider
+}
+
+var _ reconcile.Reconciler = &Reconciler{}
+
+// Reconcile reads the state of the cluster for a cr of the served kind and makes changes
+// based on the state read and what is in its spec.
+// The Controller will requeue the Request to be processed again if the returned error is
+// non-nil or Result.Requeue is true, otherwise upon completion it will remove the work from
+// the queue.
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	// attach the cr identity and a reconcile id to every log line of this reconcile
+	logger := resources.NewReconcileLogger(r.Logger, r.Kind, request.NamespacedName)
+	logger.Infof("reconciling %s", r.Kind)
+	ctx := context.TODO()
+	ctx, span := resources.StartSpan(ctx, fmt.Sprintf("%s.Reconcile", string(r.ResourceType)))
+	defer span.End()
+	cfgMgr := providers.NewConfigManager(providers.DefaultProviderConfigMapName, request.Namespace, r.Client)
+
+	// Fetch the instance
+	instance := r.NewInstance()
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	spec, err := getResourceSpec(instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	status, err := getResourceStatus(instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	// newly accepted crs start in the pending stage, providers move them through the finer
+	// grained stages as provisioning progresses
+	if status.Stage == "" {
+		status.SetStage(croType.StagePending)
+	}
+
+	// delete the resource once the ttl on its spec has elapsed, preview environments create
+	// resources with a ttl so they are not left running after the environment is gone
+	if spec.TTL != "" && instance.(metav1.Object).GetDeletionTimestamp() == nil {
+		expired, err := r.reconcileTTL(ctx, instance, spec, status, logger)
+		if err != nil {
+			if updateErr := resources.UpdatePhase(ctx, r.Client, instance, croType.PhaseFailed, croType.StatusMessage("failed to reconcile ttl on cr").WrapError(err)); updateErr != nil {
+				return reconcile.Result{}, updateErr
+			}
+			return reconcile.Result{}, errorUtil.Wrap(err, "failed to reconcile ttl on cr")
+		}
+		if expired {
+			return reconcile.Result{}, nil
+		}
+	}
+
+	stratMap, err := cfgMgr.GetStrategyMappingForDeploymentType(ctx, spec.Type)
+	if err != nil {
+		if updateErr := resources.UpdatePhase(ctx, r.Client, instance, croType.PhaseFailed, croType.StatusDeploymentConfigNotFound.WrapError(err)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		return reconcile.Result{}, errorUtil.Wrapf(err, "failed to read deployment type config for deployment %s", spec.Type)
+	}
+
+	// Check the CR for existing Strategy
+	mappedStrategy := r.StrategyForMapping(stratMap)
+	strategyToUse := mappedStrategy
+	if status.Strategy != "" {
+		strategyToUse = status.Strategy
+		if strategyToUse != mappedStrategy {
+			logger.Infof("strategy and provider already set, changing of cloud-resource-config config maps not allowed in existing installation. the existing strategy is '%s' , cloud-resource-config is now set to '%s'. operator will continue to use existing strategy", strategyToUse, mappedStrategy)
+		}
+	}
+
+	for _, p := range r.Providers {
+		if !p.SupportsStrategy(strategyToUse) {
+			continue
+		}
+		// the provider serving the cr is part of the correlation fields
+		logger := logger.WithField(resources.LoggingKeyProvider, p.GetName())
+		status.Strategy = strategyToUse
+		status.Provider = p.GetName()
+		if err := setResourceStatus(instance, status); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		// delete the resource if the deletion timestamp exists
+		if instance.(metav1.Object).GetDeletionTimestamp() != nil {
+			return r.reconcileDelete(ctx, p, instance, status, logger)
+		}
+
+		// handle skip create
+		if spec.SkipCreate {
+			logger.Infof("skipCreate found, skipping %s reconcile", r.FriendlyName)
+			if status.Phase != croType.PhasePaused {
+				r.EventRecorder.Event(instance, corev1.EventTypeNormal, resources.EventReasonPaused, fmt.Sprintf("skipCreate set, %s reconcile paused", r.FriendlyName))
+			}
+			if err := resources.UpdatePhase(ctx, r.Client, instance, croType.PhasePaused, croType.StatusSkipCreate); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{Requeue: true, RequeueAfter: p.ReconcileTime(instance)}, nil
+		}
+
+		return r.reconcileCreate(ctx, p, instance, spec, status, strategyToUse, logger)
+	}
+
+	// unsupported strategy
+	if err := resources.UpdatePhase(ctx, r.Client, instance, croType.PhaseFailed, croType.StatusUnsupportedType); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, errorUtil.Errorf("unsupported deployment strategy %s", mappedStrategy)
+}
+
+// reconcileDelete tears down the cloud resource through the provider, the finalizer is only
+// removed by the provider once teardown has completed
+func (r *Reconciler) reconcileDelete(ctx context.Context, p ResourceProvider, instance runtime.Object, status *croType.ResourceTypeStatus, logger *logrus.Entry) (reconcile.Result, error) {
+	if status.Phase != croType.PhaseDeleteInProgress {
+		r.EventRecorder.Event(instance, corev1.EventTypeNormal, resources.EventReasonDeleting, fmt.Sprintf("%s deletion started", r.FriendlyName))
+	}
+
+	// cloud teardown can be skipped explicitly when it keeps failing, the finalizer is
+	// removed so the cr can be deleted, the cloud resources are left in place
+	if resources.IsForceDelete(instance.(metav1.Object), status.FailureCount) {
+		logger.Warnf("force delete annotation found after %d failed delete attempts, skipping cloud resource cleanup", status.FailureCount)
+		r.EventRecorder.Event(instance, corev1.EventTypeWarning, resources.EventReasonDeleting, "force delete set, skipping cloud resource cleanup")
+		if err := resources.RemoveFinalizerFromObject(instance, aws.DefaultFinalizer); err != nil {
+			return reconcile.Result{}, errorUtil.Wrap(err, "failed to remove finalizer as part of force delete")
+		}
+		if err := r.Client.Update(ctx, instance); err != nil {
+			return reconcile.Result{}, errorUtil.Wrap(err, "failed to remove finalizer as part of force delete")
+		}
+		return reconcile.Result{}, nil
+	}
+
+	msg, err := p.Delete(ctx, instance)
+	// providers mutate the instance status during deletion, refresh the local copy so those
+	// changes are not lost when the status block is written back
+	if refreshed, refreshErr := getResourceStatus(instance); refreshErr == nil {
+		status = refreshed
+	}
+	status.SetStage(croType.StageDeleting)
+	if err != nil {
+		resources.RecordFailureEvent(r.EventRecorder, instance, err)
+		// surface the blocking error on the cr and expose the failure count so stuck
+		// deletions are visible without trawling operator logs
+		status.Conditions = croType.SetCondition(status.Conditions, resources.BuildDeletingCondition(err))
+		if setErr := setResourceStatus(instance, status); setErr != nil {
+			return reconcile.Result{}, setErr
+		}
+		if updateErr := resources.UpdatePhase(ctx, r.Client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		obj := instance.(metav1.Object)
+		resources.SetDeletionStuckMetric(string(r.ResourceType), obj.GetName(), obj.GetNamespace(), status.FailureCount+1)
+		return reconcile.Result{}, errorUtil.Wrapf(err, "failed to perform provider specific %s deletion", r.FriendlyName)
+	}
+
+	// the provider removes the finalizer once teardown has completed, the cloud resource is
+	// then gone and can be dropped from the ledger. A cr removed with the finalizer still in
+	// place keeps its entry, the ledger watch loop reports it as an orphaned cloud resource
+	if obj := instance.(metav1.Object); len(obj.GetFinalizers()) == 0 {
+		resources.RemoveLedgerEntry(r.Kind, obj.GetNamespace(), obj.GetName())
+	}
+
+	logger.Infof("waiting on %s to successfully delete", r.FriendlyName)
+	r.checkStuck(instance, status, logger)
+	if err := setResourceStatus(instance, status); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := resources.UpdatePhase(ctx, r.Client, instance, croType.PhaseDeleteInProgress, msg); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{Requeue: true, RequeueAfter: p.ReconcileTime(instance)}, nil
+}
+
+// reconcileCreate provisions the cloud resource through the provider and reconciles the
+// connection secret and status once the resource is available
+func (r *Reconciler) reconcileCreate(ctx context.Context, p ResourceProvider, instance runtime.Object, spec *croType.ResourceTypeSpec, status *croType.ResourceTypeStatus, strategyToUse string, logger *logrus.Entry) (reconcile.Result, error) {
+	obj := instance.(metav1.Object)
+	// capture the disruption conditions already active before the provider runs so a newly
+	// surfaced failover, node replacement or pending maintenance results in exactly one event
+	activeDisruptions := resources.ActiveDisruptionConditions(status.Conditions)
+	details, msg, err := p.Create(ctx, instance)
+	// providers mutate the instance status during creation (resource id, stage, restore
+	// times), refresh the local copy so those changes are not lost when the status block is
+	// written back
+	if refreshed, refreshErr := getResourceStatus(instance); refreshErr == nil {
+		status = refreshed
+	}
+	r.emitDisruptionEvents(instance, status, activeDisruptions)
+	// record the cloud resource in the ledger as soon as its cloud identifier is known, so the
+	// resource can be traced back to a cloud identifier even if the cr is removed without
+	// teardown completing
+	if status.ResourceID != "" {
+		resources.RecordLedgerEntry(resources.LedgerEntry{
+			Kind:         r.Kind,
+			Namespace:    obj.GetNamespace(),
+			Name:         obj.GetName(),
+			ResourceType: string(r.ResourceType),
+			Provider:     status.Provider,
+			ResourceID:   status.ResourceID,
+		})
+	}
+	if err != nil {
+		status.SecretRef = &croType.SecretRef{}
+		status.Conditions = croType.SetCondition(status.Conditions, resources.BuildFailureCondition(err))
+		if setErr := setResourceStatus(instance, status); setErr != nil {
+			return reconcile.Result{}, setErr
+		}
+		if updateErr := resources.UpdatePhase(ctx, r.Client, instance, croType.PhaseFailed, msg.WrapError(err)); updateErr != nil {
+			return reconcile.Result{}, updateErr
+		}
+		resources.SetFailureMetric(string(r.ResourceType), obj.GetName(), obj.GetNamespace(), err)
+		resources.RecordFailureEvent(r.EventRecorder, instance, err)
+		logger.Errorf("failed to create %s: %v", r.FriendlyName, err)
+		// requeue based on the error class, failures that will not resolve without user
+		// intervention back off to the max instead of using the controller backoff
+		return reconcile.Result{Requeue: true, RequeueAfter: resources.GetErrorReconcileTime(err, status.FailureCount+1)}, nil
+	}
+	// dry run crs only report the plan, no cloud resources are provisioned
+	if spec.DryRun {
+		r.EventRecorder.Event(instance, corev1.EventTypeNormal, resources.EventReasonDryRun, string(msg))
+		if err := resources.UpdatePhase(ctx, r.Client, instance, croType.PhasePaused, msg); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: p.ReconcileTime(instance)}, nil
+	}
+	if details == nil {
+		logger.Infof("secret data is still reconciling, %s is not yet available", r.FriendlyName)
+		status.SecretRef = &croType.SecretRef{}
+		// providers report network work themselves, anything still pending is now provisioning
+		if status.Stage == "" || status.Stage == croType.StagePending {
+			status.SetStage(croType.StageProvisioning)
+		}
+		r.checkStuck(instance, status, logger)
+		if setErr := setResourceStatus(instance, status); setErr != nil {
+			return reconcile.Result{}, setErr
+		}
+		if err := resources.UpdatePhase(ctx, r.Client, instance, croType.PhaseInProgress, msg); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: p.ReconcileTime(instance)}, nil
+	}
+
+	// return the connection secret
+	if err := r.ResourceProvider.ReconcileResultSecret(ctx, instance, details.Data()); err != nil {
+		return reconcile.Result{}, errorUtil.Wrap(err, "failed to reconcile secret")
+	}
+
+	// optionally verify the resource end to end from inside the cluster once it is
+	// available, the cloud api can report available while the network path from the
+	// workloads is broken. The smoke test runs once, its result is the verified condition
+	if resources.SmokeTestEnabled(string(r.ResourceType)) && !hasCondition(status.Conditions, resources.ConditionTypeVerified) {
+		secretNamespace := obj.GetNamespace()
+		if spec.SecretRef.Namespace != "" {
+			secretNamespace = spec.SecretRef.Namespace
+		}
+		condition, err := resources.ReconcileSmokeTestJob(ctx, r.Client, string(r.ResourceType), secretNamespace, spec.SecretRef.Name, obj.GetName())
+		if err != nil {
+			return reconcile.Result{}, errorUtil.Wrap(err, "failed to reconcile smoke test job")
+		}
+		if condition != nil {
+			status.Conditions = croType.SetCondition(status.Conditions, *condition)
+			eventType := corev1.EventTypeNormal
+			if condition.Status != "True" {
+				eventType = corev1.EventTypeWarning
+			}
+			r.EventRecorder.Event(instance, eventType, resources.EventReasonVerified, condition.Message)
+		}
+	}
+
+	if status.Phase != croType.PhaseComplete {
+		r.EventRecorder.Event(instance, corev1.EventTypeNormal, resources.EventReasonProvisioned, fmt.Sprintf("%s successfully provisioned", r.FriendlyName))
+	}
+	status.Phase = croType.PhaseComplete
+	status.Message = msg
+	status.SecretRef = spec.SecretRef
+	status.Strategy = strategyToUse
+	status.Provider = p.GetName()
+	status.SetStage(croType.StageReady)
+	if err := setResourceStatus(instance, status); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		return reconcile.Result{}, errorUtil.Wrapf(err, "failed to update instance %s in namespace %s", obj.GetName(), obj.GetNamespace())
+	}
+	return reconcile.Result{Requeue: true, RequeueAfter: p.ReconcileTime(instance)}, nil
+}
+
+// checkStuck marks a resource that has stayed in its current stage past the configured
+// timeout as degraded and exposes the alert metric, so a cloud resource that never reaches a
+// terminal state is surfaced instead of silently requeueing forever. The condition clears
+// itself once the resource moves on
+func (r *Reconciler) checkStuck(instance runtime.Object, status *croType.ResourceTypeStatus, logger *logrus.Entry) {
+	timeout := resources.StageTimeout(status.Stage)
+	if timeout == 0 {
+		return
+	}
+	elapsed := resources.TimeInStage(status)
+	stuck := elapsed > timeout
+	condition := resources.BuildStuckCondition(status.Stage, elapsed, timeout)
+	wasDegraded := false
+	for _, c := range status.Conditions {
+		if c.Type == resources.ConditionTypeDegraded && c.Status == "True" {
+			wasDegraded = true
+		}
+	}
+	status.Conditions = croType.SetCondition(status.Conditions, condition)
+	obj := instance.(metav1.Object)
+	resources.SetResourceStuckMetric(string(r.ResourceType), obj.GetName(), obj.GetNamespace(), status.Stage, stuck)
+	if stuck {
+		logger.Warn(condition.Message)
+		// the event is only emitted on the transition so repeated requeues of an already
+		// degraded resource do not flood the event stream
+		if !wasDegraded {
+			r.EventRecorder.Event(instance, corev1.EventTypeWarning, resources.EventReasonDegraded, condition.Message)
+		}
+	}
+}
+
+// reconcileTTL deletes the resource once the ttl on its spec has elapsed, counted from the
+// creation time of the cr. While the resource is still within its ttl the upcoming expiry is
+// surfaced as a condition, a warning event at the start of the warning window and the nearing
+// expiry metric. Returns true when the resource was deleted
+func (r *Reconciler) reconcileTTL(ctx context.Context, instance runtime.Object, spec *croType.ResourceTypeSpec, status *croType.ResourceTypeStatus, logger *logrus.Entry) (bool, error) {
+	obj := instance.(metav1.Object)
+	expiry, err := resources.ResourceTTLExpiry(spec.TTL, obj.GetCreationTimestamp().Time)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	resources.SetResourceTTLNearingExpiryMetric(string(r.ResourceType), obj.GetName(), obj.GetNamespace(), resources.ResourceTTLNearingExpiry(expiry, now))
+	condition := resources.BuildTTLExpiringCondition(expiry, now)
+	wasExpiring := false
+	for _, c := range status.Conditions {
+		if c.Type == resources.ConditionTypeTTLExpiring && c.Status == "True" {
+			wasExpiring = true
+		}
+	}
+	status.Conditions = croType.SetCondition(status.Conditions, condition)
+	if now.Before(expiry) {
+		// the event is only emitted on the transition into the warning window so repeated
+		// requeues of an expiring resource do not flood the event stream
+		if condition.Status == "True" && !wasExpiring {
+			logger.Warn(condition.Message)
+			r.EventRecorder.Event(instance, corev1.EventTypeWarning, resources.EventReasonTTLExpiring, condition.Message)
+		}
+		return false, nil
+	}
+	logger.Infof("ttl of %s elapsed, deleting %s", spec.TTL, r.FriendlyName)
+	r.EventRecorder.Event(instance, corev1.EventTypeWarning, resources.EventReasonTTLExpired, condition.Message)
+	if err := r.Client.Delete(ctx, instance); err != nil {
+		return false, errorUtil.Wrapf(err, "failed to delete %s after its ttl elapsed", r.FriendlyName)
+	}
+	return true, nil
+}
+
+// emitDisruptionEvents bridges the disruption conditions surfaced by providers (failovers,
+// node replacements, pending maintenance) to kubernetes events, so application teams can
+// correlate latency spikes with cloud side activity from kubectl describe output. Events are
+// only emitted on the transition so an ongoing disruption does not flood the event stream
+func (r *Reconciler) emitDisruptionEvents(instance runtime.Object, status *croType.ResourceTypeStatus, wasActive map[string]bool) {
+	for _, condition := range status.Conditions {
+		if !resources.IsDisruptionConditionType(condition.Type) || condition.Status != "True" {
+			continue
+		}
+		if wasActive[condition.Type] {
+			continue
+		}
+		r.EventRecorder.Event(instance, corev1.EventTypeWarning, condition.Type, condition.Message)
+	}
+}
+
+// hasCondition returns true when a condition of the given type exists regardless of status
+func hasCondition(conditions []croType.StatusCondition, conditionType string) bool {
+	for _, c := range conditions {
+		if c.Type == conditionType {
+			return true
+		}
+	}
+	return false
+}
+
+func getResourceSpec(inst runtime.Object) (*croType.ResourceTypeSpec, error) {
+	spec := &croType.ResourceTypeSpec{}
+	if err := runtime.Field(reflect.ValueOf(inst).Elem(), "Spec", spec); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to retrieve spec block from object")
+	}
+	return spec, nil
+}
+
+func getResourceStatus(inst runtime.Object) (*croType.ResourceTypeStatus, error) {
+	status := &croType.ResourceTypeStatus{}
+	if err := runtime.Field(reflect.ValueOf(inst).Elem(), "Status", status); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to retrieve status block from object")
+	}
+	return status, nil
+}
+
+func setResourceStatus(inst runtime.Object, status *croType.ResourceTypeStatus) error {
+	if err := runtime.SetField(*status, reflect.ValueOf(inst).Elem(), "Status"); err != nil {
+		return errorUtil.Wrap(err, "failed to set status block of object")
+	}
+	return nil
+}
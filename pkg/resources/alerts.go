@@ -0,0 +1,185 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+
+	errorUtil "github.com/pkg/errors"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// default alert thresholds, used when the strategy config for the tier does not define its own
+const (
+	defaultFreeStoragePercent    = 10
+	defaultMaxConnections        = 100
+	defaultReplicationLagSeconds = 30
+	defaultMemoryUsagePercent    = 80
+)
+
+// AlertThresholds tier aware thresholds for the prometheus rules generated per provisioned
+// resource, configurable through the strategy config map for the tier
+type AlertThresholds struct {
+	// FreeStoragePercent alert when free storage drops below this percentage of the allocated
+	// storage
+	FreeStoragePercent *int64 `json:"freeStoragePercent,omitempty"`
+	// MaxConnections alert when the number of connections in use exceeds this value
+	MaxConnections *int64 `json:"maxConnections,omitempty"`
+	// ReplicationLagSeconds alert when replication lag exceeds this value
+	ReplicationLagSeconds *int64 `json:"replicationLagSeconds,omitempty"`
+	// MemoryUsagePercent alert when memory usage exceeds this percentage of the available
+	// memory
+	MemoryUsagePercent *int64 `json:"memoryUsagePercent,omitempty"`
+}
+
+func (at *AlertThresholds) freeStoragePercent() int64 {
+	if at != nil && at.FreeStoragePercent != nil {
+		return *at.FreeStoragePercent
+	}
+	return defaultFreeStoragePercent
+}
+
+func (at *AlertThresholds) maxConnections() int64 {
+	if at != nil && at.MaxConnections != nil {
+		return *at.MaxConnections
+	}
+	return defaultMaxConnections
+}
+
+func (at *AlertThresholds) replicationLagSeconds() int64 {
+	if at != nil && at.ReplicationLagSeconds != nil {
+		return *at.ReplicationLagSeconds
+	}
+	return defaultReplicationLagSeconds
+}
+
+func (at *AlertThresholds) memoryUsagePercent() int64 {
+	if at != nil && at.MemoryUsagePercent != nil {
+		return *at.MemoryUsagePercent
+	}
+	return defaultMemoryUsagePercent
+}
+
+// resourceAlert a single alert generated for a provisioned resource
+type resourceAlert struct {
+	ruleName  string
+	alertName string
+	desc      string
+	expr      string
+	severity  string
+}
+
+// buildPostgresAlerts builds the alerts generated for a postgres cr, the expressions are
+// scoped to the cr identity labels exposed on the cro metrics
+func buildPostgresAlerts(cr *v1alpha1.Postgres, thresholds *AlertThresholds) []resourceAlert {
+	selector := buildAlertMetricSelector(cr.Name, cr.Namespace)
+	return []resourceAlert{
+		{
+			ruleName:  fmt.Sprintf("cro-postgres-%s-low-free-storage", cr.Name),
+			alertName: "PostgresLowFreeStorage",
+			desc:      fmt.Sprintf("free storage for postgres instance %s in namespace %s is below %d%% of the allocated storage", cr.Name, cr.Namespace, thresholds.freeStoragePercent()),
+			expr:      fmt.Sprintf("cro_postgres_free_storage_average%[1]s / cro_postgres_current_allocated_storage%[1]s * 100 < %[2]d", selector, thresholds.freeStoragePercent()),
+			severity:  "warning",
+		},
+		{
+			ruleName:  fmt.Sprintf("cro-postgres-%s-high-connections", cr.Name),
+			alertName: "PostgresHighConnections",
+			desc:      fmt.Sprintf("postgres instance %s in namespace %s has more than %d connections in use", cr.Name, cr.Namespace, thresholds.maxConnections()),
+			expr:      fmt.Sprintf("cro_postgres_database_connections_average%s > %d", selector, thresholds.maxConnections()),
+			severity:  "warning",
+		},
+		{
+			ruleName:  fmt.Sprintf("cro-postgres-%s-unavailable", cr.Name),
+			alertName: "PostgresInstanceUnavailable",
+			desc:      fmt.Sprintf("postgres instance %s in namespace %s is reporting as unavailable", cr.Name, cr.Namespace),
+			expr:      fmt.Sprintf("cro_postgres_available%s == 0", selector),
+			severity:  "critical",
+		},
+	}
+}
+
+// buildRedisAlerts builds the alerts generated for a redis cr, the expressions are scoped to
+// the cr identity labels exposed on the cro metrics
+func buildRedisAlerts(cr *v1alpha1.Redis, thresholds *AlertThresholds) []resourceAlert {
+	selector := buildAlertMetricSelector(cr.Name, cr.Namespace)
+	return []resourceAlert{
+		{
+			ruleName:  fmt.Sprintf("cro-redis-%s-high-connections", cr.Name),
+			alertName: "RedisHighConnections",
+			desc:      fmt.Sprintf("redis instance %s in namespace %s has more than %d client connections", cr.Name, cr.Namespace, thresholds.maxConnections()),
+			expr:      fmt.Sprintf("cro_redis_current_connections_average%s > %d", selector, thresholds.maxConnections()),
+			severity:  "warning",
+		},
+		{
+			ruleName:  fmt.Sprintf("cro-redis-%s-replication-lag", cr.Name),
+			alertName: "RedisReplicationLag",
+			desc:      fmt.Sprintf("redis instance %s in namespace %s has replication lag above %d seconds", cr.Name, cr.Namespace, thresholds.replicationLagSeconds()),
+			expr:      fmt.Sprintf("cro_redis_replication_lag_average%s > %d", selector, thresholds.replicationLagSeconds()),
+			severity:  "warning",
+		},
+		{
+			ruleName:  fmt.Sprintf("cro-redis-%s-high-memory-usage", cr.Name),
+			alertName: "RedisHighMemoryUsage",
+			desc:      fmt.Sprintf("redis instance %s in namespace %s is using more than %d%% of its available memory", cr.Name, cr.Namespace, thresholds.memoryUsagePercent()),
+			expr:      fmt.Sprintf("cro_redis_memory_usage_percentage_average%s > %d", selector, thresholds.memoryUsagePercent()),
+			severity:  "warning",
+		},
+		{
+			ruleName:  fmt.Sprintf("cro-redis-%s-unavailable", cr.Name),
+			alertName: "RedisCacheUnavailable",
+			desc:      fmt.Sprintf("redis instance %s in namespace %s is reporting as unavailable", cr.Name, cr.Namespace),
+			expr:      fmt.Sprintf("cro_redis_available%s == 0", selector),
+			severity:  "critical",
+		},
+	}
+}
+
+// buildAlertMetricSelector builds the label selector scoping alert expressions to the metrics
+// of a single cr
+func buildAlertMetricSelector(name, namespace string) string {
+	return fmt.Sprintf("{resourceID='%s',namespace='%s'}", name, namespace)
+}
+
+// ReconcilePostgresAlerts creates or updates the prometheus rules generated for a postgres cr
+func ReconcilePostgresAlerts(ctx context.Context, c client.Client, cr *v1alpha1.Postgres, thresholds *AlertThresholds) error {
+	return reconcileResourceAlerts(ctx, c, cr.Namespace, buildPostgresAlerts(cr, thresholds))
+}
+
+// DeletePostgresAlerts removes the prometheus rules generated for a postgres cr
+func DeletePostgresAlerts(ctx context.Context, c client.Client, cr *v1alpha1.Postgres) error {
+	return deleteResourceAlerts(ctx, c, cr.Namespace, buildPostgresAlerts(cr, nil))
+}
+
+// ReconcileRedisAlerts creates or updates the prometheus rules generated for a redis cr
+func ReconcileRedisAlerts(ctx context.Context, c client.Client, cr *v1alpha1.Redis, thresholds *AlertThresholds) error {
+	return reconcileResourceAlerts(ctx, c, cr.Namespace, buildRedisAlerts(cr, thresholds))
+}
+
+// DeleteRedisAlerts removes the prometheus rules generated for a redis cr
+func DeleteRedisAlerts(ctx context.Context, c client.Client, cr *v1alpha1.Redis) error {
+	return deleteResourceAlerts(ctx, c, cr.Namespace, buildRedisAlerts(cr, nil))
+}
+
+func reconcileResourceAlerts(ctx context.Context, c client.Client, namespace string, alerts []resourceAlert) error {
+	for _, alert := range alerts {
+		labels := map[string]string{
+			"severity": alert.severity,
+		}
+		if _, err := ReconcilePrometheusRule(ctx, c, alert.ruleName, namespace, alert.alertName, alert.desc, intstr.FromString(alert.expr), labels); err != nil {
+			return errorUtil.Wrapf(err, "failed to reconcile prometheus rule %s", alert.ruleName)
+		}
+	}
+	return nil
+}
+
+func deleteResourceAlerts(ctx context.Context, c client.Client, namespace string, alerts []resourceAlert) error {
+	for _, alert := range alerts {
+		if err := DeletePrometheusRule(ctx, c, alert.ruleName, namespace); err != nil && !k8serr.IsNotFound(err) {
+			return errorUtil.Wrapf(err, "failed to delete prometheus rule %s", alert.ruleName)
+		}
+	}
+	return nil
+}
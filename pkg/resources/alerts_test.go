@@ -0,0 +1,116 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+
+	"github.com/aws/aws-sdk-go/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func buildTestAlertPostgres() *v1alpha1.Postgres {
+	return &v1alpha1.Postgres{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+		},
+	}
+}
+
+func TestBuildPostgresAlerts(t *testing.T) {
+	cases := []struct {
+		name         string
+		thresholds   *AlertThresholds
+		expectedExpr string
+	}{
+		{
+			name:         "test default thresholds",
+			thresholds:   nil,
+			expectedExpr: "cro_postgres_database_connections_average{resourceID='test',namespace='test-ns'} > 100",
+		},
+		{
+			name: "test tier thresholds applied",
+			thresholds: &AlertThresholds{
+				MaxConnections: aws.Int64(500),
+			},
+			expectedExpr: "cro_postgres_database_connections_average{resourceID='test',namespace='test-ns'} > 500",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			alerts := buildPostgresAlerts(buildTestAlertPostgres(), tc.thresholds)
+			if len(alerts) != 3 {
+				t.Fatalf("unexpected number of alerts, expected 3 but got %d", len(alerts))
+			}
+			var connectionsExpr string
+			for _, alert := range alerts {
+				if alert.alertName == "PostgresHighConnections" {
+					connectionsExpr = alert.expr
+				}
+			}
+			if connectionsExpr != tc.expectedExpr {
+				t.Fatalf("unexpected expression, expected %s but got %s", tc.expectedExpr, connectionsExpr)
+			}
+		})
+	}
+}
+
+func TestAlertThresholdDefaults(t *testing.T) {
+	var thresholds *AlertThresholds
+	if thresholds.freeStoragePercent() != defaultFreeStoragePercent {
+		t.Fatalf("unexpected default free storage percent %d", thresholds.freeStoragePercent())
+	}
+	if thresholds.maxConnections() != defaultMaxConnections {
+		t.Fatalf("unexpected default max connections %d", thresholds.maxConnections())
+	}
+	if thresholds.replicationLagSeconds() != defaultReplicationLagSeconds {
+		t.Fatalf("unexpected default replication lag %d", thresholds.replicationLagSeconds())
+	}
+	if thresholds.memoryUsagePercent() != defaultMemoryUsagePercent {
+		t.Fatalf("unexpected default memory usage percent %d", thresholds.memoryUsagePercent())
+	}
+}
+
+func TestBuildRedisAlerts(t *testing.T) {
+	cases := []struct {
+		name         string
+		thresholds   *AlertThresholds
+		expectedExpr string
+	}{
+		{
+			name:         "test default thresholds",
+			thresholds:   nil,
+			expectedExpr: "cro_redis_memory_usage_percentage_average{resourceID='test',namespace='test-ns'} > 80",
+		},
+		{
+			name: "test tier thresholds applied",
+			thresholds: &AlertThresholds{
+				MemoryUsagePercent: aws.Int64(90),
+			},
+			expectedExpr: "cro_redis_memory_usage_percentage_average{resourceID='test',namespace='test-ns'} > 90",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			alerts := buildRedisAlerts(&v1alpha1.Redis{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test-ns",
+				},
+			}, tc.thresholds)
+			if len(alerts) != 4 {
+				t.Fatalf("unexpected number of alerts, expected 4 but got %d", len(alerts))
+			}
+			var memoryExpr string
+			for _, alert := range alerts {
+				if alert.alertName == "RedisHighMemoryUsage" {
+					memoryExpr = alert.expr
+				}
+			}
+			if memoryExpr != tc.expectedExpr {
+				t.Fatalf("unexpected expression, expected %s but got %s", tc.expectedExpr, memoryExpr)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	errorUtil "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// EnvAuditConfigMapEnabled when set to true the in memory audit ring buffer is also
+	// persisted to the audit config map for compliance review
+	EnvAuditConfigMapEnabled = "AUDIT_LOG_CONFIGMAP"
+	// DefaultAuditConfigMapName the config map the audit ring buffer is persisted to
+	DefaultAuditConfigMapName = "cloud-resource-audit"
+
+	auditConfigMapKey           = "entries"
+	auditConfigMapWriteInterval = time.Minute
+	// the audit trail is bounded so a long running operator cannot grow it unbounded, the
+	// dedicated log stream holds the full history
+	auditRingBufferSize = 200
+)
+
+// AuditResource identifies the cr a cloud mutation was performed for
+type AuditResource struct {
+	Kind      string `json:"resourceKind"`
+	Namespace string `json:"resourceNamespace"`
+	Name      string `json:"resourceName"`
+}
+
+// AuditEntry a single mutating cloud api call recorded for compliance review
+type AuditEntry struct {
+	Time       time.Time      `json:"time"`
+	Provider   string         `json:"provider"`
+	Service    string         `json:"service"`
+	Operation  string         `json:"operation"`
+	ParamsHash string         `json:"paramsHash"`
+	Result     string         `json:"result"`
+	Resource   *AuditResource `json:"resource,omitempty"`
+}
+
+type auditResourceContextKey struct{}
+
+// WithAuditResource attaches the cr identity to the context so cloud mutations performed for
+// it can be attributed in the audit trail
+func WithAuditResource(ctx context.Context, kind, namespace, name string) context.Context {
+	return context.WithValue(ctx, auditResourceContextKey{}, &AuditResource{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+	})
+}
+
+// AuditResourceFromContext returns the cr identity attached to the context, nil when unset
+func AuditResourceFromContext(ctx context.Context) *AuditResource {
+	resource, _ := ctx.Value(auditResourceContextKey{}).(*AuditResource)
+	return resource
+}
+
+var (
+	auditEntries []AuditEntry
+	auditLock    sync.Mutex
+)
+
+// RecordAuditEntry appends a mutation to the bounded in memory audit trail and writes it to
+// the dedicated audit log stream
+func RecordAuditEntry(entry AuditEntry) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	auditLock.Lock()
+	auditEntries = append(auditEntries, entry)
+	if len(auditEntries) > auditRingBufferSize {
+		auditEntries = auditEntries[len(auditEntries)-auditRingBufferSize:]
+	}
+	auditLock.Unlock()
+
+	fields := logrus.Fields{
+		"audit":      "cloud-mutation",
+		"provider":   entry.Provider,
+		"service":    entry.Service,
+		"operation":  entry.Operation,
+		"paramsHash": entry.ParamsHash,
+		"result":     entry.Result,
+	}
+	if entry.Resource != nil {
+		fields[LoggingKeyKind] = entry.Resource.Kind
+		fields[LoggingKeyNamespace] = entry.Resource.Namespace
+		fields[LoggingKeyName] = entry.Resource.Name
+	}
+	logrus.WithFields(fields).Info("cloud mutation performed")
+}
+
+// AuditEntries returns a copy of the in memory audit trail
+func AuditEntries() []AuditEntry {
+	auditLock.Lock()
+	defer auditLock.Unlock()
+	entries := make([]AuditEntry, len(auditEntries))
+	copy(entries, auditEntries)
+	return entries
+}
+
+// WriteAuditConfigMap persists the in memory audit trail to the audit config map
+func WriteAuditConfigMap(ctx context.Context, c client.Client, namespace string) error {
+	rawEntries, err := json.Marshal(AuditEntries())
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to marshal audit entries")
+	}
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DefaultAuditConfigMapName,
+			Namespace: namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, c, cm, func() error {
+		cm.Data = map[string]string{
+			auditConfigMapKey: string(rawEntries),
+		}
+		return nil
+	}); err != nil {
+		return errorUtil.Wrapf(err, "failed to write audit config map %s", DefaultAuditConfigMapName)
+	}
+	return nil
+}
+
+// WatchAuditConfigMap periodically persists the audit trail to the audit config map while
+// config map persistence is enabled, the loop returns when the stop channel is closed
+func WatchAuditConfigMap(c client.Client, namespace string, stop <-chan struct{}) error {
+	logger := logrus.WithFields(logrus.Fields{"audit_config": DefaultAuditConfigMapName})
+	for {
+		if enabled, ok := LookupConfig(EnvAuditConfigMapEnabled); ok && enabled == "true" {
+			if err := WriteAuditConfigMap(context.TODO(), c, namespace); err != nil {
+				logger.Errorf("failed to persist audit trail: %v", err)
+			}
+		}
+		select {
+		case <-stop:
+			// flush on shutdown so mutations recorded since the last interval write are not
+			// lost when the operator receives a termination signal
+			if enabled, ok := LookupConfig(EnvAuditConfigMapEnabled); ok && enabled == "true" {
+				if err := WriteAuditConfigMap(context.TODO(), c, namespace); err != nil {
+					logger.Errorf("failed to persist audit trail on shutdown: %v", err)
+				}
+			}
+			return nil
+		case <-time.After(auditConfigMapWriteInterval):
+		}
+	}
+}
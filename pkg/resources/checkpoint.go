@@ -0,0 +1,77 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/annotations"
+	errorUtil "github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// InFlightOperationAnnotation holds the identity of an async cloud operation issued for
+	// the cr, the annotation is written before the operation is issued and removed once the
+	// operation is observed complete, so a restarted operator resumes polling instead of
+	// losing track of work issued by a previous pod
+	InFlightOperationAnnotation = "integreatly.org/in-flight-operation"
+)
+
+// InFlightOperation identifies an async cloud operation issued for a cr
+type InFlightOperation struct {
+	// Operation the cloud api call that was issued, e.g. rds:CreateDBInstance
+	Operation string `json:"operation"`
+	// Resource the cloud identifier the operation was issued against
+	Resource  string      `json:"resource"`
+	StartedAt metav1.Time `json:"startedAt"`
+}
+
+// SetInFlightOperation checkpoints an async cloud operation on the cr, the annotation is
+// persisted before the operation is issued so the work survives an operator restart
+func SetInFlightOperation(ctx context.Context, c client.Client, obj runtime.Object, operation string, resource string) error {
+	raw, err := json.Marshal(InFlightOperation{
+		Operation: operation,
+		Resource:  resource,
+		StartedAt: metav1.Now(),
+	})
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to marshal in-flight operation")
+	}
+	annotations.Add(obj.(metav1.Object), InFlightOperationAnnotation, string(raw))
+	if err := c.Update(ctx, obj); err != nil {
+		return errorUtil.Wrap(err, "failed to persist in-flight operation annotation")
+	}
+	return nil
+}
+
+// InFlightOperationFrom returns the checkpointed operation on the cr, false when none is
+// recorded or the annotation cannot be parsed
+func InFlightOperationFrom(obj metav1.Object) (*InFlightOperation, bool) {
+	raw, ok := obj.GetAnnotations()[InFlightOperationAnnotation]
+	if !ok || raw == "" {
+		return nil, false
+	}
+	operation := &InFlightOperation{}
+	if err := json.Unmarshal([]byte(raw), operation); err != nil {
+		return nil, false
+	}
+	return operation, true
+}
+
+// ClearInFlightOperation removes the checkpoint from the cr once the operation is observed
+// complete, a cr without a checkpoint is left untouched
+func ClearInFlightOperation(ctx context.Context, c client.Client, obj runtime.Object) error {
+	objMeta := obj.(metav1.Object)
+	objAnnotations := objMeta.GetAnnotations()
+	if _, ok := objAnnotations[InFlightOperationAnnotation]; !ok {
+		return nil
+	}
+	delete(objAnnotations, InFlightOperationAnnotation)
+	objMeta.SetAnnotations(objAnnotations)
+	if err := c.Update(ctx, obj); err != nil {
+		return errorUtil.Wrap(err, "failed to remove in-flight operation annotation")
+	}
+	return nil
+}
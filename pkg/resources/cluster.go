@@ -49,6 +49,7 @@ func GetClusterInfrastructure(ctx context.Context, c client.Client) (*v1.Infrast
 //go:generate moq -out cluster_moq.go . PodCommander
 type PodCommander interface {
 	ExecIntoPod(dpl *appsv1.Deployment, cmd string) error
+	ExecIntoPodByName(ns string, pod string, cmd string) error
 }
 
 type OpenShiftPodCommander struct {
@@ -67,6 +68,16 @@ func (pc *OpenShiftPodCommander) ExecIntoPod(dpl *appsv1.Deployment, cmd string)
 	return nil
 }
 
+// ExecIntoPodByName runs a command in a named pod, used for workloads such as statefulsets where
+// the target pod cannot be discovered through a deployment label selector
+func (pc *OpenShiftPodCommander) ExecIntoPodByName(ns string, pod string, cmd string) error {
+	toRun := []string{"/bin/bash", "-c", cmd}
+	if _, stderr, err := runExec(pc.ClientSet, toRun, pod, ns); err != nil {
+		return errorUtil.Wrapf(err, "failed to exec, %s", stderr)
+	}
+	return nil
+}
+
 // run exec command on pod
 func runExec(cs *kubernetes.Clientset, command []string, pod, ns string) (string, string, error) {
 	req := cs.CoreV1().RESTClient().Post().
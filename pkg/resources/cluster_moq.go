@@ -9,7 +9,8 @@ import (
 )
 
 var (
-	lockPodCommanderMockExecIntoPod sync.RWMutex
+	lockPodCommanderMockExecIntoPod       sync.RWMutex
+	lockPodCommanderMockExecIntoPodByName sync.RWMutex
 )
 
 // Ensure, that PodCommanderMock does implement PodCommander.
@@ -18,23 +19,29 @@ var _ PodCommander = &PodCommanderMock{}
 
 // PodCommanderMock is a mock implementation of PodCommander.
 //
-//     func TestSomethingThatUsesPodCommander(t *testing.T) {
+//	    func TestSomethingThatUsesPodCommander(t *testing.T) {
 //
-//         // make and configure a mocked PodCommander
-//         mockedPodCommander := &PodCommanderMock{
-//             ExecIntoPodFunc: func(dpl *v1.Deployment, cmd string) error {
-// 	               panic("mock out the ExecIntoPod method")
-//             },
-//         }
+//	        // make and configure a mocked PodCommander
+//	        mockedPodCommander := &PodCommanderMock{
+//	            ExecIntoPodFunc: func(dpl *v1.Deployment, cmd string) error {
+//		               panic("mock out the ExecIntoPod method")
+//	            },
+//	            ExecIntoPodByNameFunc: func(ns string, pod string, cmd string) error {
+//		               panic("mock out the ExecIntoPodByName method")
+//	            },
+//	        }
 //
-//         // use mockedPodCommander in code that requires PodCommander
-//         // and then make assertions.
+//	        // use mockedPodCommander in code that requires PodCommander
+//	        // and then make assertions.
 //
-//     }
+//	    }
 type PodCommanderMock struct {
 	// ExecIntoPodFunc mocks the ExecIntoPod method.
 	ExecIntoPodFunc func(dpl *v1.Deployment, cmd string) error
 
+	// ExecIntoPodByNameFunc mocks the ExecIntoPodByName method.
+	ExecIntoPodByNameFunc func(ns string, pod string, cmd string) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// ExecIntoPod holds details about calls to the ExecIntoPod method.
@@ -44,6 +51,15 @@ type PodCommanderMock struct {
 			// Cmd is the cmd argument value.
 			Cmd string
 		}
+		// ExecIntoPodByName holds details about calls to the ExecIntoPodByName method.
+		ExecIntoPodByName []struct {
+			// Ns is the ns argument value.
+			Ns string
+			// Pod is the pod argument value.
+			Pod string
+			// Cmd is the cmd argument value.
+			Cmd string
+		}
 	}
 }
 
@@ -67,7 +83,8 @@ func (mock *PodCommanderMock) ExecIntoPod(dpl *v1.Deployment, cmd string) error
 
 // ExecIntoPodCalls gets all the calls that were made to ExecIntoPod.
 // Check the length with:
-//     len(mockedPodCommander.ExecIntoPodCalls())
+//
+//	len(mockedPodCommander.ExecIntoPodCalls())
 func (mock *PodCommanderMock) ExecIntoPodCalls() []struct {
 	Dpl *v1.Deployment
 	Cmd string
@@ -81,3 +98,43 @@ func (mock *PodCommanderMock) ExecIntoPodCalls() []struct {
 	lockPodCommanderMockExecIntoPod.RUnlock()
 	return calls
 }
+
+// ExecIntoPodByName calls ExecIntoPodByNameFunc.
+func (mock *PodCommanderMock) ExecIntoPodByName(ns string, pod string, cmd string) error {
+	if mock.ExecIntoPodByNameFunc == nil {
+		panic("PodCommanderMock.ExecIntoPodByNameFunc: method is nil but PodCommander.ExecIntoPodByName was just called")
+	}
+	callInfo := struct {
+		Ns  string
+		Pod string
+		Cmd string
+	}{
+		Ns:  ns,
+		Pod: pod,
+		Cmd: cmd,
+	}
+	lockPodCommanderMockExecIntoPodByName.Lock()
+	mock.calls.ExecIntoPodByName = append(mock.calls.ExecIntoPodByName, callInfo)
+	lockPodCommanderMockExecIntoPodByName.Unlock()
+	return mock.ExecIntoPodByNameFunc(ns, pod, cmd)
+}
+
+// ExecIntoPodByNameCalls gets all the calls that were made to ExecIntoPodByName.
+// Check the length with:
+//
+//	len(mockedPodCommander.ExecIntoPodByNameCalls())
+func (mock *PodCommanderMock) ExecIntoPodByNameCalls() []struct {
+	Ns  string
+	Pod string
+	Cmd string
+} {
+	var calls []struct {
+		Ns  string
+		Pod string
+		Cmd string
+	}
+	lockPodCommanderMockExecIntoPodByName.RLock()
+	calls = mock.calls.ExecIntoPodByName
+	lockPodCommanderMockExecIntoPodByName.RUnlock()
+	return calls
+}
@@ -1,30 +1,87 @@
 package resources
 
 import (
-	"os"
+	"math/rand"
 	"strconv"
-	"strings"
 	"time"
-
-	"github.com/google/uuid"
-
-	errorUtil "github.com/pkg/errors"
 )
 
 const (
 	EnvForceReconcileTimeout   = "ENV_FORCE_RECONCILE_TIMEOUT"
 	EnvMetricsReconcileTimeout = "ENV_METRIC_RECONCILE_TIMEOUT"
+	EnvMaxConcurrentReconciles = "MAX_CONCURRENT_RECONCILES"
+	EnvHealthyReconcileTimeout = "ENV_HEALTHY_RECONCILE_TIMEOUT"
+	EnvErrorBackoffBaseTimeout = "ENV_ERROR_BACKOFF_BASE_TIMEOUT"
+	EnvErrorBackoffMaxTimeout  = "ENV_ERROR_BACKOFF_MAX_TIMEOUT"
+	EnvTagKeyPrefix            = "TAG_KEY_PREFIX"
 	DefaultTagKeyPrefix        = "integreatly.org/"
+	// FIPSModeConfigKey when set to "true" the operator runs in fips mode, services with
+	// dedicated fips endpoints are pinned to them and generated passwords are read directly
+	// from the approved system random source
+	FIPSModeConfigKey = "FIPS_MODE"
 	// Set the reconcile duration for this controller.
 	// Currently it will be called once every 5 minutes
 	MetricsWatchDuration = 5 * time.Minute
 )
 
+var (
+	// default backoff window for failing resources
+	defaultErrorBackoffBase = time.Second * 10
+	defaultErrorBackoffMax  = time.Minute * 10
+)
+
 //GetForcedReconcileTimeOrDefault returns envar for reconcile time else returns default time
 func GetForcedReconcileTimeOrDefault(defaultTo time.Duration) time.Duration {
-	recTime, exist := os.LookupEnv(EnvForceReconcileTimeout)
+	recTime, exist := LookupConfig(EnvForceReconcileTimeout)
+	if exist {
+		rt, err := strconv.ParseInt(recTime, 10, 64)
+		if err != nil {
+			return defaultTo
+		}
+		return time.Duration(rt)
+	}
+	return defaultTo
+}
+
+//GetHealthyReconcileTimeOrDefault returns the steady state requeue duration for resources in a
+//complete phase, envar takes precedence over the provided default
+func GetHealthyReconcileTimeOrDefault(defaultTo time.Duration) time.Duration {
+	recTime, exist := LookupConfig(EnvHealthyReconcileTimeout)
 	if exist {
 		rt, err := strconv.ParseInt(recTime, 10, 64)
+		if err != nil {
+			return GetForcedReconcileTimeOrDefault(defaultTo)
+		}
+		return time.Duration(rt)
+	}
+	return GetForcedReconcileTimeOrDefault(defaultTo)
+}
+
+//GetErrorBackoffTime returns an exponentially increasing requeue duration with jitter for a
+//resource that has failed the given number of consecutive reconciles, base and max are
+//configurable through envars
+func GetErrorBackoffTime(failureCount int64) time.Duration {
+	base := getEnvDurationOrDefault(EnvErrorBackoffBaseTimeout, defaultErrorBackoffBase)
+	max := getEnvDurationOrDefault(EnvErrorBackoffMaxTimeout, defaultErrorBackoffMax)
+	if failureCount < 1 {
+		failureCount = 1
+	}
+	backoff := max
+	// shifting beyond 32 would overflow long before any realistic max is reached
+	if failureCount <= 32 {
+		backoff = base << uint(failureCount-1)
+	}
+	if backoff <= 0 || backoff > max {
+		backoff = max
+	}
+	// up to 25% jitter so failing resources do not requeue in lock step
+	return backoff + time.Duration(rand.Int63n(int64(backoff/4)+1))
+}
+
+func getEnvDurationOrDefault(envVar string, defaultTo time.Duration) time.Duration {
+	raw, exist := LookupConfig(envVar)
+	if exist {
+		rt, err := strconv.ParseInt(raw, 10, 64)
 		if err != nil {
 			return defaultTo
 		}
@@ -35,7 +92,7 @@ func GetForcedReconcileTimeOrDefault(defaultTo time.Duration) time.Duration {
 
 //GetMetricReconcileTimeOrDefault returns envar for reconcile time else returns default time
 func GetMetricReconcileTimeOrDefault(defaultTo time.Duration) time.Duration {
-	recTime, exist := os.LookupEnv(EnvMetricsReconcileTimeout)
+	recTime, exist := LookupConfig(EnvMetricsReconcileTimeout)
 	if exist {
 		rt, err := strconv.ParseInt(recTime, 10, 64)
 		if err != nil {
@@ -46,17 +103,28 @@ func GetMetricReconcileTimeOrDefault(defaultTo time.Duration) time.Duration {
 	return defaultTo
 }
 
-func GeneratePassword() (string, error) {
-	generatedPassword, err := uuid.NewRandom()
-	if err != nil {
-		return "", errorUtil.Wrap(err, "error generating password")
+//GetMaxConcurrentReconciles returns envar for max concurrent reconciles per controller else returns default
+func GetMaxConcurrentReconciles(defaultTo int) int {
+	rawConcurrency, exist := LookupConfig(EnvMaxConcurrentReconciles)
+	if exist {
+		concurrency, err := strconv.Atoi(rawConcurrency)
+		if err != nil || concurrency < 1 {
+			return defaultTo
+		}
+		return concurrency
 	}
-	return strings.Replace(generatedPassword.String(), "-", "", 10), nil
+	return defaultTo
+}
+
+// IsFIPSEnabled returns true when the operator is configured to run in fips mode
+func IsFIPSEnabled() bool {
+	enabled, ok := LookupConfig(FIPSModeConfigKey)
+	return ok && enabled == "true"
 }
 
 func GetOrganizationTag() string {
 	// get the environment from the CR
-	organizationTag, exists := os.LookupEnv("TAG_KEY_PREFIX")
+	organizationTag, exists := LookupConfig(EnvTagKeyPrefix)
 	if !exists {
 		organizationTag = DefaultTagKeyPrefix
 	}
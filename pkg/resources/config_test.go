@@ -46,6 +46,41 @@ func TestGetForcedReconcileTimeOrDefault(t *testing.T) {
 	}
 }
 
+func TestGetErrorBackoffTime(t *testing.T) {
+	var tests = []struct {
+		name         string
+		failureCount int64
+		wantMin      time.Duration
+		wantMax      time.Duration
+	}{
+		{
+			name:         "test first failure starts at the base backoff",
+			failureCount: 1,
+			wantMin:      defaultErrorBackoffBase,
+			wantMax:      defaultErrorBackoffBase + defaultErrorBackoffBase/4,
+		},
+		{
+			name:         "test backoff doubles per failure",
+			failureCount: 3,
+			wantMin:      defaultErrorBackoffBase * 4,
+			wantMax:      defaultErrorBackoffBase*4 + defaultErrorBackoffBase,
+		},
+		{
+			name:         "test backoff is capped at the max",
+			failureCount: 100,
+			wantMin:      defaultErrorBackoffMax,
+			wantMax:      defaultErrorBackoffMax + defaultErrorBackoffMax/4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetErrorBackoffTime(tt.failureCount); got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("GetErrorBackoffTime() = %v, want between %v and %v", got, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
 func TestGetOrganizationTag(t *testing.T) {
 	tests := []struct {
 		name string
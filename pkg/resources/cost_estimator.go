@@ -0,0 +1,101 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultPricingConfigMapName the operator level config map containing published on demand
+// pricing, the data entry overrides the static default pricing table used for cost estimation
+const DefaultPricingConfigMapName = "cloud-resource-pricing"
+
+const (
+	pricingConfigMapKey = "pricing"
+	// on demand pricing is published hourly, estimates use the aws convention of 730 hours
+	// in an average month
+	hoursPerMonth = 730
+)
+
+// PricingTable maps provisioned instance classes and storage to published on demand pricing,
+// used to estimate the monthly cost of a provisioned resource for chargeback dashboards
+type PricingTable struct {
+	// InstanceHourlyCost maps provider instance classes to their published hourly on demand
+	// cost in usd
+	InstanceHourlyCost map[string]float64 `json:"instanceHourlyCost"`
+	// StorageGiBMonthlyCost the published cost in usd of one GiB of provisioned storage for
+	// a month
+	StorageGiBMonthlyCost float64 `json:"storageGiBMonthlyCost"`
+}
+
+// static default pricing, published us-east-1 on demand rates for the instance classes the
+// default size classes map to, values are refreshed from the pricing config map when set
+var defaultPricingTable = &PricingTable{
+	InstanceHourlyCost: map[string]float64{
+		"db.t3.micro":     0.018,
+		"db.t3.small":     0.036,
+		"db.t3.medium":    0.072,
+		"db.m5.large":     0.178,
+		"db.m5.xlarge":    0.356,
+		"cache.t3.micro":  0.017,
+		"cache.m5.large":  0.156,
+		"cache.m5.xlarge": 0.311,
+	},
+	StorageGiBMonthlyCost: 0.115,
+}
+
+// GetPricingTable returns the pricing table used for cost estimation, the static defaults can
+// be refreshed by publishing updated rates in the pricing config map in the operator namespace
+func GetPricingTable(ctx context.Context, c client.Client) *PricingTable {
+	namespace, err := k8sutil.GetWatchNamespace()
+	if err != nil {
+		return defaultPricingTable
+	}
+	cm := &v1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: DefaultPricingConfigMapName, Namespace: namespace}, cm); err != nil {
+		return defaultPricingTable
+	}
+	table := &PricingTable{}
+	if err := json.Unmarshal([]byte(cm.Data[pricingConfigMapKey]), table); err != nil {
+		logrus.Errorf("failed to parse pricing config map %s, falling back to default pricing: %v", DefaultPricingConfigMapName, err)
+		return defaultPricingTable
+	}
+	return applyPricingDefaults(table)
+}
+
+// applyPricingDefaults fills any rates missing from an overridden pricing table with the
+// static defaults so a partial override stays usable
+func applyPricingDefaults(table *PricingTable) *PricingTable {
+	if table.InstanceHourlyCost == nil {
+		table.InstanceHourlyCost = map[string]float64{}
+	}
+	for class, cost := range defaultPricingTable.InstanceHourlyCost {
+		if _, ok := table.InstanceHourlyCost[class]; !ok {
+			table.InstanceHourlyCost[class] = cost
+		}
+	}
+	if table.StorageGiBMonthlyCost == 0 {
+		table.StorageGiBMonthlyCost = defaultPricingTable.StorageGiBMonthlyCost
+	}
+	return table
+}
+
+// EstimateMonthlyCost estimates the monthly on demand cost in usd of the given number of
+// instances of an instance class with the given provisioned storage, instance classes the
+// table has no rate for return false so they can be skipped rather than reported as free
+func (pt *PricingTable) EstimateMonthlyCost(instanceClass string, instanceCount int64, storageGiB int64) (float64, bool) {
+	hourlyCost, ok := pt.InstanceHourlyCost[instanceClass]
+	if !ok {
+		return 0, false
+	}
+	if instanceCount < 1 {
+		instanceCount = 1
+	}
+	return hourlyCost*hoursPerMonth*float64(instanceCount) + float64(storageGiB)*pt.StorageGiBMonthlyCost, true
+}
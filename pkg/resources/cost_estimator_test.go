@@ -0,0 +1,85 @@
+package resources
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPricingTable_EstimateMonthlyCost(t *testing.T) {
+	table := &PricingTable{
+		InstanceHourlyCost: map[string]float64{
+			"db.t3.small": 0.036,
+		},
+		StorageGiBMonthlyCost: 0.115,
+	}
+	cases := []struct {
+		name          string
+		instanceClass string
+		instanceCount int64
+		storageGiB    int64
+		expectedCost  float64
+		expectedOk    bool
+	}{
+		{
+			name:          "test known instance class with storage",
+			instanceClass: "db.t3.small",
+			instanceCount: 1,
+			storageGiB:    20,
+			expectedCost:  0.036*hoursPerMonth + 20*0.115,
+			expectedOk:    true,
+		},
+		{
+			name:          "test instance count multiplies the instance cost",
+			instanceClass: "db.t3.small",
+			instanceCount: 2,
+			storageGiB:    0,
+			expectedCost:  0.036 * hoursPerMonth * 2,
+			expectedOk:    true,
+		},
+		{
+			name:          "test zero instance count treated as one",
+			instanceClass: "db.t3.small",
+			instanceCount: 0,
+			storageGiB:    0,
+			expectedCost:  0.036 * hoursPerMonth,
+			expectedOk:    true,
+		},
+		{
+			name:          "test unknown instance class is skipped",
+			instanceClass: "db.x1e.32xlarge",
+			instanceCount: 1,
+			storageGiB:    20,
+			expectedCost:  0,
+			expectedOk:    false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cost, ok := table.EstimateMonthlyCost(tc.instanceClass, tc.instanceCount, tc.storageGiB)
+			if ok != tc.expectedOk {
+				t.Fatalf("unexpected ok, expected %v but got %v", tc.expectedOk, ok)
+			}
+			// allow for floating point rounding in the expected expressions
+			if math.Abs(cost-tc.expectedCost) > 0.000001 {
+				t.Fatalf("unexpected cost, expected %f but got %f", tc.expectedCost, cost)
+			}
+		})
+	}
+}
+
+func TestApplyPricingDefaults(t *testing.T) {
+	table := applyPricingDefaults(&PricingTable{
+		InstanceHourlyCost: map[string]float64{
+			"db.t3.small": 0.05,
+		},
+	})
+	if table.InstanceHourlyCost["db.t3.small"] != 0.05 {
+		t.Fatalf("expected overridden rate to be kept, got %f", table.InstanceHourlyCost["db.t3.small"])
+	}
+	if table.InstanceHourlyCost["cache.t3.micro"] != defaultPricingTable.InstanceHourlyCost["cache.t3.micro"] {
+		t.Fatalf("expected missing rates to fall back to defaults, got %f", table.InstanceHourlyCost["cache.t3.micro"])
+	}
+	if table.StorageGiBMonthlyCost != defaultPricingTable.StorageGiBMonthlyCost {
+		t.Fatalf("expected missing storage rate to fall back to default, got %f", table.StorageGiBMonthlyCost)
+	}
+}
@@ -18,23 +18,33 @@ import (
 )
 
 const (
-	sleepytime                                = 3600
-	DefaultPostgresMaintenanceMetricName      = "cro_postgres_service_maintenance"
-	DefaultPostgresInfoMetricName             = "cro_postgres_info"
-	DefaultPostgresAvailMetricName            = "cro_postgres_available"
-	DefaultPostgresConnectionMetricName       = "cro_postgres_connection"
-	DefaultPostgresStatusMetricName           = "cro_postgres_status_phase"
-	DefaultPostgresDeletionMetricName         = "cro_postgres_deletion_timestamp"
-	DefaultPostgresSnapshotStatusMetricName   = "cro_postgres_snapshot_status_phase"
-	DefaultPostgresAllocatedStorageMetricName = "cro_postgres_current_allocated_storage"
-	DefaultRedisMaintenanceMetricName         = "cro_redis_service_maintenance"
-	DefaultRedisInfoMetricName                = "cro_redis_info"
-	DefaultRedisAvailMetricName               = "cro_redis_available"
-	DefaultRedisConnectionMetricName          = "cro_redis_connection"
-	DefaultRedisStatusMetricName              = "cro_redis_status_phase"
-	DefaultRedisDeletionMetricName            = "cro_redis_deletion_timestamp"
-	DefaultRedisSnapshotStatusMetricName      = "cro_redis_snapshot_status_phase"
-	DefaultBlobStorageStatusMetricName        = "cro_blobstorage_status_phase"
+	sleepytime                                   = 3600
+	DefaultPostgresMaintenanceMetricName         = "cro_postgres_service_maintenance"
+	DefaultPostgresInfoMetricName                = "cro_postgres_info"
+	DefaultPostgresAvailMetricName               = "cro_postgres_available"
+	DefaultPostgresConnectionMetricName          = "cro_postgres_connection"
+	DefaultPostgresStatusMetricName              = "cro_postgres_status_phase"
+	DefaultPostgresDeletionMetricName            = "cro_postgres_deletion_timestamp"
+	DefaultPostgresSnapshotStatusMetricName      = "cro_postgres_snapshot_status_phase"
+	DefaultPostgresAllocatedStorageMetricName    = "cro_postgres_current_allocated_storage"
+	DefaultPostgresMaxAllocatedStorageMetricName = "cro_postgres_max_allocated_storage"
+	DefaultRedisMaintenanceMetricName            = "cro_redis_service_maintenance"
+	DefaultRedisInfoMetricName                   = "cro_redis_info"
+	DefaultRedisAvailMetricName                  = "cro_redis_available"
+	DefaultRedisConnectionMetricName             = "cro_redis_connection"
+	DefaultRedisStatusMetricName                 = "cro_redis_status_phase"
+	DefaultRedisDeletionMetricName               = "cro_redis_deletion_timestamp"
+	DefaultRedisSnapshotStatusMetricName         = "cro_redis_snapshot_status_phase"
+	DefaultBlobStorageStatusMetricName           = "cro_blobstorage_status_phase"
+	DefaultResourceDriftMetricName               = "cro_resource_drift"
+	DefaultCredentialRotationMetricName          = "cro_credential_rotation_timestamp"
+	DefaultCloudAPIThrottledCallsMetricName      = "cro_cloud_api_throttled_calls"
+	DefaultResourceFailuresMetricName            = "cro_resource_failures"
+	DefaultResourceDeletionStuckMetricName       = "cro_resource_deletion_stuck"
+	DefaultResourceStuckMetricName               = "cro_resource_stuck"
+	DefaultResourceEstimatedCostMetricName       = "cro_resource_estimated_monthly_cost"
+	DefaultResourceTTLNearingExpiryMetricName    = "cro_resource_ttl_nearing_expiry"
+	DefaultResourceOrphanedMetricName            = "cro_orphaned_cloud_resources"
 
 	BytesInGibiBytes = 1073741824
 )
@@ -65,7 +75,7 @@ func StartGaugeVector() {
 	}()
 }
 
-//SetMetric Set exports a Prometheus Gauge
+// SetMetric Set exports a Prometheus Gauge
 func SetMetric(name string, labels map[string]string, value float64) {
 	// set vector value
 	gv, ok := MetricVecs[name]
@@ -89,7 +99,7 @@ func SetMetric(name string, labels map[string]string, value float64) {
 	logrus.Info(fmt.Sprintf("successfully created new gauge vector metric %s", name))
 }
 
-//SetMetricCurrentTime Set current time wraps set metric
+// SetMetricCurrentTime Set current time wraps set metric
 func SetMetricCurrentTime(name string, labels map[string]string) {
 	SetMetric(name, labels, float64(time.Now().UnixNano())/1e9)
 }
@@ -126,8 +136,10 @@ func ReconcilePrometheusRule(ctx context.Context, client client.Client, ruleName
 		},
 	}
 
-	// create or update the resource
+	// create or update the resource, the spec is reapplied on update so threshold changes
+	// propagate to existing rules
 	_, err := controllerutil.CreateOrUpdate(ctx, client, rule, func() error {
+		rule.Spec.Groups = groups
 		return nil
 	})
 	if err != nil {
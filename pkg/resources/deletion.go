@@ -0,0 +1,48 @@
+package resources
+
+import (
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ForceDeleteAnnotation when set to "true" on a resource cr whose deletion is blocked by
+	// repeated cloud teardown failures, the cloud cleanup is skipped and the finalizer removed
+	// so the cr can be deleted, the cloud resources are left in place. The annotation is only
+	// honoured after the failure threshold is reached so a transient failure cannot silently
+	// leak cloud resources
+	ForceDeleteAnnotation = "integreatly.org/force-delete"
+
+	// ConditionTypeDeleting the condition carrying the error currently blocking a deletion
+	ConditionTypeDeleting = "Deleting"
+
+	// forceDeleteFailureThreshold the number of consecutive failed delete reconciles required
+	// before the force delete annotation is honoured
+	forceDeleteFailureThreshold = 3
+)
+
+// BuildDeletingCondition builds a status condition carrying the error currently blocking the
+// deletion of a resource, so users can see why a deletion hangs without trawling operator logs
+func BuildDeletingCondition(err error) croType.StatusCondition {
+	return croType.StatusCondition{
+		Type:    ConditionTypeDeleting,
+		Status:  "True",
+		Message: err.Error(),
+	}
+}
+
+// SetDeletionStuckMetric exposes the consecutive delete failure count for a resource so stuck
+// deletions can be alerted on
+func SetDeletionStuckMetric(resourceType string, name string, namespace string, failureCount int64) {
+	SetMetric(DefaultResourceDeletionStuckMetricName, map[string]string{
+		"resourceType": resourceType,
+		"name":         name,
+		"namespace":    namespace,
+	}, float64(failureCount))
+}
+
+// IsForceDelete returns true when the force delete annotation is set and enough consecutive
+// delete failures have been recorded for it to be honoured
+func IsForceDelete(obj metav1.Object, failureCount int64) bool {
+	return obj.GetAnnotations()[ForceDeleteAnnotation] == "true" && failureCount >= forceDeleteFailureThreshold
+}
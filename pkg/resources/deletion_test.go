@@ -0,0 +1,51 @@
+package resources
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsForceDelete(t *testing.T) {
+	cases := []struct {
+		name         string
+		annotations  map[string]string
+		failureCount int64
+		expected     bool
+	}{
+		{
+			name:         "test annotation honoured after failure threshold",
+			annotations:  map[string]string{ForceDeleteAnnotation: "true"},
+			failureCount: forceDeleteFailureThreshold,
+			expected:     true,
+		},
+		{
+			name:         "test annotation ignored below failure threshold",
+			annotations:  map[string]string{ForceDeleteAnnotation: "true"},
+			failureCount: forceDeleteFailureThreshold - 1,
+			expected:     false,
+		},
+		{
+			name:         "test missing annotation ignored",
+			annotations:  nil,
+			failureCount: forceDeleteFailureThreshold,
+			expected:     false,
+		},
+		{
+			name:         "test annotation must be true",
+			annotations:  map[string]string{ForceDeleteAnnotation: "yes"},
+			failureCount: forceDeleteFailureThreshold,
+			expected:     false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{
+				Annotations: tc.annotations,
+			}
+			if got := IsForceDelete(obj, tc.failureCount); got != tc.expected {
+				t.Fatalf("unexpected result, expected %v but got %v", tc.expected, got)
+			}
+		})
+	}
+}
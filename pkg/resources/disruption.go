@@ -0,0 +1,56 @@
+package resources
+
+import (
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+)
+
+const (
+	// ConditionTypePrimaryFailover the condition set when the cloud provider reports a recent
+	// failover of the primary node of a resource
+	ConditionTypePrimaryFailover = "PrimaryFailover"
+	// ConditionTypeNodeReplacement the condition set when the cloud provider reports a node of
+	// a resource being replaced or recovered
+	ConditionTypeNodeReplacement = "NodeReplacement"
+	// ConditionTypePendingMaintenance the condition set when the cloud provider reports
+	// maintenance actions pending on a resource
+	ConditionTypePendingMaintenance = "PendingMaintenance"
+)
+
+// IsDisruptionConditionType returns true for the condition types surfacing cloud side service
+// disruptions, set by providers that can observe them and bridged to kubernetes events by the
+// shared reconciler
+func IsDisruptionConditionType(conditionType string) bool {
+	switch conditionType {
+	case ConditionTypePrimaryFailover, ConditionTypeNodeReplacement, ConditionTypePendingMaintenance:
+		return true
+	}
+	return false
+}
+
+// ActiveDisruptionConditions returns the disruption condition types currently reporting true
+// in the provided condition list
+func ActiveDisruptionConditions(conditions []croType.StatusCondition) map[string]bool {
+	active := map[string]bool{}
+	for _, condition := range conditions {
+		if IsDisruptionConditionType(condition.Type) && condition.Status == conditionStatusTrue {
+			active[condition.Type] = true
+		}
+	}
+	return active
+}
+
+// BuildDisruptionCondition builds a disruption condition, an empty message indicates no
+// disruption of the given type was observed and clears the condition with the provided
+// inactive message
+func BuildDisruptionCondition(conditionType string, message string, inactiveMessage string) croType.StatusCondition {
+	status := conditionStatusTrue
+	if message == "" {
+		status = conditionStatusFalse
+		message = inactiveMessage
+	}
+	return croType.StatusCondition{
+		Type:    conditionType,
+		Status:  status,
+		Message: message,
+	}
+}
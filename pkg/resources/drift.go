@@ -0,0 +1,55 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+)
+
+const (
+	// ResourceDriftConditionType the condition type used to surface configuration drift on a cr
+	ResourceDriftConditionType = "ResourceDrift"
+
+	conditionStatusTrue  = "True"
+	conditionStatusFalse = "False"
+)
+
+// DriftItem represents a single field where the configuration reported by the cloud provider
+// diverges from the strategy-derived configuration we expect the resource to be in
+type DriftItem struct {
+	Field    string
+	Expected string
+	Actual   string
+}
+
+// BuildDriftMessage builds a human readable summary of drifted fields, used in the drift status condition
+func BuildDriftMessage(items []DriftItem) string {
+	if len(items) == 0 {
+		return "resource configuration matches expected strategy"
+	}
+	msgs := make([]string, 0, len(items))
+	for _, item := range items {
+		msgs = append(msgs, fmt.Sprintf("%s expected %s but found %s", item.Field, item.Expected, item.Actual))
+	}
+	return fmt.Sprintf("resource configuration drift detected: %s", strings.Join(msgs, ", "))
+}
+
+// BuildDriftCondition builds the drift status condition for a resource from a set of drifted fields
+func BuildDriftCondition(items []DriftItem) croType.StatusCondition {
+	status := conditionStatusFalse
+	if len(items) > 0 {
+		status = conditionStatusTrue
+	}
+	return croType.StatusCondition{
+		Type:    ResourceDriftConditionType,
+		Status:  status,
+		Message: BuildDriftMessage(items),
+	}
+}
+
+// SetResourceDriftMetric exposes the number of drifted fields on a resource as a gauge,
+// a value of 0 indicates the resource matches the expected configuration
+func SetResourceDriftMetric(labels map[string]string, items []DriftItem) {
+	SetMetric(DefaultResourceDriftMetricName, labels, float64(len(items)))
+}
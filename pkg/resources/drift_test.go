@@ -0,0 +1,85 @@
+package resources
+
+import (
+	"testing"
+)
+
+func TestBuildDriftMessage(t *testing.T) {
+	type args struct {
+		items []DriftItem
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "test message for no drift",
+			args: args{items: nil},
+			want: "resource configuration matches expected strategy",
+		},
+		{
+			name: "test message for single drifted field",
+			args: args{
+				items: []DriftItem{
+					{Field: "dbInstanceClass", Expected: "db.t3.small", Actual: "db.t3.micro"},
+				},
+			},
+			want: "resource configuration drift detected: dbInstanceClass expected db.t3.small but found db.t3.micro",
+		},
+		{
+			name: "test message for multiple drifted fields",
+			args: args{
+				items: []DriftItem{
+					{Field: "dbInstanceClass", Expected: "db.t3.small", Actual: "db.t3.micro"},
+					{Field: "multiAZ", Expected: "true", Actual: "false"},
+				},
+			},
+			want: "resource configuration drift detected: dbInstanceClass expected db.t3.small but found db.t3.micro, multiAZ expected true but found false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildDriftMessage(tt.args.items); got != tt.want {
+				t.Errorf("BuildDriftMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildDriftCondition(t *testing.T) {
+	type args struct {
+		items []DriftItem
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus string
+	}{
+		{
+			name:       "test condition is false when no drift is found",
+			args:       args{items: nil},
+			wantStatus: conditionStatusFalse,
+		},
+		{
+			name: "test condition is true when drift is found",
+			args: args{
+				items: []DriftItem{
+					{Field: "multiAZ", Expected: "true", Actual: "false"},
+				},
+			},
+			wantStatus: conditionStatusTrue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildDriftCondition(tt.args.items)
+			if got.Type != ResourceDriftConditionType {
+				t.Errorf("BuildDriftCondition() type = %v, want %v", got.Type, ResourceDriftConditionType)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("BuildDriftCondition() status = %v, want %v", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package resources
+
+import (
+	"time"
+
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	errorUtil "github.com/pkg/errors"
+)
+
+// ErrorClass classifies provider failures so controllers can drive requeue behaviour, status
+// condition reasons and metric labels from the type of failure rather than the message
+type ErrorClass string
+
+const (
+	// ErrorClassAuth credentials are missing, invalid or lack the required permissions
+	ErrorClassAuth ErrorClass = "AuthError"
+	// ErrorClassQuotaExceeded a cloud provider quota or account limit was hit
+	ErrorClassQuotaExceeded ErrorClass = "QuotaExceeded"
+	// ErrorClassTransient a temporary failure that is expected to resolve on retry
+	ErrorClassTransient ErrorClass = "Transient"
+	// ErrorClassInvalidConfig the strategy or cr configuration is invalid and will not succeed
+	// on retry without user intervention
+	ErrorClassInvalidConfig ErrorClass = "InvalidConfig"
+	// ErrorClassConflict the requested change conflicts with the current state of the resource
+	ErrorClassConflict ErrorClass = "Conflict"
+)
+
+// ClassifiedError an error annotated with a class, wrapping behaves like a pkg/errors wrapped
+// error so existing Cause chains are preserved
+type ClassifiedError struct {
+	class ErrorClass
+	err   error
+}
+
+func (e *ClassifiedError) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the underlying error, allowing errorUtil.Cause to walk through the class
+func (e *ClassifiedError) Cause() error {
+	return e.err
+}
+
+// Unwrap supports the standard library errors helpers
+func (e *ClassifiedError) Unwrap() error {
+	return e.err
+}
+
+// WrapWithClass wraps an error with a message and annotates it with a class
+func WrapWithClass(err error, class ErrorClass, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return &ClassifiedError{
+		class: class,
+		err:   errorUtil.Wrap(err, msg),
+	}
+}
+
+// NewClassifiedError returns a new error annotated with a class
+func NewClassifiedError(class ErrorClass, msg string) error {
+	return &ClassifiedError{
+		class: class,
+		err:   errorUtil.New(msg),
+	}
+}
+
+// GetErrorClass returns the class of an error, walking the cause chain to find the annotation,
+// unclassified errors default to Transient so they keep the standard backoff behaviour
+func GetErrorClass(err error) ErrorClass {
+	for err != nil {
+		if classifiedErr, ok := err.(*ClassifiedError); ok {
+			return classifiedErr.class
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			break
+		}
+		err = cause.Cause()
+	}
+	return ErrorClassTransient
+}
+
+// IsErrorClass returns true when the error is annotated with the provided class
+func IsErrorClass(err error, class ErrorClass) bool {
+	return GetErrorClass(err) == class
+}
+
+// GetErrorReconcileTime returns the requeue duration for a failed reconcile based on the error
+// class, failures that will not resolve without user intervention requeue at the max backoff
+// instead of hammering the cloud api
+func GetErrorReconcileTime(err error, failureCount int64) time.Duration {
+	switch GetErrorClass(err) {
+	case ErrorClassAuth, ErrorClassInvalidConfig, ErrorClassQuotaExceeded:
+		return getEnvDurationOrDefault(EnvErrorBackoffMaxTimeout, defaultErrorBackoffMax)
+	default:
+		return GetErrorBackoffTime(failureCount)
+	}
+}
+
+// BuildFailureCondition builds a status condition for a failed reconcile, the condition type is
+// the error class so consumers can alert on specific failure modes
+func BuildFailureCondition(err error) croType.StatusCondition {
+	return croType.StatusCondition{
+		Type:    string(GetErrorClass(err)),
+		Status:  "True",
+		Message: err.Error(),
+	}
+}
+
+// SetFailureMetric exposes a failure metric for a resource labelled with the error class
+func SetFailureMetric(resourceType string, name string, namespace string, err error) {
+	SetMetric(DefaultResourceFailuresMetricName, map[string]string{
+		"resourceType": resourceType,
+		"name":         name,
+		"namespace":    namespace,
+		"errorClass":   string(GetErrorClass(err)),
+	}, 1)
+}
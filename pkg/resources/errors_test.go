@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"testing"
+
+	errorUtil "github.com/pkg/errors"
+)
+
+func TestGetErrorClass(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected ErrorClass
+	}{
+		{
+			name:     "test classified error returns its class",
+			err:      NewClassifiedError(ErrorClassAuth, "test"),
+			expected: ErrorClassAuth,
+		},
+		{
+			name:     "test class is found through wrapped causes",
+			err:      errorUtil.Wrap(WrapWithClass(errorUtil.New("test"), ErrorClassInvalidConfig, "test"), "outer"),
+			expected: ErrorClassInvalidConfig,
+		},
+		{
+			name:     "test unclassified error defaults to transient",
+			err:      errorUtil.New("test"),
+			expected: ErrorClassTransient,
+		},
+		{
+			name:     "test nil error defaults to transient",
+			err:      nil,
+			expected: ErrorClassTransient,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if class := GetErrorClass(tc.err); class != tc.expected {
+				t.Fatalf("unexpected error class, expected %s but got %s", tc.expected, class)
+			}
+		})
+	}
+}
+
+func TestGetErrorReconcileTime(t *testing.T) {
+	cases := []struct {
+		name         string
+		err          error
+		failureCount int64
+		expected     string
+	}{
+		{
+			name:         "test invalid config requeues at max backoff",
+			err:          NewClassifiedError(ErrorClassInvalidConfig, "test"),
+			failureCount: 1,
+			expected:     "max",
+		},
+		{
+			name:         "test auth error requeues at max backoff",
+			err:          NewClassifiedError(ErrorClassAuth, "test"),
+			failureCount: 1,
+			expected:     "max",
+		},
+		{
+			name:         "test transient error uses standard backoff",
+			err:          errorUtil.New("test"),
+			failureCount: 1,
+			expected:     "backoff",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			requeue := GetErrorReconcileTime(tc.err, tc.failureCount)
+			if tc.expected == "max" && requeue != defaultErrorBackoffMax {
+				t.Fatalf("expected max backoff %v but got %v", defaultErrorBackoffMax, requeue)
+			}
+			if tc.expected == "backoff" && (requeue < defaultErrorBackoffBase || requeue >= defaultErrorBackoffMax) {
+				t.Fatalf("expected standard backoff for first failure but got %v", requeue)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package resources
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// event reasons shared by the controllers so lifecycle steps are consistent across resource
+// types in kubectl describe output
+const (
+	EventReasonProvisioned     = "Provisioned"
+	EventReasonDeleting        = "Deleting"
+	EventReasonPaused          = "Paused"
+	EventReasonDryRun          = "DryRun"
+	EventReasonDegraded        = "Degraded"
+	EventReasonSnapshotCreated = "SnapshotCreated"
+	EventReasonTTLExpiring     = "TTLExpiring"
+	EventReasonTTLExpired      = "TTLExpired"
+	EventReasonVerified        = "Verified"
+)
+
+// RecordFailureEvent emits a warning event on the cr, the reason is the error class so auth,
+// quota and configuration failures can be distinguished without reading the operator logs
+func RecordFailureEvent(recorder record.EventRecorder, object runtime.Object, err error) {
+	if recorder == nil || err == nil {
+		return
+	}
+	recorder.Event(object, v1.EventTypeWarning, string(GetErrorClass(err)), err.Error())
+}
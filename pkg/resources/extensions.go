@@ -0,0 +1,31 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+)
+
+const (
+	// ConditionTypeUnsupportedExtensions the condition type used to surface requested
+	// database extensions the engine does not allow
+	ConditionTypeUnsupportedExtensions = "UnsupportedExtensions"
+)
+
+// BuildUnsupportedExtensionsCondition builds the status condition surfacing requested
+// database extensions missing from the engine's allowed extension list
+func BuildUnsupportedExtensionsCondition(unsupported []string) croType.StatusCondition {
+	if len(unsupported) == 0 {
+		return croType.StatusCondition{
+			Type:    ConditionTypeUnsupportedExtensions,
+			Status:  conditionStatusFalse,
+			Message: "all requested extensions are supported by the engine",
+		}
+	}
+	return croType.StatusCondition{
+		Type:    ConditionTypeUnsupportedExtensions,
+		Status:  conditionStatusTrue,
+		Message: fmt.Sprintf("requested extensions are not supported by the engine: %s", strings.Join(unsupported, ", ")),
+	}
+}
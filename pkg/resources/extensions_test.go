@@ -0,0 +1,50 @@
+package resources
+
+import (
+	"testing"
+)
+
+func TestBuildUnsupportedExtensionsCondition(t *testing.T) {
+	type args struct {
+		unsupported []string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantStatus  string
+		wantMessage string
+	}{
+		{
+			name:        "test condition for no unsupported extensions",
+			args:        args{unsupported: nil},
+			wantStatus:  "False",
+			wantMessage: "all requested extensions are supported by the engine",
+		},
+		{
+			name:        "test condition for single unsupported extension",
+			args:        args{unsupported: []string{"timescaledb"}},
+			wantStatus:  "True",
+			wantMessage: "requested extensions are not supported by the engine: timescaledb",
+		},
+		{
+			name:        "test condition for multiple unsupported extensions",
+			args:        args{unsupported: []string{"pg_cron", "timescaledb"}},
+			wantStatus:  "True",
+			wantMessage: "requested extensions are not supported by the engine: pg_cron, timescaledb",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := BuildUnsupportedExtensionsCondition(tt.args.unsupported)
+			if condition.Type != ConditionTypeUnsupportedExtensions {
+				t.Fatalf("unexpected condition type %s", condition.Type)
+			}
+			if condition.Status != tt.wantStatus {
+				t.Fatalf("unexpected condition status, expected %s but got %s", tt.wantStatus, condition.Status)
+			}
+			if condition.Message != tt.wantMessage {
+				t.Fatalf("unexpected condition message, expected %s but got %s", tt.wantMessage, condition.Message)
+			}
+		})
+	}
+}
@@ -45,6 +45,20 @@ func remove(list []string, s string) []string {
 	return list
 }
 
+// RemoveFinalizerFromObject removes the finalizer through the object meta block of the
+// instance, used where only the runtime object is available
+func RemoveFinalizerFromObject(inst runtime.Object, finalizer string) error {
+	om := &v1.ObjectMeta{}
+	if err := runtime.Field(reflect.ValueOf(inst).Elem(), "ObjectMeta", om); err != nil {
+		return errorUtil.Wrap(err, "failed to retrieve object meta block from object")
+	}
+	RemoveFinalizer(om, finalizer)
+	if err := runtime.SetField(*om, reflect.ValueOf(inst).Elem(), "ObjectMeta"); err != nil {
+		return errorUtil.Wrap(err, "failed to set object meta block of object")
+	}
+	return nil
+}
+
 func CreateFinalizer(ctx context.Context, c client.Client, inst runtime.Object, df string) error {
 	dt := &v1.ObjectMeta{}
 	if err := runtime.Field(reflect.ValueOf(inst).Elem(), "ObjectMeta", dt); err != nil {
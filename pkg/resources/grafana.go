@@ -0,0 +1,98 @@
+package resources
+
+import (
+	"context"
+
+	errorUtil "github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// DefaultGrafanaDashboardName the name of the grafana dashboard cr rendering the health of
+// provisioned cloud resources, created when dashboard provisioning is enabled
+const DefaultGrafanaDashboardName = "cloud-resources"
+
+// grafanaDashboardGroupVersionKind the gvk of the grafana operator dashboard cr, the grafana
+// operator types are not vendored so the dashboard is managed as an unstructured object
+var grafanaDashboardGroupVersionKind = schema.GroupVersionKind{
+	Group:   "integreatly.org",
+	Version: "v1alpha1",
+	Kind:    "GrafanaDashboard",
+}
+
+// cloudResourcesDashboardJSON the dashboard rendered for provisioned cloud resources, panels
+// are driven by the cro metrics exposed by the providers and the cloudmetrics controller
+const cloudResourcesDashboardJSON = `{
+  "title": "Cloud Resources",
+  "tags": ["cloud-resource-operator"],
+  "timezone": "browser",
+  "panels": [
+    {
+      "title": "Resource Availability",
+      "type": "stat",
+      "gridPos": {"h": 6, "w": 12, "x": 0, "y": 0},
+      "targets": [
+        {"expr": "cro_postgres_available", "legendFormat": "postgres {{resourceID}}"},
+        {"expr": "cro_redis_available", "legendFormat": "redis {{resourceID}}"}
+      ]
+    },
+    {
+      "title": "Provisioning In Progress",
+      "type": "table",
+      "gridPos": {"h": 6, "w": 12, "x": 12, "y": 0},
+      "targets": [
+        {"expr": "cro_postgres_status_phase{statusPhase='in progress'} == 1", "legendFormat": "postgres {{resourceID}}"},
+        {"expr": "cro_redis_status_phase{statusPhase='in progress'} == 1", "legendFormat": "redis {{resourceID}}"}
+      ]
+    },
+    {
+      "title": "Postgres Free Storage",
+      "type": "graph",
+      "gridPos": {"h": 8, "w": 12, "x": 0, "y": 6},
+      "targets": [
+        {"expr": "cro_postgres_free_storage_average", "legendFormat": "{{resourceID}}"}
+      ]
+    },
+    {
+      "title": "Database Connections",
+      "type": "graph",
+      "gridPos": {"h": 8, "w": 12, "x": 12, "y": 6},
+      "targets": [
+        {"expr": "cro_postgres_database_connections_average", "legendFormat": "postgres {{resourceID}}"},
+        {"expr": "cro_redis_current_connections_average", "legendFormat": "redis {{resourceID}}"}
+      ]
+    },
+    {
+      "title": "Estimated Monthly Cost",
+      "type": "graph",
+      "gridPos": {"h": 8, "w": 12, "x": 0, "y": 14},
+      "targets": [
+        {"expr": "cro_resource_estimated_monthly_cost", "legendFormat": "{{resourceID}}"}
+      ]
+    }
+  ],
+  "schemaVersion": 16
+}`
+
+// ReconcileGrafanaDashboards creates or updates the grafana dashboard crs rendering the
+// health of provisioned cloud resources, requires the grafana operator to be installed
+func ReconcileGrafanaDashboards(ctx context.Context, c client.Client, namespace string) error {
+	dashboard := &unstructured.Unstructured{}
+	dashboard.SetGroupVersionKind(grafanaDashboardGroupVersionKind)
+	dashboard.SetName(DefaultGrafanaDashboardName)
+	dashboard.SetNamespace(namespace)
+	if _, err := controllerutil.CreateOrUpdate(ctx, c, dashboard, func() error {
+		dashboard.SetLabels(map[string]string{
+			"monitoring-key": "middleware",
+		})
+		return unstructured.SetNestedMap(dashboard.Object, map[string]interface{}{
+			"name": DefaultGrafanaDashboardName + ".json",
+			"json": cloudResourcesDashboardJSON,
+		}, "spec")
+	}); err != nil {
+		return errorUtil.Wrapf(err, "failed to reconcile grafana dashboard %s", DefaultGrafanaDashboardName)
+	}
+	return nil
+}
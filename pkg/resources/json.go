@@ -0,0 +1,33 @@
+package resources
+
+import (
+	"encoding/json"
+
+	errorUtil "github.com/pkg/errors"
+)
+
+// MergeRawJSON performs a shallow merge of two raw json objects, top level keys in the
+// override object take precedence over keys in the base object
+func MergeRawJSON(base json.RawMessage, override json.RawMessage) (json.RawMessage, error) {
+	if len(override) == 0 {
+		return base, nil
+	}
+	merged := map[string]interface{}{}
+	if len(base) > 0 {
+		if err := json.Unmarshal(base, &merged); err != nil {
+			return nil, errorUtil.Wrap(err, "failed to unmarshal base json")
+		}
+	}
+	overrideMap := map[string]interface{}{}
+	if err := json.Unmarshal(override, &overrideMap); err != nil {
+		return nil, errorUtil.Wrap(err, "failed to unmarshal override json")
+	}
+	for key, value := range overrideMap {
+		merged[key] = value
+	}
+	mergedJSON, err := json.Marshal(merged)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to marshal merged json")
+	}
+	return mergedJSON, nil
+}
@@ -0,0 +1,67 @@
+package resources
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMergeRawJSON(t *testing.T) {
+	type args struct {
+		base     json.RawMessage
+		override json.RawMessage
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "test base is returned when override is empty",
+			args: args{
+				base:     json.RawMessage(`{"dbInstanceClass":"db.t3.small"}`),
+				override: nil,
+			},
+			want: `{"dbInstanceClass":"db.t3.small"}`,
+		},
+		{
+			name: "test override keys take precedence over base keys",
+			args: args{
+				base:     json.RawMessage(`{"dbInstanceClass":"db.t3.small","multiAZ":true}`),
+				override: json.RawMessage(`{"dbInstanceClass":"db.t3.large"}`),
+			},
+			want: `{"dbInstanceClass":"db.t3.large","multiAZ":true}`,
+		},
+		{
+			name: "test keys only in override are merged into base",
+			args: args{
+				base:     json.RawMessage(`{"multiAZ":true}`),
+				override: json.RawMessage(`{"allocatedStorage":50}`),
+			},
+			want: `{"allocatedStorage":50,"multiAZ":true}`,
+		},
+		{
+			name: "test error on invalid override json",
+			args: args{
+				base:     json.RawMessage(`{}`),
+				override: json.RawMessage(`invalid`),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergeRawJSON(tt.args.base, tt.args.override)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MergeRawJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("MergeRawJSON() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	errorUtil "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// DefaultResourceLedgerConfigMapName the config map holding the ledger of provisioned
+	// cloud resources and the crs that own them, kept so cloud resources can be traced back
+	// even after the owning cr and its namespace are gone
+	DefaultResourceLedgerConfigMapName = "cloud-resource-ledger"
+
+	ledgerCheckInterval = 5 * time.Minute
+)
+
+// LedgerEntry records a provisioned cloud resource and the cr that owns it
+type LedgerEntry struct {
+	// Kind the cr kind that owns the cloud resource
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// ResourceType the cro resource type of the cloud resource
+	ResourceType string `json:"resourceType"`
+	// Provider the provider that provisioned the cloud resource
+	Provider string `json:"provider"`
+	// ResourceID the cloud native identifier of the provisioned resource, the handle needed
+	// to clean the resource up manually once the owning cr is gone
+	ResourceID string      `json:"resourceID"`
+	RecordedAt metav1.Time `json:"recordedAt"`
+}
+
+var (
+	ledgerEntries = map[string]LedgerEntry{}
+	ledgerLock    sync.Mutex
+	ledgerLoaded  bool
+)
+
+func ledgerKey(kind string, namespace string, name string) string {
+	return fmt.Sprintf("%s.%s.%s", kind, namespace, name)
+}
+
+// RecordLedgerEntry records a provisioned cloud resource in the ledger, the entry is persisted
+// to the ledger config map by the ledger watch loop
+func RecordLedgerEntry(entry LedgerEntry) {
+	if entry.RecordedAt.IsZero() {
+		entry.RecordedAt = metav1.Now()
+	}
+	ledgerLock.Lock()
+	defer ledgerLock.Unlock()
+	key := ledgerKey(entry.Kind, entry.Namespace, entry.Name)
+	// keep the original record time when the entry is re-recorded on requeue
+	if existing, ok := ledgerEntries[key]; ok && existing.ResourceID == entry.ResourceID {
+		entry.RecordedAt = existing.RecordedAt
+	}
+	ledgerEntries[key] = entry
+}
+
+// RemoveLedgerEntry removes a cloud resource from the ledger once its teardown has completed
+func RemoveLedgerEntry(kind string, namespace string, name string) {
+	ledgerLock.Lock()
+	defer ledgerLock.Unlock()
+	delete(ledgerEntries, ledgerKey(kind, namespace, name))
+}
+
+// LedgerEntries returns a copy of the ledger
+func LedgerEntries() []LedgerEntry {
+	ledgerLock.Lock()
+	defer ledgerLock.Unlock()
+	entries := make([]LedgerEntry, 0, len(ledgerEntries))
+	for _, entry := range ledgerEntries {
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// WriteResourceLedgerConfigMap persists the ledger to the ledger config map
+func WriteResourceLedgerConfigMap(ctx context.Context, c client.Client, namespace string) error {
+	rawEntries, err := json.Marshal(LedgerEntries())
+	if err != nil {
+		return errorUtil.Wrap(err, "failed to marshal ledger entries")
+	}
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DefaultResourceLedgerConfigMapName,
+			Namespace: namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, c, cm, func() error {
+		cm.Data = map[string]string{
+			"entries": string(rawEntries),
+		}
+		return nil
+	}); err != nil {
+		return errorUtil.Wrapf(err, "failed to write ledger config map %s", DefaultResourceLedgerConfigMapName)
+	}
+	return nil
+}
+
+// loadResourceLedgerConfigMap hydrates the ledger from the ledger config map, entries recorded
+// by a previous operator pod are merged so a restart does not lose track of provisioned
+// resources. Entries recorded since startup take precedence
+func loadResourceLedgerConfigMap(ctx context.Context, c client.Client, namespace string) error {
+	cm := &v1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: DefaultResourceLedgerConfigMapName, Namespace: namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return errorUtil.Wrapf(err, "failed to read ledger config map %s", DefaultResourceLedgerConfigMapName)
+	}
+	var entries []LedgerEntry
+	if err := json.Unmarshal([]byte(cm.Data["entries"]), &entries); err != nil {
+		return errorUtil.Wrapf(err, "failed to parse ledger config map %s", DefaultResourceLedgerConfigMapName)
+	}
+	ledgerLock.Lock()
+	defer ledgerLock.Unlock()
+	for _, entry := range entries {
+		key := ledgerKey(entry.Kind, entry.Namespace, entry.Name)
+		if _, ok := ledgerEntries[key]; !ok {
+			ledgerEntries[key] = entry
+		}
+	}
+	return nil
+}
+
+// ReconcileOrphanedResources compares the ledger against the crs in the cluster and returns
+// the entries whose owning cr no longer exists. A cloud resource only ends up orphaned when
+// its cr was removed without teardown completing, e.g. a force delete or a namespace deletion
+// while cloud teardown was failing, so every returned entry is a leaked cloud resource that
+// needs manual cleanup using the recorded cloud identifier
+func ReconcileOrphanedResources(ctx context.Context, c client.Client) ([]LedgerEntry, error) {
+	orphans := []LedgerEntry{}
+	for _, entry := range LedgerEntries() {
+		instance := newInstanceForKind(entry.Kind)
+		if instance == nil {
+			continue
+		}
+		err := c.Get(ctx, types.NamespacedName{Namespace: entry.Namespace, Name: entry.Name}, instance)
+		if err == nil {
+			SetOrphanedResourceMetric(entry, false)
+			continue
+		}
+		if !errors.IsNotFound(err) {
+			return nil, errorUtil.Wrapf(err, "failed to look up %s %s in namespace %s", entry.Kind, entry.Name, entry.Namespace)
+		}
+		SetOrphanedResourceMetric(entry, true)
+		orphans = append(orphans, entry)
+	}
+	return orphans, nil
+}
+
+func newInstanceForKind(kind string) runtime.Object {
+	switch kind {
+	case "Postgres":
+		return &v1alpha1.Postgres{}
+	case "Redis":
+		return &v1alpha1.Redis{}
+	case "BlobStorage":
+		return &v1alpha1.BlobStorage{}
+	}
+	return nil
+}
+
+// SetOrphanedResourceMetric exposes whether a cloud resource recorded in the ledger has lost
+// its owning cr so leaked cloud resources can be alerted on, a value of 1 indicates the
+// resource is orphaned. The cloud identifier is exposed as a label so the alert carries the
+// handle needed for manual cleanup
+func SetOrphanedResourceMetric(entry LedgerEntry, orphaned bool) {
+	value := float64(0)
+	if orphaned {
+		value = 1
+	}
+	SetMetric(DefaultResourceOrphanedMetricName, map[string]string{
+		"resourceType": entry.ResourceType,
+		"name":         entry.Name,
+		"namespace":    entry.Namespace,
+		"resourceID":   entry.ResourceID,
+	}, value)
+}
+
+// WatchResourceLedger periodically persists the ledger of provisioned cloud resources to the
+// ledger config map and reports entries whose owning cr no longer exists, the loop returns
+// when the stop channel is closed
+func WatchResourceLedger(c client.Client, namespace string, stop <-chan struct{}) error {
+	logger := logrus.WithFields(logrus.Fields{"ledger_config": DefaultResourceLedgerConfigMapName})
+	for {
+		ctx := context.TODO()
+		ledgerLock.Lock()
+		loaded := ledgerLoaded
+		ledgerLock.Unlock()
+		if !loaded {
+			if err := loadResourceLedgerConfigMap(ctx, c, namespace); err != nil {
+				logger.Errorf("failed to load ledger: %v", err)
+			} else {
+				ledgerLock.Lock()
+				ledgerLoaded = true
+				ledgerLock.Unlock()
+			}
+		}
+		orphans, err := ReconcileOrphanedResources(ctx, c)
+		if err != nil {
+			logger.Errorf("failed to check ledger for orphaned resources: %v", err)
+		}
+		for _, orphan := range orphans {
+			logger.Warnf("cloud resource %s provisioned for %s %s in namespace %s is orphaned, the owning cr no longer exists and the cloud resource needs manual cleanup", orphan.ResourceID, orphan.Kind, orphan.Name, orphan.Namespace)
+		}
+		if err := WriteResourceLedgerConfigMap(ctx, c, namespace); err != nil {
+			logger.Errorf("failed to persist ledger: %v", err)
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(ledgerCheckInterval):
+		}
+	}
+}
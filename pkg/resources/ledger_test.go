@@ -0,0 +1,45 @@
+package resources
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceLedger(t *testing.T) {
+	resetLedger := func() {
+		ledgerLock.Lock()
+		ledgerEntries = map[string]LedgerEntry{}
+		ledgerLock.Unlock()
+	}
+	t.Run("test entries are recorded per owning cr", func(t *testing.T) {
+		resetLedger()
+		RecordLedgerEntry(LedgerEntry{Kind: "Postgres", Namespace: "test", Name: "test", ResourceID: "arn:test:postgres"})
+		RecordLedgerEntry(LedgerEntry{Kind: "Redis", Namespace: "test", Name: "test", ResourceID: "arn:test:redis"})
+		if entries := LedgerEntries(); len(entries) != 2 {
+			t.Errorf("LedgerEntries() returned %d entries, want 2", len(entries))
+		}
+	})
+	t.Run("test re-recording an entry keeps the original record time", func(t *testing.T) {
+		resetLedger()
+		recordedAt := metav1.NewTime(time.Date(2026, time.August, 19, 10, 0, 0, 0, time.UTC))
+		RecordLedgerEntry(LedgerEntry{Kind: "Postgres", Namespace: "test", Name: "test", ResourceID: "arn:test:postgres", RecordedAt: recordedAt})
+		RecordLedgerEntry(LedgerEntry{Kind: "Postgres", Namespace: "test", Name: "test", ResourceID: "arn:test:postgres"})
+		entries := LedgerEntries()
+		if len(entries) != 1 {
+			t.Fatalf("LedgerEntries() returned %d entries, want 1", len(entries))
+		}
+		if !entries[0].RecordedAt.Equal(&recordedAt) {
+			t.Errorf("entry record time = %v, want %v", entries[0].RecordedAt, recordedAt)
+		}
+	})
+	t.Run("test removing an entry after teardown", func(t *testing.T) {
+		resetLedger()
+		RecordLedgerEntry(LedgerEntry{Kind: "Postgres", Namespace: "test", Name: "test", ResourceID: "arn:test:postgres"})
+		RemoveLedgerEntry("Postgres", "test", "test")
+		if entries := LedgerEntries(); len(entries) != 0 {
+			t.Errorf("LedgerEntries() returned %d entries after removal, want 0", len(entries))
+		}
+	})
+}
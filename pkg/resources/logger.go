@@ -1,11 +1,24 @@
 package resources
 
 import (
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
 	LoggingKeyAction = "action"
+
+	// correlation fields attached to every log line written during a reconcile
+	LoggingKeyKind        = "resourceKind"
+	LoggingKeyName        = "resourceName"
+	LoggingKeyNamespace   = "resourceNamespace"
+	LoggingKeyProvider    = "provider"
+	LoggingKeyReconcileID = "reconcileID"
+
+	// EnvLogLevel the log level, settable through the operator config map so the level can
+	// be changed without restarting the operator
+	EnvLogLevel = "LOG_LEVEL"
 )
 
 func NewActionLogger(logger *logrus.Entry, action string) *logrus.Entry {
@@ -15,3 +28,34 @@ func NewActionLogger(logger *logrus.Entry, action string) *logrus.Entry {
 func NewActionLoggerWithFields(logger *logrus.Entry, fields logrus.Fields) *logrus.Entry {
 	return logger.WithFields(fields)
 }
+
+// NewReconcileLogger returns a logger with the cr identity and a unique reconcile id
+// attached, so every log line of a single reconcile can be correlated
+func NewReconcileLogger(logger *logrus.Entry, kind string, namespacedName types.NamespacedName) *logrus.Entry {
+	return logger.WithFields(logrus.Fields{
+		LoggingKeyKind:        kind,
+		LoggingKeyNamespace:   namespacedName.Namespace,
+		LoggingKeyName:        namespacedName.Name,
+		LoggingKeyReconcileID: uuid.New().String(),
+	})
+}
+
+// SetLogLevel parses and applies a logrus level, an invalid level keeps the current level
+func SetLogLevel(level string) {
+	parsedLevel, err := logrus.ParseLevel(level)
+	if err != nil {
+		logrus.Errorf("invalid log level %s, keeping level %s", level, logrus.GetLevel())
+		return
+	}
+	logrus.SetLevel(parsedLevel)
+}
+
+// applyLogLevelConfig applies the log level from the operator config or environment, used by
+// the operator config watcher so level changes take effect without a restart
+func applyLogLevelConfig() {
+	if level, ok := LookupConfig(EnvLogLevel); ok {
+		if parsedLevel, err := logrus.ParseLevel(level); err == nil && parsedLevel != logrus.GetLevel() {
+			SetLogLevel(level)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package resources
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultOperatorConfigMapName the config map holding operator wide configuration, keys
+	// match the equivalent environment variable names so either mechanism can be used
+	DefaultOperatorConfigMapName = "cloud-resources-operator-config"
+
+	// how often the operator config map is reloaded
+	operatorConfigWatchInterval = time.Minute
+)
+
+var (
+	operatorConfig     = map[string]string{}
+	operatorConfigLock sync.RWMutex
+)
+
+// LookupConfig returns the value for an operator configuration key, values from the operator
+// config map take precedence over the equivalent environment variable so configuration can be
+// changed without restarting the operator
+func LookupConfig(key string) (string, bool) {
+	operatorConfigLock.RLock()
+	value, ok := operatorConfig[key]
+	operatorConfigLock.RUnlock()
+	if ok && value != "" {
+		return value, true
+	}
+	return os.LookupEnv(key)
+}
+
+// SetOperatorConfig replaces the operator config overrides, exposed for the config watcher and
+// tests
+func SetOperatorConfig(data map[string]string) {
+	if data == nil {
+		data = map[string]string{}
+	}
+	operatorConfigLock.Lock()
+	defer operatorConfigLock.Unlock()
+	operatorConfig = data
+}
+
+// WatchOperatorConfig periodically reloads the operator config map from the provided namespace
+// so timing and tagging configuration changes take effect without an operator restart, the loop
+// returns when the stop channel is closed
+func WatchOperatorConfig(c client.Client, namespace string, stop <-chan struct{}) error {
+	logger := logrus.WithFields(logrus.Fields{"operator_config": DefaultOperatorConfigMapName})
+	for {
+		cm := &v1.ConfigMap{}
+		err := c.Get(context.TODO(), types.NamespacedName{Name: DefaultOperatorConfigMapName, Namespace: namespace}, cm)
+		if err != nil {
+			// a missing config map means the operator falls back to environment variables
+			if !errors.IsNotFound(err) {
+				logger.Errorf("failed to get operator config map: %v", err)
+			}
+			SetOperatorConfig(nil)
+		} else {
+			SetOperatorConfig(cm.Data)
+		}
+		// pick up log level changes from the reloaded configuration
+		applyLogLevelConfig()
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(operatorConfigWatchInterval):
+		}
+	}
+}
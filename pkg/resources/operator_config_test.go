@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLookupConfig(t *testing.T) {
+	const testKey = "TEST_OPERATOR_CONFIG_KEY"
+	defer SetOperatorConfig(nil)
+	defer os.Unsetenv(testKey)
+
+	cases := []struct {
+		name          string
+		envValue      string
+		configValue   string
+		expectedValue string
+		expectedOk    bool
+	}{
+		{
+			name:       "test missing key is not found",
+			expectedOk: false,
+		},
+		{
+			name:          "test environment variable is returned",
+			envValue:      "fromEnv",
+			expectedValue: "fromEnv",
+			expectedOk:    true,
+		},
+		{
+			name:          "test config map value takes precedence over environment variable",
+			envValue:      "fromEnv",
+			configValue:   "fromConfig",
+			expectedValue: "fromConfig",
+			expectedOk:    true,
+		},
+		{
+			name:          "test empty config map value falls back to environment variable",
+			envValue:      "fromEnv",
+			configValue:   "",
+			expectedValue: "fromEnv",
+			expectedOk:    true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Unsetenv(testKey)
+			if tc.envValue != "" {
+				if err := os.Setenv(testKey, tc.envValue); err != nil {
+					t.Fatal(err)
+				}
+			}
+			SetOperatorConfig(map[string]string{testKey: tc.configValue})
+			value, ok := LookupConfig(testKey)
+			if ok != tc.expectedOk {
+				t.Fatalf("unexpected lookup result, expected %t but got %t", tc.expectedOk, ok)
+			}
+			if value != tc.expectedValue {
+				t.Fatalf("unexpected value, expected %s but got %s", tc.expectedValue, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package resources
+
+import (
+	cryptoRand "crypto/rand"
+	"math/big"
+	"strconv"
+	"strings"
+
+	errorUtil "github.com/pkg/errors"
+)
+
+const (
+	// EnvPasswordLength the operator configuration key holding the length of generated
+	// passwords
+	EnvPasswordLength = "PASSWORD_LENGTH"
+	// EnvPasswordSymbols when set to "true" generated passwords include symbols, the symbol
+	// set avoids the characters the database engines reject
+	EnvPasswordSymbols = "PASSWORD_SYMBOLS"
+	// EnvPasswordExcludeChars characters that are never used in generated passwords, for
+	// engines or clients that reject characters beyond the engine restrictions
+	EnvPasswordExcludeChars = "PASSWORD_EXCLUDE_CHARACTERS"
+
+	defaultPasswordLength = 32
+	// bounds keep a mis-typed config value from generating passwords an engine rejects, rds
+	// master passwords allow 8 to 128 characters
+	minPasswordLength = 16
+	maxPasswordLength = 99
+
+	passwordLowerChars = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpperChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigitChars = "0123456789"
+	// the symbol set excludes the characters rds master passwords reject, '/', '"', '@' and
+	// space, along with quoting characters that commonly break connection strings
+	passwordSymbolChars = "!#$%&()*+,-.:;<=>?[]^_{|}~"
+)
+
+// PasswordPolicy the shape of generated passwords, built from the operator configuration so
+// the policy can be changed without an operator restart
+type PasswordPolicy struct {
+	Length            int
+	Symbols           bool
+	ExcludeCharacters string
+}
+
+// DefaultPasswordPolicy the policy used when no configuration is set, 32 characters of upper
+// and lower case letters and digits, matching the shape of previously generated passwords
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		Length: defaultPasswordLength,
+	}
+}
+
+// PasswordPolicyFromConfig builds the password policy from the operator configuration,
+// falling back to the default policy for unset or invalid values
+func PasswordPolicyFromConfig() PasswordPolicy {
+	policy := DefaultPasswordPolicy()
+	if value, ok := LookupConfig(EnvPasswordLength); ok {
+		if length, err := strconv.Atoi(value); err == nil {
+			if length < minPasswordLength {
+				length = minPasswordLength
+			}
+			if length > maxPasswordLength {
+				length = maxPasswordLength
+			}
+			policy.Length = length
+		}
+	}
+	if value, ok := LookupConfig(EnvPasswordSymbols); ok {
+		policy.Symbols = value == "true"
+	}
+	if value, ok := LookupConfig(EnvPasswordExcludeChars); ok {
+		policy.ExcludeCharacters = value
+	}
+	return policy
+}
+
+// GeneratePassword generates a password following the policy from the operator configuration,
+// the characters come from the system random source so generation stays inside the approved
+// crypto boundary in fips mode
+func GeneratePassword() (string, error) {
+	return GeneratePasswordWithPolicy(PasswordPolicyFromConfig())
+}
+
+// GeneratePasswordWithPolicy generates a password following the provided policy, the password
+// contains at least one character from every enabled character class
+func GeneratePasswordWithPolicy(policy PasswordPolicy) (string, error) {
+	classes := [][]rune{
+		filterRunes(passwordLowerChars, policy.ExcludeCharacters),
+		filterRunes(passwordUpperChars, policy.ExcludeCharacters),
+		filterRunes(passwordDigitChars, policy.ExcludeCharacters),
+	}
+	if policy.Symbols {
+		classes = append(classes, filterRunes(passwordSymbolChars, policy.ExcludeCharacters))
+	}
+	var charset []rune
+	for _, class := range classes {
+		charset = append(charset, class...)
+	}
+	if len(charset) == 0 {
+		return "", errorUtil.New("password policy excludes every available character")
+	}
+	length := policy.Length
+	if length < len(classes) {
+		length = len(classes)
+	}
+	password := make([]rune, length)
+	// seed one character from each non empty class so every enabled class is represented,
+	// then fill the remainder from the full character set and shuffle
+	position := 0
+	for _, class := range classes {
+		if len(class) == 0 {
+			continue
+		}
+		char, err := randomRune(class)
+		if err != nil {
+			return "", err
+		}
+		password[position] = char
+		position++
+	}
+	for ; position < length; position++ {
+		char, err := randomRune(charset)
+		if err != nil {
+			return "", err
+		}
+		password[position] = char
+	}
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+func filterRunes(chars string, exclude string) []rune {
+	var filtered []rune
+	for _, char := range chars {
+		if strings.ContainsRune(exclude, char) {
+			continue
+		}
+		filtered = append(filtered, char)
+	}
+	return filtered
+}
+
+func randomRune(chars []rune) (rune, error) {
+	index, err := randomInt(len(chars))
+	if err != nil {
+		return 0, err
+	}
+	return chars[index], nil
+}
+
+func randomInt(max int) (int, error) {
+	value, err := cryptoRand.Int(cryptoRand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		return 0, errorUtil.Wrap(err, "error generating password")
+	}
+	return int(value.Int64()), nil
+}
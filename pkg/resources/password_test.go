@@ -0,0 +1,102 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicyFromConfig(t *testing.T) {
+	type args struct {
+		config map[string]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want PasswordPolicy
+	}{
+		{
+			name: "test default policy is used when no configuration is set",
+			args: args{config: nil},
+			want: PasswordPolicy{Length: 32},
+		},
+		{
+			name: "test length, symbols and exclusions come from the configuration",
+			args: args{config: map[string]string{
+				EnvPasswordLength:       "40",
+				EnvPasswordSymbols:      "true",
+				EnvPasswordExcludeChars: "lIO0",
+			}},
+			want: PasswordPolicy{Length: 40, Symbols: true, ExcludeCharacters: "lIO0"},
+		},
+		{
+			name: "test length is clamped to the engine safe bounds",
+			args: args{config: map[string]string{EnvPasswordLength: "5"}},
+			want: PasswordPolicy{Length: 16},
+		},
+		{
+			name: "test invalid length falls back to the default",
+			args: args{config: map[string]string{EnvPasswordLength: "not a number"}},
+			want: PasswordPolicy{Length: 32},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetOperatorConfig(tt.args.config)
+			defer SetOperatorConfig(nil)
+			if got := PasswordPolicyFromConfig(); got != tt.want {
+				t.Errorf("PasswordPolicyFromConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeneratePasswordWithPolicy(t *testing.T) {
+	type args struct {
+		policy PasswordPolicy
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantErr     bool
+		wantSymbols bool
+	}{
+		{
+			name: "test default policy generates letters and digits",
+			args: args{policy: DefaultPasswordPolicy()},
+		},
+		{
+			name:        "test symbols are included when enabled",
+			args:        args{policy: PasswordPolicy{Length: 32, Symbols: true}},
+			wantSymbols: true,
+		},
+		{
+			name: "test excluded characters never appear",
+			args: args{policy: PasswordPolicy{Length: 32, ExcludeCharacters: "abcdefghijklmnopqrstuvwxyz"}},
+		},
+		{
+			name:    "test a policy excluding every character is rejected",
+			args:    args{policy: PasswordPolicy{Length: 32, ExcludeCharacters: passwordLowerChars + passwordUpperChars + passwordDigitChars}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GeneratePasswordWithPolicy(tt.args.policy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GeneratePasswordWithPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != tt.args.policy.Length {
+				t.Errorf("GeneratePasswordWithPolicy() length = %d, want %d", len(got), tt.args.policy.Length)
+			}
+			if strings.ContainsAny(got, tt.args.policy.ExcludeCharacters) {
+				t.Errorf("GeneratePasswordWithPolicy() = %v, contains excluded characters %v", got, tt.args.policy.ExcludeCharacters)
+			}
+			if tt.wantSymbols && !strings.ContainsAny(got, passwordSymbolChars) {
+				t.Errorf("GeneratePasswordWithPolicy() = %v, expected at least one symbol", got)
+			}
+		})
+	}
+}
@@ -24,6 +24,12 @@ func UpdatePhase(ctx context.Context, client client.Client, inst runtime.Object,
 	}
 	rts.Message = msg
 	rts.Phase = phase
+	// track consecutive failures so controllers can back off failing resources
+	if phase == croType.PhaseFailed {
+		rts.FailureCount++
+	} else {
+		rts.FailureCount = 0
+	}
 	if err := runtime.SetField(*rts, reflect.ValueOf(inst).Elem(), "Status"); err != nil {
 		return errorUtil.Wrap(err, "failed to set status block of object")
 	}
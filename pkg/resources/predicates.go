@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"os"
+
+	errorUtil "github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// EnvWatchLabelSelector envar holding a label selector used to restrict the crs served by the
+// operator, used in multi-tenant clusters where cro should only serve specific projects
+const EnvWatchLabelSelector = "WATCH_LABEL_SELECTOR"
+
+// BuildWatchLabelSelectorPredicate returns a predicate that filters watched objects against the
+// label selector in WATCH_LABEL_SELECTOR, all objects are matched when the envar is not set
+func BuildWatchLabelSelectorPredicate() (predicate.Predicate, error) {
+	rawSelector, exists := os.LookupEnv(EnvWatchLabelSelector)
+	if !exists || rawSelector == "" {
+		return predicate.Funcs{}, nil
+	}
+	selector, err := labels.Parse(rawSelector)
+	if err != nil {
+		return nil, errorUtil.Wrapf(err, "failed to parse label selector %s", rawSelector)
+	}
+	matches := func(o metav1.Object) bool {
+		if o == nil {
+			return false
+		}
+		return selector.Matches(labels.Set(o.GetLabels()))
+	}
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return matches(e.Meta)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return matches(e.MetaNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return matches(e.Meta)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return matches(e.Meta)
+		},
+	}, nil
+}
@@ -2,8 +2,15 @@ package resources
 
 import (
 	"context"
-	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"reflect"
+	"sort"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/annotations"
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
 
 	"github.com/pkg/errors"
 
@@ -17,6 +24,33 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+const (
+	// ServiceBindingFormatAnnotation when set to "true" on a resource cr the connection secret
+	// is written following the servicebinding.io conventions, adding type, provider and uri keys
+	// and labelling the secret as a provisioned service so it can be consumed by the service
+	// binding operator without glue code
+	ServiceBindingFormatAnnotation = "serviceBindingFormat"
+
+	// serviceBindingProvisionedServiceLabel marks the secret as a provisioned service following
+	// the servicebinding.io conventions
+	serviceBindingProvisionedServiceLabel = "servicebinding.io/provisioned-service"
+
+	// defaultServiceBindingProvider the provider placed in the secret when the cr status does
+	// not yet carry the name of the provider that provisioned the resource
+	defaultServiceBindingProvider = "cloud-resource-operator"
+
+	// standard labels placed on every connection secret so consumers can select them and tell
+	// which resource type and tier they belong to
+	secretManagedByLabel    = "app.kubernetes.io/managed-by"
+	secretManagedByValue    = "cloud-resource-operator"
+	secretResourceTypeLabel = "integreatly.org/resource-type"
+	secretResourceTierLabel = "integreatly.org/resource-tier"
+
+	// secretCredentialsHashAnnotation a stable hash of the secret data, it changes whenever the
+	// credentials change so reloader style tooling can restart consuming deployments
+	secretCredentialsHashAnnotation = "integreatly.org/credentials-hash"
+)
+
 type ReconcileResourceProvider struct {
 	Client client.Client
 	Scheme *runtime.Scheme
@@ -41,6 +75,36 @@ func (r *ReconcileResourceProvider) ReconcileResultSecret(ctx context.Context, o
 	if rts.SecretRef.Namespace != "" {
 		secNs = rts.SecretRef.Namespace
 	}
+	// credentials can be pushed to an external store in addition to, or instead of, the
+	// kubernetes secret, the publisher and path are selected through annotations on the cr
+	var publisher SecretPublisher
+	var publishPath string
+	publishOnly := false
+	if annotations.Has(obj, SecretPublisherAnnotation) {
+		var pubErr error
+		publisher, pubErr = GetSecretPublisher(obj.GetAnnotations()[SecretPublisherAnnotation])
+		if pubErr != nil {
+			if updateErr := UpdatePhase(ctx, r.Client, o, croType.PhaseFailed, "failed to find secret publisher"); updateErr != nil {
+				return updateErr
+			}
+			return errors.Wrap(pubErr, "failed to find secret publisher")
+		}
+		publishPath = buildSecretPublisherPath(obj, obj.GetAnnotations()[SecretPublisherPathAnnotation])
+		publishOnly = obj.GetAnnotations()[SecretPublisherOnlyAnnotation] == "true"
+	}
+
+	if publisher != nil {
+		if pubErr := publisher.PublishSecret(ctx, publishPath, d); pubErr != nil {
+			if updateErr := UpdatePhase(ctx, r.Client, o, croType.PhaseFailed, "failed to publish credentials to external store"); updateErr != nil {
+				return updateErr
+			}
+			return errors.Wrapf(pubErr, "failed to publish credentials to %s", publisher.GetName())
+		}
+		if publishOnly {
+			return nil
+		}
+	}
+
 	sec := &v1.Secret{
 		ObjectMeta: controllerruntime.ObjectMeta{
 			Name:      rts.SecretRef.Name,
@@ -55,6 +119,19 @@ func (r *ReconcileResourceProvider) ReconcileResultSecret(ctx context.Context, o
 			return errors.Wrapf(ownerRefErr, "failed to set owner on secret %s", sec.Name)
 		}
 		sec.Data = d
+		if annotations.Has(obj, ServiceBindingFormatAnnotation) && obj.GetAnnotations()[ServiceBindingFormatAnnotation] == "true" {
+			applyServiceBindingFormat(o, sec)
+		}
+		if sec.Labels == nil {
+			sec.Labels = map[string]string{}
+		}
+		sec.Labels[secretManagedByLabel] = secretManagedByValue
+		sec.Labels[secretResourceTypeLabel] = rts.Type
+		sec.Labels[secretResourceTierLabel] = rts.Tier
+		if sec.Annotations == nil {
+			sec.Annotations = map[string]string{}
+		}
+		sec.Annotations[secretCredentialsHashAnnotation] = buildSecretDataHash(sec.Data)
 		sec.Type = v1.SecretTypeOpaque
 		return nil
 	})
@@ -66,3 +143,57 @@ func (r *ReconcileResourceProvider) ReconcileResultSecret(ctx context.Context, o
 	}
 	return nil
 }
+
+// buildSecretDataHash returns a stable hash of the secret data, the hash only changes when the
+// data changes so it can be used to detect credential updates
+func buildSecretDataHash(d map[string][]byte) string {
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write(d[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// applyServiceBindingFormat augments the connection secret with the servicebinding.io
+// conventions, a type and provider key describing the service, a uri key where one can be
+// derived from the connection details and a label marking the secret as a provisioned service
+func applyServiceBindingFormat(o runtime.Object, sec *v1.Secret) {
+	var bindingType, uri string
+	switch o.(type) {
+	case *v1alpha1.Postgres:
+		bindingType = "postgresql"
+		uri = fmt.Sprintf("postgresql://%s:%s@%s:%s/%s", sec.Data["username"], sec.Data["password"], sec.Data["host"], sec.Data["port"], sec.Data["database"])
+	case *v1alpha1.Redis:
+		bindingType = "redis"
+		uri = fmt.Sprintf("redis://%s:%s", sec.Data["uri"], sec.Data["port"])
+	case *v1alpha1.BlobStorage:
+		bindingType = "s3"
+	}
+	if bindingType == "" {
+		return
+	}
+
+	// the provider that provisioned the resource is carried on the cr status once the first
+	// reconcile completes, fall back to the operator name until it is set
+	provider := defaultServiceBindingProvider
+	rts := &croType.ResourceTypeStatus{}
+	if err := runtime.Field(reflect.ValueOf(o).Elem(), "Status", rts); err == nil && rts.Provider != "" {
+		provider = rts.Provider
+	}
+
+	sec.Data["type"] = []byte(bindingType)
+	sec.Data["provider"] = []byte(provider)
+	if uri != "" {
+		sec.Data["uri"] = []byte(uri)
+	}
+	if sec.Labels == nil {
+		sec.Labels = map[string]string{}
+	}
+	sec.Labels[serviceBindingProvisionedServiceLabel] = "true"
+}
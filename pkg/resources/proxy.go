@@ -0,0 +1,135 @@
+package resources
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+	configv1 "github.com/openshift/api/config/v1"
+	errorUtil "github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// TrustedCABundleConfigMapName the config map holding the additional trusted ca bundle
+	// for cloud api calls made through a tls intercepting proxy, the config map can be
+	// populated by labelling it with config.openshift.io/inject-trusted-cabundle so the
+	// cluster network operator injects the cluster wide bundle
+	TrustedCABundleConfigMapName = "cloud-resource-trusted-ca"
+
+	trustedCABundleKey = "ca-bundle.crt"
+
+	// the canonical name of the cluster wide proxy object
+	clusterProxyName = "cluster"
+)
+
+// GetClusterProxy returns the cluster wide proxy configuration, nil when the proxy object
+// does not exist or carries no proxy urls
+func GetClusterProxy(ctx context.Context, c client.Client) (*configv1.ProxySpec, error) {
+	proxy := &configv1.Proxy{}
+	if err := c.Get(ctx, types.NamespacedName{Name: clusterProxyName}, proxy); err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errorUtil.Wrap(err, "failed to get cluster proxy configuration")
+	}
+	if proxy.Spec.HTTPProxy == "" && proxy.Spec.HTTPSProxy == "" {
+		return nil, nil
+	}
+	return &proxy.Spec, nil
+}
+
+// buildProxyFunc builds a proxy selection function from the cluster wide proxy configuration,
+// matching the no proxy semantics of the standard environment variables, entries match a host
+// exactly or as a domain suffix and a lone * disables proxying entirely
+func buildProxyFunc(spec *configv1.ProxySpec) func(req *http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		host := req.URL.Hostname()
+		for _, entry := range strings.Split(spec.NoProxy, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			if entry == "*" {
+				return nil, nil
+			}
+			if host == entry || strings.HasSuffix(host, "."+strings.TrimPrefix(entry, ".")) {
+				return nil, nil
+			}
+		}
+		proxyURL := spec.HTTPProxy
+		if req.URL.Scheme == "https" && spec.HTTPSProxy != "" {
+			proxyURL = spec.HTTPSProxy
+		}
+		if proxyURL == "" {
+			return nil, nil
+		}
+		return url.Parse(proxyURL)
+	}
+}
+
+// getTrustedCABundle returns the additional trusted ca bundle from the trusted ca config map
+// in the operator namespace, nil when the config map or key is not present
+func getTrustedCABundle(ctx context.Context, c client.Client) ([]byte, error) {
+	namespace, err := k8sutil.GetWatchNamespace()
+	if err != nil {
+		// without a watch namespace there is no config map to read, rely on the system roots
+		return nil, nil
+	}
+	cm := &v1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: TrustedCABundleConfigMapName, Namespace: namespace}, cm); err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errorUtil.Wrapf(err, "failed to get trusted ca config map %s in namespace %s", TrustedCABundleConfigMapName, namespace)
+	}
+	if cm.Data[trustedCABundleKey] == "" {
+		return nil, nil
+	}
+	return []byte(cm.Data[trustedCABundleKey]), nil
+}
+
+// BuildProxiedHTTPClient returns an http client for cloud api calls that honours the cluster
+// wide proxy object, falling back to the standard proxy environment variables, with the
+// additional trusted ca bundle appended to the system roots so tls intercepting proxies are
+// trusted in restricted egress environments
+func BuildProxiedHTTPClient(ctx context.Context, c client.Client) (*http.Client, error) {
+	proxyFunc := http.ProxyFromEnvironment
+	proxySpec, err := GetClusterProxy(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if proxySpec != nil {
+		proxyFunc = buildProxyFunc(proxySpec)
+	}
+	// cloud endpoints all support tls 1.2, requiring it keeps the client compliant when the
+	// operator runs on a fips cluster
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	caBundle, err := getTrustedCABundle(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if caBundle != nil {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, errorUtil.Errorf("failed to parse any certificates from trusted ca config map %s", TrustedCABundleConfigMapName)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	return &http.Client{Transport: &http.Transport{
+		Proxy:           proxyFunc,
+		TLSClientConfig: tlsConfig,
+	}}, nil
+}
@@ -0,0 +1,81 @@
+package resources
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+func TestBuildProxyFunc(t *testing.T) {
+	type args struct {
+		spec       *configv1.ProxySpec
+		requestURL string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "test https requests use the https proxy",
+			args: args{
+				spec:       &configv1.ProxySpec{HTTPProxy: "http://proxy:3128", HTTPSProxy: "https://secure-proxy:3128"},
+				requestURL: "https://rds.eu-west-1.amazonaws.com",
+			},
+			want: "https://secure-proxy:3128",
+		},
+		{
+			name: "test http requests fall back to the http proxy",
+			args: args{
+				spec:       &configv1.ProxySpec{HTTPProxy: "http://proxy:3128", HTTPSProxy: "https://secure-proxy:3128"},
+				requestURL: "http://rds.eu-west-1.amazonaws.com",
+			},
+			want: "http://proxy:3128",
+		},
+		{
+			name: "test no proxy entries match as a domain suffix",
+			args: args{
+				spec:       &configv1.ProxySpec{HTTPSProxy: "https://secure-proxy:3128", NoProxy: ".amazonaws.com"},
+				requestURL: "https://rds.eu-west-1.amazonaws.com",
+			},
+			want: "",
+		},
+		{
+			name: "test no proxy entries match a host exactly",
+			args: args{
+				spec:       &configv1.ProxySpec{HTTPSProxy: "https://secure-proxy:3128", NoProxy: "other-host,rds.eu-west-1.amazonaws.com"},
+				requestURL: "https://rds.eu-west-1.amazonaws.com",
+			},
+			want: "",
+		},
+		{
+			name: "test a lone wildcard disables proxying",
+			args: args{
+				spec:       &configv1.ProxySpec{HTTPSProxy: "https://secure-proxy:3128", NoProxy: "*"},
+				requestURL: "https://rds.eu-west-1.amazonaws.com",
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsedURL, err := url.Parse(tt.args.requestURL)
+			if err != nil {
+				t.Fatal("failed to parse request url", err)
+			}
+			got, err := buildProxyFunc(tt.args.spec)(&http.Request{URL: parsedURL})
+			if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+			gotURL := ""
+			if got != nil {
+				gotURL = got.String()
+			}
+			if gotURL != tt.want {
+				t.Errorf("buildProxyFunc() = %v, want %v", gotURL, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	errorUtil "github.com/pkg/errors"
+)
+
+const (
+	// ScheduleOverrideAnnotation when set to "true" on a cr the stop/start schedule is
+	// ignored and the resource is kept running, used to keep an environment up outside the
+	// configured working window without editing the spec
+	ScheduleOverrideAnnotation = "integreatly.org/schedule-override"
+
+	// how far back a cron expression is evaluated when looking for its most recent firing,
+	// wide enough to cover schedules that only fire on one day of the week
+	cronLookback = 8 * 24 * time.Hour
+)
+
+// cronField the allowed values of a single cron field as a set
+type cronField map[int]bool
+
+// CronSchedule a parsed five field cron expression (minute hour day-of-month month
+// day-of-week)
+type CronSchedule struct {
+	minute     cronField
+	hour       cronField
+	dayOfMonth cronField
+	month      cronField
+	dayOfWeek  cronField
+	// whether the day fields were restricted, needed for the standard cron rule that a time
+	// matches when either restricted day field matches
+	dayOfMonthRestricted bool
+	dayOfWeekRestricted  bool
+}
+
+// ParseCron parses a five field cron expression supporting "*", lists, ranges and steps,
+// day-of-week accepts 0 to 7 with both 0 and 7 meaning sunday
+func ParseCron(expression string) (*CronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, errorUtil.New(fmt.Sprintf("invalid cron expression %q, expected 5 fields, got %d", expression, len(fields)))
+	}
+	minute, err := parseCronField(fields[0], 0, 59)
+	if err != nil {
+		return nil, errorUtil.Wrapf(err, "invalid minute field in cron expression %q", expression)
+	}
+	hour, err := parseCronField(fields[1], 0, 23)
+	if err != nil {
+		return nil, errorUtil.Wrapf(err, "invalid hour field in cron expression %q", expression)
+	}
+	dayOfMonth, err := parseCronField(fields[2], 1, 31)
+	if err != nil {
+		return nil, errorUtil.Wrapf(err, "invalid day-of-month field in cron expression %q", expression)
+	}
+	month, err := parseCronField(fields[3], 1, 12)
+	if err != nil {
+		return nil, errorUtil.Wrapf(err, "invalid month field in cron expression %q", expression)
+	}
+	dayOfWeek, err := parseCronField(fields[4], 0, 7)
+	if err != nil {
+		return nil, errorUtil.Wrapf(err, "invalid day-of-week field in cron expression %q", expression)
+	}
+	// both 0 and 7 mean sunday
+	if dayOfWeek[7] {
+		dayOfWeek[0] = true
+		delete(dayOfWeek, 7)
+	}
+	return &CronSchedule{
+		minute:               minute,
+		hour:                 hour,
+		dayOfMonth:           dayOfMonth,
+		month:                month,
+		dayOfWeek:            dayOfWeek,
+		dayOfMonthRestricted: fields[2] != "*",
+		dayOfWeekRestricted:  fields[4] != "*",
+	}, nil
+}
+
+func parseCronField(field string, min int, max int) (cronField, error) {
+	values := cronField{}
+	for _, part := range strings.Split(field, ",") {
+		rangePart := part
+		step := 1
+		if index := strings.Index(part, "/"); index != -1 {
+			rangePart = part[:index]
+			parsedStep, err := strconv.Atoi(part[index+1:])
+			if err != nil || parsedStep < 1 {
+				return nil, errorUtil.New(fmt.Sprintf("invalid step value %q", part[index+1:]))
+			}
+			step = parsedStep
+		}
+		start, end := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			parsedStart, startErr := strconv.Atoi(bounds[0])
+			parsedEnd, endErr := strconv.Atoi(bounds[1])
+			if startErr != nil || endErr != nil {
+				return nil, errorUtil.New(fmt.Sprintf("invalid range %q", rangePart))
+			}
+			start, end = parsedStart, parsedEnd
+		default:
+			parsed, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return nil, errorUtil.New(fmt.Sprintf("invalid value %q", rangePart))
+			}
+			start, end = parsed, parsed
+		}
+		if start < min || end > max || start > end {
+			return nil, errorUtil.New(fmt.Sprintf("value %q out of range %d-%d", rangePart, min, max))
+		}
+		for value := start; value <= end; value += step {
+			values[value] = true
+		}
+	}
+	return values, nil
+}
+
+// Matches returns true when the schedule fires at the given time, following the standard cron
+// rule that a time matches when either of the restricted day fields matches
+func (s *CronSchedule) Matches(t time.Time) bool {
+	if !s.minute[t.Minute()] || !s.hour[t.Hour()] || !s.month[int(t.Month())] {
+		return false
+	}
+	dayOfMonthMatches := s.dayOfMonth[t.Day()]
+	dayOfWeekMatches := s.dayOfWeek[int(t.Weekday())]
+	if s.dayOfMonthRestricted && s.dayOfWeekRestricted {
+		return dayOfMonthMatches || dayOfWeekMatches
+	}
+	return dayOfMonthMatches && dayOfWeekMatches
+}
+
+// LastFiring returns the most recent time the schedule fired at or before the given time, the
+// zero time when the schedule did not fire within the lookback window
+func (s *CronSchedule) LastFiring(now time.Time) time.Time {
+	t := now.Truncate(time.Minute)
+	for earliest := now.Add(-cronLookback); !t.Before(earliest); t = t.Add(-time.Minute) {
+		if s.Matches(t) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// ScheduleDesiredStopped evaluates a stop/start schedule at the given time, the resource is
+// desired stopped when the stop expression fired more recently than the start expression
+func ScheduleDesiredStopped(schedule *croType.ScheduleSpec, now time.Time) (bool, error) {
+	startSchedule, err := ParseCron(schedule.Start)
+	if err != nil {
+		return false, errorUtil.Wrap(err, "failed to parse schedule start expression")
+	}
+	stopSchedule, err := ParseCron(schedule.Stop)
+	if err != nil {
+		return false, errorUtil.Wrap(err, "failed to parse schedule stop expression")
+	}
+	lastStart := startSchedule.LastFiring(now)
+	lastStop := stopSchedule.LastFiring(now)
+	// a schedule that has not fired yet keeps the resource running
+	if lastStop.IsZero() {
+		return false, nil
+	}
+	return lastStop.After(lastStart), nil
+}
+
+// IsScheduleOverridden returns true when the schedule override annotation keeps the resource
+// running regardless of the configured schedule
+func IsScheduleOverridden(annotations map[string]string) bool {
+	return annotations[ScheduleOverrideAnnotation] == "true"
+}
@@ -0,0 +1,163 @@
+package resources
+
+import (
+	"testing"
+	"time"
+
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+)
+
+func TestParseCron(t *testing.T) {
+	cases := []struct {
+		name       string
+		expression string
+		wantErr    bool
+	}{
+		{
+			name:       "test simple expression parses",
+			expression: "0 8 * * 1-5",
+			wantErr:    false,
+		},
+		{
+			name:       "test lists and steps parse",
+			expression: "*/15 9-17 1,15 * *",
+			wantErr:    false,
+		},
+		{
+			name:       "test wrong number of fields errors",
+			expression: "0 8 * *",
+			wantErr:    true,
+		},
+		{
+			name:       "test out of range value errors",
+			expression: "0 25 * * *",
+			wantErr:    true,
+		},
+		{
+			name:       "test non numeric value errors",
+			expression: "0 eight * * *",
+			wantErr:    true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseCron(tc.expression)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ParseCron() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronSchedule_Matches(t *testing.T) {
+	// wednesday 19th august 2026, 18:00
+	wednesdayEvening := time.Date(2026, time.August, 19, 18, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name       string
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		{
+			name:       "test exact minute and hour match",
+			expression: "0 18 * * *",
+			time:       wednesdayEvening,
+			want:       true,
+		},
+		{
+			name:       "test weekday restriction matches",
+			expression: "0 18 * * 1-5",
+			time:       wednesdayEvening,
+			want:       true,
+		},
+		{
+			name:       "test weekday restriction does not match weekend days",
+			expression: "0 18 * * 0,6",
+			time:       wednesdayEvening,
+			want:       false,
+		},
+		{
+			name:       "test sunday as 7 matches sunday",
+			expression: "0 18 * * 7",
+			time:       time.Date(2026, time.August, 23, 18, 0, 0, 0, time.UTC),
+			want:       true,
+		},
+		{
+			name:       "test restricted day of month or day of week matches on either",
+			expression: "0 18 1 * 3",
+			time:       wednesdayEvening,
+			want:       true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseCron(tc.expression)
+			if err != nil {
+				t.Fatalf("ParseCron() unexpected error %v", err)
+			}
+			if got := schedule.Matches(tc.time); got != tc.want {
+				t.Errorf("Matches() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestScheduleDesiredStopped(t *testing.T) {
+	// wednesday 19th august 2026, 20:30, after a weekday 08:00-18:00 working window
+	now := time.Date(2026, time.August, 19, 20, 30, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		schedule *croType.ScheduleSpec
+		now      time.Time
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name: "test stopped outside the working window",
+			schedule: &croType.ScheduleSpec{
+				Start: "0 8 * * 1-5",
+				Stop:  "0 18 * * 1-5",
+			},
+			now:  now,
+			want: true,
+		},
+		{
+			name: "test running inside the working window",
+			schedule: &croType.ScheduleSpec{
+				Start: "0 8 * * 1-5",
+				Stop:  "0 18 * * 1-5",
+			},
+			now:  time.Date(2026, time.August, 19, 12, 0, 0, 0, time.UTC),
+			want: false,
+		},
+		{
+			name: "test running when the stop expression has not fired within the lookback",
+			schedule: &croType.ScheduleSpec{
+				Start: "0 8 * * *",
+				Stop:  "0 0 31 2 *",
+			},
+			now:  now,
+			want: false,
+		},
+		{
+			name: "test invalid start expression errors",
+			schedule: &croType.ScheduleSpec{
+				Start: "not a cron",
+				Stop:  "0 18 * * *",
+			},
+			now:     now,
+			wantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ScheduleDesiredStopped(tc.schedule, tc.now)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ScheduleDesiredStopped() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ScheduleDesiredStopped() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SecretPublisherAnnotation names the publisher the connection credentials are pushed to,
+	// publishers are registered at startup and looked up by name when the secret is reconciled
+	SecretPublisherAnnotation = "secretPublisher"
+
+	// SecretPublisherPathAnnotation the path the credentials are published under, the
+	// placeholders {namespace} and {name} are replaced with the values from the cr
+	SecretPublisherPathAnnotation = "secretPublisherPath"
+
+	// SecretPublisherOnlyAnnotation when set to "true" the credentials are only pushed to the
+	// publisher and no kubernetes secret is written, for clusters where plaintext etcd secrets
+	// are disallowed
+	SecretPublisherOnlyAnnotation = "secretPublisherOnly"
+
+	// defaultSecretPublisherPath the path template used when none is set on the cr
+	defaultSecretPublisherPath = "{namespace}/{name}"
+)
+
+// SecretPublisher publishes connection credentials to a store other than a kubernetes secret,
+// implementations are registered by name and selected per cr through annotations
+type SecretPublisher interface {
+	GetName() string
+	PublishSecret(ctx context.Context, path string, data map[string][]byte) error
+}
+
+var (
+	secretPublishersLock sync.RWMutex
+	secretPublishers     = map[string]SecretPublisher{}
+)
+
+// RegisterSecretPublisher makes a publisher available for selection through the
+// secretPublisher annotation, registering a second publisher with the same name replaces the
+// first
+func RegisterSecretPublisher(p SecretPublisher) {
+	secretPublishersLock.Lock()
+	defer secretPublishersLock.Unlock()
+	secretPublishers[p.GetName()] = p
+}
+
+// GetSecretPublisher returns the publisher registered under the given name
+func GetSecretPublisher(name string) (SecretPublisher, error) {
+	secretPublishersLock.RLock()
+	defer secretPublishersLock.RUnlock()
+	p, ok := secretPublishers[name]
+	if !ok {
+		return nil, fmt.Errorf("no secret publisher registered with name %s", name)
+	}
+	return p, nil
+}
+
+// buildSecretPublisherPath resolves the path template from the cr, replacing the {namespace}
+// and {name} placeholders with the values from the cr
+func buildSecretPublisherPath(obj metav1.Object, template string) string {
+	if template == "" {
+		template = defaultSecretPublisherPath
+	}
+	path := strings.Replace(template, "{namespace}", obj.GetNamespace(), -1)
+	return strings.Replace(path, "{name}", obj.GetName(), -1)
+}
@@ -0,0 +1,91 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildSecretPublisherPath(t *testing.T) {
+	obj := &metav1.ObjectMeta{
+		Name:      "test-postgres",
+		Namespace: "test-namespace",
+	}
+	cases := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "test default template used when none is set",
+			template: "",
+			expected: "test-namespace/test-postgres",
+		},
+		{
+			name:     "test placeholders are replaced in a custom template",
+			template: "secret/data/rhmi/{namespace}/{name}",
+			expected: "secret/data/rhmi/test-namespace/test-postgres",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if path := buildSecretPublisherPath(obj, tc.template); path != tc.expected {
+				t.Fatalf("unexpected path, expected %s but got %s", tc.expected, path)
+			}
+		})
+	}
+}
+
+func TestGetSecretPublisher(t *testing.T) {
+	RegisterSecretPublisher(NewVaultSecretPublisher("http://localhost:8200", "test"))
+	if _, err := GetSecretPublisher("vault"); err != nil {
+		t.Fatal("expected registered publisher to be found", err)
+	}
+	if _, err := GetSecretPublisher("unregistered"); err == nil {
+		t.Fatal("expected error for unregistered publisher")
+	}
+}
+
+func TestVaultSecretPublisher_PublishSecret(t *testing.T) {
+	var gotPath, gotToken string
+	var gotBody map[string]map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotToken = r.Header.Get("X-Vault-Token")
+		body, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotBody)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	p := NewVaultSecretPublisher(ts.URL, "test-token")
+	if err := p.PublishSecret(context.TODO(), "secret/data/test", map[string][]byte{"username": []byte("user")}); err != nil {
+		t.Fatal("unexpected error publishing secret", err)
+	}
+	if gotPath != "/v1/secret/data/test" {
+		t.Fatalf("unexpected request path %s", gotPath)
+	}
+	if gotToken != "test-token" {
+		t.Fatalf("unexpected vault token %s", gotToken)
+	}
+	if gotBody["data"]["username"] != "user" {
+		t.Fatalf("unexpected request body %+v", gotBody)
+	}
+}
+
+func TestVaultSecretPublisher_PublishSecretFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	p := NewVaultSecretPublisher(ts.URL, "test-token")
+	if err := p.PublishSecret(context.TODO(), "secret/data/test", map[string][]byte{}); err == nil {
+		t.Fatal("expected error on non 2xx response")
+	}
+}
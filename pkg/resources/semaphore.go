@@ -0,0 +1,70 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// ProvisioningConcurrencyConfigKey the operator configuration key holding the maximum
+	// number of simultaneous expensive provisioning operations (instance creations and
+	// modifications) per provider, a per provider override can be set by appending the upper
+	// cased provider scope, e.g. PROVISIONING_CONCURRENCY_RDS
+	ProvisioningConcurrencyConfigKey = "PROVISIONING_CONCURRENCY"
+
+	// the default cap keeps a bulk rollout of crs from tripping cloud api throttles while
+	// still allowing some parallelism
+	defaultProvisioningConcurrency = 2
+)
+
+var (
+	provisioningSlots = map[string]map[string]bool{}
+	provisioningLock  sync.Mutex
+)
+
+// ProvisioningConcurrency returns the maximum number of simultaneous expensive provisioning
+// operations for a provider scope, the operator wide and per scope overrides from the
+// operator configuration take precedence over the built in default
+func ProvisioningConcurrency(scope string) int {
+	limit := defaultProvisioningConcurrency
+	for _, key := range []string{ProvisioningConcurrencyConfigKey, fmt.Sprintf("%s_%s", ProvisioningConcurrencyConfigKey, strings.ToUpper(scope))} {
+		if value, found := LookupConfig(key); found {
+			if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+				limit = parsed
+			}
+		}
+	}
+	return limit
+}
+
+// AcquireProvisioningSlot reserves a slot for an expensive provisioning operation in the
+// provider scope, false is returned when the configured number of simultaneous operations is
+// already in flight and the reconcile should requeue instead of issuing the operation. The
+// acquire is re-entrant, a requeued reconcile of a cr already holding a slot re-enters it
+func AcquireProvisioningSlot(scope string, holder string) bool {
+	provisioningLock.Lock()
+	defer provisioningLock.Unlock()
+	holders := provisioningSlots[scope]
+	if holders == nil {
+		holders = map[string]bool{}
+		provisioningSlots[scope] = holders
+	}
+	if holders[holder] {
+		return true
+	}
+	if len(holders) >= ProvisioningConcurrency(scope) {
+		return false
+	}
+	holders[holder] = true
+	return true
+}
+
+// ReleaseProvisioningSlot releases the slot held by the cr once its expensive provisioning
+// operation is observed complete, a cr without a slot is left untouched
+func ReleaseProvisioningSlot(scope string, holder string) {
+	provisioningLock.Lock()
+	defer provisioningLock.Unlock()
+	delete(provisioningSlots[scope], holder)
+}
@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestProvisioningSlots(t *testing.T) {
+	resetSlots := func() {
+		provisioningLock.Lock()
+		defer provisioningLock.Unlock()
+		provisioningSlots = map[string]map[string]bool{}
+	}
+
+	t.Run("test slots are capped at the configured concurrency", func(t *testing.T) {
+		resetSlots()
+		for i := 0; i < defaultProvisioningConcurrency; i++ {
+			if !AcquireProvisioningSlot("rds", fmt.Sprintf("test/test-%d", i)) {
+				t.Fatalf("AcquireProvisioningSlot() holder %d refused below the concurrency limit", i)
+			}
+		}
+		if AcquireProvisioningSlot("rds", "test/test-overflow") {
+			t.Errorf("AcquireProvisioningSlot() granted a slot above the concurrency limit")
+		}
+	})
+
+	t.Run("test a holder re-enters its own slot", func(t *testing.T) {
+		resetSlots()
+		for i := 0; i < defaultProvisioningConcurrency; i++ {
+			AcquireProvisioningSlot("rds", fmt.Sprintf("test/test-%d", i))
+		}
+		if !AcquireProvisioningSlot("rds", "test/test-0") {
+			t.Errorf("AcquireProvisioningSlot() refused a holder already holding a slot")
+		}
+	})
+
+	t.Run("test releasing a slot frees it for another holder", func(t *testing.T) {
+		resetSlots()
+		for i := 0; i < defaultProvisioningConcurrency; i++ {
+			AcquireProvisioningSlot("rds", fmt.Sprintf("test/test-%d", i))
+		}
+		ReleaseProvisioningSlot("rds", "test/test-0")
+		if !AcquireProvisioningSlot("rds", "test/test-waiting") {
+			t.Errorf("AcquireProvisioningSlot() refused a slot after one was released")
+		}
+	})
+
+	t.Run("test scopes hold independent slots", func(t *testing.T) {
+		resetSlots()
+		for i := 0; i < defaultProvisioningConcurrency; i++ {
+			AcquireProvisioningSlot("rds", fmt.Sprintf("test/test-%d", i))
+		}
+		if !AcquireProvisioningSlot("elasticache", "test/test") {
+			t.Errorf("AcquireProvisioningSlot() refused a slot in an unrelated scope")
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	errorUtil "github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SmokeTestConfigKey enables the post provisioning smoke test job when set to true
+	SmokeTestConfigKey = "SMOKE_TEST"
+	// SmokeTestImageConfigKeyPrefix overrides the image the smoke test job of a resource
+	// type runs with, the prefix is suffixed with the upper cased resource type,
+	// e.g. SMOKE_TEST_IMAGE_POSTGRES
+	SmokeTestImageConfigKeyPrefix = "SMOKE_TEST_IMAGE_"
+	// ConditionTypeVerified records the result of the smoke test job run after provisioning
+	ConditionTypeVerified = "Verified"
+	// the smoke test job is expected to finish in seconds, a job still running after this
+	// deadline is failed by kubernetes and reported as a failed verification
+	smokeTestDeadlineSeconds = int64(300)
+	smokeTestBackoffLimit    = int32(2)
+)
+
+// default images of the smoke test job per resource type, each provides the client tooling
+// the verification command of the type needs
+var smokeTestImages = map[string]string{
+	"postgres":    "registry.redhat.io/rhscl/postgresql-10-rhel7",
+	"redis":       "registry.redhat.io/rhscl/redis-32-rhel7",
+	"blobstorage": "amazon/aws-cli",
+}
+
+// verification commands per resource type, the connection secret of the resource is exposed
+// to the job through environment variables so the commands reference its keys directly
+var smokeTestCommands = map[string]string{
+	"postgres":    "PGPASSWORD=$password psql --host=$host --port=$port --username=$username --dbname=$database --command='SELECT 1'",
+	"redis":       "redis-cli -h $uri -p $port ping",
+	"blobstorage": "export AWS_ACCESS_KEY_ID=$credentialKeyID AWS_SECRET_ACCESS_KEY=$credentialSecretKey; aws --region $bucketRegion s3api put-object --bucket $bucketName --key cro-smoke-test && aws --region $bucketRegion s3api get-object --bucket $bucketName --key cro-smoke-test /tmp/cro-smoke-test && aws --region $bucketRegion s3api delete-object --bucket $bucketName --key cro-smoke-test",
+}
+
+// SmokeTestEnabled returns true when the post provisioning smoke test job is enabled
+// through the operator config and a verification command exists for the resource type
+func SmokeTestEnabled(resourceType string) bool {
+	val, ok := LookupConfig(SmokeTestConfigKey)
+	return ok && val == "true" && smokeTestCommands[resourceType] != ""
+}
+
+// ReconcileSmokeTestJob ensures the verification job for a provisioned resource exists and
+// returns the verified condition once the job has finished, nil is returned while the job
+// is still running. The job connects to the resource from inside the cluster, catching
+// network path misconfigurations the cloud api cannot see. Finished jobs are removed, the
+// result lives on as the condition on the cr
+func ReconcileSmokeTestJob(ctx context.Context, c client.Client, resourceType, namespace, secretName string, resourceName string) (*croType.StatusCondition, error) {
+	jobName := fmt.Sprintf("%s-smoke-test", resourceName)
+	job := &batchv1.Job{}
+	if err := c.Get(ctx, k8sTypes.NamespacedName{Namespace: namespace, Name: jobName}, job); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, errorUtil.Wrap(err, "failed to get smoke test job")
+		}
+		if err := c.Create(ctx, buildSmokeTestJob(jobName, namespace, resourceType, secretName)); err != nil {
+			return nil, errorUtil.Wrap(err, "failed to create smoke test job")
+		}
+		return nil, nil
+	}
+	condition := buildVerifiedCondition(job, resourceType)
+	if condition == nil {
+		return nil, nil
+	}
+	if err := c.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+		return nil, errorUtil.Wrap(err, "failed to remove finished smoke test job")
+	}
+	return condition, nil
+}
+
+// buildVerifiedCondition maps the terminal condition of a finished smoke test job to the
+// verified condition of the cr, nil is returned while the job has no terminal condition
+func buildVerifiedCondition(job *batchv1.Job, resourceType string) *croType.StatusCondition {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return &croType.StatusCondition{
+				Type:    ConditionTypeVerified,
+				Status:  "True",
+				Message: fmt.Sprintf("%s smoke test succeeded", resourceType),
+			}
+		case batchv1.JobFailed:
+			return &croType.StatusCondition{
+				Type:    ConditionTypeVerified,
+				Status:  "False",
+				Message: fmt.Sprintf("%s smoke test failed: %s", resourceType, c.Message),
+			}
+		}
+	}
+	return nil
+}
+
+func buildSmokeTestJob(name, namespace, resourceType, secretName string) *batchv1.Job {
+	image := smokeTestImages[resourceType]
+	if override, ok := LookupConfig(SmokeTestImageConfigKeyPrefix + strings.ToUpper(resourceType)); ok {
+		image = override
+	}
+	backoffLimit := smokeTestBackoffLimit
+	deadline := smokeTestDeadlineSeconds
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &deadline,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:    "smoke-test",
+							Image:   image,
+							Command: []string{"/bin/sh", "-c", smokeTestCommands[resourceType]},
+							EnvFrom: []corev1.EnvFromSource{
+								{
+									SecretRef: &corev1.SecretEnvSource{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: secretName,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package resources
+
+import (
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSmokeTestEnabled(t *testing.T) {
+	cases := []struct {
+		name         string
+		config       map[string]string
+		resourceType string
+		expected     bool
+	}{
+		{
+			name:         "test enabled for a supported resource type",
+			config:       map[string]string{SmokeTestConfigKey: "true"},
+			resourceType: "postgres",
+			expected:     true,
+		},
+		{
+			name:         "test disabled by default",
+			resourceType: "postgres",
+			expected:     false,
+		},
+		{
+			name:         "test disabled for an unsupported resource type",
+			config:       map[string]string{SmokeTestConfigKey: "true"},
+			resourceType: "unknown",
+			expected:     false,
+		},
+		{
+			name:         "test config value must be true",
+			config:       map[string]string{SmokeTestConfigKey: "yes"},
+			resourceType: "postgres",
+			expected:     false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetOperatorConfig(tc.config)
+			defer SetOperatorConfig(nil)
+			if got := SmokeTestEnabled(tc.resourceType); got != tc.expected {
+				t.Errorf("SmokeTestEnabled() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBuildVerifiedCondition(t *testing.T) {
+	cases := []struct {
+		name           string
+		conditions     []batchv1.JobCondition
+		expectedNil    bool
+		expectedStatus string
+	}{
+		{
+			name: "test a completed job verifies the resource",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			},
+			expectedStatus: "True",
+		},
+		{
+			name: "test a failed job fails the verification",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "deadline exceeded"},
+			},
+			expectedStatus: "False",
+		},
+		{
+			name:        "test a running job has no result",
+			expectedNil: true,
+		},
+		{
+			name: "test terminal conditions must be true",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionFalse},
+			},
+			expectedNil: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			condition := buildVerifiedCondition(&batchv1.Job{
+				Status: batchv1.JobStatus{Conditions: tc.conditions},
+			}, "postgres")
+			if (condition == nil) != tc.expectedNil {
+				t.Fatalf("buildVerifiedCondition() = %v, expectedNil %v", condition, tc.expectedNil)
+			}
+			if condition != nil {
+				if condition.Type != ConditionTypeVerified {
+					t.Errorf("buildVerifiedCondition() type = %s, want %s", condition.Type, ConditionTypeVerified)
+				}
+				if condition.Status != tc.expectedStatus {
+					t.Errorf("buildVerifiedCondition() status = %s, want %s", condition.Status, tc.expectedStatus)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+)
+
+const (
+	// ConditionTypeDegraded the condition set when a resource has stayed in an in-flight
+	// provisioning stage for longer than the configured timeout
+	ConditionTypeDegraded = "Degraded"
+
+	// StageTimeoutConfigKey the operator configuration key holding the timeout for in-flight
+	// provisioning stages, parsed as a go duration e.g. 90m. A per stage override can be set
+	// by appending the upper cased stage name, e.g. STAGE_TIMEOUT_PROVISIONING
+	StageTimeoutConfigKey = "STAGE_TIMEOUT"
+)
+
+// defaultStageTimeouts how long a resource may stay in an in-flight stage before it is
+// reported as stuck, stages without an entry are never subject to a timeout
+var defaultStageTimeouts = map[croType.StatusStage]time.Duration{
+	croType.StageModifyingNetwork: time.Hour,
+	croType.StageProvisioning:     2 * time.Hour,
+	croType.StageDeleting:         2 * time.Hour,
+}
+
+// StageTimeout returns the timeout for a provisioning stage, operator wide and per stage
+// overrides from the operator configuration take precedence over the built in defaults. A
+// zero duration disables the timeout for the stage
+func StageTimeout(stage croType.StatusStage) time.Duration {
+	timeout, ok := defaultStageTimeouts[stage]
+	if !ok {
+		return 0
+	}
+	for _, key := range []string{StageTimeoutConfigKey, fmt.Sprintf("%s_%s", StageTimeoutConfigKey, strings.ToUpper(string(stage)))} {
+		if value, found := LookupConfig(key); found {
+			if parsed, err := time.ParseDuration(value); err == nil {
+				timeout = parsed
+			}
+		}
+	}
+	return timeout
+}
+
+// TimeInStage returns how long the resource has been in its current stage based on the
+// recorded stage transitions, the zero duration is returned when no transition time was
+// recorded for the stage
+func TimeInStage(status *croType.ResourceTypeStatus) time.Duration {
+	for i := len(status.StageHistory) - 1; i >= 0; i-- {
+		transition := status.StageHistory[i]
+		if transition.Stage == status.Stage && !transition.StartedAt.IsZero() {
+			return time.Since(transition.StartedAt.Time)
+		}
+	}
+	return 0
+}
+
+// BuildStuckCondition builds the degraded status condition for a resource from the time it
+// has spent in its current stage and the timeout configured for that stage
+func BuildStuckCondition(stage croType.StatusStage, elapsed, timeout time.Duration) croType.StatusCondition {
+	status := conditionStatusFalse
+	message := fmt.Sprintf("resource is progressing through stage %s", stage)
+	if elapsed > timeout {
+		status = conditionStatusTrue
+		message = fmt.Sprintf("resource has been in stage %s for %s, exceeding the configured timeout of %s", stage, elapsed.Round(time.Second), timeout)
+	}
+	return croType.StatusCondition{
+		Type:    ConditionTypeDegraded,
+		Status:  status,
+		Message: message,
+	}
+}
+
+// SetResourceStuckMetric exposes whether a resource has exceeded the timeout for its current
+// stage so stuck provisioning and deletion can be alerted on, a value of 1 indicates the
+// resource is stuck
+func SetResourceStuckMetric(resourceType string, name string, namespace string, stage croType.StatusStage, stuck bool) {
+	value := float64(0)
+	if stuck {
+		value = 1
+	}
+	SetMetric(DefaultResourceStuckMetricName, map[string]string{
+		"resourceType": resourceType,
+		"name":         name,
+		"namespace":    namespace,
+		"stage":        string(stage),
+	}, value)
+}
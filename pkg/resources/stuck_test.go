@@ -0,0 +1,143 @@
+package resources
+
+import (
+	"testing"
+	"time"
+
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStageTimeout(t *testing.T) {
+	type args struct {
+		stage  croType.StatusStage
+		config map[string]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want time.Duration
+	}{
+		{
+			name: "test built in default is used for in-flight stages",
+			args: args{stage: croType.StageProvisioning},
+			want: 2 * time.Hour,
+		},
+		{
+			name: "test stages without a default are not subject to a timeout",
+			args: args{stage: croType.StageReady},
+			want: 0,
+		},
+		{
+			name: "test operator wide override applies to all in-flight stages",
+			args: args{
+				stage:  croType.StageModifyingNetwork,
+				config: map[string]string{StageTimeoutConfigKey: "30m"},
+			},
+			want: 30 * time.Minute,
+		},
+		{
+			name: "test per stage override takes precedence over the operator wide override",
+			args: args{
+				stage: croType.StageDeleting,
+				config: map[string]string{
+					StageTimeoutConfigKey:               "30m",
+					StageTimeoutConfigKey + "_DELETING": "4h",
+				},
+			},
+			want: 4 * time.Hour,
+		},
+		{
+			name: "test invalid override falls back to the default",
+			args: args{
+				stage:  croType.StageProvisioning,
+				config: map[string]string{StageTimeoutConfigKey: "not a duration"},
+			},
+			want: 2 * time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetOperatorConfig(tt.args.config)
+			defer SetOperatorConfig(nil)
+			if got := StageTimeout(tt.args.stage); got != tt.want {
+				t.Errorf("StageTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeInStage(t *testing.T) {
+	type args struct {
+		status *croType.ResourceTypeStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantMin time.Duration
+	}{
+		{
+			name:    "test zero duration when no transitions are recorded",
+			args:    args{status: &croType.ResourceTypeStatus{Stage: croType.StageProvisioning}},
+			wantMin: 0,
+		},
+		{
+			name: "test duration is measured from the latest transition into the current stage",
+			args: args{
+				status: &croType.ResourceTypeStatus{
+					Stage: croType.StageProvisioning,
+					StageHistory: []croType.StageTransition{
+						{Stage: croType.StagePending, StartedAt: metav1.NewTime(time.Now().Add(-2 * time.Hour))},
+						{Stage: croType.StageProvisioning, StartedAt: metav1.NewTime(time.Now().Add(-time.Hour))},
+					},
+				},
+			},
+			wantMin: time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TimeInStage(tt.args.status)
+			if got < tt.wantMin {
+				t.Errorf("TimeInStage() = %v, want at least %v", got, tt.wantMin)
+			}
+			if tt.wantMin == 0 && got != 0 {
+				t.Errorf("TimeInStage() = %v, want 0", got)
+			}
+		})
+	}
+}
+
+func TestBuildStuckCondition(t *testing.T) {
+	type args struct {
+		elapsed time.Duration
+		timeout time.Duration
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStatus string
+	}{
+		{
+			name:       "test condition is false while within the timeout",
+			args:       args{elapsed: time.Minute, timeout: time.Hour},
+			wantStatus: conditionStatusFalse,
+		},
+		{
+			name:       "test condition is true once the timeout is exceeded",
+			args:       args{elapsed: 2 * time.Hour, timeout: time.Hour},
+			wantStatus: conditionStatusTrue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildStuckCondition(croType.StageProvisioning, tt.args.elapsed, tt.args.timeout)
+			if got.Type != ConditionTypeDegraded {
+				t.Errorf("BuildStuckCondition() type = %v, want %v", got.Type, ConditionTypeDegraded)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("BuildStuckCondition() status = %v, want %v", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
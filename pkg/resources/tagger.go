@@ -0,0 +1,110 @@
+package resources
+
+import (
+	"context"
+	"sort"
+
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+
+	errorUtil "github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultTagConfigMapName the operator level config map containing user supplied tags,
+// data entries in the config map are applied directly as tags on every created cloud resource
+const DefaultTagConfigMapName = "cloud-resource-tags"
+
+// Tag is a generic cross provider key value tag, converted to the provider specific tag
+// type before being applied to a cloud resource
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// BuildDefaultResourceTags builds the cross provider tag set that is applied to every created
+// cloud resource, combining the organization, cluster id, resource type, resource name and
+// product tags with any user supplied tags from the operator level tag config map
+func BuildDefaultResourceTags(ctx context.Context, c client.Client, specType string, om metav1.ObjectMeta) ([]*Tag, error) {
+	organizationTag := GetOrganizationTag()
+	clusterID, err := GetClusterID(ctx, c)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to get cluster id")
+	}
+	tags := []*Tag{
+		{
+			Key:   organizationTag + "clusterID",
+			Value: clusterID,
+		},
+		{
+			Key:   organizationTag + "resource-type",
+			Value: specType,
+		},
+		{
+			Key:   organizationTag + "resource-name",
+			Value: om.Name,
+		},
+	}
+	if om.Labels["productName"] != "" {
+		tags = append(tags, &Tag{
+			Key:   organizationTag + "product-name",
+			Value: om.Labels["productName"],
+		})
+	}
+	userTags, err := getUserResourceTags(ctx, c)
+	if err != nil {
+		return nil, errorUtil.Wrap(err, "failed to get user resource tags")
+	}
+	return MergeResourceTags(tags, userTags), nil
+}
+
+// MergeResourceTags merges user supplied tags into the default tag set, default tags take
+// precedence over user tags with the same key, user tags are appended in key order to keep
+// the built tag set deterministic
+func MergeResourceTags(tags []*Tag, userTags map[string]string) []*Tag {
+	userTagKeys := make([]string, 0, len(userTags))
+	for key := range userTags {
+		userTagKeys = append(userTagKeys, key)
+	}
+	sort.Strings(userTagKeys)
+	for _, key := range userTagKeys {
+		if tagKeysContain(tags, key) {
+			continue
+		}
+		tags = append(tags, &Tag{
+			Key:   key,
+			Value: userTags[key],
+		})
+	}
+	return tags
+}
+
+// getUserResourceTags reads user supplied tags from the operator level tag config map, a
+// missing config map is treated as no user tags being defined
+func getUserResourceTags(ctx context.Context, c client.Client) (map[string]string, error) {
+	namespace, err := k8sutil.GetWatchNamespace()
+	if err != nil {
+		// treat a missing watch namespace as no user tags being defined
+		return nil, nil
+	}
+	cm := &v1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: DefaultTagConfigMapName, Namespace: namespace}, cm); err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errorUtil.Wrapf(err, "failed to get tag config map %s in namespace %s", DefaultTagConfigMapName, namespace)
+	}
+	return cm.Data, nil
+}
+
+func tagKeysContain(tags []*Tag, key string) bool {
+	for _, tag := range tags {
+		if tag.Key == key {
+			return true
+		}
+	}
+	return false
+}
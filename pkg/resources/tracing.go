@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// EnableTracing turns on always-on span sampling and registers the log span exporter, spans
+// cover each reconcile, the provider action it runs and the individual cloud api calls made,
+// additional exporters (e.g. an otlp agent) can be registered through trace.RegisterExporter
+func EnableTracing() {
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.AlwaysSample()})
+	trace.RegisterExporter(&logSpanExporter{})
+}
+
+// StartSpan starts a span with the given name, child spans started from the returned context
+// are parented to it
+func StartSpan(ctx context.Context, name string) (context.Context, *trace.Span) {
+	return trace.StartSpan(ctx, name)
+}
+
+// logSpanExporter logs completed spans with their duration so slow reconciles and cloud api
+// calls can be traced without an external tracing backend
+type logSpanExporter struct{}
+
+func (e *logSpanExporter) ExportSpan(sd *trace.SpanData) {
+	logrus.WithFields(logrus.Fields{
+		"span":       sd.Name,
+		"traceID":    sd.TraceID.String(),
+		"durationMs": float64(sd.EndTime.Sub(sd.StartTime)) / float64(time.Millisecond),
+	}).Debug("span completed")
+}
@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"fmt"
+	"time"
+
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+	errorUtil "github.com/pkg/errors"
+)
+
+const (
+	// ConditionTypeTTLExpiring the condition set when a resource with a ttl on its spec is
+	// within the warning window of its expiry, or has expired
+	ConditionTypeTTLExpiring = "TTLExpiring"
+
+	// how long before the ttl expiry a resource is reported as nearing expiry, giving teams
+	// a chance to extend the ttl before the resource is deleted
+	ttlExpiryWarningWindow = 6 * time.Hour
+)
+
+// ResourceTTLExpiry returns the time a resource with the given ttl expires, the ttl is parsed
+// as a go duration and counted from the creation time of the cr
+func ResourceTTLExpiry(ttl string, createdAt time.Time) (time.Time, error) {
+	duration, err := time.ParseDuration(ttl)
+	if err != nil {
+		return time.Time{}, errorUtil.Wrapf(err, "failed to parse ttl %q on cr as a duration", ttl)
+	}
+	if duration <= 0 {
+		return time.Time{}, errorUtil.Errorf("ttl %q on cr must be a positive duration", ttl)
+	}
+	return createdAt.Add(duration), nil
+}
+
+// ResourceTTLNearingExpiry returns true when the resource is within the warning window of its
+// expiry
+func ResourceTTLNearingExpiry(expiry time.Time, now time.Time) bool {
+	return now.Before(expiry) && expiry.Sub(now) <= ttlExpiryWarningWindow
+}
+
+// BuildTTLExpiringCondition builds the ttl status condition for a resource from its expiry
+// time, the condition is true from the start of the warning window until the resource is
+// deleted
+func BuildTTLExpiringCondition(expiry time.Time, now time.Time) croType.StatusCondition {
+	status := conditionStatusFalse
+	message := fmt.Sprintf("resource expires at %s", expiry.Format(time.RFC3339))
+	switch {
+	case !now.Before(expiry):
+		status = conditionStatusTrue
+		message = fmt.Sprintf("resource ttl expired at %s, the resource will be deleted", expiry.Format(time.RFC3339))
+	case ResourceTTLNearingExpiry(expiry, now):
+		status = conditionStatusTrue
+		message = fmt.Sprintf("resource expires in %s at %s", expiry.Sub(now).Round(time.Minute), expiry.Format(time.RFC3339))
+	}
+	return croType.StatusCondition{
+		Type:    ConditionTypeTTLExpiring,
+		Status:  status,
+		Message: message,
+	}
+}
+
+// SetResourceTTLNearingExpiryMetric exposes whether a resource is within the warning window of
+// its ttl expiry so upcoming automatic deletions can be alerted on, a value of 1 indicates the
+// resource is nearing expiry
+func SetResourceTTLNearingExpiryMetric(resourceType string, name string, namespace string, nearing bool) {
+	value := float64(0)
+	if nearing {
+		value = 1
+	}
+	SetMetric(DefaultResourceTTLNearingExpiryMetricName, map[string]string{
+		"resourceType": resourceType,
+		"name":         name,
+		"namespace":    namespace,
+	}, value)
+}
@@ -0,0 +1,79 @@
+package resources
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResourceTTLExpiry(t *testing.T) {
+	createdAt := time.Date(2026, time.August, 19, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name    string
+		ttl     string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "test ttl is counted from the creation time",
+			ttl:  "72h",
+			want: createdAt.Add(72 * time.Hour),
+		},
+		{
+			name:    "test non duration ttl errors",
+			ttl:     "3 days",
+			wantErr: true,
+		},
+		{
+			name:    "test negative ttl errors",
+			ttl:     "-1h",
+			wantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ResourceTTLExpiry(tc.ttl, createdAt)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ResourceTTLExpiry() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && !got.Equal(tc.want) {
+				t.Errorf("ResourceTTLExpiry() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildTTLExpiringCondition(t *testing.T) {
+	expiry := time.Date(2026, time.August, 19, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name       string
+		now        time.Time
+		wantStatus string
+	}{
+		{
+			name:       "test false outside the warning window",
+			now:        expiry.Add(-48 * time.Hour),
+			wantStatus: "False",
+		},
+		{
+			name:       "test true inside the warning window",
+			now:        expiry.Add(-time.Hour),
+			wantStatus: "True",
+		},
+		{
+			name:       "test true once expired",
+			now:        expiry.Add(time.Minute),
+			wantStatus: "True",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			condition := BuildTTLExpiringCondition(expiry, tc.now)
+			if condition.Type != ConditionTypeTTLExpiring {
+				t.Errorf("BuildTTLExpiringCondition() type = %s, want %s", condition.Type, ConditionTypeTTLExpiring)
+			}
+			if condition.Status != tc.wantStatus {
+				t.Errorf("BuildTTLExpiringCondition() status = %s, want %s", condition.Status, tc.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// EnvVaultAddr the address of the vault server credentials are published to, the vault
+	// publisher is only registered when this is set
+	EnvVaultAddr = "VAULT_ADDR"
+	// EnvVaultToken the token used to authenticate against the vault server
+	EnvVaultToken = "VAULT_TOKEN"
+
+	vaultSecretPublisherName = "vault"
+	vaultTokenHeader         = "X-Vault-Token" //#nosec G101 -- header name, not a credential
+	vaultRequestTimeout      = time.Second * 10
+)
+
+// VaultSecretPublisher publishes connection credentials to a hashicorp vault kv version 2
+// secrets engine over its http api, the path on the cr must include the data segment of the
+// mount, e.g. secret/data/my-postgres
+type VaultSecretPublisher struct {
+	Address    string
+	Token      string
+	HTTPClient *http.Client
+}
+
+var _ SecretPublisher = (*VaultSecretPublisher)(nil)
+
+func NewVaultSecretPublisher(address, token string) *VaultSecretPublisher {
+	return &VaultSecretPublisher{
+		Address: strings.TrimSuffix(address, "/"),
+		Token:   token,
+		HTTPClient: &http.Client{
+			Timeout: vaultRequestTimeout,
+		},
+	}
+}
+
+func (p *VaultSecretPublisher) GetName() string {
+	return vaultSecretPublisherName
+}
+
+// PublishSecret writes the credentials to the vault server under the given path, the kv
+// version 2 engine wraps the key value pairs in a data object
+func (p *VaultSecretPublisher) PublishSecret(ctx context.Context, path string, data map[string][]byte) error {
+	kvData := map[string]string{}
+	for k, v := range data {
+		kvData[k] = string(v)
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"data": kvData,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal credentials for vault")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/v1/%s", p.Address, strings.TrimPrefix(path, "/")), bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to build vault request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set(vaultTokenHeader, p.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to publish credentials to vault")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d publishing credentials to vault path %s", resp.StatusCode, path)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Package webhook holds the admission webhooks served by the operator webhook server.
+// Registration is opt in from the operator entry point as serving certificates must be
+// mounted into the operator pod.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// DefaultTierConfigKey the operator configuration key holding the tier applied to crs
+	// created without one
+	DefaultTierConfigKey = "DEFAULT_TIER"
+	// DefaultTypeConfigKey the operator configuration key holding the deployment type applied
+	// to crs created without one
+	DefaultTypeConfigKey = "DEFAULT_TYPE"
+)
+
+// the operator configuration key consulted for each defaultable spec field
+var defaultConfigKeys = map[string]string{
+	"tier": DefaultTierConfigKey,
+	"type": DefaultTypeConfigKey,
+}
+
+// ResourceDefaulter fills in spec.tier and spec.type on postgres, redis and blobstorage crs
+// when they are omitted, from the default-tier and default-type namespace labels (prefixed
+// with the organization tag, e.g. integreatly.org/default-tier) falling back to the operator
+// configuration, so application templates do not need to hardcode environment specific tiers
+type ResourceDefaulter struct {
+	Client client.Client
+}
+
+var _ admission.Handler = &ResourceDefaulter{}
+
+// Handle defaults the omitted spec fields of the admitted cr, the cr is admitted unchanged
+// when neither the namespace labels nor the operator configuration provide a default
+func (d *ResourceDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	// the spec shape is shared by all cro kinds so the cr is mutated as raw json rather than
+	// decoding into a per kind type, unknown fields survive the round trip untouched
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	spec, ok := obj["spec"].(map[string]interface{})
+	if !ok {
+		spec = map[string]interface{}{}
+		obj["spec"] = spec
+	}
+	var missing []string
+	for field := range defaultConfigKeys {
+		if value, _ := spec[field].(string); value == "" {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) == 0 {
+		return admission.Allowed("")
+	}
+	ns := &corev1.Namespace{}
+	if err := d.Client.Get(ctx, k8sTypes.NamespacedName{Name: req.Namespace}, ns); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	mutated := false
+	for _, field := range missing {
+		if value := defaultForField(ns.Labels, field); value != "" {
+			spec[field] = value
+			mutated = true
+		}
+	}
+	if !mutated {
+		return admission.Allowed("")
+	}
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// the namespace label takes precedence over the operator wide configuration default
+func defaultForField(nsLabels map[string]string, field string) string {
+	if value := nsLabels[fmt.Sprintf("%sdefault-%s", resources.GetOrganizationTag(), field)]; value != "" {
+		return value
+	}
+	if value, found := resources.LookupConfig(defaultConfigKeys[field]); found && value != "" {
+		return value
+	}
+	return ""
+}
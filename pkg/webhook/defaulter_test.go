@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/resources"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func buildTestNamespace(labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test",
+			Labels: labels,
+		},
+	}
+}
+
+func buildTestRequest(rawObject string) admission.Request {
+	return admission.Request{
+		AdmissionRequest: admissionv1beta1.AdmissionRequest{
+			Namespace: "test",
+			Object:    runtime.RawExtension{Raw: []byte(rawObject)},
+		},
+	}
+}
+
+func TestResourceDefaulter_Handle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal("failed to build scheme", err)
+	}
+	type args struct {
+		namespace *corev1.Namespace
+		config    map[string]string
+		rawObject string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantPatches int
+		wantTier    string
+	}{
+		{
+			name: "test omitted tier and type are defaulted from namespace labels",
+			args: args{
+				namespace: buildTestNamespace(map[string]string{
+					"integreatly.org/default-tier": "development",
+					"integreatly.org/default-type": "workers",
+				}),
+				rawObject: `{"spec":{"secretRef":{"name":"test"}}}`,
+			},
+			wantPatches: 2,
+		},
+		{
+			name: "test the operator config is used when the namespace carries no labels",
+			args: args{
+				namespace: buildTestNamespace(nil),
+				config: map[string]string{
+					DefaultTierConfigKey: "production",
+					DefaultTypeConfigKey: "workers",
+				},
+				rawObject: `{"spec":{"secretRef":{"name":"test"}}}`,
+			},
+			wantPatches: 2,
+		},
+		{
+			name: "test a namespace label takes precedence over the operator config",
+			args: args{
+				namespace: buildTestNamespace(map[string]string{
+					"integreatly.org/default-tier": "development",
+					"integreatly.org/default-type": "workers",
+				}),
+				config: map[string]string{
+					DefaultTierConfigKey: "production",
+				},
+				rawObject: `{"spec":{"tier":"","type":""}}`,
+			},
+			wantPatches: 2,
+			wantTier:    "development",
+		},
+		{
+			name: "test a cr with tier and type set is admitted unchanged",
+			args: args{
+				namespace: buildTestNamespace(map[string]string{
+					"integreatly.org/default-tier": "development",
+				}),
+				rawObject: `{"spec":{"tier":"production","type":"workers"}}`,
+			},
+			wantPatches: 0,
+		},
+		{
+			name: "test a cr is admitted unchanged when no default is configured",
+			args: args{
+				namespace: buildTestNamespace(nil),
+				rawObject: `{"spec":{}}`,
+			},
+			wantPatches: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources.SetOperatorConfig(tt.args.config)
+			defer resources.SetOperatorConfig(nil)
+			d := &ResourceDefaulter{
+				Client: fake.NewFakeClientWithScheme(scheme, tt.args.namespace),
+			}
+			resp := d.Handle(context.TODO(), buildTestRequest(tt.args.rawObject))
+			if !resp.Allowed {
+				t.Fatalf("Handle() response not allowed, %v", resp.Result)
+			}
+			if len(resp.Patches) != tt.wantPatches {
+				t.Errorf("Handle() returned %d patches, want %d, patches %v", len(resp.Patches), tt.wantPatches, resp.Patches)
+			}
+			if tt.wantTier != "" {
+				for _, patch := range resp.Patches {
+					if patch.Path == "/spec/tier" && patch.Value != tt.wantTier {
+						t.Errorf("Handle() patched tier to %v, want %s", patch.Value, tt.wantTier)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Package conformance provides a shared contract test suite for the provider interfaces.
+// Every PostgresProvider, RedisProvider and BlobStorageProvider implementation is expected
+// to behave the same way at the interface boundary, creates are idempotent, repeated
+// deletes are graceful, connection details carry the canonical secret shape and only the
+// declared strategies are supported. Provider packages run the suite from their own tests
+// with their fixtures and mocks, keeping behaviour consistent across providers without a
+// live cloud account.
+package conformance
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+)
+
+// the secret keys every postgres provider must publish, applications consume the
+// connection secret by these names regardless of the provider that filled it
+var postgresRequiredSecretKeys = []string{"username", "password", "host", "database", "port"}
+
+// the secret keys every redis provider must publish
+var redisRequiredSecretKeys = []string{"uri", "port"}
+
+// RunPostgresProviderConformance runs the provider contract against a postgres provider,
+// setup returns a freshly wired provider and a cr the provider can serve to completion
+func RunPostgresProviderConformance(t *testing.T, setup func() (providers.PostgresProvider, *v1alpha1.Postgres)) {
+	ctx := context.TODO()
+	t.Run("test the provider reports a name and honours its strategies", func(t *testing.T) {
+		p, _ := setup()
+		if p.GetName() == "" {
+			t.Fatalf("expected provider to report a name")
+		}
+		if p.SupportsStrategy("unsupported-test-strategy") {
+			t.Fatalf("expected provider to reject an unknown strategy")
+		}
+	})
+	t.Run("test create is idempotent", func(t *testing.T) {
+		p, cr := setup()
+		first, _, err := p.CreatePostgres(ctx, cr)
+		if err != nil {
+			t.Fatalf("unexpected error on first create: %v", err)
+		}
+		second, _, err := p.CreatePostgres(ctx, cr)
+		if err != nil {
+			t.Fatalf("unexpected error on repeated create: %v", err)
+		}
+		if first != nil && second != nil && !reflect.DeepEqual(first.DeploymentDetails.Data(), second.DeploymentDetails.Data()) {
+			t.Fatalf("expected repeated creates to return the same details, got %v and %v", first.DeploymentDetails.Data(), second.DeploymentDetails.Data())
+		}
+	})
+	t.Run("test connection details carry the canonical secret shape", func(t *testing.T) {
+		p, cr := setup()
+		instance, _, err := p.CreatePostgres(ctx, cr)
+		if err != nil {
+			t.Fatalf("unexpected error on create: %v", err)
+		}
+		if instance == nil {
+			t.Fatalf("expected the fixture to provision to completion, no details returned")
+		}
+		assertSecretShape(t, instance.DeploymentDetails.Data(), postgresRequiredSecretKeys)
+	})
+	t.Run("test repeated delete is graceful", func(t *testing.T) {
+		p, cr := setup()
+		if _, _, err := p.CreatePostgres(ctx, cr); err != nil {
+			t.Fatalf("unexpected error on create: %v", err)
+		}
+		if _, err := p.DeletePostgres(ctx, cr); err != nil {
+			t.Fatalf("unexpected error on first delete: %v", err)
+		}
+		if _, err := p.DeletePostgres(ctx, cr); err != nil {
+			t.Fatalf("unexpected error on repeated delete: %v", err)
+		}
+	})
+}
+
+// RunRedisProviderConformance runs the provider contract against a redis provider, setup
+// returns a freshly wired provider and a cr the provider can serve to completion
+func RunRedisProviderConformance(t *testing.T, setup func() (providers.RedisProvider, *v1alpha1.Redis)) {
+	ctx := context.TODO()
+	t.Run("test the provider reports a name and honours its strategies", func(t *testing.T) {
+		p, _ := setup()
+		if p.GetName() == "" {
+			t.Fatalf("expected provider to report a name")
+		}
+		if p.SupportsStrategy("unsupported-test-strategy") {
+			t.Fatalf("expected provider to reject an unknown strategy")
+		}
+	})
+	t.Run("test create is idempotent", func(t *testing.T) {
+		p, cr := setup()
+		first, _, err := p.CreateRedis(ctx, cr)
+		if err != nil {
+			t.Fatalf("unexpected error on first create: %v", err)
+		}
+		second, _, err := p.CreateRedis(ctx, cr)
+		if err != nil {
+			t.Fatalf("unexpected error on repeated create: %v", err)
+		}
+		if first != nil && second != nil && !reflect.DeepEqual(first.DeploymentDetails.Data(), second.DeploymentDetails.Data()) {
+			t.Fatalf("expected repeated creates to return the same details, got %v and %v", first.DeploymentDetails.Data(), second.DeploymentDetails.Data())
+		}
+	})
+	t.Run("test connection details carry the canonical secret shape", func(t *testing.T) {
+		p, cr := setup()
+		cluster, _, err := p.CreateRedis(ctx, cr)
+		if err != nil {
+			t.Fatalf("unexpected error on create: %v", err)
+		}
+		if cluster == nil {
+			t.Fatalf("expected the fixture to provision to completion, no details returned")
+		}
+		assertSecretShape(t, cluster.DeploymentDetails.Data(), redisRequiredSecretKeys)
+	})
+	t.Run("test repeated delete is graceful", func(t *testing.T) {
+		p, cr := setup()
+		if _, _, err := p.CreateRedis(ctx, cr); err != nil {
+			t.Fatalf("unexpected error on create: %v", err)
+		}
+		if _, err := p.DeleteRedis(ctx, cr); err != nil {
+			t.Fatalf("unexpected error on first delete: %v", err)
+		}
+		if _, err := p.DeleteRedis(ctx, cr); err != nil {
+			t.Fatalf("unexpected error on repeated delete: %v", err)
+		}
+	})
+}
+
+// RunBlobStorageProviderConformance runs the provider contract against a blob storage
+// provider. Unlike postgres and redis the secret shape of blob storage differs per
+// provider, so the keys the implementation must publish are passed by the caller
+func RunBlobStorageProviderConformance(t *testing.T, setup func() (providers.BlobStorageProvider, *v1alpha1.BlobStorage), requiredSecretKeys []string) {
+	ctx := context.TODO()
+	t.Run("test the provider reports a name and honours its strategies", func(t *testing.T) {
+		p, _ := setup()
+		if p.GetName() == "" {
+			t.Fatalf("expected provider to report a name")
+		}
+		if p.SupportsStrategy("unsupported-test-strategy") {
+			t.Fatalf("expected provider to reject an unknown strategy")
+		}
+	})
+	t.Run("test create is idempotent", func(t *testing.T) {
+		p, cr := setup()
+		first, _, err := p.CreateStorage(ctx, cr)
+		if err != nil {
+			t.Fatalf("unexpected error on first create: %v", err)
+		}
+		second, _, err := p.CreateStorage(ctx, cr)
+		if err != nil {
+			t.Fatalf("unexpected error on repeated create: %v", err)
+		}
+		if first != nil && second != nil && !reflect.DeepEqual(first.DeploymentDetails.Data(), second.DeploymentDetails.Data()) {
+			t.Fatalf("expected repeated creates to return the same details, got %v and %v", first.DeploymentDetails.Data(), second.DeploymentDetails.Data())
+		}
+	})
+	t.Run("test connection details carry the expected secret shape", func(t *testing.T) {
+		p, cr := setup()
+		instance, _, err := p.CreateStorage(ctx, cr)
+		if err != nil {
+			t.Fatalf("unexpected error on create: %v", err)
+		}
+		if instance == nil {
+			t.Fatalf("expected the fixture to provision to completion, no details returned")
+		}
+		assertSecretShape(t, instance.DeploymentDetails.Data(), requiredSecretKeys)
+	})
+	t.Run("test repeated delete is graceful", func(t *testing.T) {
+		p, cr := setup()
+		if _, _, err := p.CreateStorage(ctx, cr); err != nil {
+			t.Fatalf("unexpected error on create: %v", err)
+		}
+		if _, err := p.DeleteStorage(ctx, cr); err != nil {
+			t.Fatalf("unexpected error on first delete: %v", err)
+		}
+		if _, err := p.DeleteStorage(ctx, cr); err != nil {
+			t.Fatalf("unexpected error on repeated delete: %v", err)
+		}
+	})
+}
+
+func assertSecretShape(t *testing.T, data map[string][]byte, requiredKeys []string) {
+	for _, key := range requiredKeys {
+		if len(data[key]) == 0 {
+			t.Fatalf("expected connection details to contain a value for %s, got %v", key, data)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package harness
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	croType "github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1/types"
+
+	"github.com/integr8ly/cloud-resource-operator/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/cloud-resource-operator/pkg/providers"
+	errorUtil "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TestPostgresLifecycle walks a postgres cr through the full reconcile lifecycle against
+// the fake provider, provisioning, availability with the connection secret and deletion
+func TestPostgresLifecycle(t *testing.T) {
+	ctx := context.TODO()
+	pg := &v1alpha1.Postgres{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-postgres",
+			Namespace: "test-ns",
+		},
+		Spec: v1alpha1.PostgresSpec{
+			Type: "managed",
+			Tier: "production",
+			SecretRef: &croType.SecretRef{
+				Name: "test-postgres-sec",
+			},
+		},
+	}
+	h := NewPostgres(t, pg)
+	request := reconcile.Request{
+		NamespacedName: k8sTypes.NamespacedName{
+			Name:      pg.Name,
+			Namespace: pg.Namespace,
+		},
+	}
+
+	// the provider reports no details yet, the cr moves to in progress with a finalizer
+	if _, err := h.Reconciler.Reconcile(request); err != nil {
+		t.Fatalf("unexpected error on provisioning reconcile: %v", err)
+	}
+	if err := h.Client.Get(ctx, request.NamespacedName, pg); err != nil {
+		t.Fatalf("failed to get postgres cr: %v", err)
+	}
+	if pg.Status.Phase != croType.PhaseInProgress {
+		t.Fatalf("expected phase %s, got %s", croType.PhaseInProgress, pg.Status.Phase)
+	}
+	if len(pg.GetFinalizers()) == 0 {
+		t.Fatalf("expected finalizer to be set during provisioning")
+	}
+
+	// the provider becomes available, the connection secret is written and the cr completes
+	h.Provider.Available = true
+	h.Provider.Details = &providers.PostgresDeploymentDetails{
+		Username: "testuser",
+		Password: "testpass",
+		Host:     "example.com",
+		Database: "postgres",
+		Port:     5432,
+	}
+	if _, err := h.Reconciler.Reconcile(request); err != nil {
+		t.Fatalf("unexpected error on completion reconcile: %v", err)
+	}
+	if err := h.Client.Get(ctx, request.NamespacedName, pg); err != nil {
+		t.Fatalf("failed to get postgres cr: %v", err)
+	}
+	if pg.Status.Phase != croType.PhaseComplete {
+		t.Fatalf("expected phase %s, got %s", croType.PhaseComplete, pg.Status.Phase)
+	}
+	if pg.Status.Stage != croType.StageReady {
+		t.Fatalf("expected stage %s, got %s", croType.StageReady, pg.Status.Stage)
+	}
+	sec := &corev1.Secret{}
+	if err := h.Client.Get(ctx, k8sTypes.NamespacedName{Name: pg.Spec.SecretRef.Name, Namespace: pg.Namespace}, sec); err != nil {
+		t.Fatalf("failed to get connection secret: %v", err)
+	}
+	if string(sec.Data["username"]) != "testuser" {
+		t.Fatalf("expected username testuser in connection secret, got %s", sec.Data["username"])
+	}
+
+	// deletion runs through the provider, the finalizer is removed once teardown completes
+	now := metav1.Now()
+	pg.DeletionTimestamp = &now
+	if err := h.Client.Update(ctx, pg); err != nil {
+		t.Fatalf("failed to mark postgres cr for deletion: %v", err)
+	}
+	if _, err := h.Reconciler.Reconcile(request); err != nil {
+		t.Fatalf("unexpected error on deletion reconcile: %v", err)
+	}
+	// fetch into a fresh object, the fake client merges into reused structs
+	deleted := &v1alpha1.Postgres{}
+	if err := h.Client.Get(ctx, request.NamespacedName, deleted); err != nil {
+		t.Fatalf("failed to get postgres cr: %v", err)
+	}
+	if len(deleted.GetFinalizers()) != 0 {
+		t.Fatalf("expected finalizer to be removed on deletion, got %v", deleted.GetFinalizers())
+	}
+	if deleted.Status.Stage != croType.StageDeleting {
+		t.Fatalf("expected stage %s, got %s", croType.StageDeleting, deleted.Status.Stage)
+	}
+}
+
+// TestPostgresProvisioningFailure verifies a provider error is surfaced on the cr as a
+// failed phase and failure condition instead of being swallowed by the reconcile loop
+func TestPostgresProvisioningFailure(t *testing.T) {
+	ctx := context.TODO()
+	pg := &v1alpha1.Postgres{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-postgres",
+			Namespace: "test-ns",
+		},
+		Spec: v1alpha1.PostgresSpec{
+			Type: "managed",
+			Tier: "production",
+			SecretRef: &croType.SecretRef{
+				Name: "test-postgres-sec",
+			},
+		},
+	}
+	h := NewPostgres(t, pg)
+	h.Provider.CreateFn = func(ctx context.Context, o runtime.Object) (providers.DeploymentDetails, croType.StatusMessage, error) {
+		return nil, "creation failed", errorUtil.New("quota exceeded")
+	}
+	request := reconcile.Request{
+		NamespacedName: k8sTypes.NamespacedName{
+			Name:      pg.Name,
+			Namespace: pg.Namespace,
+		},
+	}
+
+	// provider errors requeue with backoff rather than erroring the reconcile
+	if _, err := h.Reconciler.Reconcile(request); err != nil {
+		t.Fatalf("unexpected error on failing reconcile: %v", err)
+	}
+	if err := h.Client.Get(ctx, request.NamespacedName, pg); err != nil {
+		t.Fatalf("failed to get postgres cr: %v", err)
+	}
+	if pg.Status.Phase != croType.PhaseFailed {
+		t.Fatalf("expected phase %s, got %s", croType.PhaseFailed, pg.Status.Phase)
+	}
+	foundFailure := false
+	for _, condition := range pg.Status.Conditions {
+		if condition.Status == "True" && strings.Contains(condition.Message, "quota exceeded") {
+			foundFailure = true
+		}
+	}
+	if !foundFailure {
+		t.Fatalf("expected failure condition on cr, got %v", pg.Status.Conditions)
+	}
+}